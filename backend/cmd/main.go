@@ -4,11 +4,16 @@ import (
 	"bluelink-backend/internal/blockchain"
 	"bluelink-backend/internal/config"
 	"bluelink-backend/internal/database"
+	"bluelink-backend/internal/humancheck"
+	"bluelink-backend/internal/limits"
 	"bluelink-backend/internal/middleware"
+	"bluelink-backend/internal/pricing"
 	"bluelink-backend/internal/repository"
 	"bluelink-backend/internal/routes"
+	"bluelink-backend/internal/screening"
 	"bluelink-backend/internal/services"
 	"bluelink-backend/internal/session"
+	"bluelink-backend/internal/storage"
 	"context"
 	"fmt"
 	"log"
@@ -68,21 +73,98 @@ func main() {
 	txRepo := repository.NewTransactionRepository(db.DB)
 	sessionRepo := repository.NewSessionRepository(db.DB)
 	nonceRepo := repository.NewNonceRepository(db.DB)
+	proposalRepo := repository.NewBondProposalRepository(db.DB)
+	impactRepo := repository.NewImpactRepository(db.DB)
+	marketRepo := repository.NewMarketRepository(db.DB)
+	coinRepo := repository.NewCoinMetadataRepository(db.DB)
+	priceRepo := repository.NewPriceRepository(db.DB)
+	kycRepo := repository.NewKYCRepository(db.DB)
+	issuerApplicationRepo := repository.NewIssuerApplicationRepository(db.DB)
+	organizationRepo := repository.NewOrganizationRepository(db.DB)
+	userWalletRepo := repository.NewUserWalletRepository(db.DB)
+	screeningRepo := repository.NewScreeningRepository(db.DB)
+	limitRepo := repository.NewInvestmentLimitRepository(db.DB)
+	auditRepo := repository.NewAuditRepository(db.DB)
+	apiKeyRepo := repository.NewAPIKeyRepository(db.DB)
+	roleRepo := repository.NewRoleRepository(db.DB)
+	loginEventRepo := repository.NewLoginEventRepository(db.DB)
+	notificationRepo := repository.NewNotificationRepository(db.DB)
+	authLockoutRepo := repository.NewAuthLockoutRepository(db.DB)
 
 	// 6. 初始化 Sui Client
 	log.Println("Initializing Sui client...")
 	suiClient := sui.NewSuiClient(cfg.SuiRPCURL)
 	log.Println("Sui client initialized")
 
+	// 幣種元數據登錄表（各服務與事件監聽器共用快取）
+	coinRegistry := blockchain.NewCoinRegistry(suiClient, coinRepo)
+
 	// 7. 初始化 Services
-	userService := services.NewUserService(userRepo)
+	auditService := services.NewAuditService(auditRepo)
+	userService := services.NewUserService(userRepo, roleRepo, auditService)
 	bondService := services.NewBondService(bondRepo)
-	bondTokenService := services.NewBondTokenService(bondTokenRepo)
-	syncService := services.NewSyncService(suiClient, cfg.SuiPackageID, bondRepo, userRepo, txRepo)
+	bondTokenService := services.NewBondTokenService(bondTokenRepo, txRepo)
+	syncService := services.NewSyncService(suiClient, cfg.SuiPackageID, bondRepo, userRepo, txRepo, proposalRepo, coinRegistry)
+	proposalService := services.NewBondProposalService(proposalRepo, bondRepo, auditService)
+	impactService := services.NewImpactService(impactRepo, bondRepo)
+	valuationService := services.NewValuationService(bondRepo, txRepo, cfg)
+	coinService := services.NewCoinService(coinRegistry, coinRepo)
+
+	priceProvider, err := pricing.NewProvider(cfg)
+	if err != nil {
+		log.Fatalf("Failed to initialize price provider: %v", err)
+	}
+	priceService := services.NewPriceService(priceProvider, priceRepo, coinRegistry, cfg)
+
+	// 投資限額（交易前檢查、事件監聽器與二級市場成交共用）
+	limitChecker := limits.NewChecker(limitRepo, priceService, cfg)
+	limitService := services.NewInvestmentLimitService(limitChecker, limitRepo, userRepo, bondRepo, auditService, cfg)
+	marketService := services.NewMarketService(suiClient, cfg.SuiPackageID, marketRepo, bondRepo, bondTokenRepo, txRepo, userRepo, coinRegistry, limitChecker)
 
 	// 8. 初始化 Session Manager（使用 PostgreSQL）
-	sessionManager := session.NewPostgresSessionManager(sessionRepo)
-	log.Println("✅ Using PostgreSQL Session Manager (persistent sessions)")
+	sessionPolicy := session.PolicyFromConfig(cfg)
+	sessionManager := session.NewPostgresSessionManager(sessionRepo, sessionPolicy)
+	log.Printf("✅ Using PostgreSQL Session Manager (timeout %s, idle %s, max %d devices, eviction %s, sliding %t)",
+		sessionPolicy.AbsoluteTimeout, sessionPolicy.IdleTimeout, sessionPolicy.MaxDevices, sessionPolicy.Eviction, sessionPolicy.SlidingExpiry)
+
+	// Token 模式：短效 access token + 輪替的 refresh token
+	var tokenManager *session.TokenManager
+	if cfg.SessionMode == "token" {
+		tokenManager = session.NewTokenManager(sessionManager, sessionRepo, cfg.JWTSecret, time.Duration(cfg.AccessTokenTTL)*time.Second)
+		log.Printf("✅ Using token session mode (access token TTL %ds)", cfg.AccessTokenTTL)
+	}
+
+	// KYC 文件儲存
+	blobStore, err := storage.NewBlobStore(cfg)
+	if err != nil {
+		log.Fatalf("Failed to initialize blob store: %v", err)
+	}
+	kycService := services.NewKYCService(kycRepo, userRepo, blobStore, sessionManager, auditService, cfg)
+	adminUserService := services.NewAdminUserService(userService, userRepo, txRepo, sessionManager, auditService)
+	roleService := services.NewRoleService(roleRepo, userRepo, sessionManager, auditService)
+	issuerApplicationService := services.NewIssuerApplicationService(issuerApplicationRepo, userRepo, blobStore, roleService, auditService, cfg)
+	organizationService := services.NewOrganizationService(organizationRepo, userRepo, bondRepo, proposalRepo, roleService)
+	walletService := services.NewWalletService(userWalletRepo, userRepo, sessionManager, auditService)
+	apiKeyService := services.NewAPIKeyService(apiKeyRepo, userRepo, roleService, auditService)
+	notificationService := services.NewNotificationService(notificationRepo)
+	loginHistoryService := services.NewLoginHistoryService(loginEventRepo, userRepo, sessionManager, notificationService, cfg)
+
+	// 登入暴力破解防護（人機驗證：HUMAN_CHECK=none | pow | captcha）
+	humanVerifier, err := humancheck.NewVerifier(cfg)
+	if err != nil {
+		log.Fatalf("Failed to initialize human check: %v", err)
+	}
+	authGuardService := services.NewAuthGuardService(authLockoutRepo, nonceRepo, humanVerifier, auditService, cfg)
+
+	// 制裁/黑名單比對（名單無法載入時拒絕啟動）
+	denyList := screening.NewDenyList(cfg.ScreeningListPaths)
+	if err := denyList.Reload(); err != nil {
+		log.Fatalf("Failed to load deny lists: %v", err)
+	}
+	screener := screening.NewScreener(denyList, screeningRepo, userRepo, sessionManager)
+	screeningService := services.NewScreeningService(screener, screeningRepo, userRepo, bondTokenRepo, auditService, cfg)
+	screeningService.Start(ctx)
+	authGuardService.Start(ctx)
 
 	// 9. 初始化並啟動區塊鏈事件監聽器
 	if cfg.SuiPackageID != "" {
@@ -92,6 +174,12 @@ func main() {
 			txRepo,
 			bondRepo,
 			userRepo,
+			proposalRepo,
+			bondTokenRepo,
+			marketRepo,
+			coinRegistry,
+			screener,
+			limitChecker,
 			cfg.SuiPackageID,
 		)
 
@@ -112,10 +200,11 @@ func main() {
 	r.Use(middleware.CORSMiddleware(cfg.CORSAllowedOrigins))
 	r.Use(middleware.RecoveryMiddleware())
 	r.Use(middleware.RequestIDMiddleware())
+	r.Use(middleware.AuditContextMiddleware())
 	r.Use(middleware.LoggingMiddleware())
 
 	// 12. 設定路由
-	routes.SetupRoutes(r, userService, bondService, bondTokenService, syncService, sessionManager, nonceRepo, cfg)
+	routes.SetupRoutes(r, userService, bondService, bondTokenService, syncService, proposalService, impactService, marketService, valuationService, coinService, priceService, kycService, screeningService, limitService, adminUserService, issuerApplicationService, organizationService, walletService, auditService, apiKeyService, roleService, loginHistoryService, notificationService, authGuardService, sessionManager, tokenManager, nonceRepo, cfg)
 
 	// 13. 健康檢查路由
 	r.GET("/health", func(c *gin.Context) {
@@ -138,6 +138,25 @@ func printMigrationStatus(ctx context.Context, db *database.PostgresDB) {
 func resetDatabase(ctx context.Context, db *database.PostgresDB) error {
 	// 刪除所有表
 	tables := []string{
+		"audit_events",
+		"user_wallets",
+		"organization_members",
+		"organizations",
+		"issuer_application_documents",
+		"issuer_applications",
+		"compliance_alerts",
+		"compliance_flags",
+		"screening_results",
+		"kyc_documents",
+		"kyc_applications",
+		"price_snapshots",
+		"coin_metadata",
+		"market_orders",
+		"impact_report_values",
+		"impact_reports",
+		"impact_kpis",
+		"bond_impact",
+		"bond_proposals",
 		"user_bonds",
 		"transactions",
 		"sessions",
@@ -0,0 +1,105 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+)
+
+// Actor 執行操作的主體與請求來源（由 middleware 放入 request context）
+type Actor struct {
+	UserID        *int64
+	WalletAddress string
+	Role          string
+	IPAddress     string
+	UserAgent     string
+	RequestID     string
+}
+
+type actorKey struct{}
+
+// WithActor 將操作主體放入 context
+func WithActor(ctx context.Context, actor *Actor) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFromContext 取得 context 中的操作主體（系統背景工作回傳空的 Actor）
+func ActorFromContext(ctx context.Context) *Actor {
+	if actor, ok := ctx.Value(actorKey{}).(*Actor); ok && actor != nil {
+		return actor
+	}
+	return &Actor{}
+}
+
+// WithUser 以指定使用者作為操作主體（例如登入成功時 session 尚未建立）
+func WithUser(ctx context.Context, userID int64, walletAddress, role string) context.Context {
+	actor := *ActorFromContext(ctx)
+	actor.UserID = &userID
+	actor.WalletAddress = walletAddress
+	actor.Role = role
+	return WithActor(ctx, &actor)
+}
+
+// Diff 比對操作前後的資料，只保留有變動的欄位
+// before / after 為 nil 時（建立或刪除）保留另一方的完整資料
+func Diff(before, after any) (json.RawMessage, json.RawMessage, error) {
+	beforeFields, err := toFields(before)
+	if err != nil {
+		return nil, nil, err
+	}
+	afterFields, err := toFields(after)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if beforeFields != nil && afterFields != nil {
+		for key, value := range beforeFields {
+			if other, ok := afterFields[key]; ok && reflect.DeepEqual(value, other) {
+				delete(beforeFields, key)
+				delete(afterFields, key)
+			}
+		}
+	}
+
+	beforeJSON, err := marshalFields(beforeFields)
+	if err != nil {
+		return nil, nil, err
+	}
+	afterJSON, err := marshalFields(afterFields)
+	if err != nil {
+		return nil, nil, err
+	}
+	return beforeJSON, afterJSON, nil
+}
+
+// toFields 將資料轉為 JSON 欄位對應（非物件的值以 "value" 欄位表示）
+func toFields(value any) (map[string]any, error) {
+	if value == nil {
+		return nil, nil
+	}
+	if v := reflect.ValueOf(value); v.Kind() == reflect.Ptr && v.IsNil() {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields map[string]any
+	if err := json.Unmarshal(data, &fields); err != nil {
+		var scalar any
+		if err := json.Unmarshal(data, &scalar); err != nil {
+			return nil, err
+		}
+		return map[string]any{"value": scalar}, nil
+	}
+	return fields, nil
+}
+
+func marshalFields(fields map[string]any) (json.RawMessage, error) {
+	if len(fields) == 0 {
+		return nil, nil
+	}
+	return json.Marshal(fields)
+}
@@ -17,13 +17,15 @@ import (
 type ChainReader struct {
 	suiClient sui.ISuiAPI
 	packageID string
+	coins     *CoinRegistry // 幣種元數據（取得計價幣種小數位數）
 }
 
 // NewChainReader 創建鏈上數據讀取器
-func NewChainReader(suiClient sui.ISuiAPI, packageID string) *ChainReader {
+func NewChainReader(suiClient sui.ISuiAPI, packageID string, coins *CoinRegistry) *ChainReader {
 	return &ChainReader{
 		suiClient: suiClient,
 		packageID: packageID,
+		coins:     coins,
 	}
 }
 
@@ -33,6 +35,8 @@ type BondProjectOnChain struct {
 	BondName           string
 	Issuer             string
 	IssuerName         string
+	CoinType           string // BondProject<T> 的型別參數
+	CoinDecimals       int
 	BondImageUrl       string
 	TokenImageUrl      string
 	MetadataUrl        string
@@ -125,12 +129,27 @@ func (cr *ChainReader) GetBondProjectByID(ctx context.Context, objectID string)
 		return nil, fmt.Errorf("object %s has no fields", objectID)
 	}
 
+	// 計價幣種取自型別參數，例如 0x..::blue_link::BondProject<0x..::usdc::USDC>
+	// 舊版非泛型合約沒有型別參數，NormalizeCoinType 會回傳 SUI
+	coinType := coinTypeFromObjectType(resp.Data.Type)
+	if coinType == "" {
+		coinType = coinTypeFromObjectType(content.Type)
+	}
+	coinType = models.NormalizeCoinType(coinType)
+
+	coinDecimals, err := cr.coinDecimals(ctx, coinType)
+	if err != nil {
+		return nil, err
+	}
+
 	// 提取數據
 	bondProject := &BondProjectOnChain{
 		ObjectID:           objectID,
 		BondName:           getStringField(fields, "bond_name"),
 		Issuer:             getStringField(fields, "issuer"),
 		IssuerName:         getStringField(fields, "issuer_name"),
+		CoinType:           coinType,
+		CoinDecimals:       coinDecimals,
 		BondImageUrl:       getStringField(fields, "bond_image_url"),
 		TokenImageUrl:      getStringField(fields, "token_image_url"),
 		MetadataUrl:        getStringField(fields, "metadata_url"),
@@ -151,12 +170,15 @@ func (cr *ChainReader) GetBondProjectByID(ctx context.Context, objectID string)
 	logger.Info("   🆔 Object ID: %s", objectID)
 	logger.Info("   👤 Issuer: %s", bondProject.Issuer)
 	logger.Info("   🏢 Issuer Name: %s", bondProject.IssuerName)
-	logger.Info("   💰 Total Amount: %d MIST (%.2f SUI)",
+	logger.Info("   🪙 Coin: %s (%d decimals)", bondProject.CoinType, bondProject.CoinDecimals)
+	logger.Info("   💰 Total Amount: %d (%s %s)",
 		bondProject.TotalAmount,
-		float64(bondProject.TotalAmount)/1e9)
-	logger.Info("   📈 Amount Raised: %d MIST (%.2f SUI)",
+		models.FormatAmount(bondProject.TotalAmount, bondProject.CoinDecimals),
+		models.CoinSymbolFromType(bondProject.CoinType))
+	logger.Info("   📈 Amount Raised: %d (%s %s)",
 		bondProject.AmountRaised,
-		float64(bondProject.AmountRaised)/1e9)
+		models.FormatAmount(bondProject.AmountRaised, bondProject.CoinDecimals),
+		models.CoinSymbolFromType(bondProject.CoinType))
 	logger.Info("   📊 Annual Interest Rate: %d (%.2f%%)",
 		bondProject.AnnualInterestRate,
 		float64(bondProject.AnnualInterestRate)/100)
@@ -181,6 +203,8 @@ func (bp *BondProjectOnChain) ToBondModel() *models.Bond {
 		IssuerAddress:      bp.Issuer,
 		IssuerName:         bp.IssuerName,
 		BondName:           bp.BondName,
+		CoinType:           bp.CoinType,
+		CoinDecimals:       bp.CoinDecimals,
 		BondImageUrl:       bp.BondImageUrl,
 		TokenImageUrl:      bp.TokenImageUrl,
 		MetadataUrl:        bp.MetadataUrl,
@@ -200,6 +224,49 @@ func (bp *BondProjectOnChain) ToBondModel() *models.Bond {
 	}
 }
 
+// coinDecimals 取得計價幣種的小數位數
+func (cr *ChainReader) coinDecimals(ctx context.Context, coinType string) (int, error) {
+	if cr.coins == nil {
+		if coinType == models.SuiCoinType {
+			return models.SuiDecimals, nil
+		}
+		return 0, fmt.Errorf("no coin registry to resolve decimals of %s", coinType)
+	}
+
+	decimals, err := cr.coins.Decimals(ctx, coinType)
+	if err != nil {
+		return 0, fmt.Errorf("failed to resolve coin decimals: %w", err)
+	}
+	return decimals, nil
+}
+
+// coinTypeFromObjectType 取出泛型對象型別的第一個型別參數（非泛型時回傳空字串）
+// 例如 0xabc::blue_link::BondProject<0x2::sui::SUI> → 0x2::sui::SUI
+func coinTypeFromObjectType(objectType string) string {
+	start := strings.Index(objectType, "<")
+	end := strings.LastIndex(objectType, ">")
+	if start < 0 || end <= start {
+		return ""
+	}
+
+	// 只取第一個型別參數，需略過巢狀泛型中的逗號
+	params := objectType[start+1 : end]
+	depth := 0
+	for i, ch := range params {
+		switch ch {
+		case '<':
+			depth++
+		case '>':
+			depth--
+		case ',':
+			if depth == 0 {
+				return strings.TrimSpace(params[:i])
+			}
+		}
+	}
+	return strings.TrimSpace(params)
+}
+
 // 輔助函數：安全地從 fields map 中提取字符串
 func getStringField(fields map[string]interface{}, key string) string {
 	if val, ok := fields[key]; ok {
@@ -0,0 +1,117 @@
+package blockchain
+
+import (
+	"bluelink-backend/internal/logger"
+	"bluelink-backend/internal/models"
+	"bluelink-backend/internal/repository"
+	"context"
+	"fmt"
+	"sync"
+
+	suiModels "github.com/block-vision/sui-go-sdk/models"
+	"github.com/block-vision/sui-go-sdk/sui"
+)
+
+// CoinRegistry 幣種元數據登錄表
+// 依序查詢記憶體快取、coin_metadata 表，最後才呼叫 suix_getCoinMetadata 並寫回資料庫
+type CoinRegistry struct {
+	suiClient sui.ISuiAPI
+	repo      *repository.CoinMetadataRepository
+
+	mu    sync.RWMutex
+	cache map[string]*models.CoinMetadata
+}
+
+// NewCoinRegistry 創建幣種元數據登錄表
+func NewCoinRegistry(suiClient sui.ISuiAPI, repo *repository.CoinMetadataRepository) *CoinRegistry {
+	return &CoinRegistry{
+		suiClient: suiClient,
+		repo:      repo,
+		cache:     make(map[string]*models.CoinMetadata),
+	}
+}
+
+// Get 取得幣種元數據
+func (cr *CoinRegistry) Get(ctx context.Context, coinType string) (*models.CoinMetadata, error) {
+	coinType = models.NormalizeCoinType(coinType)
+
+	cr.mu.RLock()
+	meta, ok := cr.cache[coinType]
+	cr.mu.RUnlock()
+	if ok {
+		return meta, nil
+	}
+
+	if cr.repo != nil {
+		stored, err := cr.repo.GetByCoinType(ctx, coinType)
+		if err != nil {
+			return nil, err
+		}
+		if stored != nil {
+			cr.store(stored)
+			return stored, nil
+		}
+	}
+
+	meta, err := cr.fetch(ctx, coinType)
+	if err != nil {
+		return nil, err
+	}
+
+	if cr.repo != nil {
+		if err := cr.repo.Upsert(ctx, meta); err != nil {
+			logger.Error("Failed to persist coin metadata for %s: %v", coinType, err)
+		}
+	}
+
+	cr.store(meta)
+	logger.Info("🪙 Coin metadata registered: %s (%s, %d decimals)", meta.Symbol, coinType, meta.Decimals)
+	return meta, nil
+}
+
+// Decimals 取得幣種小數位數
+func (cr *CoinRegistry) Decimals(ctx context.Context, coinType string) (int, error) {
+	meta, err := cr.Get(ctx, coinType)
+	if err != nil {
+		return 0, err
+	}
+	return meta.Decimals, nil
+}
+
+// fetch 從鏈上讀取幣種元數據
+func (cr *CoinRegistry) fetch(ctx context.Context, coinType string) (*models.CoinMetadata, error) {
+	resp, err := cr.suiClient.SuiXGetCoinMetadata(ctx, suiModels.SuiXGetCoinMetadataRequest{
+		CoinType: coinType,
+	})
+	if err != nil {
+		// SUI 的小數位數固定，節點暫時無法連線時不影響使用
+		if coinType == models.SuiCoinType {
+			return &models.CoinMetadata{CoinType: coinType, Decimals: models.SuiDecimals, Symbol: "SUI", Name: "Sui"}, nil
+		}
+		return nil, fmt.Errorf("failed to get coin metadata for %s: %w", coinType, err)
+	}
+
+	if resp.Symbol == "" {
+		return nil, fmt.Errorf("coin metadata for %s not found", coinType)
+	}
+
+	meta := &models.CoinMetadata{
+		CoinType:    coinType,
+		Decimals:    resp.Decimals,
+		Symbol:      resp.Symbol,
+		Name:        resp.Name,
+		Description: resp.Description,
+		IconUrl:     resp.IconUrl,
+	}
+	if resp.Id != "" {
+		meta.MetadataID = &resp.Id
+	}
+
+	return meta, nil
+}
+
+func (cr *CoinRegistry) store(meta *models.CoinMetadata) {
+	cr.mu.Lock()
+	cr.cache[meta.CoinType] = meta
+	cr.mu.Unlock()
+}
@@ -1,9 +1,11 @@
 package blockchain
 
 import (
+	"bluelink-backend/internal/limits"
 	"bluelink-backend/internal/logger"
 	"bluelink-backend/internal/models"
 	"bluelink-backend/internal/repository"
+	"bluelink-backend/internal/screening"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -16,15 +18,20 @@ import (
 
 // EventListener Sui 區塊鏈事件監聽器
 type EventListener struct {
-	suiClient   sui.ISuiAPI                       // Sui 區塊鏈客戶端（查詢事件）
-	chainReader *ChainReader                      // 鏈上數據讀取器
-	txRepo      *repository.TransactionRepository // 交易 Repository
-	bondRepo    *repository.BondRepository        // 債券 Repository
-	userRepo    *repository.UserRepository        // 使用者 Repository
-	packageID   string                            // 合約地址（過濾事件用）
-	stopChan    chan struct{}                     // 停止信號通道
-	isRunning   bool                              // 運行狀態
-	lastCursor  *suiModels.EventId                // 游標（追蹤查詢進度）
+	suiClient    sui.ISuiAPI                        // Sui 區塊鏈客戶端（查詢事件）
+	chainReader  *ChainReader                       // 鏈上數據讀取器
+	txRepo       *repository.TransactionRepository  // 交易 Repository
+	bondRepo     *repository.BondRepository         // 債券 Repository
+	userRepo     *repository.UserRepository         // 使用者 Repository
+	proposalRepo *repository.BondProposalRepository // 債券申請 Repository
+	tokenRepo    *repository.BondTokenRepository    // 債券代幣 Repository
+	marketRepo   *repository.MarketRepository       // 二級市場掛單 Repository
+	screener     *screening.Screener                // 制裁/黑名單比對
+	limits       *limits.Checker                    // 投資限額檢查
+	packageID    string                             // 合約地址（過濾事件用）
+	stopChan     chan struct{}                      // 停止信號通道
+	isRunning    bool                               // 運行狀態
+	lastCursor   *suiModels.EventId                 // 游標（追蹤查詢進度）
 }
 
 // NewEventListener 創建事件監聽器
@@ -33,18 +40,29 @@ func NewEventListener(
 	txRepo *repository.TransactionRepository,
 	bondRepo *repository.BondRepository,
 	userRepo *repository.UserRepository,
+	proposalRepo *repository.BondProposalRepository,
+	tokenRepo *repository.BondTokenRepository,
+	marketRepo *repository.MarketRepository,
+	coins *CoinRegistry,
+	screener *screening.Screener,
+	limitChecker *limits.Checker,
 	packageID string,
 ) *EventListener {
 	return &EventListener{
-		suiClient:   suiClient,
-		chainReader: NewChainReader(suiClient, packageID),
-		txRepo:      txRepo,
-		bondRepo:    bondRepo,
-		userRepo:    userRepo,
-		packageID:   packageID,
-		stopChan:    make(chan struct{}),
-		isRunning:   false,
-		lastCursor:  nil,
+		suiClient:    suiClient,
+		chainReader:  NewChainReader(suiClient, packageID, coins),
+		txRepo:       txRepo,
+		bondRepo:     bondRepo,
+		userRepo:     userRepo,
+		proposalRepo: proposalRepo,
+		tokenRepo:    tokenRepo,
+		marketRepo:   marketRepo,
+		screener:     screener,
+		limits:       limitChecker,
+		packageID:    packageID,
+		stopChan:     make(chan struct{}),
+		isRunning:    false,
+		lastCursor:   nil,
 	}
 }
 
@@ -79,6 +97,10 @@ func (el *EventListener) pollEvents(ctx context.Context) {
 	ticker := time.NewTicker(15 * time.Second) // 每 5 秒查詢一次
 	defer ticker.Stop()
 
+	// 代幣轉移沒有合約事件，以較低頻率比對鏈上持有者
+	transferTicker := time.NewTicker(time.Minute)
+	defer transferTicker.Stop()
+
 	for {
 		select {
 		case <-ctx.Done():
@@ -91,6 +113,13 @@ func (el *EventListener) pollEvents(ctx context.Context) {
 			if err := el.queryAndProcessEvents(ctx); err != nil {
 				logger.Error("Error querying events: %v", err)
 			}
+		case <-transferTicker.C:
+			if err := el.indexTransfers(ctx); err != nil {
+				logger.Error("Error indexing token transfers: %v", err)
+			}
+			if err := el.reconcileListings(ctx); err != nil {
+				logger.Error("Error reconciling market listings: %v", err)
+			}
 		}
 	}
 }
@@ -197,8 +226,8 @@ func (el *EventListener) handleBondProjectCreated(ctx context.Context, event sui
 
 	issuerAddress := bondData.Issuer
 
-	// 查詢或創建發行者
-	user, err := el.userRepo.GetByWalletAddress(ctx, issuerAddress)
+	// 查詢或創建發行者（包含已刪除的帳戶，錢包地址唯一，重新建立會違反唯一約束）
+	user, err := el.userRepo.GetByWalletAddressUnscoped(ctx, issuerAddress)
 	if err != nil {
 		return fmt.Errorf("failed to get user: %w", err)
 	}
@@ -227,8 +256,13 @@ func (el *EventListener) handleBondProjectCreated(ctx context.Context, event sui
 		logger.Info("✅ Bond created in database:")
 		logger.Info("   📋 Name: %s", bond.BondName)
 		logger.Info("   🆔 On-chain ID: %s", bond.OnChainID)
-		logger.Info("   💰 Total Amount: %d MIST (%.2f SUI)", bond.TotalAmount, float64(bond.TotalAmount)/1e9)
+		logger.Info("   💰 Total Amount: %d (%s)", bond.TotalAmount, bond.FormatAmount(bond.TotalAmount))
 		logger.Info("   📊 Annual Interest Rate: %d (%.2f%%)", bond.AnnualInterestRate, float64(bond.AnnualInterestRate)/100)
+
+		// 關聯已核准的申請，沒有的話標記需審核
+		if err := LinkBondToProposal(ctx, el.proposalRepo, el.bondRepo, bond); err != nil {
+			logger.Error("Failed to link bond %s to proposal: %v", bond.OnChainID, err)
+		}
 	} else {
 		bond = existingBond
 		logger.Info("Bond already exists: %s", bond.BondName)
@@ -282,7 +316,7 @@ func (el *EventListener) handleBondTokensPurchased(ctx context.Context, event su
 	}
 
 	// 查詢或創建使用者
-	user, err := el.userRepo.GetByWalletAddress(ctx, buyerAddress)
+	user, err := el.userRepo.GetByWalletAddressUnscoped(ctx, buyerAddress)
 	if err != nil {
 		return err
 	}
@@ -320,8 +354,19 @@ func (el *EventListener) handleBondTokensPurchased(ctx context.Context, event su
 		return fmt.Errorf("failed to create transaction with user bond: %w", err)
 	}
 
-	logger.Info("✅ Bond purchased: %s bought token %s of %s (amount: %.2f SUI)",
-		buyerAddress, tokenID, bond.BondName, amount)
+	// 建立代幣索引（二級市場以 bond_tokens.owner 驗證掛單）
+	if err := el.indexBondToken(ctx, bond, tokenID); err != nil {
+		logger.Error("Failed to index bond token %s: %v", tokenID, err)
+	}
+
+	// 購買者命中名單時標記供合規審查
+	el.screenActivity(ctx, buyerAddress, &user.ID, models.ScreeningTriggerPurchase, event.Id.TxDigest, models.EventBondPurchased, &bond.ID)
+
+	// 購買後超出投資限額時建立合規警示（一級市場購買金額即為代幣面額）
+	el.reviewLimits(ctx, user, buyerAddress, bond, int64(amount), int64(amount), event.Id.TxDigest)
+
+	logger.Info("✅ Bond purchased: %s bought token %s of %s (amount: %s)",
+		buyerAddress, tokenID, bond.BondName, bond.FormatAmount(int64(amount)))
 	return nil
 }
 
@@ -346,7 +391,7 @@ func (el *EventListener) handleBondTokenRedeemed(ctx context.Context, event suiM
 		return fmt.Errorf("bond not found: %s", projectID)
 	}
 
-	user, err := el.userRepo.GetByWalletAddress(ctx, redeemerAddress)
+	user, err := el.userRepo.GetByWalletAddressUnscoped(ctx, redeemerAddress)
 	if err != nil || user == nil {
 		return fmt.Errorf("user not found: %s", redeemerAddress)
 	}
@@ -373,8 +418,11 @@ func (el *EventListener) handleBondTokenRedeemed(ctx context.Context, event suiM
 		return fmt.Errorf("failed to create transaction with user bond: %w", err)
 	}
 
-	logger.Info("✅ Bond redeemed: %s redeemed token %s (amount: %.2f SUI)",
-		redeemerAddress, tokenID, redemptionAmount)
+	// 更新代幣狀態並取消該代幣的掛單
+	el.markTokenRedeemed(ctx, tokenID)
+
+	logger.Info("✅ Bond redeemed: %s redeemed token %s (amount: %s)",
+		redeemerAddress, tokenID, bond.FormatAmount(int64(redemptionAmount)))
 	return nil
 }
 
@@ -398,7 +446,7 @@ func (el *EventListener) handleRedemptionFundsDeposited(ctx context.Context, eve
 		return fmt.Errorf("bond not found: %s", projectID)
 	}
 
-	user, err := el.userRepo.GetByWalletAddress(ctx, issuerAddress)
+	user, err := el.userRepo.GetByWalletAddressUnscoped(ctx, issuerAddress)
 	if err != nil || user == nil {
 		// 如果發行者不存在，創建一個
 		user, err = el.userRepo.Create(ctx, issuerAddress)
@@ -426,8 +474,8 @@ func (el *EventListener) handleRedemptionFundsDeposited(ctx context.Context, eve
 		return fmt.Errorf("failed to create transaction: %w", err)
 	}
 
-	logger.Info("✅ Redemption funds deposited: %s deposited %.2f SUI to %s",
-		issuerAddress, amount, bond.BondName)
+	logger.Info("✅ Redemption funds deposited: %s deposited %s to %s",
+		issuerAddress, bond.FormatAmount(int64(amount)), bond.BondName)
 	return nil
 }
 
@@ -451,7 +499,7 @@ func (el *EventListener) handleFundsWithdrawn(ctx context.Context, event suiMode
 		return fmt.Errorf("bond not found: %s", projectID)
 	}
 
-	user, err := el.userRepo.GetByWalletAddress(ctx, withdrawerAddress)
+	user, err := el.userRepo.GetByWalletAddressUnscoped(ctx, withdrawerAddress)
 	if err != nil || user == nil {
 		return fmt.Errorf("user not found: %s", withdrawerAddress)
 	}
@@ -475,8 +523,8 @@ func (el *EventListener) handleFundsWithdrawn(ctx context.Context, event suiMode
 		return fmt.Errorf("failed to create transaction: %w", err)
 	}
 
-	logger.Info("Funds withdrawn: %s withdrew %.2f SUI from %s",
-		withdrawerAddress, amount, bond.BondName)
+	logger.Info("Funds withdrawn: %s withdrew %s from %s",
+		withdrawerAddress, bond.FormatAmount(int64(amount)), bond.BondName)
 	return nil
 }
 
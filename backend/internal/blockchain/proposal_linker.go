@@ -0,0 +1,41 @@
+package blockchain
+
+import (
+	"bluelink-backend/internal/logger"
+	"bluelink-backend/internal/models"
+	"bluelink-backend/internal/repository"
+	"context"
+	"fmt"
+)
+
+// LinkBondToProposal 將新上鏈的債券關聯到已核准的申請
+// 以發行者地址與債券名稱比對；找不到對應申請時將債券標記為需審核
+func LinkBondToProposal(
+	ctx context.Context,
+	proposalRepo *repository.BondProposalRepository,
+	bondRepo *repository.BondRepository,
+	bond *models.Bond,
+) error {
+	if proposalRepo == nil {
+		return nil
+	}
+
+	proposalID, err := proposalRepo.LinkApproved(ctx, bond)
+	if err != nil {
+		return fmt.Errorf("failed to link bond proposal: %w", err)
+	}
+
+	if proposalID != nil {
+		logger.Info("🔗 Bond %s linked to approved proposal %d", bond.OnChainID, *proposalID)
+		return nil
+	}
+
+	if err := bondRepo.SetNeedsReview(ctx, bond.ID, true); err != nil {
+		return fmt.Errorf("failed to flag bond for review: %w", err)
+	}
+	bond.NeedsReview = true
+
+	logger.Warn("⚠️ Bond %s (%s) by %s has no approved proposal, flagged for review",
+		bond.BondName, bond.OnChainID, bond.IssuerAddress)
+	return nil
+}
@@ -0,0 +1,152 @@
+package blockchain
+
+import (
+	"bluelink-backend/internal/limits"
+	"bluelink-backend/internal/logger"
+	"bluelink-backend/internal/models"
+	"bluelink-backend/internal/screening"
+	"context"
+	"fmt"
+	"strings"
+)
+
+// indexBondToken 從鏈上讀取 BondToken 並寫入 bond_tokens（已存在則略過）
+// 代幣的計價幣種與所屬債券相同
+func (el *EventListener) indexBondToken(ctx context.Context, bond *models.Bond, tokenID string) error {
+	if el.tokenRepo == nil || tokenID == "" {
+		return nil
+	}
+
+	existing, err := el.tokenRepo.GetByOnChainID(ctx, tokenID)
+	if err != nil {
+		return fmt.Errorf("failed to get bond token: %w", err)
+	}
+	if existing != nil {
+		return nil
+	}
+
+	tokenData, err := el.chainReader.GetBondTokenByID(ctx, tokenID)
+	if err != nil {
+		return fmt.Errorf("failed to get bond token from chain: %w", err)
+	}
+
+	token := tokenData.ToBondTokenModel()
+	token.CoinType = bond.CoinType
+	token.CoinDecimals = bond.CoinDecimals
+	if err := el.tokenRepo.Create(ctx, token); err != nil {
+		return fmt.Errorf("failed to create bond token: %w", err)
+	}
+
+	logger.Info("🎫 Bond token indexed: %s #%d owned by %s", token.OnChainID, token.TokenNumber, token.Owner)
+	return nil
+}
+
+// markTokenRedeemed 更新代幣為已贖回並取消其掛單
+func (el *EventListener) markTokenRedeemed(ctx context.Context, tokenID string) {
+	if tokenID == "" {
+		return
+	}
+
+	if el.tokenRepo != nil {
+		token, err := el.tokenRepo.GetByOnChainID(ctx, tokenID)
+		if err != nil {
+			logger.Error("Failed to get bond token %s: %v", tokenID, err)
+		} else if token != nil {
+			if err := el.tokenRepo.UpdateRedeemed(ctx, token.ID, true); err != nil {
+				logger.Error("Failed to mark bond token %s redeemed: %v", tokenID, err)
+			}
+		}
+	}
+
+	el.cancelListings(ctx, tokenID, models.CancelReasonRedeemed)
+}
+
+// cancelListings 取消代幣的所有有效賣單
+func (el *EventListener) cancelListings(ctx context.Context, tokenID, reason string) {
+	if el.marketRepo == nil {
+		return
+	}
+
+	cancelled, err := el.marketRepo.CancelOpenAsksForToken(ctx, tokenID, reason)
+	if err != nil {
+		logger.Error("Failed to cancel listings for token %s: %v", tokenID, err)
+		return
+	}
+	if cancelled > 0 {
+		logger.Info("🛑 Cancelled %d listing(s) for token %s (%s)", cancelled, tokenID, reason)
+	}
+}
+
+// reconcileListings 比對有效賣單與鏈上持有者
+// 代幣已被銷毀（贖回）或不再由掛單者持有（已轉移）時自動取消掛單
+func (el *EventListener) reconcileListings(ctx context.Context) error {
+	if el.marketRepo == nil {
+		return nil
+	}
+
+	listings, err := el.marketRepo.ListOpenAskTokens(ctx)
+	if err != nil {
+		return err
+	}
+	if len(listings) == 0 {
+		return nil
+	}
+
+	tokenIDs := make([]string, 0, len(listings))
+	for tokenID := range listings {
+		tokenIDs = append(tokenIDs, tokenID)
+	}
+
+	states, err := el.chainReader.GetObjectStates(ctx, tokenIDs)
+	if err != nil {
+		return err
+	}
+
+	for tokenID, maker := range listings {
+		state, exists := states[tokenID]
+		switch {
+		case !exists:
+			el.cancelListings(ctx, tokenID, models.CancelReasonRedeemed)
+		case !strings.EqualFold(state.Owner, maker):
+			el.cancelListings(ctx, tokenID, models.CancelReasonTransferred)
+		}
+	}
+
+	return nil
+}
+
+// screenActivity 比對鏈上活動的接收方，命中名單時建立合規標記
+func (el *EventListener) screenActivity(ctx context.Context, address string, userID *int64, trigger, txDigest, eventType string, bondID *int64) {
+	if el.screener == nil {
+		return
+	}
+
+	subject := screening.Subject{
+		Address: address,
+		UserID:  userID,
+		Trigger: trigger,
+		TxHash:  txDigest,
+	}
+	if _, err := el.screener.ScreenActivity(ctx, subject, eventType, bondID); err != nil {
+		logger.Error("Failed to screen %s for tx %s: %v", address, txDigest, err)
+	}
+}
+
+// reviewLimits 成交後檢查接收方的投資限額與持有集中度，違規時建立合規警示
+// amount 為 0 表示直接轉移，不計入投資額度
+func (el *EventListener) reviewLimits(ctx context.Context, user *models.User, wallet string, bond *models.Bond, amount, faceValue int64, txDigest string) {
+	if el.limits == nil {
+		return
+	}
+
+	trade := limits.Trade{
+		User:      user,
+		Wallet:    wallet,
+		Bond:      bond,
+		Amount:    amount,
+		FaceValue: faceValue,
+	}
+	if err := el.limits.Review(ctx, trade, txDigest); err != nil {
+		logger.Error("Failed to review limits for %s in tx %s: %v", wallet, txDigest, err)
+	}
+}
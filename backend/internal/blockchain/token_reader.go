@@ -0,0 +1,192 @@
+package blockchain
+
+import (
+	"bluelink-backend/internal/models"
+	"context"
+	"fmt"
+	"strings"
+
+	suiModels "github.com/block-vision/sui-go-sdk/models"
+)
+
+// BondTokenOnChain 鏈上 BondToken 對象的數據結構
+type BondTokenOnChain struct {
+	ObjectID           string
+	ProjectID          string
+	BondName           string
+	TokenImageUrl      string
+	MaturityDate       int64 // timestamp in milliseconds
+	AnnualInterestRate int64
+	TokenNumber        int64
+	Owner              string
+	Amount             int64
+	PurchaseDate       int64 // timestamp in milliseconds
+	IsRedeemed         bool
+}
+
+// ObjectState 對象目前的持有者與最後一次修改它的交易
+type ObjectState struct {
+	Owner               string
+	PreviousTransaction string
+}
+
+// TokenTransfer 交易中 BondToken 的所有權變更
+type TokenTransfer struct {
+	TokenID     string
+	NewOwner    string
+	Sender      string
+	TimestampMs string
+}
+
+// GetBondTokenByID 根據對象 ID 讀取 BondToken 數據
+func (cr *ChainReader) GetBondTokenByID(ctx context.Context, objectID string) (*BondTokenOnChain, error) {
+	resp, err := cr.suiClient.SuiGetObject(ctx, suiModels.SuiGetObjectRequest{
+		ObjectId: objectID,
+		Options: suiModels.SuiObjectDataOptions{
+			ShowContent: true,
+			ShowType:    true,
+			ShowOwner:   true,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get object %s: %w", objectID, err)
+	}
+
+	if resp.Data == nil {
+		return nil, fmt.Errorf("object %s not found", objectID)
+	}
+
+	content := resp.Data.Content
+	if content == nil || content.DataType != "moveObject" || content.Fields == nil {
+		return nil, fmt.Errorf("object %s is not a BondToken", objectID)
+	}
+
+	fields := content.Fields
+
+	// 以實際持有者為準（代幣可能已被轉移，欄位 owner 僅為購買者）
+	owner := ownerAddress(resp.Data.Owner)
+	if owner == "" {
+		owner = getStringField(fields, "owner")
+	}
+
+	return &BondTokenOnChain{
+		ObjectID:           objectID,
+		ProjectID:          getStringField(fields, "project_id"),
+		BondName:           getStringField(fields, "bond_name"),
+		TokenImageUrl:      getStringField(fields, "token_image_url"),
+		MaturityDate:       getInt64Field(fields, "maturity_date"),
+		AnnualInterestRate: getInt64Field(fields, "annual_interest_rate"),
+		TokenNumber:        getInt64Field(fields, "token_number"),
+		Owner:              owner,
+		Amount:             getInt64Field(fields, "amount"),
+		PurchaseDate:       getInt64Field(fields, "purchase_date"),
+		IsRedeemed:         getBoolField(fields, "is_redeemed"),
+	}, nil
+}
+
+// GetObjectStates 批次查詢對象的持有者地址與最後修改交易
+// 已刪除（例如贖回時銷毀）的對象不會出現在結果中
+func (cr *ChainReader) GetObjectStates(ctx context.Context, objectIDs []string) (map[string]*ObjectState, error) {
+	states := make(map[string]*ObjectState, len(objectIDs))
+
+	// sui_multiGetObjects 每次最多 50 個
+	const batchSize = 50
+	for start := 0; start < len(objectIDs); start += batchSize {
+		end := start + batchSize
+		if end > len(objectIDs) {
+			end = len(objectIDs)
+		}
+
+		resp, err := cr.suiClient.SuiMultiGetObjects(ctx, suiModels.SuiMultiGetObjectsRequest{
+			ObjectIds: objectIDs[start:end],
+			Options: suiModels.SuiObjectDataOptions{
+				ShowOwner:               true,
+				ShowPreviousTransaction: true,
+			},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get objects: %w", err)
+		}
+
+		for _, obj := range resp {
+			if obj == nil || obj.Data == nil {
+				continue
+			}
+			states[obj.Data.ObjectId] = &ObjectState{
+				Owner:               ownerAddress(obj.Data.Owner),
+				PreviousTransaction: obj.Data.PreviousTransaction,
+			}
+		}
+	}
+
+	return states, nil
+}
+
+// GetTokenTransferFromTransaction 從交易的 ObjectChanges 找出指定 BondToken 的新持有者
+func (cr *ChainReader) GetTokenTransferFromTransaction(ctx context.Context, txDigest, tokenID string) (*TokenTransfer, error) {
+	txResp, err := cr.suiClient.SuiGetTransactionBlock(ctx, suiModels.SuiGetTransactionBlockRequest{
+		Digest: txDigest,
+		Options: suiModels.SuiTransactionBlockOptions{
+			ShowEffects:       true,
+			ShowObjectChanges: true,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transaction: %w", err)
+	}
+
+	if txResp.Effects.Status.Status != "" && txResp.Effects.Status.Status != "success" {
+		return nil, fmt.Errorf("transaction %s did not succeed", txDigest)
+	}
+
+	for _, change := range txResp.ObjectChanges {
+		if change.ObjectId != tokenID {
+			continue
+		}
+		if change.Type != "mutated" && change.Type != "transferred" {
+			continue
+		}
+		if !strings.Contains(change.ObjectType, "BondToken") {
+			return nil, fmt.Errorf("object %s is not a BondToken", tokenID)
+		}
+
+		return &TokenTransfer{
+			TokenID:     tokenID,
+			NewOwner:    change.GetObjectChangeAddressOwner(),
+			Sender:      change.Sender,
+			TimestampMs: txResp.TimestampMs,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("token %s was not transferred in transaction %s", tokenID, txDigest)
+}
+
+// ToBondTokenModel 將鏈上數據轉換為數據庫模型
+func (bt *BondTokenOnChain) ToBondTokenModel() *models.BondToken {
+	return &models.BondToken{
+		OnChainID:          bt.ObjectID,
+		ProjectID:          bt.ProjectID,
+		BondName:           bt.BondName,
+		TokenImageUrl:      bt.TokenImageUrl,
+		MaturityDate:       bt.MaturityDate,
+		AnnualInterestRate: bt.AnnualInterestRate,
+		TokenNumber:        bt.TokenNumber,
+		Owner:              bt.Owner,
+		Amount:             bt.Amount,
+		PurchaseDate:       bt.PurchaseDate,
+		IsRedeemed:         bt.IsRedeemed,
+	}
+}
+
+// ownerAddress 從 Owner 欄位取出地址持有者（共享或不可變對象回傳空字串）
+func ownerAddress(owner interface{}) string {
+	switch o := owner.(type) {
+	case map[string]interface{}:
+		if addr, ok := o["AddressOwner"].(string); ok {
+			return addr
+		}
+	case suiModels.ObjectOwner:
+		return o.AddressOwner
+	}
+	return ""
+}
@@ -0,0 +1,138 @@
+package blockchain
+
+import (
+	"bluelink-backend/internal/logger"
+	"bluelink-backend/internal/models"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// transferScanBatch 每批比對的代幣數量
+const transferScanBatch = 200
+
+// indexTransfers 比對 bond_tokens.owner 與鏈上實際持有者，記錄錢包之間的直接轉移
+// 合約不會為 transfer::public_transfer 發出事件，因此以輪詢對象狀態偵測
+func (el *EventListener) indexTransfers(ctx context.Context) error {
+	if el.tokenRepo == nil {
+		return nil
+	}
+
+	for offset := 0; ; offset += transferScanBatch {
+		tokens, err := el.tokenRepo.ListActive(ctx, transferScanBatch, offset)
+		if err != nil {
+			return err
+		}
+		if len(tokens) == 0 {
+			return nil
+		}
+
+		tokenIDs := make([]string, 0, len(tokens))
+		for _, token := range tokens {
+			tokenIDs = append(tokenIDs, token.OnChainID)
+		}
+
+		states, err := el.chainReader.GetObjectStates(ctx, tokenIDs)
+		if err != nil {
+			return err
+		}
+
+		for _, token := range tokens {
+			state, exists := states[token.OnChainID]
+			// 已銷毀的代幣由贖回事件處理；共享或被包裝的對象沒有地址持有者
+			if !exists || state.Owner == "" || strings.EqualFold(state.Owner, token.Owner) {
+				continue
+			}
+
+			if err := el.recordTransfer(ctx, token, state); err != nil {
+				logger.Error("Failed to record transfer of token %s: %v", token.OnChainID, err)
+			}
+		}
+
+		if len(tokens) < transferScanBatch {
+			return nil
+		}
+	}
+}
+
+// recordTransfer 記錄一次代幣轉移：更新持有者與持倉、寫入交易記錄、取消原持有者的掛單
+func (el *EventListener) recordTransfer(ctx context.Context, token *models.BondToken, state *ObjectState) error {
+	fromAddress := token.Owner
+	toAddress := state.Owner
+	txDigest := state.PreviousTransaction
+
+	// 已記錄過（例如二級市場成交時已寫入），只需同步持有者
+	existing, err := el.txRepo.GetByTxHashAndToken(ctx, txDigest, token.OnChainID)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return el.tokenRepo.UpdateOwner(ctx, token.OnChainID, toAddress)
+	}
+
+	bond, err := el.bondRepo.GetByOnChainID(ctx, token.ProjectID)
+	if err != nil || bond == nil {
+		return fmt.Errorf("bond not found: %s", token.ProjectID)
+	}
+
+	// 已刪除的帳戶仍持有錢包地址，轉移紀錄歸屬於原使用者
+	toUser, err := el.userRepo.GetByWalletAddressUnscoped(ctx, toAddress)
+	if err != nil {
+		return err
+	}
+	if toUser == nil {
+		toUser, err = el.userRepo.Create(ctx, toAddress)
+		if err != nil {
+			return fmt.Errorf("failed to create user: %w", err)
+		}
+	}
+
+	var fromUserID *int64
+	fromUser, err := el.userRepo.GetByWalletAddressUnscoped(ctx, fromAddress)
+	if err != nil {
+		return err
+	}
+	if fromUser != nil {
+		fromUserID = &fromUser.ID
+	}
+
+	timestamp := time.Now()
+	if transfer, err := el.chainReader.GetTokenTransferFromTransaction(ctx, txDigest, token.OnChainID); err == nil {
+		timestamp = parseTimestamp(transfer.TimestampMs)
+	} else {
+		logger.Warn("Could not read transfer transaction %s: %v", txDigest, err)
+	}
+
+	metadata, _ := MetadataToJSON(map[string]interface{}{
+		"from":     fromAddress,
+		"to":       toAddress,
+		"token_id": token.OnChainID,
+		"source":   "wallet_transfer",
+	})
+
+	quantity := int64(1)
+	tx := &models.Transaction{
+		TxHash:        txDigest,
+		EventType:     models.EventBondTransferred,
+		BondID:        &bond.ID,
+		UserID:        &toUser.ID,
+		WalletAddress: toAddress,
+		Quantity:      &quantity,
+		Status:        models.TxStatusConfirmed,
+		Timestamp:     timestamp,
+		Metadata:      metadata,
+	}
+
+	// 直接轉移沒有成交價，接收方以面額作為持倉成本
+	if err := el.txRepo.CreateTransferWithOwnership(ctx, tx, token.OnChainID, fromUserID, float64(token.Amount)); err != nil {
+		return err
+	}
+
+	el.cancelListings(ctx, token.OnChainID, models.CancelReasonTransferred)
+	el.screenActivity(ctx, toAddress, &toUser.ID, models.ScreeningTriggerTransfer, txDigest, models.EventBondTransferred, &bond.ID)
+	el.reviewLimits(ctx, toUser, toAddress, bond, 0, token.Amount, txDigest)
+
+	logger.Info("🔁 Bond token transferred: %s %s → %s (tx %s)", token.OnChainID, fromAddress, toAddress, txDigest)
+	return nil
+}
@@ -0,0 +1,294 @@
+package blockchain
+
+import (
+	"bluelink-backend/internal/models"
+	"bluelink-backend/internal/repository"
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	suiModels "github.com/block-vision/sui-go-sdk/models"
+	"github.com/block-vision/sui-go-sdk/sui"
+)
+
+const (
+	testProjectID = "0xproject"
+	testTokenID   = "0xtoken"
+	testDigest    = "TxDigest111"
+	sellerAddress = "0x00000000000000000000000000000000000000000000000000000000000000aa"
+	buyerAddress  = "0x00000000000000000000000000000000000000000000000000000000000000bb"
+)
+
+var (
+	bondTokenTestColumns = []string{
+		"id", "on_chain_id", "project_id",
+		"bond_name", "token_image_url", "maturity_date", "annual_interest_rate",
+		"token_number", "owner", "amount", "purchase_date", "is_redeemed",
+		"coin_type", "coin_decimals",
+		"created_at", "updated_at", "deleted_at",
+	}
+	transactionTestColumns = []string{
+		"id", "tx_hash", "event_type", "bond_id", "user_id", "wallet_address",
+		"amount", "quantity", "price", "status", "block_number", "timestamp", "metadata", "created_at",
+	}
+	bondTestColumns = []string{
+		"id", "on_chain_id", "issuer_address", "issuer_name", "bond_name",
+		"bond_image_url", "token_image_url", "metadata_url",
+		"total_amount", "amount_raised", "amount_redeemed",
+		"tokens_issued", "tokens_redeemed",
+		"annual_interest_rate", "maturity_date", "issue_date",
+		"active", "redeemable",
+		"raised_funds_balance", "redemption_pool_balance",
+		"coin_type", "coin_decimals",
+		"needs_review",
+		"created_at", "updated_at", "deleted_at",
+	}
+	userTestColumns = []string{
+		"id", "wallet_address", "role", "institution_name", "name", "timezone", "language",
+		"kyc_status", "kyc_level", "kyc_verified_at", "country", "is_blacklisted",
+		"daily_limit", "monthly_limit", "max_bond_share",
+		"created_at", "updated_at", "deleted_at",
+	}
+)
+
+// fakeSuiClient 只實作轉移索引會用到的 RPC，其餘方法呼叫時會 panic
+type fakeSuiClient struct {
+	sui.ISuiAPI
+	objects      []*suiModels.SuiObjectResponse
+	transaction  suiModels.SuiTransactionBlockResponse
+	requestedIDs []string
+}
+
+func (f *fakeSuiClient) SuiMultiGetObjects(ctx context.Context, req suiModels.SuiMultiGetObjectsRequest) ([]*suiModels.SuiObjectResponse, error) {
+	f.requestedIDs = append(f.requestedIDs, req.ObjectIds...)
+	return f.objects, nil
+}
+
+func (f *fakeSuiClient) SuiGetTransactionBlock(ctx context.Context, req suiModels.SuiGetTransactionBlockRequest) (suiModels.SuiTransactionBlockResponse, error) {
+	if req.Digest != f.transaction.Digest {
+		return suiModels.SuiTransactionBlockResponse{}, errors.New("transaction not found")
+	}
+	return f.transaction, nil
+}
+
+func ownedObject(objectID, owner, digest string) *suiModels.SuiObjectResponse {
+	return &suiModels.SuiObjectResponse{
+		Data: &suiModels.SuiObjectData{
+			ObjectId:            objectID,
+			Owner:               map[string]interface{}{"AddressOwner": owner},
+			PreviousTransaction: digest,
+		},
+	}
+}
+
+func newTestListener(t *testing.T, client *fakeSuiClient) (*EventListener, sqlmock.Sqlmock) {
+	t.Helper()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	listener := &EventListener{
+		suiClient:   client,
+		chainReader: NewChainReader(client, "0xpackage", nil),
+		txRepo:      repository.NewTransactionRepository(db),
+		bondRepo:    repository.NewBondRepository(db),
+		userRepo:    repository.NewUserRepository(db),
+		tokenRepo:   repository.NewBondTokenRepository(db),
+		marketRepo:  repository.NewMarketRepository(db),
+	}
+	return listener, mock
+}
+
+func expectActiveTokens(mock sqlmock.Sqlmock, owners map[string]string, order ...string) {
+	now := time.Now()
+	rows := sqlmock.NewRows(bondTokenTestColumns)
+	for i, tokenID := range order {
+		rows.AddRow(
+			i+1, tokenID, testProjectID,
+			"Test Bond", "", int64(1893456000000), 500,
+			i+1, owners[tokenID], 1000000000, now.UnixMilli(), false,
+			"0x2::sui::SUI", 9,
+			now, now, nil,
+		)
+	}
+	mock.ExpectQuery(`FROM bond_tokens\s+WHERE is_redeemed = false`).
+		WithArgs(transferScanBatch, 0).
+		WillReturnRows(rows)
+}
+
+func TestIndexTransfersSkipsUnchangedOwners(t *testing.T) {
+	client := &fakeSuiClient{
+		objects: []*suiModels.SuiObjectResponse{
+			// 持有者相同，僅大小寫不同
+			ownedObject("0xsame", "0x00000000000000000000000000000000000000000000000000000000000000AA", testDigest),
+			// 共享或被包裝的對象沒有地址持有者
+			{Data: &suiModels.SuiObjectData{ObjectId: "0xshared", Owner: map[string]interface{}{"Shared": map[string]interface{}{}}}},
+			// 已銷毀的對象不會出現在結果中（0xburned）
+		},
+	}
+	listener, mock := newTestListener(t, client)
+
+	expectActiveTokens(mock, map[string]string{
+		"0xsame":   sellerAddress,
+		"0xshared": sellerAddress,
+		"0xburned": sellerAddress,
+	}, "0xsame", "0xshared", "0xburned")
+
+	if err := listener.indexTransfers(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if len(client.requestedIDs) != 3 {
+		t.Fatalf("requested objects = %v, want all 3 active tokens", client.requestedIDs)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestIndexTransfersOnlySyncsOwnerWhenAlreadyRecorded(t *testing.T) {
+	client := &fakeSuiClient{
+		objects: []*suiModels.SuiObjectResponse{ownedObject(testTokenID, buyerAddress, testDigest)},
+	}
+	listener, mock := newTestListener(t, client)
+
+	expectActiveTokens(mock, map[string]string{testTokenID: sellerAddress}, testTokenID)
+
+	// 二級市場成交時已寫入轉移紀錄
+	now := time.Now()
+	mock.ExpectQuery(`FROM transactions\s+WHERE tx_hash = \$1 AND metadata->>'token_id' = \$2`).
+		WithArgs(testDigest, testTokenID).
+		WillReturnRows(sqlmock.NewRows(transactionTestColumns).AddRow(
+			1, testDigest, models.EventBondTransferred, 3, 9, buyerAddress,
+			nil, 1, 1200000000, models.TxStatusConfirmed, nil, now, nil, now,
+		))
+	mock.ExpectExec(`UPDATE bond_tokens\s+SET owner = \$1`).
+		WithArgs(buyerAddress, sqlmock.AnyArg(), testTokenID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	if err := listener.indexTransfers(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestIndexTransfersRecordsTransferToDeletedAccount(t *testing.T) {
+	timestampMs := int64(1767225600000)
+	client := &fakeSuiClient{
+		objects: []*suiModels.SuiObjectResponse{ownedObject(testTokenID, buyerAddress, testDigest)},
+		transaction: suiModels.SuiTransactionBlockResponse{
+			Digest:      testDigest,
+			TimestampMs: "1767225600000",
+			ObjectChanges: []suiModels.ObjectChange{{
+				Type:       "mutated",
+				Sender:     sellerAddress,
+				Owner:      map[string]interface{}{"AddressOwner": buyerAddress},
+				ObjectType: "0xpackage::bond::BondToken",
+				ObjectId:   testTokenID,
+			}},
+		},
+	}
+	listener, mock := newTestListener(t, client)
+
+	expectActiveTokens(mock, map[string]string{testTokenID: sellerAddress}, testTokenID)
+
+	now := time.Now()
+	bondID := int64(3)
+	buyerID := int64(9)
+	sellerID := int64(4)
+
+	mock.ExpectQuery(`FROM transactions\s+WHERE tx_hash = \$1 AND metadata->>'token_id' = \$2`).
+		WithArgs(testDigest, testTokenID).
+		WillReturnRows(sqlmock.NewRows(transactionTestColumns))
+	mock.ExpectQuery(`FROM bonds\s+WHERE on_chain_id = \$1`).
+		WithArgs(testProjectID).
+		WillReturnRows(sqlmock.NewRows(bondTestColumns).AddRow(
+			bondID, testProjectID, sellerAddress, "Issuer", "Test Bond",
+			"", "", "",
+			100000000000, 1000000000, 0,
+			1, 0,
+			500, "2030-01-01", "2025-01-01",
+			true, false,
+			1000000000, 0,
+			"0x2::sui::SUI", 9,
+			false,
+			now, now, nil,
+		))
+
+	// 接收方帳戶已刪除：沿用原使用者，不得重新建立
+	userQuery := `FROM users\s+WHERE wallet_address = \$1 OR id = \(SELECT user_id FROM user_wallets`
+	mock.ExpectQuery(userQuery).
+		WithArgs(buyerAddress).
+		WillReturnRows(sqlmock.NewRows(userTestColumns).AddRow(
+			buyerID, buyerAddress, "buyer", nil, nil, "UTC", "en",
+			"pending", 0, nil, "", false,
+			nil, nil, nil,
+			now, now, now,
+		))
+	mock.ExpectQuery(userQuery).
+		WithArgs(sellerAddress).
+		WillReturnRows(sqlmock.NewRows(userTestColumns).AddRow(
+			sellerID, sellerAddress, "buyer", nil, nil, "UTC", "en",
+			"approved", 1, nil, "", false,
+			nil, nil, nil,
+			now, now, nil,
+		))
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`INSERT INTO transactions`).
+		WithArgs(
+			testDigest, models.EventBondTransferred, &bondID, &buyerID, buyerAddress,
+			nil, sqlmock.AnyArg(), nil, models.TxStatusConfirmed, nil,
+			time.UnixMilli(timestampMs), sqlmock.AnyArg(), sqlmock.AnyArg(),
+		).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(11, now))
+	mock.ExpectExec(`UPDATE bond_tokens\s+SET owner = \$1`).
+		WithArgs(buyerAddress, sqlmock.AnyArg(), testTokenID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO user_bonds`).
+		WithArgs(sellerID, bondID, int64(-1), float64(0), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO user_bonds`).
+		WithArgs(buyerID, bondID, int64(1), float64(1000000000), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	// 原持有者的掛單隨代幣轉出而失效
+	mock.ExpectExec(`UPDATE market_orders\s+SET status = 'cancelled'`).
+		WithArgs(models.CancelReasonTransferred, sqlmock.AnyArg(), testTokenID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	if err := listener.indexTransfers(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRecordTransferFailsWithoutBond(t *testing.T) {
+	listener, mock := newTestListener(t, &fakeSuiClient{})
+
+	mock.ExpectQuery(`FROM transactions\s+WHERE tx_hash = \$1`).
+		WithArgs(testDigest, testTokenID).
+		WillReturnRows(sqlmock.NewRows(transactionTestColumns))
+	mock.ExpectQuery(`FROM bonds\s+WHERE on_chain_id = \$1`).
+		WithArgs(testProjectID).
+		WillReturnError(sql.ErrNoRows)
+
+	token := &models.BondToken{OnChainID: testTokenID, ProjectID: testProjectID, Owner: sellerAddress, Amount: 1000000000}
+	state := &ObjectState{Owner: buyerAddress, PreviousTransaction: testDigest}
+	if err := listener.recordTransfer(context.Background(), token, state); err == nil {
+		t.Fatal("recordTransfer() error = nil, want bond not found")
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -3,8 +3,12 @@ package config
 import (
 	"fmt"
 	"log"
+	"math"
 	"net/url"
 	"os"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/joho/godotenv"
@@ -19,6 +23,11 @@ type Config struct {
 	// Sui 區塊鏈設定
 	SuiRPCURL    string
 	SuiPackageID string // 合約 Package ID
+	SuiNetwork   string // "testnet" | "mainnet" | "devnet" | "localnet"
+
+	// Sign-In with Sui 登入訊息設定
+	SIWSDomain string // 前端網域，例如 app.bluelink.io
+	SIWSURI    string // 前端網址，例如 https://app.bluelink.io
 
 	// 資料庫設定
 	DatabaseURL string // 完整的資料庫連接字串（生產環境使用）
@@ -30,12 +39,82 @@ type Config struct {
 	DBSSLMode   string
 
 	// JWT/Session 設定
+	SessionMode    string // "cookie"（session_id Cookie）| "token"（access token + refresh token）
 	JWTSecret      string
-	SessionTimeout int // 秒
+	SessionTimeout int // 秒，Session 絕對有效期（token 模式為 refresh token 有效期）
+	AccessTokenTTL int // 秒（token 模式 access token 有效期，亦為撤銷生效的最長延遲）
+
+	// Session 政策
+	SessionIdleTimeout   int    // 秒，閒置超過即失效
+	SessionMaxDevices    int    // 同一使用者（含綁定錢包）同時登入的裝置上限
+	SessionEviction      string // 達到上限時："oldest"（登出最久未活動的裝置）| "reject"（拒絕登入）
+	SessionSlidingExpiry bool   // 到期時間隨活動延後 SESSION_IDLE_TIMEOUT，不超過建立後 SESSION_TIMEOUT
+
+	// 登入異常偵測
+	GeoCountryHeader           string // 反向代理提供來源國家的 Header，例如 CF-IPCountry（空字串表示不判斷國家）
+	LoginFailureAlertThreshold int    // 時間窗內登入失敗達此次數時通知使用者
+	LoginFailureWindow         int    // 秒，登入失敗的統計時間窗
+
+	// 登入暴力破解防護
+	AuthWalletMaxFailures    int    // 同一錢包自同一 IP 在時間窗內失敗達此次數即鎖定
+	AuthIPMaxFailures        int    // 同一 IP 在時間窗內失敗達此次數即鎖定
+	AuthFailureWindow        int    // 秒，失敗計數的時間窗
+	AuthLockoutBase          int    // 秒，第一次鎖定的時長（之後每次加倍）
+	AuthLockoutMax           int    // 秒，鎖定時長上限
+	AuthMaxOutstandingNonces int    // 全平台同時有效的登入挑戰達此數量後需人機驗證（未啟用時拒絕）
+	AuthMaxNoncesPerIP       int    // 單一 IP 同時有效的登入挑戰上限
+	HumanCheck               string // "none" | "pow"（proof-of-work）| "captcha"
+	HumanCheckAfter          int    // 錢包或 IP 失敗達此次數後，請求挑戰需通過人機驗證（0 表示一律需要）
+	PoWDifficulty            int    // proof-of-work 需要的前導零位元數
+	CaptchaVerifyURL         string // siteverify API，例如 https://challenges.cloudflare.com/turnstile/v0/siteverify
+	CaptchaSecret            string
+	CaptchaSiteKey           string
+
+	// 估值設定（年化利率以小數表示，0.05 = 5%）
+	YieldCurve          []YieldCurvePoint  // 無風險殖利率曲線，依期限排序且期限不重複
+	DefaultCreditSpread float64            // 未個別設定之發行者的信用利差
+	IssuerCreditSpreads map[string]float64 // 發行者地址 → 信用利差
+
+	// 匯率設定
+	PriceProvider        string             // "static" | "file" | "http"
+	PriceStaticRates     map[string]float64 // "SUI/USD" → 匯率（static 使用）
+	PriceFilePath        string             // 匯率 JSON 檔案路徑（file 使用）
+	PriceHTTPURL         string             // 匯率 API，{base} 與 {quote} 會被替換（http 使用）
+	PriceCacheTTL        int                // 匯率快取秒數
+	PriceQuoteCurrencies []string           // 可換算的法幣
+
+	// KYC 設定
+	BlobStore           string // "local"
+	BlobStoreDir        string // 本機儲存目錄（local 使用）
+	KYCMaxDocumentSize  int64  // 單一文件大小上限（bytes）
+	KYCMinLevelInvestor int    // 購買/交易債券所需的最低 KYC 等級
+	KYCMinLevelIssuer   int    // 發行債券所需的最低 KYC 等級
+
+	// 制裁/黑名單比對設定
+	ScreeningListPaths      []string // 名單檔案路徑（CSV 或 JSON）
+	ScreeningRescanInterval int      // 定期重新比對持有者的間隔秒數（0 表示停用）
+
+	// 投資限額設定
+	InvestmentLimitCurrency string                       // 限額計價的法幣（需在 PriceQuoteCurrencies 中）
+	InvestmentLevelLimits   map[int]InvestmentLevelLimit // KYC 等級 → 預設限額（未設定的等級不限）
 
 	// 其他設定
 	LogLevel           string
 	CORSAllowedOrigins []string // CORS 允許的來源清單
+	CSRFTrustedOrigins []string // 允許以 Cookie 發出狀態變更請求的來源（未設定時僅允許同源）
+}
+
+// YieldCurvePoint 殖利率曲線上的一個節點
+type YieldCurvePoint struct {
+	TenorYears float64 // 期限（年）
+	Rate       float64 // 年化殖利率
+}
+
+// InvestmentLevelLimit 單一 KYC 等級的預設投資限額，nil 表示不限
+type InvestmentLevelLimit struct {
+	Daily        *float64 // 每日投資金額上限
+	Monthly      *float64 // 每月投資金額上限
+	MaxBondShare *float64 // 單一債券最高持有比例（0~1，以面額佔募集總額計）
 }
 
 // LoadConfig 從環境變數載入配置
@@ -64,15 +143,79 @@ func LoadConfig() *Config {
 		SuiRPCURL:    getEnv("SUI_RPC_URL", "https://fullnode.testnet.sui.io:443"),
 		SuiPackageID: getEnv("SUI_PACKAGE_ID", ""),
 
+		// Sign-In with Sui 設定
+		SIWSURI: getEnv("SIWS_URI", "http://localhost:3000"),
+
 		// 安全設定
+		SessionMode:    getEnv("SESSION_MODE", "cookie"),
 		JWTSecret:      getEnv("JWT_SECRET", "your-secret-key-change-in-production"),
 		SessionTimeout: getEnvAsInt("SESSION_TIMEOUT", 86400), // 24 小時
+		AccessTokenTTL: getEnvAsInt("ACCESS_TOKEN_TTL", 300),  // 5 分鐘
+
+		// Session 政策
+		SessionIdleTimeout:   getEnvAsInt("SESSION_IDLE_TIMEOUT", 1800), // 30 分鐘
+		SessionMaxDevices:    getEnvAsInt("SESSION_MAX_DEVICES", 3),
+		SessionEviction:      getEnv("SESSION_EVICTION", "oldest"),
+		SessionSlidingExpiry: getEnvAsBool("SESSION_SLIDING_EXPIRY", false),
+
+		// 估值設定
+		YieldCurve:          parseYieldCurve(getEnv("VALUATION_YIELD_CURVE", "0.25:0.04,1:0.042,3:0.045,5:0.047,10:0.05")),
+		DefaultCreditSpread: getEnvAsFloat("VALUATION_DEFAULT_CREDIT_SPREAD", 0.02),
+		IssuerCreditSpreads: parseCreditSpreads(getEnv("VALUATION_ISSUER_CREDIT_SPREADS", "")),
+
+		// 匯率設定
+		PriceProvider:        getEnv("PRICE_PROVIDER", "static"),
+		PriceStaticRates:     parsePriceRates(getEnv("PRICE_STATIC_RATES", "")),
+		PriceFilePath:        getEnv("PRICE_FILE_PATH", ""),
+		PriceHTTPURL:         getEnv("PRICE_HTTP_URL", ""),
+		PriceCacheTTL:        getEnvAsInt("PRICE_CACHE_TTL", 60),
+		PriceQuoteCurrencies: parseCurrencies(getEnv("PRICE_QUOTE_CURRENCIES", "USD,TWD")),
+
+		// KYC 設定
+		BlobStore:           getEnv("BLOB_STORE", "local"),
+		BlobStoreDir:        getEnv("BLOB_STORE_DIR", "./data/blobs"),
+		KYCMaxDocumentSize:  int64(getEnvAsInt("KYC_MAX_DOCUMENT_SIZE", 10<<20)), // 10 MB
+		KYCMinLevelInvestor: getEnvAsInt("KYC_MIN_LEVEL_INVESTOR", 1),
+		KYCMinLevelIssuer:   getEnvAsInt("KYC_MIN_LEVEL_ISSUER", 2),
+
+		// 登入異常偵測
+		GeoCountryHeader:           getEnv("GEO_COUNTRY_HEADER", ""),
+		LoginFailureAlertThreshold: getEnvAsInt("LOGIN_FAILURE_ALERT_THRESHOLD", 5),
+		LoginFailureWindow:         getEnvAsInt("LOGIN_FAILURE_WINDOW", 900), // 15 分鐘
+
+		// 登入暴力破解防護
+		AuthWalletMaxFailures:    getEnvAsInt("AUTH_WALLET_MAX_FAILURES", 5),
+		AuthIPMaxFailures:        getEnvAsInt("AUTH_IP_MAX_FAILURES", 20),
+		AuthFailureWindow:        getEnvAsInt("AUTH_FAILURE_WINDOW", 900), // 15 分鐘
+		AuthLockoutBase:          getEnvAsInt("AUTH_LOCKOUT_BASE", 60),    // 1 分鐘
+		AuthLockoutMax:           getEnvAsInt("AUTH_LOCKOUT_MAX", 86400),  // 1 天
+		AuthMaxOutstandingNonces: getEnvAsInt("AUTH_MAX_OUTSTANDING_NONCES", 10000),
+		AuthMaxNoncesPerIP:       getEnvAsInt("AUTH_MAX_NONCES_PER_IP", 20),
+		HumanCheck:               strings.ToLower(getEnv("HUMAN_CHECK", "none")),
+		HumanCheckAfter:          getEnvAsInt("HUMAN_CHECK_AFTER", 3),
+		PoWDifficulty:            getEnvAsInt("POW_DIFFICULTY", 20),
+		CaptchaVerifyURL:         getEnv("CAPTCHA_VERIFY_URL", ""),
+		CaptchaSecret:            getEnv("CAPTCHA_SECRET", ""),
+		CaptchaSiteKey:           getEnv("CAPTCHA_SITE_KEY", ""),
+
+		// 制裁/黑名單比對設定
+		ScreeningListPaths:      parseList(getEnv("SCREENING_LIST_PATHS", "")),
+		ScreeningRescanInterval: getEnvAsInt("SCREENING_RESCAN_INTERVAL", 86400), // 每天
+
+		// 投資限額設定
+		InvestmentLimitCurrency: strings.ToUpper(getEnv("INVESTMENT_LIMIT_CURRENCY", "USD")),
+		InvestmentLevelLimits:   parseInvestmentLimits(getEnv("INVESTMENT_LEVEL_LIMITS", "0:0/0/0,1:10000/50000/0.1,2:250000/1000000/0.25")),
 
 		// 其他設定
 		LogLevel:           getEnv("LOG_LEVEL", "info"),
 		CORSAllowedOrigins: parseCORSOrigins(getEnv("CORS_ALLOWED_ORIGINS", "*")),
+		CSRFTrustedOrigins: parseOriginList(getEnv("CSRF_TRUSTED_ORIGINS", "")),
 	}
 
+	// 未指定時由 RPC URL 推斷網路、由 SIWS_URI 推斷網域
+	config.SuiNetwork = getEnv("SUI_NETWORK", suiNetworkFromRPC(config.SuiRPCURL))
+	config.SIWSDomain = getEnv("SIWS_DOMAIN", hostFromURL(config.SIWSURI))
+
 	// 根據環境決定資料庫配置方式
 	if environment == "production" {
 		// 生產環境：必須使用 DATABASE_URL
@@ -159,6 +302,193 @@ func getEnvAsInt(key string, defaultValue int) int {
 	return value
 }
 
+func getEnvAsFloat(key string, defaultValue float64) float64 {
+	valueStr := os.Getenv(key)
+	if valueStr == "" {
+		return defaultValue
+	}
+
+	value, err := strconv.ParseFloat(valueStr, 64)
+	if err != nil {
+		log.Printf("Invalid float for %s, using default: %g", key, defaultValue)
+		return defaultValue
+	}
+	return value
+}
+
+// parseYieldCurve 解析殖利率曲線字串，格式："期限年:利率,..."，例如 "1:0.042,5:0.047"
+// 依期限排序並合併重複的節點；格式錯誤或同一期限有不同利率時無法折現，直接終止
+func parseYieldCurve(curveStr string) []YieldCurvePoint {
+	points := []YieldCurvePoint{}
+	for _, pair := range strings.Split(curveStr, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		parts := strings.SplitN(strings.TrimSpace(pair), ":", 2)
+		if len(parts) != 2 {
+			log.Fatalf("Invalid VALUATION_YIELD_CURVE point %q, expected tenor:rate", pair)
+		}
+
+		tenor, err1 := strconv.ParseFloat(strings.TrimSpace(parts[0]), 64)
+		rate, err2 := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
+		if err1 != nil || err2 != nil || math.IsNaN(tenor) || math.IsInf(tenor, 0) || tenor < 0 ||
+			math.IsNaN(rate) || math.IsInf(rate, 0) || rate <= -1 {
+			log.Fatalf("Invalid VALUATION_YIELD_CURVE point %q", pair)
+		}
+		points = append(points, YieldCurvePoint{TenorYears: tenor, Rate: rate})
+	}
+
+	sort.Slice(points, func(i, j int) bool {
+		return points[i].TenorYears < points[j].TenorYears
+	})
+
+	curve := []YieldCurvePoint{}
+	for _, point := range points {
+		if n := len(curve); n > 0 && curve[n-1].TenorYears == point.TenorYears {
+			if curve[n-1].Rate != point.Rate {
+				log.Fatalf("VALUATION_YIELD_CURVE has conflicting rates for tenor %g", point.TenorYears)
+			}
+			continue
+		}
+		curve = append(curve, point)
+	}
+	return curve
+}
+
+// parseCreditSpreads 解析發行者信用利差，格式："地址:利差,..."
+func parseCreditSpreads(spreadsStr string) map[string]float64 {
+	spreads := make(map[string]float64)
+	for _, pair := range strings.Split(spreadsStr, ",") {
+		parts := strings.SplitN(strings.TrimSpace(pair), ":", 2)
+		if len(parts) != 2 {
+			continue
+		}
+
+		spread, err := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
+		if err != nil {
+			log.Printf("Invalid credit spread for %s, skipping", parts[0])
+			continue
+		}
+		spreads[strings.ToLower(strings.TrimSpace(parts[0]))] = spread
+	}
+	return spreads
+}
+
+// parsePriceRates 解析固定匯率，格式："幣種/法幣:匯率,..."，例如 "SUI/USD:1.25,SUI/TWD:40"
+func parsePriceRates(ratesStr string) map[string]float64 {
+	rates := make(map[string]float64)
+	for _, pair := range strings.Split(ratesStr, ",") {
+		parts := strings.SplitN(strings.TrimSpace(pair), ":", 2)
+		if len(parts) != 2 || !strings.Contains(parts[0], "/") {
+			continue
+		}
+
+		rate, err := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
+		if err != nil || rate <= 0 {
+			log.Printf("Invalid price rate %q, skipping", pair)
+			continue
+		}
+		rates[strings.ToUpper(strings.TrimSpace(parts[0]))] = rate
+	}
+	return rates
+}
+
+// parseCurrencies 解析法幣代碼清單
+func parseCurrencies(currenciesStr string) []string {
+	currencies := []string{}
+	for _, currency := range strings.Split(currenciesStr, ",") {
+		if trimmed := strings.ToUpper(strings.TrimSpace(currency)); trimmed != "" {
+			currencies = append(currencies, trimmed)
+		}
+	}
+	return currencies
+}
+
+// parseInvestmentLimits 解析各 KYC 等級的投資限額
+// 格式："等級:每日/每月/單一債券比例,..."，"-" 表示不限，例如 "1:10000/50000/0.1,2:-/-/0.25"
+func parseInvestmentLimits(limitsStr string) map[int]InvestmentLevelLimit {
+	limits := make(map[int]InvestmentLevelLimit)
+	for _, pair := range strings.Split(limitsStr, ",") {
+		parts := strings.SplitN(strings.TrimSpace(pair), ":", 2)
+		if len(parts) != 2 {
+			continue
+		}
+
+		level, err := strconv.Atoi(strings.TrimSpace(parts[0]))
+		values := strings.Split(parts[1], "/")
+		if err != nil || len(values) != 3 {
+			log.Printf("Invalid investment limit %q, skipping", pair)
+			continue
+		}
+
+		parsed := make([]*float64, 3)
+		valid := true
+		for i, value := range values {
+			value = strings.TrimSpace(value)
+			if value == "-" || value == "" {
+				continue
+			}
+			number, err := strconv.ParseFloat(value, 64)
+			if err != nil || number < 0 {
+				valid = false
+				break
+			}
+			parsed[i] = &number
+		}
+		if !valid || (parsed[2] != nil && *parsed[2] > 1) {
+			log.Printf("Invalid investment limit %q, skipping", pair)
+			continue
+		}
+
+		limits[level] = InvestmentLevelLimit{Daily: parsed[0], Monthly: parsed[1], MaxBondShare: parsed[2]}
+	}
+	return limits
+}
+
+// parseList 解析逗號分隔的字串清單
+func parseList(listStr string) []string {
+	items := []string{}
+	for _, item := range strings.Split(listStr, ",") {
+		if trimmed := strings.TrimSpace(item); trimmed != "" {
+			items = append(items, trimmed)
+		}
+	}
+	return items
+}
+
+// suiNetworkFromRPC 由 RPC URL 推斷 Sui 網路名稱，無法判斷時視為 testnet
+func suiNetworkFromRPC(rpcURL string) string {
+	for _, network := range []string{"mainnet", "testnet", "devnet"} {
+		if strings.Contains(rpcURL, network) {
+			return network
+		}
+	}
+	if strings.Contains(rpcURL, "localhost") || strings.Contains(rpcURL, "127.0.0.1") {
+		return "localnet"
+	}
+	return "testnet"
+}
+
+// hostFromURL 取得網址的主機部分（含埠號）
+func hostFromURL(rawURL string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	return parsed.Host
+}
+
+// parseOriginList 解析以逗號分隔的來源清單（未設定時回傳空清單）
+func parseOriginList(originsStr string) []string {
+	result := []string{}
+	for _, origin := range strings.Split(originsStr, ",") {
+		if trimmed := strings.TrimSpace(origin); trimmed != "" {
+			result = append(result, trimmed)
+		}
+	}
+	return result
+}
+
 // parseCORSOrigins 解析 CORS 允許來源字串
 func parseCORSOrigins(originsStr string) []string {
 	// 如果是 "*"，返回包含 "*" 的陣列
@@ -184,22 +514,22 @@ func parseCORSOrigins(originsStr string) []string {
 	return result
 }
 
-// func getEnvAsBool(key string, defaultValue bool) bool {
-// 	valueStr := os.Getenv(key)
-// 	if valueStr == "" {
-// 		return defaultValue
-// 	}
+func getEnvAsBool(key string, defaultValue bool) bool {
+	valueStr := os.Getenv(key)
+	if valueStr == "" {
+		return defaultValue
+	}
 
-// 	switch valueStr {
-// 	case "true", "1", "yes", "on":
-// 		return true
-// 	case "false", "0", "no", "off":
-// 		return false
-// 	default:
-// 		log.Printf("Invalid boolean for %s, using default: %t", key, defaultValue)
-// 		return defaultValue
-// 	}
-// }
+	switch valueStr {
+	case "true", "1", "yes", "on":
+		return true
+	case "false", "0", "no", "off":
+		return false
+	default:
+		log.Printf("Invalid boolean for %s, using default: %t", key, defaultValue)
+		return defaultValue
+	}
+}
 
 func (c *Config) Validate() {
 	if c.Environment == "production" {
@@ -210,6 +540,72 @@ func (c *Config) Validate() {
 		if c.DBPassword == "" {
 			log.Fatal("DB_PASSWORD must be set in production!")
 		}
+		if strings.HasPrefix(c.SIWSDomain, "localhost") {
+			log.Fatal("SIWS_URI / SIWS_DOMAIN must be set in production!")
+		}
+	}
+
+	// 投資限額以 PriceQuoteCurrencies 中的法幣計價
+	supported := false
+	for _, currency := range c.PriceQuoteCurrencies {
+		if currency == c.InvestmentLimitCurrency {
+			supported = true
+			break
+		}
+	}
+	if !supported {
+		log.Fatalf("INVESTMENT_LIMIT_CURRENCY %s must be one of PRICE_QUOTE_CURRENCIES", c.InvestmentLimitCurrency)
+	}
+
+	if c.SessionMode != "cookie" && c.SessionMode != "token" {
+		log.Fatalf("SESSION_MODE must be cookie or token, got %s", c.SessionMode)
+	}
+	if c.SessionTimeout <= 0 || c.SessionIdleTimeout <= 0 {
+		log.Fatal("SESSION_TIMEOUT and SESSION_IDLE_TIMEOUT must be positive")
+	}
+	if c.SessionMaxDevices < 1 {
+		log.Fatal("SESSION_MAX_DEVICES must be at least 1")
+	}
+	if c.SessionEviction != "oldest" && c.SessionEviction != "reject" {
+		log.Fatalf("SESSION_EVICTION must be oldest or reject, got %s", c.SessionEviction)
+	}
+	// CSRF 來源必須明確列出，不沿用 CORS 的 "*"
+	if slices.Contains(c.CSRFTrustedOrigins, "*") {
+		log.Fatal("CSRF_TRUSTED_ORIGINS must list explicit origins, \"*\" is not allowed")
+	}
+	if c.Environment == "production" && c.SessionMode == "cookie" && len(c.CSRFTrustedOrigins) == 0 {
+		log.Fatal("CSRF_TRUSTED_ORIGINS must be set in production cookie session mode!")
+	}
+	if c.SessionMode == "token" {
+		if len(c.JWTSecret) < 32 {
+			log.Fatal("JWT_SECRET must be at least 32 characters in token session mode")
+		}
+		if c.AccessTokenTTL <= 0 || c.AccessTokenTTL >= c.SessionTimeout {
+			log.Fatal("ACCESS_TOKEN_TTL must be positive and shorter than SESSION_TIMEOUT")
+		}
+	}
+	if c.LoginFailureAlertThreshold < 1 || c.LoginFailureWindow <= 0 {
+		log.Fatal("LOGIN_FAILURE_ALERT_THRESHOLD must be at least 1 and LOGIN_FAILURE_WINDOW must be positive")
+	}
+	if c.AuthWalletMaxFailures < 1 || c.AuthIPMaxFailures < 1 || c.AuthFailureWindow <= 0 {
+		log.Fatal("AUTH_WALLET_MAX_FAILURES and AUTH_IP_MAX_FAILURES must be at least 1 and AUTH_FAILURE_WINDOW must be positive")
+	}
+	if c.AuthLockoutBase <= 0 || c.AuthLockoutMax < c.AuthLockoutBase {
+		log.Fatal("AUTH_LOCKOUT_BASE must be positive and not greater than AUTH_LOCKOUT_MAX")
+	}
+	if c.AuthMaxOutstandingNonces < 1 || c.AuthMaxNoncesPerIP < 1 {
+		log.Fatal("AUTH_MAX_OUTSTANDING_NONCES and AUTH_MAX_NONCES_PER_IP must be at least 1")
+	}
+	if c.HumanCheck == "pow" && (c.PoWDifficulty < 1 || c.PoWDifficulty > 32) {
+		log.Fatal("POW_DIFFICULTY must be between 1 and 32")
+	}
+
+	if len(c.YieldCurve) == 0 {
+		log.Fatal("VALUATION_YIELD_CURVE must have at least one point")
+	}
+
+	if c.SIWSDomain == "" {
+		log.Fatalf("SIWS_DOMAIN could not be derived from SIWS_URI %s", c.SIWSURI)
 	}
 
 	log.Printf("Configuration loaded successfully")
@@ -263,6 +263,835 @@ func GetMigrations() []Migration {
 			`,
 			Down: `DROP TABLE IF EXISTS nonces;`,
 		},
+		{
+			Version:     10,
+			Description: "Create bond_proposals table and review flag on bonds",
+			Up: `
+				CREATE TABLE IF NOT EXISTS bond_proposals (
+					id BIGSERIAL PRIMARY KEY,
+					issuer_user_id BIGINT NOT NULL,
+					issuer_address VARCHAR(66) NOT NULL,
+					issuer_name VARCHAR(255) NOT NULL,
+					bond_name VARCHAR(255) NOT NULL,
+					description TEXT,
+
+					-- 債券條款（金額單位：計價幣種最小單位，利率單位：basis points）
+					total_amount BIGINT NOT NULL,
+					annual_interest_rate BIGINT NOT NULL,
+					maturity_date VARCHAR(10) NOT NULL,
+
+					-- 圖片與文件
+					bond_image_url VARCHAR(500),
+					token_image_url VARCHAR(500),
+					metadata_url VARCHAR(500),
+					documents JSONB NOT NULL DEFAULT '[]',
+
+					-- 審核流程
+					status VARCHAR(20) NOT NULL DEFAULT 'draft',
+					review_comment TEXT,
+					reviewed_by BIGINT,
+					reviewed_at TIMESTAMP,
+					submitted_at TIMESTAMP,
+
+					-- 鏈上關聯
+					bond_id BIGINT,
+					on_chain_id VARCHAR(66),
+
+					created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					deleted_at TIMESTAMP,
+					FOREIGN KEY (issuer_user_id) REFERENCES users(id) ON DELETE CASCADE,
+					FOREIGN KEY (reviewed_by) REFERENCES users(id) ON DELETE SET NULL,
+					FOREIGN KEY (bond_id) REFERENCES bonds(id) ON DELETE SET NULL,
+					CONSTRAINT chk_proposal_status CHECK (status IN ('draft', 'submitted', 'approved', 'rejected', 'linked'))
+				);
+
+				CREATE INDEX IF NOT EXISTS idx_bond_proposals_issuer_user_id ON bond_proposals(issuer_user_id);
+				CREATE INDEX IF NOT EXISTS idx_bond_proposals_issuer_address ON bond_proposals(issuer_address);
+				CREATE INDEX IF NOT EXISTS idx_bond_proposals_status ON bond_proposals(status);
+				CREATE INDEX IF NOT EXISTS idx_bond_proposals_bond_id ON bond_proposals(bond_id);
+
+				ALTER TABLE bonds
+				ADD COLUMN IF NOT EXISTS needs_review BOOLEAN NOT NULL DEFAULT false;
+
+				CREATE INDEX IF NOT EXISTS idx_bonds_needs_review ON bonds(needs_review);
+			`,
+			Down: `
+				DROP INDEX IF EXISTS idx_bonds_needs_review;
+				ALTER TABLE bonds DROP COLUMN IF EXISTS needs_review;
+				DROP TABLE IF EXISTS bond_proposals;
+			`,
+		},
+		{
+			Version:     11,
+			Description: "Create impact metadata and impact report tables",
+			Up: `
+				CREATE TABLE IF NOT EXISTS bond_impact (
+					id BIGSERIAL PRIMARY KEY,
+					bond_id BIGINT UNIQUE NOT NULL,
+					category VARCHAR(50) NOT NULL,
+					sdg_targets INTEGER[] NOT NULL DEFAULT '{}',
+					location JSONB, -- GeoJSON geometry / Feature
+					created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					FOREIGN KEY (bond_id) REFERENCES bonds(id) ON DELETE CASCADE,
+					CONSTRAINT chk_impact_category CHECK (category IN (
+						'fisheries', 'coral', 'plastics', 'mangroves', 'wastewater',
+						'coastal_resilience', 'marine_protected_areas', 'shipping', 'other'
+					))
+				);
+
+				CREATE INDEX IF NOT EXISTS idx_bond_impact_category ON bond_impact(category);
+
+				CREATE TABLE IF NOT EXISTS impact_kpis (
+					id BIGSERIAL PRIMARY KEY,
+					bond_id BIGINT NOT NULL,
+					name VARCHAR(255) NOT NULL,
+					unit VARCHAR(50) NOT NULL,
+					description TEXT,
+					baseline DECIMAL(20, 4) NOT NULL DEFAULT 0,
+					target DECIMAL(20, 4) NOT NULL,
+					created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					FOREIGN KEY (bond_id) REFERENCES bonds(id) ON DELETE CASCADE
+				);
+
+				CREATE INDEX IF NOT EXISTS idx_impact_kpis_bond_id ON impact_kpis(bond_id);
+
+				CREATE TABLE IF NOT EXISTS impact_reports (
+					id BIGSERIAL PRIMARY KEY,
+					bond_id BIGINT NOT NULL,
+					submitted_by BIGINT,
+					period_start VARCHAR(10) NOT NULL,
+					period_end VARCHAR(10) NOT NULL,
+					summary TEXT,
+					attachment_url VARCHAR(500),
+					created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					FOREIGN KEY (bond_id) REFERENCES bonds(id) ON DELETE CASCADE,
+					FOREIGN KEY (submitted_by) REFERENCES users(id) ON DELETE SET NULL
+				);
+
+				CREATE INDEX IF NOT EXISTS idx_impact_reports_bond_id ON impact_reports(bond_id);
+				CREATE INDEX IF NOT EXISTS idx_impact_reports_period_end ON impact_reports(period_end);
+
+				CREATE TABLE IF NOT EXISTS impact_report_values (
+					id BIGSERIAL PRIMARY KEY,
+					report_id BIGINT NOT NULL,
+					kpi_id BIGINT NOT NULL,
+					value DECIMAL(20, 4) NOT NULL,
+					note TEXT,
+					FOREIGN KEY (report_id) REFERENCES impact_reports(id) ON DELETE CASCADE,
+					FOREIGN KEY (kpi_id) REFERENCES impact_kpis(id) ON DELETE CASCADE,
+					UNIQUE (report_id, kpi_id)
+				);
+
+				CREATE INDEX IF NOT EXISTS idx_impact_report_values_kpi_id ON impact_report_values(kpi_id);
+			`,
+			Down: `
+				DROP TABLE IF EXISTS impact_report_values;
+				DROP TABLE IF EXISTS impact_reports;
+				DROP TABLE IF EXISTS impact_kpis;
+				DROP TABLE IF EXISTS bond_impact;
+			`,
+		},
+		{
+			Version:     12,
+			Description: "Create market_orders table for secondary market",
+			Up: `
+				CREATE TABLE IF NOT EXISTS market_orders (
+					id BIGSERIAL PRIMARY KEY,
+					bond_id BIGINT NOT NULL,
+					side VARCHAR(4) NOT NULL,
+					token_id VARCHAR(66), -- 賣單必填（BondToken on_chain_id）
+
+					-- 掛單者與簽名
+					maker_user_id BIGINT NOT NULL,
+					maker_address VARCHAR(66) NOT NULL,
+					price BIGINT NOT NULL, -- 債券計價幣種（bonds.coin_type）最小單位
+					nonce VARCHAR(64) NOT NULL,
+					expires_at TIMESTAMP,
+					message TEXT NOT NULL,
+					signature TEXT UNIQUE NOT NULL,
+
+					-- 狀態與成交資訊
+					status VARCHAR(20) NOT NULL DEFAULT 'open',
+					cancel_reason VARCHAR(50),
+					taker_address VARCHAR(66),
+					filled_token_id VARCHAR(66),
+					filled_tx_hash VARCHAR(66),
+					filled_at TIMESTAMP,
+
+					created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					FOREIGN KEY (bond_id) REFERENCES bonds(id) ON DELETE CASCADE,
+					FOREIGN KEY (maker_user_id) REFERENCES users(id) ON DELETE CASCADE,
+					CONSTRAINT chk_market_order_side CHECK (side IN ('ask', 'bid')),
+					CONSTRAINT chk_market_order_status CHECK (status IN ('open', 'filled', 'cancelled')),
+					CONSTRAINT chk_market_order_price CHECK (price > 0),
+					CONSTRAINT chk_market_order_token CHECK (side = 'bid' OR token_id IS NOT NULL)
+				);
+
+				-- 每個代幣同時只能有一筆有效賣單
+				CREATE UNIQUE INDEX IF NOT EXISTS idx_market_orders_open_ask
+					ON market_orders(token_id) WHERE status = 'open' AND side = 'ask';
+				CREATE INDEX IF NOT EXISTS idx_market_orders_bond_status ON market_orders(bond_id, status);
+				CREATE INDEX IF NOT EXISTS idx_market_orders_maker_address ON market_orders(maker_address);
+			`,
+			Down: `DROP TABLE IF EXISTS market_orders;`,
+		},
+		{
+			Version:     13,
+			Description: "Allow multiple token records per transaction and index token history",
+			Up: `
+				-- 一筆鏈上交易可能轉移多個代幣，改以 (tx_hash, token_id) 作為唯一鍵
+				ALTER TABLE transactions DROP CONSTRAINT IF EXISTS transactions_tx_hash_key;
+				CREATE UNIQUE INDEX IF NOT EXISTS idx_transactions_tx_hash_token
+					ON transactions(tx_hash, (COALESCE(metadata->>'token_id', '')));
+				CREATE INDEX IF NOT EXISTS idx_transactions_token_id ON transactions((metadata->>'token_id'));
+			`,
+			Down: `
+				DROP INDEX IF EXISTS idx_transactions_token_id;
+				DROP INDEX IF EXISTS idx_transactions_tx_hash_token;
+				ALTER TABLE transactions ADD CONSTRAINT transactions_tx_hash_key UNIQUE (tx_hash);
+			`,
+		},
+		{
+			Version:     14,
+			Description: "Add coin denomination to bonds and bond tokens and create coin metadata registry",
+			Up: `
+				-- 債券計價幣種（BondProject<T> 的型別參數）與小數位數，既有資料皆為 SUI
+				ALTER TABLE bonds
+					ADD COLUMN IF NOT EXISTS coin_type TEXT NOT NULL DEFAULT '0x2::sui::SUI',
+					ADD COLUMN IF NOT EXISTS coin_decimals SMALLINT NOT NULL DEFAULT 9;
+				ALTER TABLE bond_tokens
+					ADD COLUMN IF NOT EXISTS coin_type TEXT NOT NULL DEFAULT '0x2::sui::SUI',
+					ADD COLUMN IF NOT EXISTS coin_decimals SMALLINT NOT NULL DEFAULT 9;
+
+				-- 幣種元數據快取（來源：suix_getCoinMetadata）
+				CREATE TABLE IF NOT EXISTS coin_metadata (
+					coin_type TEXT PRIMARY KEY,
+					metadata_id VARCHAR(66),
+					decimals SMALLINT NOT NULL CHECK (decimals >= 0),
+					symbol VARCHAR(32) NOT NULL,
+					name VARCHAR(255) NOT NULL DEFAULT '',
+					description TEXT NOT NULL DEFAULT '',
+					icon_url TEXT NOT NULL DEFAULT '',
+					created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+					updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+				);
+
+				INSERT INTO coin_metadata (coin_type, decimals, symbol, name)
+				VALUES ('0x2::sui::SUI', 9, 'SUI', 'Sui')
+				ON CONFLICT (coin_type) DO NOTHING;
+			`,
+			Down: `
+				DROP TABLE IF EXISTS coin_metadata;
+				ALTER TABLE bond_tokens DROP COLUMN IF EXISTS coin_decimals, DROP COLUMN IF EXISTS coin_type;
+				ALTER TABLE bonds DROP COLUMN IF EXISTS coin_decimals, DROP COLUMN IF EXISTS coin_type;
+			`,
+		},
+		{
+			Version:     15,
+			Description: "Create price_snapshots table",
+			Up: `
+				-- 歷史匯率（1 單位幣種 = rate 單位法幣）
+				CREATE TABLE IF NOT EXISTS price_snapshots (
+					id BIGSERIAL PRIMARY KEY,
+					base VARCHAR(32) NOT NULL,
+					quote VARCHAR(8) NOT NULL,
+					rate NUMERIC(30, 12) NOT NULL CHECK (rate > 0),
+					source VARCHAR(32) NOT NULL,
+					observed_at TIMESTAMP NOT NULL,
+					created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+					CONSTRAINT uq_price_snapshots UNIQUE (base, quote, source, observed_at)
+				);
+
+				CREATE INDEX IF NOT EXISTS idx_price_snapshots_pair ON price_snapshots(base, quote, observed_at DESC);
+			`,
+			Down: `DROP TABLE IF EXISTS price_snapshots;`,
+		},
+		{
+			Version:     16,
+			Description: "Add KYC status to users and sessions and create KYC application tables",
+			Up: `
+				-- 使用者 KYC 狀態（kyc_level：0 未認證、1 基本、2 進階）
+				ALTER TABLE users
+					ADD COLUMN IF NOT EXISTS kyc_status VARCHAR(20) NOT NULL DEFAULT 'none',
+					ADD COLUMN IF NOT EXISTS kyc_level SMALLINT NOT NULL DEFAULT 0,
+					ADD COLUMN IF NOT EXISTS kyc_verified_at TIMESTAMP,
+					ADD COLUMN IF NOT EXISTS country VARCHAR(2);
+				ALTER TABLE users ADD CONSTRAINT chk_kyc_status
+					CHECK (kyc_status IN ('none', 'pending', 'verified', 'rejected'));
+				ALTER TABLE users ADD CONSTRAINT chk_kyc_level CHECK (kyc_level BETWEEN 0 AND 2);
+
+				-- Session 攜帶 KYC 狀態，供 middleware 判斷權限
+				ALTER TABLE sessions
+					ADD COLUMN IF NOT EXISTS kyc_status VARCHAR(20) NOT NULL DEFAULT 'none',
+					ADD COLUMN IF NOT EXISTS kyc_level SMALLINT NOT NULL DEFAULT 0;
+
+				CREATE TABLE IF NOT EXISTS kyc_applications (
+					id BIGSERIAL PRIMARY KEY,
+					user_id BIGINT NOT NULL,
+					wallet_address VARCHAR(66) NOT NULL,
+					requested_level SMALLINT NOT NULL,
+
+					-- 申請人資料
+					full_name VARCHAR(255) NOT NULL,
+					date_of_birth VARCHAR(10) NOT NULL, -- 格式: YYYY-MM-DD
+					country VARCHAR(2) NOT NULL,        -- ISO 3166-1 alpha-2
+					address TEXT,
+
+					-- 審核流程
+					status VARCHAR(20) NOT NULL DEFAULT 'draft',
+					approved_level SMALLINT,
+					review_comment TEXT,
+					reviewed_by BIGINT,
+					reviewed_at TIMESTAMP,
+					submitted_at TIMESTAMP,
+
+					created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+					FOREIGN KEY (reviewed_by) REFERENCES users(id) ON DELETE SET NULL,
+					CONSTRAINT chk_kyc_application_status CHECK (status IN ('draft', 'pending', 'approved', 'rejected')),
+					CONSTRAINT chk_kyc_application_level CHECK (requested_level BETWEEN 1 AND 2)
+				);
+
+				-- 每位使用者同時只能有一筆進行中（草稿或審核中）的申請
+				CREATE UNIQUE INDEX IF NOT EXISTS idx_kyc_applications_open
+					ON kyc_applications(user_id) WHERE status IN ('draft', 'pending');
+				CREATE INDEX IF NOT EXISTS idx_kyc_applications_status ON kyc_applications(status);
+
+				CREATE TABLE IF NOT EXISTS kyc_documents (
+					id BIGSERIAL PRIMARY KEY,
+					application_id BIGINT NOT NULL,
+					doc_type VARCHAR(30) NOT NULL,
+					file_name VARCHAR(255) NOT NULL,
+					content_type VARCHAR(100) NOT NULL,
+					size_bytes BIGINT NOT NULL,
+					storage_key TEXT NOT NULL, -- Blob Store 中的物件鍵
+					sha256 VARCHAR(64) NOT NULL,
+					uploaded_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					FOREIGN KEY (application_id) REFERENCES kyc_applications(id) ON DELETE CASCADE,
+					CONSTRAINT chk_kyc_document_type CHECK (doc_type IN (
+						'passport', 'national_id', 'drivers_license', 'proof_of_address',
+						'business_registration', 'other'
+					))
+				);
+
+				CREATE INDEX IF NOT EXISTS idx_kyc_documents_application_id ON kyc_documents(application_id);
+			`,
+			Down: `
+				DROP TABLE IF EXISTS kyc_documents;
+				DROP TABLE IF EXISTS kyc_applications;
+				ALTER TABLE sessions DROP COLUMN IF EXISTS kyc_level, DROP COLUMN IF EXISTS kyc_status;
+				ALTER TABLE users DROP CONSTRAINT IF EXISTS chk_kyc_level;
+				ALTER TABLE users DROP CONSTRAINT IF EXISTS chk_kyc_status;
+				ALTER TABLE users
+					DROP COLUMN IF EXISTS country,
+					DROP COLUMN IF EXISTS kyc_verified_at,
+					DROP COLUMN IF EXISTS kyc_level,
+					DROP COLUMN IF EXISTS kyc_status;
+			`,
+		},
+		{
+			Version:     17,
+			Description: "Add blacklist flag and create screening history and compliance flag tables",
+			Up: `
+				ALTER TABLE users ADD COLUMN IF NOT EXISTS is_blacklisted BOOLEAN NOT NULL DEFAULT FALSE;
+				CREATE INDEX IF NOT EXISTS idx_users_is_blacklisted ON users(is_blacklisted) WHERE is_blacklisted;
+
+				-- 每次名單比對的紀錄（包含未命中），供合規稽核
+				CREATE TABLE IF NOT EXISTS screening_results (
+					id BIGSERIAL PRIMARY KEY,
+					wallet_address VARCHAR(66) NOT NULL,
+					user_id BIGINT,
+					trigger VARCHAR(20) NOT NULL,
+					result VARCHAR(10) NOT NULL,
+					list_name VARCHAR(100),
+					reason TEXT,
+					list_version VARCHAR(64) NOT NULL, -- 比對時名單內容的雜湊
+					tx_hash VARCHAR(66),
+					screened_by BIGINT,                -- 手動比對的管理員
+					created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE SET NULL,
+					FOREIGN KEY (screened_by) REFERENCES users(id) ON DELETE SET NULL,
+					CONSTRAINT chk_screening_trigger CHECK (trigger IN ('login', 'purchase', 'transfer', 'periodic', 'manual')),
+					CONSTRAINT chk_screening_result CHECK (result IN ('clear', 'match'))
+				);
+
+				CREATE INDEX IF NOT EXISTS idx_screening_results_wallet ON screening_results(wallet_address, created_at DESC);
+				CREATE INDEX IF NOT EXISTS idx_screening_results_match ON screening_results(created_at DESC) WHERE result = 'match';
+
+				-- 名單地址的鏈上活動，待合規人員審查
+				CREATE TABLE IF NOT EXISTS compliance_flags (
+					id BIGSERIAL PRIMARY KEY,
+					wallet_address VARCHAR(66) NOT NULL,
+					user_id BIGINT,
+					tx_hash VARCHAR(66) NOT NULL,
+					event_type VARCHAR(50) NOT NULL,
+					bond_id BIGINT,
+					list_name VARCHAR(100) NOT NULL,
+					reason TEXT,
+					status VARCHAR(20) NOT NULL DEFAULT 'open',
+					resolution_note TEXT,
+					resolved_by BIGINT,
+					resolved_at TIMESTAMP,
+					created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE SET NULL,
+					FOREIGN KEY (bond_id) REFERENCES bonds(id) ON DELETE SET NULL,
+					FOREIGN KEY (resolved_by) REFERENCES users(id) ON DELETE SET NULL,
+					CONSTRAINT chk_compliance_flag_status CHECK (status IN ('open', 'resolved')),
+					CONSTRAINT uq_compliance_flags_activity UNIQUE (wallet_address, tx_hash)
+				);
+
+				CREATE INDEX IF NOT EXISTS idx_compliance_flags_status ON compliance_flags(status, created_at);
+			`,
+			Down: `
+				DROP TABLE IF EXISTS compliance_flags;
+				DROP TABLE IF EXISTS screening_results;
+				DROP INDEX IF EXISTS idx_users_is_blacklisted;
+				ALTER TABLE users DROP COLUMN IF EXISTS is_blacklisted;
+			`,
+		},
+		{
+			Version:     18,
+			Description: "Add per-user investment limits and create compliance alerts table",
+			Up: `
+				-- 個別使用者的限額（NULL 表示沿用 KYC 等級的預設值），金額以 INVESTMENT_LIMIT_CURRENCY 計
+				ALTER TABLE users ADD COLUMN IF NOT EXISTS daily_limit NUMERIC(20, 2);
+				ALTER TABLE users ADD COLUMN IF NOT EXISTS monthly_limit NUMERIC(20, 2);
+				ALTER TABLE users ADD COLUMN IF NOT EXISTS max_bond_share NUMERIC(5, 4);
+				ALTER TABLE users ADD CONSTRAINT chk_users_max_bond_share
+					CHECK (max_bond_share IS NULL OR (max_bond_share >= 0 AND max_bond_share <= 1));
+
+				CREATE INDEX IF NOT EXISTS idx_transactions_user_timestamp ON transactions(user_id, timestamp);
+
+				-- 事後發現的限額違規，待合規人員審查
+				CREATE TABLE IF NOT EXISTS compliance_alerts (
+					id BIGSERIAL PRIMARY KEY,
+					user_id BIGINT NOT NULL,
+					wallet_address VARCHAR(66) NOT NULL,
+					tx_hash VARCHAR(66) NOT NULL,
+					bond_id BIGINT,
+					alert_type VARCHAR(30) NOT NULL,
+					limit_value NUMERIC(20, 4) NOT NULL,
+					actual_value NUMERIC(20, 4) NOT NULL,
+					currency VARCHAR(10),               -- 限額類型為比例時為 NULL
+					status VARCHAR(20) NOT NULL DEFAULT 'open',
+					resolution_note TEXT,
+					resolved_by BIGINT,
+					resolved_at TIMESTAMP,
+					created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+					FOREIGN KEY (bond_id) REFERENCES bonds(id) ON DELETE SET NULL,
+					FOREIGN KEY (resolved_by) REFERENCES users(id) ON DELETE SET NULL,
+					CONSTRAINT chk_compliance_alert_type CHECK (alert_type IN ('daily_limit', 'monthly_limit', 'bond_concentration')),
+					CONSTRAINT chk_compliance_alert_status CHECK (status IN ('open', 'resolved')),
+					CONSTRAINT uq_compliance_alerts_tx UNIQUE (tx_hash, wallet_address, alert_type)
+				);
+
+				CREATE INDEX IF NOT EXISTS idx_compliance_alerts_status ON compliance_alerts(status, created_at);
+				CREATE INDEX IF NOT EXISTS idx_compliance_alerts_user_id ON compliance_alerts(user_id);
+			`,
+			Down: `
+				DROP TABLE IF EXISTS compliance_alerts;
+				DROP INDEX IF EXISTS idx_transactions_user_timestamp;
+				ALTER TABLE users DROP CONSTRAINT IF EXISTS chk_users_max_bond_share;
+				ALTER TABLE users DROP COLUMN IF EXISTS max_bond_share;
+				ALTER TABLE users DROP COLUMN IF EXISTS monthly_limit;
+				ALTER TABLE users DROP COLUMN IF EXISTS daily_limit;
+			`,
+		},
+		{
+			Version:     19,
+			Description: "Create issuer upgrade application tables",
+			Up: `
+				-- 買方申請升級為發行者，審核紀錄永久保留
+				CREATE TABLE IF NOT EXISTS issuer_applications (
+					id BIGSERIAL PRIMARY KEY,
+					user_id BIGINT NOT NULL,
+					wallet_address VARCHAR(66) NOT NULL,
+
+					-- 機構與聯絡人資料
+					institution_name VARCHAR(255) NOT NULL,
+					registration_number VARCHAR(100) NOT NULL, -- 公司登記/統一編號
+					contact_name VARCHAR(255) NOT NULL,
+					contact_email VARCHAR(255) NOT NULL,
+					contact_phone VARCHAR(50),
+
+					-- 審核流程
+					status VARCHAR(20) NOT NULL DEFAULT 'draft',
+					review_comment TEXT,
+					reviewed_by BIGINT,
+					reviewed_at TIMESTAMP,
+					submitted_at TIMESTAMP,
+
+					created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+					FOREIGN KEY (reviewed_by) REFERENCES users(id) ON DELETE SET NULL,
+					CONSTRAINT chk_issuer_application_status CHECK (status IN ('draft', 'pending', 'approved', 'denied'))
+				);
+
+				-- 每位使用者同時只能有一筆進行中（草稿或審核中）的申請
+				CREATE UNIQUE INDEX IF NOT EXISTS idx_issuer_applications_open
+					ON issuer_applications(user_id) WHERE status IN ('draft', 'pending');
+				CREATE INDEX IF NOT EXISTS idx_issuer_applications_status ON issuer_applications(status);
+
+				CREATE TABLE IF NOT EXISTS issuer_application_documents (
+					id BIGSERIAL PRIMARY KEY,
+					application_id BIGINT NOT NULL,
+					doc_type VARCHAR(30) NOT NULL,
+					file_name VARCHAR(255) NOT NULL,
+					content_type VARCHAR(100) NOT NULL,
+					size_bytes BIGINT NOT NULL,
+					storage_key TEXT NOT NULL, -- Blob Store 中的物件鍵
+					sha256 VARCHAR(64) NOT NULL,
+					uploaded_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					FOREIGN KEY (application_id) REFERENCES issuer_applications(id) ON DELETE CASCADE,
+					CONSTRAINT chk_issuer_document_type CHECK (doc_type IN (
+						'business_registration', 'articles_of_association', 'tax_registration',
+						'authorization_letter', 'other'
+					))
+				);
+
+				CREATE INDEX IF NOT EXISTS idx_issuer_application_documents_application_id
+					ON issuer_application_documents(application_id);
+			`,
+			Down: `
+				DROP TABLE IF EXISTS issuer_application_documents;
+				DROP TABLE IF EXISTS issuer_applications;
+			`,
+		},
+		{
+			Version:     20,
+			Description: "Create organization and organization member tables",
+			Up: `
+				-- 發行機構（一個機構可有多個成員錢包，例如金庫、營運、簽署人）
+				CREATE TABLE IF NOT EXISTS organizations (
+					id BIGSERIAL PRIMARY KEY,
+					name VARCHAR(255) NOT NULL,
+					registration_number VARCHAR(100), -- 公司登記/統一編號
+					created_by BIGINT,
+					created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					FOREIGN KEY (created_by) REFERENCES users(id) ON DELETE SET NULL
+				);
+
+				-- 每個使用者（錢包）最多屬於一個機構
+				CREATE TABLE IF NOT EXISTS organization_members (
+					id BIGSERIAL PRIMARY KEY,
+					organization_id BIGINT NOT NULL,
+					user_id BIGINT NOT NULL,
+					org_role VARCHAR(20) NOT NULL,
+					added_by BIGINT,
+					created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					FOREIGN KEY (organization_id) REFERENCES organizations(id) ON DELETE CASCADE,
+					FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+					FOREIGN KEY (added_by) REFERENCES users(id) ON DELETE SET NULL,
+					CONSTRAINT uq_organization_members_user UNIQUE (user_id),
+					CONSTRAINT chk_organization_member_role CHECK (org_role IN ('owner', 'manager', 'viewer'))
+				);
+
+				CREATE INDEX IF NOT EXISTS idx_organization_members_organization_id ON organization_members(organization_id);
+				CREATE INDEX IF NOT EXISTS idx_bonds_issuer_address_lower ON bonds(LOWER(issuer_address));
+			`,
+			Down: `
+				DROP INDEX IF EXISTS idx_bonds_issuer_address_lower;
+				DROP TABLE IF EXISTS organization_members;
+				DROP TABLE IF EXISTS organizations;
+			`,
+		},
+		{
+			Version:     21,
+			Description: "Create user linked wallets table",
+			Up: `
+				-- 使用者綁定的額外錢包（主錢包仍為 users.wallet_address）
+				CREATE TABLE IF NOT EXISTS user_wallets (
+					id BIGSERIAL PRIMARY KEY,
+					user_id BIGINT NOT NULL,
+					wallet_address VARCHAR(66) NOT NULL,
+					label VARCHAR(100),
+					linked_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+					CONSTRAINT uq_user_wallets_wallet_address UNIQUE (wallet_address)
+				);
+
+				CREATE INDEX IF NOT EXISTS idx_user_wallets_user_id ON user_wallets(user_id);
+			`,
+			Down: `
+				DROP TABLE IF EXISTS user_wallets;
+			`,
+		},
+		{
+			Version:     22,
+			Description: "Create append-only audit events table",
+			Up: `
+				-- 稽核事件：誰在何時從何處對什麼做了什麼（僅新增）
+				CREATE TABLE IF NOT EXISTS audit_events (
+					id BIGSERIAL PRIMARY KEY,
+					actor_id BIGINT,
+					actor_wallet VARCHAR(66),
+					actor_role VARCHAR(20),
+					action VARCHAR(64) NOT NULL,
+					target_type VARCHAR(32) NOT NULL,
+					target_id VARCHAR(128),
+					ip_address VARCHAR(45),
+					user_agent TEXT,
+					request_id VARCHAR(64),
+					before JSONB,
+					after JSONB,
+					created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+				);
+
+				CREATE INDEX IF NOT EXISTS idx_audit_events_created_at ON audit_events(created_at);
+				CREATE INDEX IF NOT EXISTS idx_audit_events_actor_id ON audit_events(actor_id);
+				CREATE INDEX IF NOT EXISTS idx_audit_events_action ON audit_events(action);
+				CREATE INDEX IF NOT EXISTS idx_audit_events_target ON audit_events(target_type, target_id);
+
+				-- 禁止修改或刪除稽核事件
+				CREATE OR REPLACE FUNCTION audit_events_append_only() RETURNS TRIGGER AS $$
+				BEGIN
+					RAISE EXCEPTION 'audit_events is append-only';
+				END;
+				$$ LANGUAGE plpgsql;
+
+				DROP TRIGGER IF EXISTS trg_audit_events_append_only ON audit_events;
+				CREATE TRIGGER trg_audit_events_append_only
+					BEFORE UPDATE OR DELETE ON audit_events
+					FOR EACH ROW EXECUTE FUNCTION audit_events_append_only();
+			`,
+			Down: `
+				DROP TABLE IF EXISTS audit_events;
+				DROP FUNCTION IF EXISTS audit_events_append_only();
+			`,
+		},
+		{
+			Version:     23,
+			Description: "Store issued sign-in message with nonces",
+			Up: `
+				-- 挑戰時發出的完整訊息，驗證時需與簽署的訊息一致
+				ALTER TABLE nonces
+				ADD COLUMN IF NOT EXISTS message TEXT;
+			`,
+			Down: `
+				ALTER TABLE nonces DROP COLUMN IF EXISTS message;
+			`,
+		},
+		{
+			Version:     24,
+			Description: "Key nonces by nonce to allow concurrent challenges per wallet",
+			Up: `
+				-- 同一地址可同時有多個有效挑戰，改以 nonce 本身為唯一鍵
+				ALTER TABLE nonces DROP CONSTRAINT IF EXISTS nonces_wallet_address_key;
+				CREATE UNIQUE INDEX IF NOT EXISTS idx_nonces_nonce ON nonces(nonce);
+			`,
+			Down: `
+				DROP INDEX IF EXISTS idx_nonces_nonce;
+				DELETE FROM nonces a USING nonces b
+				WHERE a.wallet_address = b.wallet_address AND a.id < b.id;
+				ALTER TABLE nonces ADD CONSTRAINT nonces_wallet_address_key UNIQUE (wallet_address);
+			`,
+		},
+		{
+			Version:     25,
+			Description: "Add refresh token hash to sessions",
+			Up: `
+				-- token 模式：僅保存 refresh token 的 SHA-256 雜湊，每次刷新即輪替
+				ALTER TABLE sessions
+				ADD COLUMN IF NOT EXISTS refresh_token_hash VARCHAR(64);
+			`,
+			Down: `
+				ALTER TABLE sessions DROP COLUMN IF EXISTS refresh_token_hash;
+			`,
+		},
+		{
+			Version:     26,
+			Description: "Create API keys table",
+			Up: `
+				-- 程式化存取用的 API key：以前綴查找，僅保存 SHA-256 雜湊
+				CREATE TABLE IF NOT EXISTS api_keys (
+					id BIGSERIAL PRIMARY KEY,
+					user_id BIGINT NOT NULL,
+					name VARCHAR(100) NOT NULL,
+					prefix VARCHAR(32) NOT NULL,
+					key_hash VARCHAR(64) NOT NULL,
+					scopes TEXT[] NOT NULL,
+					ip_allowlist TEXT[],
+					expires_at TIMESTAMP,
+					last_used_at TIMESTAMP,
+					last_used_ip VARCHAR(45),
+					rotated_at TIMESTAMP,
+					revoked_at TIMESTAMP,
+					created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+					CONSTRAINT uq_api_keys_prefix UNIQUE (prefix)
+				);
+
+				CREATE INDEX IF NOT EXISTS idx_api_keys_user_id ON api_keys(user_id);
+			`,
+			Down: `
+				DROP TABLE IF EXISTS api_keys;
+			`,
+		},
+		{
+			Version:     27,
+			Description: "Create roles and user roles for permission-based access control",
+			Up: `
+				-- 角色定義：角色對應權限集合，可由管理員維護（系統角色不可刪除）
+				CREATE TABLE IF NOT EXISTS roles (
+					name VARCHAR(20) PRIMARY KEY,
+					description TEXT,
+					permissions TEXT[] NOT NULL DEFAULT '{}',
+					is_system BOOLEAN NOT NULL DEFAULT FALSE,
+					created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+				);
+
+				INSERT INTO roles (name, description, permissions, is_system) VALUES
+					('buyer', 'Investor', '{}', TRUE),
+					('issuer', 'Bond issuer', '{bonds:propose,impact:manage}', TRUE),
+					('admin', 'Platform administrator', '{*}', TRUE)
+				ON CONFLICT (name) DO NOTHING;
+
+				-- 使用者的額外角色（主要角色仍為 users.role）
+				CREATE TABLE IF NOT EXISTS user_roles (
+					user_id BIGINT NOT NULL,
+					role VARCHAR(20) NOT NULL,
+					granted_by BIGINT,
+					granted_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					PRIMARY KEY (user_id, role),
+					FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+					FOREIGN KEY (role) REFERENCES roles(name) ON DELETE CASCADE
+				);
+
+				CREATE INDEX IF NOT EXISTS idx_user_roles_role ON user_roles(role);
+
+				-- 主要角色改由 roles 表約束
+				ALTER TABLE users DROP CONSTRAINT IF EXISTS chk_role;
+				ALTER TABLE users DROP CONSTRAINT IF EXISTS fk_users_role;
+				ALTER TABLE users ADD CONSTRAINT fk_users_role FOREIGN KEY (role) REFERENCES roles(name);
+
+				-- Session 快取登入時的額外角色，授予或移除時同步更新
+				ALTER TABLE sessions
+				ADD COLUMN IF NOT EXISTS roles TEXT[];
+			`,
+			Down: `
+				ALTER TABLE sessions DROP COLUMN IF EXISTS roles;
+				ALTER TABLE users DROP CONSTRAINT IF EXISTS fk_users_role;
+				ALTER TABLE users ADD CONSTRAINT chk_role CHECK (role IN ('buyer', 'issuer', 'admin'));
+				DROP TABLE IF EXISTS user_roles;
+				DROP TABLE IF EXISTS roles;
+			`,
+		},
+		{
+			Version:     28,
+			Description: "Create login events and user notifications",
+			Up: `
+				-- 登入紀錄（成功與失敗皆保留，登出後仍可查詢）
+				CREATE TABLE IF NOT EXISTS login_events (
+					id BIGSERIAL PRIMARY KEY,
+					user_id BIGINT,                          -- 錢包尚未註冊時為 NULL
+					wallet_address VARCHAR(66) NOT NULL,
+					success BOOLEAN NOT NULL,
+					failure_reason VARCHAR(50),
+					ip_address VARCHAR(45),
+					ip_network VARCHAR(50),                  -- IPv4 /24、IPv6 /48
+					country VARCHAR(2),                      -- 由反向代理的國家 Header 取得（未設定時為 NULL）
+					user_agent TEXT,
+					device VARCHAR(100),                     -- 由 User-Agent 解析的裝置標籤
+					session_ref VARCHAR(16),                 -- Session ID 雜湊（成功時）
+					created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+				);
+
+				CREATE INDEX IF NOT EXISTS idx_login_events_user ON login_events(user_id, created_at DESC);
+				CREATE INDEX IF NOT EXISTS idx_login_events_wallet ON login_events(wallet_address, created_at DESC);
+				CREATE INDEX IF NOT EXISTS idx_login_events_session_ref ON login_events(session_ref);
+
+				-- 使用者通知（例如可疑登入警示）
+				CREATE TABLE IF NOT EXISTS notifications (
+					id BIGSERIAL PRIMARY KEY,
+					user_id BIGINT NOT NULL,
+					type VARCHAR(50) NOT NULL,
+					title VARCHAR(255) NOT NULL,
+					message TEXT NOT NULL,
+					data JSONB,
+					read_at TIMESTAMP,
+					created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+				);
+
+				CREATE INDEX IF NOT EXISTS idx_notifications_user ON notifications(user_id, created_at DESC);
+				CREATE INDEX IF NOT EXISTS idx_notifications_unread ON notifications(user_id) WHERE read_at IS NULL;
+			`,
+			Down: `
+				DROP TABLE IF EXISTS notifications;
+				DROP TABLE IF EXISTS login_events;
+			`,
+		},
+		{
+			Version:     29,
+			Description: "Create auth lockouts and record nonce client IP",
+			Up: `
+				-- 登入失敗計數與漸進式鎖定（錢包與 IP 分別計算）
+				CREATE TABLE IF NOT EXISTS auth_lockouts (
+					scope VARCHAR(10) NOT NULL CHECK (scope IN ('wallet', 'ip')),
+					subject VARCHAR(100) NOT NULL,                 -- 錢包地址或 IP
+					failures INT NOT NULL DEFAULT 0,               -- 目前時間窗內的失敗次數
+					lockouts INT NOT NULL DEFAULT 0,               -- 連續鎖定次數（決定下次鎖定時長）
+					window_started_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					last_failure_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					locked_until TIMESTAMP,
+					PRIMARY KEY (scope, subject)
+				);
+
+				CREATE INDEX IF NOT EXISTS idx_auth_lockouts_locked ON auth_lockouts(locked_until) WHERE locked_until IS NOT NULL;
+
+				-- 記錄請求挑戰的 IP（限制單一 IP 同時有效的 nonce 數量）
+				ALTER TABLE nonces ADD COLUMN IF NOT EXISTS ip_address VARCHAR(45);
+				CREATE INDEX IF NOT EXISTS idx_nonces_ip ON nonces(ip_address, expires_at);
+			`,
+			Down: `
+				DROP INDEX IF EXISTS idx_nonces_ip;
+				ALTER TABLE nonces DROP COLUMN IF EXISTS ip_address;
+				DROP TABLE IF EXISTS auth_lockouts;
+			`,
+		},
+		{
+			Version:     30,
+			Description: "Track rotated refresh tokens",
+			Up: `
+				-- token 模式：已輪替的 refresh token 雜湊，再次出示時判定為外洩重用並撤銷 session
+				CREATE TABLE IF NOT EXISTS rotated_refresh_tokens (
+					token_hash VARCHAR(64) PRIMARY KEY,
+					session_id VARCHAR(36) NOT NULL,
+					rotated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					FOREIGN KEY (session_id) REFERENCES sessions(id) ON DELETE CASCADE
+				);
+
+				CREATE INDEX IF NOT EXISTS idx_rotated_refresh_tokens_session ON rotated_refresh_tokens(session_id);
+			`,
+			Down: `
+				DROP TABLE IF EXISTS rotated_refresh_tokens;
+			`,
+		},
+		{
+			Version:     31,
+			Description: "Key wallet sign-in failures by wallet and IP",
+			Up: `
+				-- 錢包範圍的失敗計數改以「錢包地址|IP」為鍵，他人從其他 IP 的失敗不會鎖定錢包擁有者
+				ALTER TABLE auth_lockouts ALTER COLUMN subject TYPE VARCHAR(120);
+				DELETE FROM auth_lockouts WHERE scope = 'wallet';
+			`,
+			Down: `
+				DELETE FROM auth_lockouts WHERE scope = 'wallet';
+				ALTER TABLE auth_lockouts ALTER COLUMN subject TYPE VARCHAR(100);
+			`,
+		},
 	}
 }
 
@@ -0,0 +1,429 @@
+package accounts
+
+import (
+	"bluelink-backend/internal/handlers/documents"
+	"bluelink-backend/internal/models"
+	"bluelink-backend/internal/services"
+	"bluelink-backend/internal/utils"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+/*
+   發行者升級流程：
+   1. 新使用者登入後一律為 buyer
+   2. 買方 → POST /issuer-applications 建立草稿（機構名稱、登記編號、聯絡人；已具有 bonds:propose 權限者不需申請）
+   3. 買方 → POST /issuer-applications/:id/documents 上傳登記文件（存放於 Blob Store）
+   4. 買方 → POST /issuer-applications/:id/submit 送出審核
+   5. 管理員 → POST /admin/issuer-applications/:id/approve 或 /deny（附意見）
+      核准後使用者角色改為 issuer 並同步到該使用者所有 Session
+   申請紀錄不刪除，可於 GET /issuer-applications 查詢歷史
+*/
+
+// IssuerApplicationHandler 處理發行者升級申請的請求
+type IssuerApplicationHandler struct {
+	applicationService *services.IssuerApplicationService
+}
+
+// NewIssuerApplicationHandler 建立新的 IssuerApplicationHandler
+func NewIssuerApplicationHandler(applicationService *services.IssuerApplicationService) *IssuerApplicationHandler {
+	return &IssuerApplicationHandler{
+		applicationService: applicationService,
+	}
+}
+
+// ListMyApplications 取得當前使用者的申請紀錄
+// GET /api/v1/issuer-applications
+func (h *IssuerApplicationHandler) ListMyApplications(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		models.RespondUnauthorized(c, err.Error())
+		return
+	}
+
+	applications, err := h.applicationService.ListMyApplications(c.Request.Context(), userID)
+	if err != nil {
+		models.RespondInternalError(c, "Failed to fetch issuer applications", err)
+		return
+	}
+
+	models.RespondWithSuccess(c, http.StatusOK, "Issuer applications retrieved successfully", gin.H{
+		"applications": applications,
+		"count":        len(applications),
+	})
+}
+
+// CreateApplication 建立發行者申請草稿
+// POST /api/v1/issuer-applications
+func (h *IssuerApplicationHandler) CreateApplication(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		models.RespondUnauthorized(c, err.Error())
+		return
+	}
+
+	walletAddress, err := utils.GetWalletAddress(c)
+	if err != nil {
+		models.RespondUnauthorized(c, err.Error())
+		return
+	}
+
+	var req IssuerApplicationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		models.RespondBadRequest(c, "Invalid request format", err)
+		return
+	}
+
+	app := req.toApplication()
+	app.UserID = userID
+	app.WalletAddress = walletAddress
+
+	if err := h.applicationService.CreateApplication(c.Request.Context(), app); err != nil {
+		respondIssuerApplicationError(c, "Failed to create issuer application", err)
+		return
+	}
+
+	models.RespondWithSuccess(c, http.StatusCreated, "Issuer application created successfully", app)
+}
+
+// GetApplication 取得自己的發行者申請
+// GET /api/v1/issuer-applications/:id
+func (h *IssuerApplicationHandler) GetApplication(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		models.RespondUnauthorized(c, err.Error())
+		return
+	}
+
+	id, ok := parseIssuerApplicationID(c)
+	if !ok {
+		return
+	}
+
+	app, err := h.applicationService.GetApplication(c.Request.Context(), id, userID)
+	if err != nil {
+		respondIssuerApplicationError(c, "Failed to fetch issuer application", err)
+		return
+	}
+
+	models.RespondWithSuccess(c, http.StatusOK, "Issuer application retrieved successfully", app)
+}
+
+// UpdateApplication 更新草稿申請
+// PUT /api/v1/issuer-applications/:id
+func (h *IssuerApplicationHandler) UpdateApplication(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		models.RespondUnauthorized(c, err.Error())
+		return
+	}
+
+	id, ok := parseIssuerApplicationID(c)
+	if !ok {
+		return
+	}
+
+	var req IssuerApplicationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		models.RespondBadRequest(c, "Invalid request format", err)
+		return
+	}
+
+	app := req.toApplication()
+	app.ID = id
+
+	if err := h.applicationService.UpdateApplication(c.Request.Context(), userID, app); err != nil {
+		respondIssuerApplicationError(c, "Failed to update issuer application", err)
+		return
+	}
+
+	updated, err := h.applicationService.GetApplication(c.Request.Context(), id, userID)
+	if err != nil {
+		respondIssuerApplicationError(c, "Failed to fetch issuer application", err)
+		return
+	}
+
+	models.RespondWithSuccess(c, http.StatusOK, "Issuer application updated successfully", updated)
+}
+
+// UploadDocument 上傳登記文件
+// POST /api/v1/issuer-applications/:id/documents
+func (h *IssuerApplicationHandler) UploadDocument(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		models.RespondUnauthorized(c, err.Error())
+		return
+	}
+
+	id, ok := parseIssuerApplicationID(c)
+	if !ok {
+		return
+	}
+
+	var req UploadIssuerDocumentRequest
+	if err := c.ShouldBind(&req); err != nil {
+		models.RespondBadRequest(c, "Invalid request format", err)
+		return
+	}
+
+	upload, ok := documents.OpenUpload(c)
+	if !ok {
+		return
+	}
+	defer upload.Close()
+
+	doc := &models.IssuerApplicationDocument{
+		DocType:     req.DocType,
+		FileName:    upload.FileName,
+		ContentType: upload.ContentType,
+		SizeBytes:   upload.Size,
+	}
+
+	if err := h.applicationService.UploadDocument(c.Request.Context(), userID, id, doc, upload); err != nil {
+		respondIssuerApplicationError(c, "Failed to upload issuer document", err)
+		return
+	}
+
+	models.RespondWithSuccess(c, http.StatusCreated, "Issuer document uploaded successfully", doc)
+}
+
+// DeleteDocument 刪除草稿申請的文件
+// DELETE /api/v1/issuer-applications/:id/documents/:document_id
+func (h *IssuerApplicationHandler) DeleteDocument(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		models.RespondUnauthorized(c, err.Error())
+		return
+	}
+
+	id, ok := parseIssuerApplicationID(c)
+	if !ok {
+		return
+	}
+
+	documentID, ok := parseIssuerDocumentID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.applicationService.DeleteDocument(c.Request.Context(), userID, id, documentID); err != nil {
+		respondIssuerApplicationError(c, "Failed to delete issuer document", err)
+		return
+	}
+
+	models.RespondWithSuccess(c, http.StatusOK, "Issuer document deleted successfully", nil)
+}
+
+// DownloadDocument 下載自己的登記文件
+// GET /api/v1/issuer-applications/:id/documents/:document_id
+func (h *IssuerApplicationHandler) DownloadDocument(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		models.RespondUnauthorized(c, err.Error())
+		return
+	}
+
+	h.serveDocument(c, userID)
+}
+
+// SubmitApplication 送出申請給管理員審核
+// POST /api/v1/issuer-applications/:id/submit
+func (h *IssuerApplicationHandler) SubmitApplication(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		models.RespondUnauthorized(c, err.Error())
+		return
+	}
+
+	id, ok := parseIssuerApplicationID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.applicationService.SubmitApplication(c.Request.Context(), userID, id); err != nil {
+		respondIssuerApplicationError(c, "Failed to submit issuer application", err)
+		return
+	}
+
+	models.RespondWithSuccess(c, http.StatusOK, "Issuer application submitted for review", nil)
+}
+
+// ===== 管理員功能 =====
+
+// ListApplications 依狀態列出申請
+// GET /api/v1/admin/issuer-applications?status=pending
+func (h *IssuerApplicationHandler) ListApplications(c *gin.Context) {
+	var req ListIssuerApplicationsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		models.RespondBadRequest(c, "Invalid request parameters", err)
+		return
+	}
+
+	applications, err := h.applicationService.ListApplications(c.Request.Context(), req.Status, req.Limit, req.Offset)
+	if err != nil {
+		models.RespondInternalError(c, "Failed to fetch issuer applications", err)
+		return
+	}
+
+	models.RespondWithSuccess(c, http.StatusOK, "Issuer applications retrieved successfully", gin.H{
+		"applications": applications,
+		"count":        len(applications),
+	})
+}
+
+// GetApplicationForReview 取得任一申請詳情
+// GET /api/v1/admin/issuer-applications/:id
+func (h *IssuerApplicationHandler) GetApplicationForReview(c *gin.Context) {
+	id, ok := parseIssuerApplicationID(c)
+	if !ok {
+		return
+	}
+
+	app, err := h.applicationService.GetApplication(c.Request.Context(), id, 0)
+	if err != nil {
+		respondIssuerApplicationError(c, "Failed to fetch issuer application", err)
+		return
+	}
+
+	models.RespondWithSuccess(c, http.StatusOK, "Issuer application retrieved successfully", app)
+}
+
+// DownloadDocumentForReview 下載任一申請的文件
+// GET /api/v1/admin/issuer-applications/:id/documents/:document_id
+func (h *IssuerApplicationHandler) DownloadDocumentForReview(c *gin.Context) {
+	h.serveDocument(c, 0)
+}
+
+// ApproveApplication 核准申請，使用者升級為發行者
+// POST /api/v1/admin/issuer-applications/:id/approve
+func (h *IssuerApplicationHandler) ApproveApplication(c *gin.Context) {
+	reviewerID, err := utils.GetUserID(c)
+	if err != nil {
+		models.RespondUnauthorized(c, err.Error())
+		return
+	}
+
+	id, ok := parseIssuerApplicationID(c)
+	if !ok {
+		return
+	}
+
+	var req ReviewIssuerApplicationRequest
+	if err := c.ShouldBindJSON(&req); err != nil && c.Request.ContentLength > 0 {
+		models.RespondBadRequest(c, "Invalid request format", err)
+		return
+	}
+
+	if err := h.applicationService.ApproveApplication(c.Request.Context(), id, reviewerID, req.Comment); err != nil {
+		respondIssuerApplicationError(c, "Failed to approve issuer application", err)
+		return
+	}
+
+	models.RespondWithSuccess(c, http.StatusOK, "Issuer application approved", nil)
+}
+
+// DenyApplication 駁回申請（需附意見）
+// POST /api/v1/admin/issuer-applications/:id/deny
+func (h *IssuerApplicationHandler) DenyApplication(c *gin.Context) {
+	reviewerID, err := utils.GetUserID(c)
+	if err != nil {
+		models.RespondUnauthorized(c, err.Error())
+		return
+	}
+
+	id, ok := parseIssuerApplicationID(c)
+	if !ok {
+		return
+	}
+
+	var req ReviewIssuerApplicationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		models.RespondBadRequest(c, "Invalid request format", err)
+		return
+	}
+
+	if err := h.applicationService.DenyApplication(c.Request.Context(), id, reviewerID, req.Comment); err != nil {
+		respondIssuerApplicationError(c, "Failed to deny issuer application", err)
+		return
+	}
+
+	models.RespondWithSuccess(c, http.StatusOK, "Issuer application denied", nil)
+}
+
+// serveDocument 串流輸出文件內容（userID 為 0 時不檢查擁有者）
+func (h *IssuerApplicationHandler) serveDocument(c *gin.Context, userID int64) {
+	id, ok := parseIssuerApplicationID(c)
+	if !ok {
+		return
+	}
+
+	documentID, ok := parseIssuerDocumentID(c)
+	if !ok {
+		return
+	}
+
+	doc, content, err := h.applicationService.OpenDocument(c.Request.Context(), userID, id, documentID)
+	if err != nil {
+		respondIssuerApplicationError(c, "Failed to fetch issuer document", err)
+		return
+	}
+	defer content.Close()
+
+	documents.Serve(c, doc.FileName, doc.ContentType, doc.SizeBytes, content)
+}
+
+// toApplication 將請求轉換為申請模型
+func (req *IssuerApplicationRequest) toApplication() *models.IssuerApplication {
+	app := &models.IssuerApplication{
+		InstitutionName:    strings.TrimSpace(req.InstitutionName),
+		RegistrationNumber: strings.TrimSpace(req.RegistrationNumber),
+		ContactName:        strings.TrimSpace(req.ContactName),
+		ContactEmail:       strings.TrimSpace(req.ContactEmail),
+	}
+
+	if req.ContactPhone != "" {
+		phone := req.ContactPhone
+		app.ContactPhone = &phone
+	}
+
+	return app
+}
+
+func parseIssuerApplicationID(c *gin.Context) (int64, bool) {
+	return documents.ParseID(c, "id", "issuer application")
+}
+
+func parseIssuerDocumentID(c *gin.Context) (int64, bool) {
+	return documents.ParseID(c, "document_id", "issuer document")
+}
+
+// respondIssuerApplicationError 將服務層錯誤轉換為對應的 HTTP 狀態碼
+func respondIssuerApplicationError(c *gin.Context, message string, err error) {
+	switch {
+	case errors.Is(err, services.ErrIssuerApplicationNotFound):
+		models.RespondNotFound(c, "Issuer application not found")
+	case errors.Is(err, services.ErrIssuerDocumentNotFound):
+		models.RespondNotFound(c, "Issuer application document not found")
+	case errors.Is(err, services.ErrUserNotFound):
+		models.RespondNotFound(c, "User not found")
+	case errors.Is(err, services.ErrIssuerApplicationForbidden):
+		models.RespondForbidden(c, "Cannot access another user's issuer application")
+	case errors.Is(err, services.ErrIssuerApplicationExists),
+		errors.Is(err, services.ErrIssuerApplicationNotEligible),
+		errors.Is(err, services.ErrIssuerApplicationNotEditable),
+		errors.Is(err, services.ErrIssuerApplicationNotInReview):
+		models.RespondWithErrorDetails(c, http.StatusConflict, message, err.Error())
+	case errors.Is(err, services.ErrIssuerDocumentTooLarge):
+		models.RespondWithErrorDetails(c, http.StatusRequestEntityTooLarge, message, err.Error())
+	case errors.Is(err, services.ErrIssuerDocumentTypeUnsupported):
+		models.RespondWithErrorDetails(c, http.StatusUnsupportedMediaType, message, err.Error())
+	case errors.Is(err, services.ErrIssuerDocumentsMissing),
+		errors.Is(err, services.ErrReviewCommentMissing):
+		models.RespondBadRequest(c, message, err)
+	default:
+		models.RespondInternalError(c, message, err)
+	}
+}
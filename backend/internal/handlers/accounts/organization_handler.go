@@ -0,0 +1,287 @@
+package accounts
+
+import (
+	"bluelink-backend/internal/models"
+	"bluelink-backend/internal/services"
+	"bluelink-backend/internal/utils"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+/*
+   機構帳戶：
+   1. 發行者 → POST /organizations 建立機構並成為 owner
+   2. owner / manager → POST /organizations/me/members 以錢包地址加入成員（金庫、營運、簽署人等）
+      機構內角色：owner（管理成員與角色）、manager（加入/移除 viewer、更新機構資料）、viewer（僅檢視）
+   3. 任一成員的發行地址所發行的債券皆歸屬該機構
+   4. 所有成員 → GET /organizations/me/dashboard 檢視機構的債券、發行申請與募資概況
+*/
+
+// OrganizationHandler 處理機構與成員的請求
+type OrganizationHandler struct {
+	organizationService *services.OrganizationService
+}
+
+// NewOrganizationHandler 建立新的 OrganizationHandler
+func NewOrganizationHandler(organizationService *services.OrganizationService) *OrganizationHandler {
+	return &OrganizationHandler{
+		organizationService: organizationService,
+	}
+}
+
+// CreateOrganization 建立機構
+// POST /api/v1/organizations
+func (h *OrganizationHandler) CreateOrganization(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		models.RespondUnauthorized(c, err.Error())
+		return
+	}
+
+	var req OrganizationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		models.RespondBadRequest(c, "Invalid request format", err)
+		return
+	}
+
+	org := req.toOrganization()
+	if err := h.organizationService.CreateOrganization(c.Request.Context(), userID, org); err != nil {
+		respondOrganizationError(c, "Failed to create organization", err)
+		return
+	}
+
+	models.RespondWithSuccess(c, http.StatusCreated, "Organization created successfully", org)
+}
+
+// GetMyOrganization 取得所屬機構與成員
+// GET /api/v1/organizations/me
+func (h *OrganizationHandler) GetMyOrganization(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		models.RespondUnauthorized(c, err.Error())
+		return
+	}
+
+	detail, err := h.organizationService.GetMyOrganization(c.Request.Context(), userID)
+	if err != nil {
+		respondOrganizationError(c, "Failed to fetch organization", err)
+		return
+	}
+
+	models.RespondWithSuccess(c, http.StatusOK, "Organization retrieved successfully", detail)
+}
+
+// UpdateMyOrganization 更新機構資料
+// PUT /api/v1/organizations/me
+func (h *OrganizationHandler) UpdateMyOrganization(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		models.RespondUnauthorized(c, err.Error())
+		return
+	}
+
+	var req OrganizationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		models.RespondBadRequest(c, "Invalid request format", err)
+		return
+	}
+
+	if err := h.organizationService.UpdateOrganization(c.Request.Context(), userID, req.toOrganization()); err != nil {
+		respondOrganizationError(c, "Failed to update organization", err)
+		return
+	}
+
+	detail, err := h.organizationService.GetMyOrganization(c.Request.Context(), userID)
+	if err != nil {
+		respondOrganizationError(c, "Failed to fetch organization", err)
+		return
+	}
+
+	models.RespondWithSuccess(c, http.StatusOK, "Organization updated successfully", detail)
+}
+
+// AddMember 加入成員
+// POST /api/v1/organizations/me/members
+func (h *OrganizationHandler) AddMember(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		models.RespondUnauthorized(c, err.Error())
+		return
+	}
+
+	var req AddMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		models.RespondBadRequest(c, "Invalid request format", err)
+		return
+	}
+
+	member, err := h.organizationService.AddMember(c.Request.Context(), userID, req.WalletAddress, req.OrgRole)
+	if err != nil {
+		respondOrganizationError(c, "Failed to add organization member", err)
+		return
+	}
+
+	models.RespondWithSuccess(c, http.StatusCreated, "Organization member added", member)
+}
+
+// UpdateMemberRole 變更成員角色
+// PUT /api/v1/organizations/me/members/:user_id
+func (h *OrganizationHandler) UpdateMemberRole(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		models.RespondUnauthorized(c, err.Error())
+		return
+	}
+
+	memberID, ok := parseMemberUserID(c)
+	if !ok {
+		return
+	}
+
+	var req UpdateMemberRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		models.RespondBadRequest(c, "Invalid request format", err)
+		return
+	}
+
+	if err := h.organizationService.UpdateMemberRole(c.Request.Context(), userID, memberID, req.OrgRole); err != nil {
+		respondOrganizationError(c, "Failed to update organization member", err)
+		return
+	}
+
+	models.RespondWithSuccess(c, http.StatusOK, "Organization member role updated", nil)
+}
+
+// RemoveMember 移除成員（成員可移除自己以退出機構）
+// DELETE /api/v1/organizations/me/members/:user_id
+func (h *OrganizationHandler) RemoveMember(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		models.RespondUnauthorized(c, err.Error())
+		return
+	}
+
+	memberID, ok := parseMemberUserID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.organizationService.RemoveMember(c.Request.Context(), userID, memberID); err != nil {
+		respondOrganizationError(c, "Failed to remove organization member", err)
+		return
+	}
+
+	models.RespondWithSuccess(c, http.StatusOK, "Organization member removed", nil)
+}
+
+// GetMyDashboard 機構發行者儀表板
+// GET /api/v1/organizations/me/dashboard
+func (h *OrganizationHandler) GetMyDashboard(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		models.RespondUnauthorized(c, err.Error())
+		return
+	}
+
+	dashboard, err := h.organizationService.GetMyDashboard(c.Request.Context(), userID)
+	if err != nil {
+		respondOrganizationError(c, "Failed to fetch organization dashboard", err)
+		return
+	}
+
+	models.RespondWithSuccess(c, http.StatusOK, "Organization dashboard retrieved successfully", dashboard)
+}
+
+// ===== 管理員功能 =====
+
+// ListOrganizations 列出所有機構
+// GET /api/v1/admin/organizations
+func (h *OrganizationHandler) ListOrganizations(c *gin.Context) {
+	var req ListOrganizationsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		models.RespondBadRequest(c, "Invalid request parameters", err)
+		return
+	}
+
+	orgs, err := h.organizationService.ListOrganizations(c.Request.Context(), req.Limit, req.Offset)
+	if err != nil {
+		models.RespondInternalError(c, "Failed to fetch organizations", err)
+		return
+	}
+
+	models.RespondWithSuccess(c, http.StatusOK, "Organizations retrieved successfully", gin.H{
+		"organizations": orgs,
+		"count":         len(orgs),
+	})
+}
+
+// GetOrganizationDashboard 檢視任一機構的成員與儀表板
+// GET /api/v1/admin/organizations/:id
+func (h *OrganizationHandler) GetOrganizationDashboard(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		models.RespondBadRequest(c, "Invalid organization ID", err)
+		return
+	}
+
+	detail, err := h.organizationService.GetOrganization(c.Request.Context(), id)
+	if err != nil {
+		respondOrganizationError(c, "Failed to fetch organization", err)
+		return
+	}
+
+	dashboard, err := h.organizationService.GetDashboard(c.Request.Context(), id)
+	if err != nil {
+		respondOrganizationError(c, "Failed to fetch organization dashboard", err)
+		return
+	}
+
+	models.RespondWithSuccess(c, http.StatusOK, "Organization retrieved successfully", gin.H{
+		"organization": detail,
+		"dashboard":    dashboard,
+	})
+}
+
+// toOrganization 將請求轉換為機構模型
+func (req *OrganizationRequest) toOrganization() *models.Organization {
+	org := &models.Organization{Name: strings.TrimSpace(req.Name)}
+	if req.RegistrationNumber != "" {
+		number := strings.TrimSpace(req.RegistrationNumber)
+		org.RegistrationNumber = &number
+	}
+	return org
+}
+
+func parseMemberUserID(c *gin.Context) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param("user_id"), 10, 64)
+	if err != nil {
+		models.RespondBadRequest(c, "Invalid member user ID", err)
+		return 0, false
+	}
+	return id, true
+}
+
+// respondOrganizationError 將服務層錯誤轉換為對應的 HTTP 狀態碼
+func respondOrganizationError(c *gin.Context, message string, err error) {
+	switch {
+	case errors.Is(err, services.ErrOrganizationNotFound),
+		errors.Is(err, services.ErrNotOrganizationMember),
+		errors.Is(err, services.ErrOrganizationMemberMissing),
+		errors.Is(err, services.ErrUserNotFound):
+		models.RespondNotFound(c, err.Error())
+	case errors.Is(err, services.ErrOrganizationForbidden),
+		errors.Is(err, services.ErrOrganizationIssuerOnly):
+		models.RespondForbidden(c, err.Error())
+	case errors.Is(err, services.ErrAlreadyOrganizationMember),
+		errors.Is(err, services.ErrLastOrganizationOwner):
+		models.RespondWithErrorDetails(c, http.StatusConflict, message, err.Error())
+	case errors.Is(err, services.ErrInvalidOrgRole):
+		models.RespondBadRequest(c, message, err)
+	default:
+		models.RespondInternalError(c, message, err)
+	}
+}
@@ -0,0 +1,83 @@
+package accounts
+
+// ListUsersRequest 查詢使用者
+type ListUsersRequest struct {
+	Role   string `form:"role" binding:"omitempty,max=20"`
+	Wallet string `form:"wallet"` // 錢包地址前綴
+	Name   string `form:"name"`   // 名稱或機構名稱（部分符合）
+	Status string `form:"status" binding:"omitempty,oneof=active deleted all"`
+	Limit  int    `form:"limit"`
+	Offset int    `form:"offset"`
+}
+
+// UpdateRoleRequest 變更使用者角色（需為 roles 表中的角色）
+type UpdateRoleRequest struct {
+	Role string `json:"role" binding:"required,max=20"`
+}
+
+// CreateRoleRequest 建立自訂角色
+type CreateRoleRequest struct {
+	Name        string   `json:"name" binding:"required"`
+	Description *string  `json:"description" binding:"omitempty,max=255"`
+	Permissions []string `json:"permissions" binding:"required"`
+}
+
+// UpdateRoleDefinitionRequest 更新角色的說明與權限
+type UpdateRoleDefinitionRequest struct {
+	Description *string  `json:"description" binding:"omitempty,max=255"`
+	Permissions []string `json:"permissions" binding:"required"`
+}
+
+// SetUserRolesRequest 替換使用者額外授予的角色（空陣列表示全部移除）
+type SetUserRolesRequest struct {
+	Roles []string `json:"roles" binding:"required"`
+}
+
+// IssuerApplicationRequest 建立或更新發行者申請
+type IssuerApplicationRequest struct {
+	InstitutionName    string `json:"institution_name" binding:"required,max=255"`
+	RegistrationNumber string `json:"registration_number" binding:"required,max=100"`
+	ContactName        string `json:"contact_name" binding:"required,max=255"`
+	ContactEmail       string `json:"contact_email" binding:"required,email,max=255"`
+	ContactPhone       string `json:"contact_phone" binding:"omitempty,max=50"`
+}
+
+// UploadIssuerDocumentRequest 上傳登記文件（multipart/form-data，檔案欄位為 file）
+type UploadIssuerDocumentRequest struct {
+	DocType string `form:"doc_type" binding:"required,oneof=business_registration articles_of_association tax_registration authorization_letter other"`
+}
+
+// ReviewIssuerApplicationRequest 管理員核准或駁回申請（駁回時必須附意見）
+type ReviewIssuerApplicationRequest struct {
+	Comment string `json:"comment"`
+}
+
+// ListIssuerApplicationsRequest 查詢申請列表
+type ListIssuerApplicationsRequest struct {
+	Status string `form:"status" binding:"omitempty,oneof=draft pending approved denied"`
+	Limit  int    `form:"limit"`
+	Offset int    `form:"offset"`
+}
+
+// OrganizationRequest 建立或更新機構
+type OrganizationRequest struct {
+	Name               string `json:"name" binding:"required,max=255"`
+	RegistrationNumber string `json:"registration_number" binding:"omitempty,max=100"`
+}
+
+// AddMemberRequest 依錢包地址加入機構成員
+type AddMemberRequest struct {
+	WalletAddress string `json:"wallet_address" binding:"required"`
+	OrgRole       string `json:"org_role" binding:"required,oneof=owner manager viewer"`
+}
+
+// UpdateMemberRoleRequest 變更成員在機構內的角色
+type UpdateMemberRoleRequest struct {
+	OrgRole string `json:"org_role" binding:"required,oneof=owner manager viewer"`
+}
+
+// ListOrganizationsRequest 查詢機構列表
+type ListOrganizationsRequest struct {
+	Limit  int `form:"limit"`
+	Offset int `form:"offset"`
+}
@@ -0,0 +1,155 @@
+package accounts
+
+import (
+	"bluelink-backend/internal/models"
+	"bluelink-backend/internal/services"
+	"bluelink-backend/internal/utils"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RoleHandler 處理角色定義與使用者角色授予的管理請求
+type RoleHandler struct {
+	roleService *services.RoleService
+}
+
+// NewRoleHandler 建立新的 RoleHandler
+func NewRoleHandler(roleService *services.RoleService) *RoleHandler {
+	return &RoleHandler{
+		roleService: roleService,
+	}
+}
+
+// ListRoles 列出所有角色定義
+// GET /api/v1/admin/roles
+func (h *RoleHandler) ListRoles(c *gin.Context) {
+	roles, err := h.roleService.ListRoles(c.Request.Context())
+	if err != nil {
+		models.RespondInternalError(c, "Failed to fetch roles", err)
+		return
+	}
+
+	models.RespondWithSuccess(c, http.StatusOK, "Roles retrieved successfully", gin.H{
+		"roles":       roles,
+		"permissions": models.Permissions,
+	})
+}
+
+// CreateRole 建立自訂角色
+// POST /api/v1/admin/roles
+func (h *RoleHandler) CreateRole(c *gin.Context) {
+	var req CreateRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		models.RespondBadRequest(c, "Invalid request format", err)
+		return
+	}
+
+	role := &models.Role{
+		Name:        req.Name,
+		Description: req.Description,
+		Permissions: req.Permissions,
+	}
+	if err := h.roleService.CreateRole(c.Request.Context(), role); err != nil {
+		respondRoleError(c, "Failed to create role", err)
+		return
+	}
+
+	models.RespondWithSuccess(c, http.StatusCreated, "Role created successfully", role)
+}
+
+// UpdateRole 更新角色的說明與權限（立即對持有該角色的使用者生效）
+// PUT /api/v1/admin/roles/:name
+func (h *RoleHandler) UpdateRole(c *gin.Context) {
+	var req UpdateRoleDefinitionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		models.RespondBadRequest(c, "Invalid request format", err)
+		return
+	}
+
+	role, err := h.roleService.UpdateRole(c.Request.Context(), c.Param("name"), req.Description, req.Permissions)
+	if err != nil {
+		respondRoleError(c, "Failed to update role", err)
+		return
+	}
+
+	models.RespondWithSuccess(c, http.StatusOK, "Role updated successfully", role)
+}
+
+// DeleteRole 刪除自訂角色
+// DELETE /api/v1/admin/roles/:name
+func (h *RoleHandler) DeleteRole(c *gin.Context) {
+	if err := h.roleService.DeleteRole(c.Request.Context(), c.Param("name")); err != nil {
+		respondRoleError(c, "Failed to delete role", err)
+		return
+	}
+
+	models.RespondWithSuccess(c, http.StatusOK, "Role deleted successfully", nil)
+}
+
+// GetUserRoles 取得使用者的主要角色與額外授予的角色
+// GET /api/v1/admin/users/:id/roles
+func (h *RoleHandler) GetUserRoles(c *gin.Context) {
+	id, ok := parseUserID(c)
+	if !ok {
+		return
+	}
+
+	roles, err := h.roleService.GetUserRoles(c.Request.Context(), id)
+	if err != nil {
+		respondRoleError(c, "Failed to fetch user roles", err)
+		return
+	}
+
+	models.RespondWithSuccess(c, http.StatusOK, "User roles retrieved successfully", roles)
+}
+
+// SetUserRoles 替換使用者額外授予的角色（該使用者的 Session 立即更新）
+// PUT /api/v1/admin/users/:id/roles
+func (h *RoleHandler) SetUserRoles(c *gin.Context) {
+	adminID, err := utils.GetUserID(c)
+	if err != nil {
+		models.RespondUnauthorized(c, err.Error())
+		return
+	}
+
+	id, ok := parseUserID(c)
+	if !ok {
+		return
+	}
+
+	var req SetUserRolesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		models.RespondBadRequest(c, "Invalid request format", err)
+		return
+	}
+
+	roles, err := h.roleService.SetUserRoles(c.Request.Context(), adminID, id, req.Roles)
+	if err != nil {
+		respondRoleError(c, "Failed to update user roles", err)
+		return
+	}
+
+	models.RespondWithSuccess(c, http.StatusOK, "User roles updated", roles)
+}
+
+// respondRoleError 將服務層錯誤轉換為對應的 HTTP 狀態碼
+func respondRoleError(c *gin.Context, message string, err error) {
+	switch {
+	case errors.Is(err, services.ErrUserNotFound):
+		models.RespondNotFound(c, "User not found")
+	case errors.Is(err, services.ErrRoleNotFound):
+		models.RespondNotFound(c, err.Error())
+	case errors.Is(err, services.ErrInvalidRoleName),
+		errors.Is(err, services.ErrInvalidPermission):
+		models.RespondBadRequest(c, message, err)
+	case errors.Is(err, services.ErrCannotModifySelf),
+		errors.Is(err, services.ErrSystemRoleProtected):
+		models.RespondForbidden(c, err.Error())
+	case errors.Is(err, services.ErrRoleAlreadyExists):
+		models.RespondWithErrorDetails(c, http.StatusConflict, message, err.Error())
+	default:
+		models.RespondInternalError(c, message, err)
+	}
+}
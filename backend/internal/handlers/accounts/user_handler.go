@@ -0,0 +1,183 @@
+package accounts
+
+import (
+	"bluelink-backend/internal/models"
+	"bluelink-backend/internal/services"
+	"bluelink-backend/internal/utils"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AdminHandler 處理管理員的使用者管理請求
+type AdminHandler struct {
+	adminUserService *services.AdminUserService
+}
+
+// NewAdminHandler 建立新的 AdminHandler
+func NewAdminHandler(adminUserService *services.AdminUserService) *AdminHandler {
+	return &AdminHandler{
+		adminUserService: adminUserService,
+	}
+}
+
+// ListUsers 依角色、錢包地址、名稱查詢使用者
+// GET /api/v1/admin/users?role=issuer&wallet=0x12&name=acme&status=active
+func (h *AdminHandler) ListUsers(c *gin.Context) {
+	var req ListUsersRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		models.RespondBadRequest(c, "Invalid request parameters", err)
+		return
+	}
+
+	filter := models.UserFilter{
+		Role:   req.Role,
+		Wallet: req.Wallet,
+		Name:   req.Name,
+		Status: req.Status,
+	}
+	users, total, err := h.adminUserService.ListUsers(c.Request.Context(), filter, req.Limit, req.Offset)
+	if err != nil {
+		models.RespondInternalError(c, "Failed to fetch users", err)
+		return
+	}
+
+	models.RespondWithSuccess(c, http.StatusOK, "Users retrieved successfully", gin.H{
+		"users": users,
+		"count": len(users),
+		"total": total,
+	})
+}
+
+// GetUser 取得使用者詳情（含登入裝置與持倉）
+// GET /api/v1/admin/users/:id
+func (h *AdminHandler) GetUser(c *gin.Context) {
+	id, ok := parseUserID(c)
+	if !ok {
+		return
+	}
+
+	detail, err := h.adminUserService.GetUserDetail(c.Request.Context(), id)
+	if err != nil {
+		respondAdminError(c, "Failed to fetch user", err)
+		return
+	}
+
+	models.RespondWithSuccess(c, http.StatusOK, "User retrieved successfully", detail)
+}
+
+// UpdateUserRole 變更使用者角色（該使用者會被登出）
+// PUT /api/v1/admin/users/:id/role
+func (h *AdminHandler) UpdateUserRole(c *gin.Context) {
+	adminID, err := utils.GetUserID(c)
+	if err != nil {
+		models.RespondUnauthorized(c, err.Error())
+		return
+	}
+
+	id, ok := parseUserID(c)
+	if !ok {
+		return
+	}
+
+	var req UpdateRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		models.RespondBadRequest(c, "Invalid request format", err)
+		return
+	}
+
+	if err := h.adminUserService.UpdateRole(c.Request.Context(), adminID, id, req.Role); err != nil {
+		respondAdminError(c, "Failed to update user role", err)
+		return
+	}
+
+	models.RespondWithSuccess(c, http.StatusOK, "User role updated", nil)
+}
+
+// DeleteUser 軟刪除使用者（該使用者會被登出）
+// DELETE /api/v1/admin/users/:id
+func (h *AdminHandler) DeleteUser(c *gin.Context) {
+	adminID, err := utils.GetUserID(c)
+	if err != nil {
+		models.RespondUnauthorized(c, err.Error())
+		return
+	}
+
+	id, ok := parseUserID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.adminUserService.DeleteUser(c.Request.Context(), adminID, id); err != nil {
+		respondAdminError(c, "Failed to delete user", err)
+		return
+	}
+
+	models.RespondWithSuccess(c, http.StatusOK, "User deleted", nil)
+}
+
+// RestoreUser 還原已刪除的使用者
+// POST /api/v1/admin/users/:id/restore
+func (h *AdminHandler) RestoreUser(c *gin.Context) {
+	adminID, err := utils.GetUserID(c)
+	if err != nil {
+		models.RespondUnauthorized(c, err.Error())
+		return
+	}
+
+	id, ok := parseUserID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.adminUserService.RestoreUser(c.Request.Context(), adminID, id); err != nil {
+		respondAdminError(c, "Failed to restore user", err)
+		return
+	}
+
+	models.RespondWithSuccess(c, http.StatusOK, "User restored", nil)
+}
+
+// ForceLogout 強制登出使用者的所有裝置
+// POST /api/v1/admin/users/:id/logout
+func (h *AdminHandler) ForceLogout(c *gin.Context) {
+	id, ok := parseUserID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.adminUserService.ForceLogout(c.Request.Context(), id); err != nil {
+		respondAdminError(c, "Failed to log out user", err)
+		return
+	}
+
+	models.RespondWithSuccess(c, http.StatusOK, "User logged out from all devices", nil)
+}
+
+func parseUserID(c *gin.Context) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		models.RespondBadRequest(c, "Invalid user ID", err)
+		return 0, false
+	}
+	return id, true
+}
+
+// respondAdminError 將服務層錯誤轉換為對應的 HTTP 狀態碼
+func respondAdminError(c *gin.Context, message string, err error) {
+	switch {
+	case errors.Is(err, services.ErrUserNotFound):
+		models.RespondNotFound(c, "User not found")
+	case errors.Is(err, services.ErrCannotModifySelf):
+		models.RespondForbidden(c, err.Error())
+	case errors.Is(err, services.ErrUserAlreadyDeleted),
+		errors.Is(err, services.ErrUserNotDeleted):
+		models.RespondWithErrorDetails(c, http.StatusConflict, message, err.Error())
+	case errors.Is(err, services.ErrInvalidRole):
+		models.RespondBadRequest(c, message, err)
+	default:
+		models.RespondInternalError(c, message, err)
+	}
+}
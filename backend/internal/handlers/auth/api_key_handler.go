@@ -0,0 +1,163 @@
+package auth
+
+import (
+	"bluelink-backend/internal/models"
+	"bluelink-backend/internal/services"
+	"bluelink-backend/internal/utils"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+/*
+   API key（程式化存取，例如發行者後台系統整合）：
+   1. 使用者登入後 → POST /api-keys (name, scopes, ip_allowlist, expires_at)
+      後端 → 回傳明文 key（僅此一次），資料庫只保存雜湊
+   2. 整合端請求時帶上 X-API-Key: blk_<prefix>_<secret>
+      後端 → 驗證 key、IP 白名單、到期時間，且路由需要的 scope 必須在 key 的權限範圍內
+   3. 輪替 → POST /api-keys/:id/rotate（舊 key 立即失效）；撤銷 → DELETE /api-keys/:id
+
+   未標示 scope 的路由（例如 API key 管理本身）不接受 API key
+*/
+
+// APIKeyHandler 處理 API key 管理請求
+type APIKeyHandler struct {
+	apiKeyService *services.APIKeyService
+}
+
+type CreateAPIKeyRequest struct {
+	Name        string     `json:"name" binding:"required,max=100"`
+	Scopes      []string   `json:"scopes" binding:"required,min=1"`
+	IPAllowlist []string   `json:"ip_allowlist" binding:"omitempty,max=20"` // IP 或 CIDR
+	ExpiresAt   *time.Time `json:"expires_at"`                              // 省略表示不過期
+}
+
+// NewAPIKeyHandler 建立新的 APIKeyHandler
+func NewAPIKeyHandler(apiKeyService *services.APIKeyService) *APIKeyHandler {
+	return &APIKeyHandler{apiKeyService: apiKeyService}
+}
+
+// ListAPIKeys 列出目前使用者的 API key
+// GET /api/v1/api-keys
+func (h *APIKeyHandler) ListAPIKeys(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		models.RespondUnauthorized(c, err.Error())
+		return
+	}
+
+	keys, err := h.apiKeyService.ListAPIKeys(c.Request.Context(), userID)
+	if err != nil {
+		respondAPIKeyError(c, "Failed to fetch api keys", err)
+		return
+	}
+
+	models.RespondWithSuccess(c, http.StatusOK, "API keys retrieved successfully", keys)
+}
+
+// CreateAPIKey 建立 API key（回應中的明文 key 僅顯示一次）
+// POST /api/v1/api-keys
+func (h *APIKeyHandler) CreateAPIKey(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		models.RespondUnauthorized(c, err.Error())
+		return
+	}
+
+	var req CreateAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		models.RespondBadRequest(c, "Invalid request format", err)
+		return
+	}
+
+	issued, err := h.apiKeyService.CreateAPIKey(c.Request.Context(), &models.APIKey{
+		UserID:      userID,
+		Name:        req.Name,
+		Scopes:      req.Scopes,
+		IPAllowlist: req.IPAllowlist,
+		ExpiresAt:   req.ExpiresAt,
+	})
+	if err != nil {
+		respondAPIKeyError(c, "Failed to create api key", err)
+		return
+	}
+
+	models.RespondWithSuccess(c, http.StatusCreated, "API key created successfully", issued)
+}
+
+// RotateAPIKey 輪替 API key（舊 key 立即失效）
+// POST /api/v1/api-keys/:id/rotate
+func (h *APIKeyHandler) RotateAPIKey(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		models.RespondUnauthorized(c, err.Error())
+		return
+	}
+
+	id, ok := parseAPIKeyID(c)
+	if !ok {
+		return
+	}
+
+	issued, err := h.apiKeyService.RotateAPIKey(c.Request.Context(), userID, id)
+	if err != nil {
+		respondAPIKeyError(c, "Failed to rotate api key", err)
+		return
+	}
+
+	models.RespondWithSuccess(c, http.StatusOK, "API key rotated successfully", issued)
+}
+
+// RevokeAPIKey 撤銷 API key
+// DELETE /api/v1/api-keys/:id
+func (h *APIKeyHandler) RevokeAPIKey(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		models.RespondUnauthorized(c, err.Error())
+		return
+	}
+
+	id, ok := parseAPIKeyID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.apiKeyService.RevokeAPIKey(c.Request.Context(), userID, id); err != nil {
+		respondAPIKeyError(c, "Failed to revoke api key", err)
+		return
+	}
+
+	models.RespondWithSuccess(c, http.StatusOK, "API key revoked successfully", nil)
+}
+
+func parseAPIKeyID(c *gin.Context) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		models.RespondBadRequest(c, "Invalid api key ID", err)
+		return 0, false
+	}
+	return id, true
+}
+
+// respondAPIKeyError 將服務層錯誤轉換為對應的 HTTP 狀態碼
+func respondAPIKeyError(c *gin.Context, message string, err error) {
+	switch {
+	case errors.Is(err, services.ErrAPIKeyNotFound),
+		errors.Is(err, services.ErrUserNotFound):
+		models.RespondNotFound(c, err.Error())
+	case errors.Is(err, services.ErrInvalidAPIKeyScope),
+		errors.Is(err, services.ErrInvalidIPAllowlist),
+		errors.Is(err, services.ErrInvalidAPIKeyExpiry):
+		models.RespondBadRequest(c, message, err)
+	case errors.Is(err, services.ErrAPIKeyScopeForbidden):
+		models.RespondForbidden(c, err.Error())
+	case errors.Is(err, services.ErrAPIKeyLimit),
+		errors.Is(err, services.ErrAPIKeyAlreadyRevoked):
+		models.RespondWithErrorDetails(c, http.StatusConflict, message, err.Error())
+	default:
+		models.RespondInternalError(c, message, err)
+	}
+}
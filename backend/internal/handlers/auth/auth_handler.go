@@ -1,30 +1,43 @@
 package auth
 
 import (
+	"bluelink-backend/internal/audit"
+	"bluelink-backend/internal/humancheck"
+	"bluelink-backend/internal/logger"
 	"bluelink-backend/internal/models"
 	"bluelink-backend/internal/repository"
 	"bluelink-backend/internal/services"
 	"bluelink-backend/internal/session"
+	"bluelink-backend/internal/siws"
+	"bluelink-backend/internal/suisig"
+	"bluelink-backend/internal/utils"
 	"crypto/rand"
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
 	"time"
 
-	suiModels "github.com/block-vision/sui-go-sdk/models"
 	"github.com/gin-gonic/gin"
 )
 
 /*
    登入流程：
-   1. 前端 → POST /auth/challenge (wallet_address)
-      後端 → 產生 nonce，儲存到內存，回傳 nonce + message
+   0. （錢包或 IP 近期多次失敗時）前端 → POST /auth/human-check (wallet_address)
+      後端 → 回傳是否需要人機驗證與題目（proof-of-work 或 CAPTCHA），前端將解答帶在 challenge 的 human_check
+
+   1. 前端 → POST /auth/challenge (wallet_address, human_check)
+      後端 → 檢查錢包與 IP 是否鎖定、人機驗證、同時有效的挑戰數量
+      後端 → 產生 nonce 與 Sign-In with Sui 結構化訊息（網域、URI、網路、有效期限、請求 ID），回傳 nonce + message
 
    2. 前端 → 使用錢包簽署 message
       錢包 → 回傳 signature
 
-   3. 前端 → POST /auth/verify (wallet_address, signature, nonce)
-      後端 → 驗證 Sui 簽名
+   3. 前端 → POST /auth/verify (wallet_address, signature, nonce, message)
+      後端 → 解析訊息並逐一驗證欄位（版本、網域、URI、網路、地址、nonce、有效期限）
+      後端 → 比對訊息與挑戰時發出的一致，驗證 Sui 簽名
+      後端 → 比對制裁/黑名單，命中則拒絕登入
       後端 → 建立 Session，儲存到內存
       後端 → 設定 HttpOnly Cookie (session_id)
       後端 → 回傳 session_id + user 資料
@@ -40,50 +53,92 @@ import (
    後端 → 刪除 session，清除 Cookie
 
    安全機制：
-   - Nonce 5 分鐘過期，使用後立即刪除（防重放攻擊）
-   - Session 24 小時過期，30 分鐘閒置自動登出
-   - 最多 3 個裝置同時登入
+   - Nonce 10 分鐘過期，使用後立即刪除（防重放攻擊）
+   - 同一錢包自同一 IP 最多 5 個同時有效的挑戰（多分頁、重試不會互相覆蓋，達上限時拒絕），全平台與單一 IP 亦有上限
+   - 簽名驗證失敗分別累計於錢包與 IP，達門檻後漸進式鎖定（管理員可解除）
+   - 登入訊息綁定網域與網路，仿冒網站取得的簽名無法重放
+   - 支援多簽（MultiSig）地址登入：驗證成員簽名的權重總和達到門檻，以多簽地址建立 Session
+   - Session 有效期、閒置逾時、滑動到期與裝置上限（登出最舊裝置或拒絕登入）由 Session 政策設定
    - HttpOnly Cookie（防 XSS）
-   - Secure Cookie（HTTPS only）
+   - Secure Cookie（HTTPS only），Cookie 有效期與 Session 政策一致
    - 記錄 IP 和 UserAgent（審計追蹤）
 */
 
 type AuthHandler struct {
-	userService    *services.UserService
-	sessionManager session.SessionManager
-	nonceRepo      *repository.NonceRepository
-	isProduction   bool // 從配置讀取的環境標誌
+	userService      *services.UserService
+	roleService      *services.RoleService
+	sessionManager   session.SessionManager
+	tokenManager     *session.TokenManager // token 模式時不為 nil
+	nonceRepo        *repository.NonceRepository
+	screeningService *services.ScreeningService
+	auditService     *services.AuditService
+	loginHistory     *services.LoginHistoryService
+	authGuard        *services.AuthGuardService
+	siwsConfig       siws.Config
+	challengeMetrics *ChallengeMetrics
 }
 
 type ChallengeRequest struct {
 	WalletAddress string `json:"wallet_address" binding:"required"`
+	Version       string `json:"version"`     // 訊息格式版本，未指定時使用目前版本
+	HumanCheck    string `json:"human_check"` // 人機驗證解答（需要時）
+}
+
+type HumanCheckRequest struct {
+	WalletAddress string `json:"wallet_address" binding:"required"`
+}
+
+type HumanCheckResponse struct {
+	Required  bool                  `json:"required"`
+	Challenge *humancheck.Challenge `json:"challenge,omitempty"`
 }
 
 type ChallengeResponse struct {
-	Nonce   string `json:"nonce"`
-	Message string `json:"message"`
+	Nonce          string `json:"nonce"`
+	Message        string `json:"message"`
+	Version        string `json:"version,omitempty"`
+	Domain         string `json:"domain,omitempty"`
+	URI            string `json:"uri,omitempty"`
+	ChainID        string `json:"chain_id,omitempty"`
+	IssuedAt       string `json:"issued_at,omitempty"`
+	ExpirationTime string `json:"expiration_time,omitempty"`
+	RequestID      string `json:"request_id,omitempty"`
 }
 
 type VerifyRequest struct {
 	WalletAddress string `json:"wallet_address" binding:"required"`
 	Signature     string `json:"signature" binding:"required"` // Base64 編碼的簽名
 	Nonce         string `json:"nonce" binding:"required"`
-	Role          string `json:"role"` // 可選：使用者角色 (buyer/issuer)，首次註冊時使用
+	Message       string `json:"message" binding:"required"` // 已簽署的完整登入訊息
+	HumanCheck    string `json:"human_check"`                // 人機驗證解答（需要時）
 }
 
 type VerifyResponse struct {
-	SessionID     string       `json:"session_id"`
-	WalletAddress string       `json:"wallet_address"`
-	User          *models.User `json:"user"`
-	ExpiresAt     int64        `json:"expires_at"`
+	SessionID     string             `json:"session_id,omitempty"` // cookie 模式
+	CSRFToken     string             `json:"csrf_token,omitempty"` // cookie 模式，狀態變更請求需帶在 X-CSRF-Token
+	Tokens        *session.TokenPair `json:"tokens,omitempty"`     // token 模式
+	WalletAddress string             `json:"wallet_address"`
+	User          *models.User       `json:"user"`
+	ExpiresAt     int64              `json:"expires_at"`
+}
+
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
 }
 
-func NewAuthHandler(userService *services.UserService, sessionManager session.SessionManager, nonceRepo *repository.NonceRepository, isProduction bool) *AuthHandler {
+func NewAuthHandler(userService *services.UserService, roleService *services.RoleService, sessionManager session.SessionManager, tokenManager *session.TokenManager, nonceRepo *repository.NonceRepository, screeningService *services.ScreeningService, auditService *services.AuditService, loginHistory *services.LoginHistoryService, authGuard *services.AuthGuardService, siwsConfig siws.Config, challengeMetrics *ChallengeMetrics) *AuthHandler {
 	return &AuthHandler{
-		userService:    userService,
-		sessionManager: sessionManager,
-		nonceRepo:      nonceRepo,
-		isProduction:   isProduction,
+		userService:      userService,
+		roleService:      roleService,
+		sessionManager:   sessionManager,
+		tokenManager:     tokenManager,
+		nonceRepo:        nonceRepo,
+		screeningService: screeningService,
+		auditService:     auditService,
+		loginHistory:     loginHistory,
+		authGuard:        authGuard,
+		siwsConfig:       siwsConfig,
+		challengeMetrics: challengeMetrics,
 	}
 }
 
@@ -96,50 +151,95 @@ func (h *AuthHandler) GenerateChallenge(c *gin.Context) {
 		return
 	}
 
-	// 生成隨機 nonce（32 bytes base64 編碼）
-	nonceBytes := make([]byte, 32)
-	if _, err := rand.Read(nonceBytes); err != nil {
-		models.RespondInternalError(c, "Failed to generate nonce", err)
+	if req.Version != "" && req.Version != siws.Version1 {
+		models.RespondWithErrorDetails(c, http.StatusBadRequest, "Unsupported message version",
+			fmt.Sprintf("Supported versions: %v", siws.SupportedVersions))
 		return
 	}
-	nonce := base64.URLEncoding.EncodeToString(nonceBytes)
 
-	// 儲存 nonce 到資料庫，使用 wallet_address 作為 KEY，TTL 設置為 10 分鐘
-	if err := h.nonceRepo.Create(c.Request.Context(), req.WalletAddress, nonce, 10*time.Minute); err != nil {
-		models.RespondInternalError(c, "Failed to store nonce", err)
+	// 鎖定、人機驗證與同時有效的挑戰數量
+	if !guardChallenge(c, h.authGuard, req.WalletAddress, req.HumanCheck) {
 		return
 	}
 
-	// 構建要簽名的訊息（前端會簽署這個訊息）
-	message := fmt.Sprintf("Sign in to BlueLink\nNonce: %s", nonce)
+	nonce, err := generateNonce()
+	if err != nil {
+		models.RespondInternalError(c, "Failed to generate nonce", err)
+		return
+	}
+
+	// 構建要簽名的結構化訊息（前端會簽署這個訊息）
+	msg := h.siwsConfig.NewMessage(req.WalletAddress, nonce, utils.GetRequestID(c), time.Now())
+	message := msg.String()
+
+	// 儲存 nonce 與訊息到資料庫（以 nonce 識別，同一錢包可同時有多個有效挑戰），TTL 與訊息有效期限一致
+	if err := h.nonceRepo.Create(c.Request.Context(), req.WalletAddress, nonce, message, c.ClientIP(), h.siwsConfig.TTL); err != nil {
+		respondNonceError(c, err)
+		return
+	}
+	h.challengeMetrics.Issued(challengePurposeLogin)
 
 	c.JSON(http.StatusOK, ChallengeResponse{
-		Nonce:   nonce,
-		Message: message,
+		Nonce:          nonce,
+		Message:        message,
+		Version:        msg.Version,
+		Domain:         msg.Domain,
+		URI:            msg.URI,
+		ChainID:        msg.ChainID,
+		IssuedAt:       msg.IssuedAt.Format(time.RFC3339),
+		ExpirationTime: msg.ExpirationTime.Format(time.RFC3339),
+		RequestID:      msg.RequestID,
 	})
 }
 
+// GetHumanCheck 查詢請求挑戰前是否需要人機驗證，需要時回傳題目
+// POST /api/v1/auth/human-check
+func (h *AuthHandler) GetHumanCheck(c *gin.Context) {
+	var req HumanCheckRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		models.RespondBadRequest(c, "Invalid request format", err)
+		return
+	}
+
+	status, err := h.authGuard.CheckChallengeAttempt(c.Request.Context(), req.WalletAddress, c.ClientIP())
+	if err != nil {
+		respondAuthGuardError(c, "Failed to check sign-in attempts", err)
+		return
+	}
+	if status.LockedUntil != nil {
+		respondLocked(c, *status.LockedUntil)
+		return
+	}
+
+	response := HumanCheckResponse{Required: status.HumanCheckRequired}
+	if status.HumanCheckRequired {
+		response.Challenge, err = h.authGuard.HumanCheckChallenge(c.Request.Context())
+		if err != nil {
+			models.RespondInternalError(c, "Failed to create human check", err)
+			return
+		}
+	}
+
+	models.RespondWithSuccess(c, http.StatusOK, "Human check status retrieved", response)
+}
+
 // VerifySignature 驗證 Sui 錢包簽名
 // POST /api/v1/auth/verify
 func (h *AuthHandler) VerifySignature(c *gin.Context) {
 	// 安全檢查：確保 handler 已正確初始化
 	if h == nil {
-		fmt.Printf("[HANDLER ERROR] handler is nil\n")
 		models.RespondInternalError(c, "Handler not initialized", fmt.Errorf("handler is nil"))
 		return
 	}
 	if h.userService == nil {
-		fmt.Printf("[HANDLER ERROR] userService is nil\n")
 		models.RespondInternalError(c, "User service not initialized", fmt.Errorf("userService is nil"))
 		return
 	}
 	if h.sessionManager == nil {
-		fmt.Printf("[HANDLER ERROR] sessionManager is nil\n")
 		models.RespondInternalError(c, "Session manager not initialized", fmt.Errorf("sessionManager is nil"))
 		return
 	}
 	if h.nonceRepo == nil {
-		fmt.Printf("[HANDLER ERROR] nonceRepo is nil\n")
 		models.RespondInternalError(c, "Nonce repository not initialized", fmt.Errorf("nonceRepo is nil"))
 		return
 	}
@@ -150,58 +250,79 @@ func (h *AuthHandler) VerifySignature(c *gin.Context) {
 		return
 	}
 
-	// 1. 驗證 nonce（使用 wallet_address 作為 KEY 查找）
-	// Verify 方法會自動檢查過期、比對 nonce、並在驗證成功後刪除（防止重放攻擊）
-	isValid, err := h.nonceRepo.Verify(c.Request.Context(), req.WalletAddress, req.Nonce)
+	// 0. 錢包或 IP 鎖定中時直接拒絕；失敗次數達門檻時需通過人機驗證
+	status, err := h.authGuard.CheckAttempt(c.Request.Context(), req.WalletAddress, c.ClientIP())
+	if err != nil {
+		models.RespondInternalError(c, "Failed to check sign-in attempts", err)
+		return
+	}
+	if !enforceAttempt(c, h.authGuard, status, req.HumanCheck) {
+		return
+	}
+
+	// 1. 解析並驗證登入訊息的每個欄位
+	msg, err := siws.Parse(req.Message)
+	if err == nil {
+		err = h.siwsConfig.Validate(msg, req.WalletAddress, req.Nonce, time.Now())
+	}
+	if err != nil {
+		logger.Warn("Invalid sign-in message: wallet=%s: %v", req.WalletAddress, err)
+		h.challengeMetrics.Failed(challengePurposeLogin, failureInvalidMessage)
+		h.requestFailed(c, req.WalletAddress, models.LoginFailureInvalidMessage)
+		models.RespondWithErrorDetails(c, http.StatusUnauthorized, "Invalid sign-in message", err.Error())
+		return
+	}
+
+	// 2. 驗證 nonce（以 nonce 與 wallet_address 查找）
+	// Verify 方法會自動檢查過期、比對 nonce 與挑戰時發出的訊息、並在驗證成功後刪除（防止重放攻擊）
+	isValid, err := h.nonceRepo.Verify(c.Request.Context(), req.WalletAddress, req.Nonce, req.Message)
 	if err != nil || !isValid {
-		// 記錄詳細錯誤
-		fmt.Printf("[NONCE ERROR] wallet=%s, error=%v\n", req.WalletAddress, err)
+		logger.Warn("Nonce verification failed: wallet=%s: %v", req.WalletAddress, err)
+		h.challengeMetrics.Failed(challengePurposeLogin, nonceFailureReason(err))
+		h.requestFailed(c, req.WalletAddress, models.LoginFailureNonce)
 		models.RespondUnauthorized(c, fmt.Sprintf("Nonce verification failed: %v", err))
 		return
 	}
-	fmt.Printf("[NONCE OK] wallet=%s verified successfully\n", req.WalletAddress)
 
-	// 2. 驗證 Sui 簽名
-	// 構建相同的 message，確保與 challenge 時的格式一致
-	message := fmt.Sprintf("Sign in to BlueLink\nNonce: %s", req.Nonce)
-	fmt.Printf("[SIG VERIFY] message=%s, signature_len=%d\n", message, len(req.Signature))
-	isSigValid, signerAddress, err := h.verifySuiSignature(req.Signature, message)
+	// 3. 驗證 Sui 簽名（簽署的是完整的登入訊息）
+	isSigValid, signerAddress, err := verifySuiSignature(req.Signature, req.Message)
 	if err != nil || !isSigValid {
-		fmt.Printf("[SIG ERROR] error=%v, valid=%v\n", err, isSigValid)
+		logger.Warn("Invalid sign-in signature: wallet=%s: %v", req.WalletAddress, err)
+		h.challengeMetrics.Failed(challengePurposeLogin, failureInvalidSignature)
+		h.credentialFailed(c, req.WalletAddress, models.LoginFailureInvalidSignature)
 		models.RespondWithErrorDetails(c, http.StatusUnauthorized, "Invalid signature",
 			fmt.Sprintf("Verification failed: %v", err))
 		return
 	}
-	fmt.Printf("[SIG OK] signer=%s\n", signerAddress)
 
-	// 3. 驗證簽名者地址是否與提供的地址匹配
+	// 4. 驗證簽名者地址是否與提供的地址匹配
 	if signerAddress != req.WalletAddress {
+		h.challengeMetrics.Failed(challengePurposeLogin, failureAddressMismatch)
+		h.credentialFailed(c, req.WalletAddress, models.LoginFailureAddressMismatch)
 		models.RespondWithErrorDetails(c, http.StatusUnauthorized, "Address mismatch",
 			fmt.Sprintf("Provided %s, signed by %s", req.WalletAddress, signerAddress))
 		return
 	}
+	h.challengeMetrics.Verified(challengePurposeLogin)
 
 	// 5. 取得或建立使用者
 	user, err := h.userService.GetByWalletAddress(c.Request.Context(), req.WalletAddress)
 	if err != nil {
-		// 使用者不存在，建立新使用者
-		// 如果前端提供了角色，使用該角色;否則預設為 buyer
-		role := req.Role
-		if role == "" {
-			role = "buyer" // 預設角色
+		// 使用者不存在，建立新使用者（一律為 buyer，發行者需透過 /issuer-applications 申請）
+		logger.Info("Creating user on first sign-in: wallet=%s", req.WalletAddress)
+		user, err = h.userService.Create(c.Request.Context(), req.WalletAddress)
+		if errors.Is(err, services.ErrUserDeleted) {
+			h.loginHistory.RecordFailure(c.Request.Context(), h.loginAttempt(c, req.WalletAddress), models.LoginFailureAccountDeleted)
+			models.RespondForbidden(c, "Account deleted")
+			return
 		}
-
-		fmt.Printf("[USER CREATE] wallet=%s, role=%s\n", req.WalletAddress, role)
-		user, err = h.userService.CreateWithRole(c.Request.Context(), req.WalletAddress, role)
 		if err != nil {
-			fmt.Printf("[USER ERROR] failed to create user: %v\n", err)
 			models.RespondInternalError(c, "Failed to create user", err)
 			return
 		}
 
 		// 確保 user 不是 nil
 		if user == nil {
-			fmt.Printf("[USER ERROR] user is nil after creation\n")
 			models.RespondInternalError(c, "Failed to create user", fmt.Errorf("user is nil"))
 			return
 		}
@@ -209,60 +330,153 @@ func (h *AuthHandler) VerifySignature(c *gin.Context) {
 
 	// 額外的安全檢查：確保 user 不是 nil
 	if user == nil {
-		fmt.Printf("[USER ERROR] user is nil after get/create\n")
 		models.RespondInternalError(c, "User data is invalid", fmt.Errorf("user is nil"))
 		return
 	}
 
-	fmt.Printf("[USER OK] user_id=%d, wallet=%s\n", user.ID, user.WalletAddress)
+	// 登入成功或被拒皆以該使用者作為稽核主體
+	auditCtx := audit.WithUser(c.Request.Context(), user.ID, req.WalletAddress, user.Role)
+
+	// 5.5 制裁/黑名單比對
+	if err := h.screeningService.ScreenLogin(c.Request.Context(), req.WalletAddress, user); err != nil {
+		if errors.Is(err, services.ErrWalletBlocked) {
+			h.auditService.Record(auditCtx, models.AuditActionLoginDenied, models.AuditTargetUser, strconv.FormatInt(user.ID, 10),
+				nil, map[string]any{"wallet_address": req.WalletAddress, "reason": err.Error()})
+			h.loginHistory.RecordFailure(c.Request.Context(), h.loginAttempt(c, req.WalletAddress), models.LoginFailureWalletBlocked)
+			models.RespondForbidden(c, "Wallet address is not permitted")
+			return
+		}
+		models.RespondWithErrorDetails(c, http.StatusServiceUnavailable, "Compliance screening unavailable", err.Error())
+		return
+	}
+
+	// 6. 建立 session（快取額外授予的角色）
+	user.Roles, err = h.roleService.GrantedRoles(c.Request.Context(), user.ID)
+	if err != nil {
+		models.RespondInternalError(c, "Failed to load user roles", err)
+		return
+	}
 
-	// 6. 建立 session
-	fmt.Printf("[SESSION CREATE] user_id=%d, wallet=%s\n", user.ID, req.WalletAddress)
 	sess, err := h.sessionManager.Create(
+		c.Request.Context(),
 		user.ID,
 		req.WalletAddress,
 		user.Role,
-		//user.KYCStatus,
+		user.Roles,
+		user.KYCStatus,
+		user.KYCLevel,
 		c.ClientIP(),
 		c.Request.UserAgent(),
 	)
+	if errors.Is(err, session.ErrMaxDevicesReached) {
+		h.loginHistory.RecordFailure(c.Request.Context(), h.loginAttempt(c, req.WalletAddress), models.LoginFailureMaxDevices)
+		models.RespondWithErrorDetails(c, http.StatusConflict, "Maximum number of signed-in devices reached", "sign out from another device first")
+		return
+	}
 	if err != nil {
-		fmt.Printf("[SESSION ERROR] failed to create session: %v\n", err)
 		models.RespondInternalError(c, "Failed to create session", err)
 		return
 	}
-	fmt.Printf("[SESSION OK] session_id=%s\n", sess.ID)
+	h.auditService.Record(auditCtx, models.AuditActionLogin, models.AuditTargetUser, strconv.FormatInt(user.ID, 10),
+		nil, map[string]any{"wallet_address": req.WalletAddress, "session": session.Ref(sess.ID)})
+	h.authGuard.RecordSuccess(c.Request.Context(), req.WalletAddress, c.ClientIP())
+	h.loginHistory.RecordSuccess(c.Request.Context(), h.loginAttempt(c, req.WalletAddress), user.ID, sess.ID)
+
+	// 7a. Token 模式：回傳 access token 與 refresh token，不設定 Cookie
+	if h.tokenManager != nil {
+		tokens, err := h.tokenManager.Issue(c.Request.Context(), sess)
+		if err != nil {
+			h.sessionManager.Delete(c.Request.Context(), sess.ID)
+			models.RespondInternalError(c, "Failed to issue tokens", err)
+			return
+		}
 
-	// 7. 設定 HttpOnly Cookie
-	// 根據環境選擇 SameSite 策略:
-	// - 生產環境: SameSiteNoneMode (需要 Secure=true)
-	// - 開發環境: SameSiteLaxMode (允許跨域 GET 請求攜帶 cookie)
-	sameSite := http.SameSiteLaxMode
-	if h.isProduction {
-		sameSite = http.SameSiteNoneMode
+		models.RespondWithSuccess(c, http.StatusOK, "Authentication successful", VerifyResponse{
+			Tokens:        tokens,
+			WalletAddress: req.WalletAddress,
+			User:          user,
+			ExpiresAt:     tokens.RefreshUntil.Unix(),
+		})
+		return
 	}
 
-	cookie := &http.Cookie{
-		Name:     "session_id",
-		Value:    sess.ID,
-		Path:     "/",
-		Domain:   "",
-		MaxAge:   int(24 * time.Hour.Seconds()),
-		Secure:   h.isProduction,
-		HttpOnly: true,
-		SameSite: sameSite,
-	}
-	http.SetCookie(c.Writer, cookie)
+	// 7. 設定 HttpOnly Cookie（有效期與 Secure / SameSite 屬性由 Session 政策決定）與 CSRF double-submit Cookie
+	policy := h.sessionManager.Policy()
+	csrfToken := policy.CSRFToken(sess.ID)
+	http.SetCookie(c.Writer, policy.Cookie(sess.ID, sess.ExpiresAt))
+	http.SetCookie(c.Writer, policy.CSRFCookie(csrfToken, sess.ExpiresAt))
 
 	// 8. 回傳成功響應
 	models.RespondWithSuccess(c, http.StatusOK, "Authentication successful", VerifyResponse{
 		SessionID:     sess.ID,
+		CSRFToken:     csrfToken,
 		WalletAddress: req.WalletAddress,
 		User:          user,
-		ExpiresAt:     sess.CreatedAt.Add(24 * time.Hour).Unix(),
+		ExpiresAt:     sess.ExpiresAt.Unix(),
 	})
 }
 
+// RefreshTokens 以 refresh token 換發新的 access token 與 refresh token（token 模式）
+// POST /api/v1/auth/refresh
+func (h *AuthHandler) RefreshTokens(c *gin.Context) {
+	if h.tokenManager == nil {
+		models.RespondNotFound(c, "Token session mode is not enabled")
+		return
+	}
+
+	var req RefreshRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		models.RespondBadRequest(c, "Invalid request format", err)
+		return
+	}
+
+	tokens, sess, err := h.tokenManager.Refresh(c.Request.Context(), req.RefreshToken)
+	if err != nil {
+		switch {
+		case errors.Is(err, session.ErrRefreshTokenReused):
+			// 舊 token 被重複使用：token 可能外洩，整個 session 已撤銷
+			auditCtx := audit.WithUser(c.Request.Context(), sess.UserID, sess.WalletAddress, sess.Role)
+			h.auditService.Record(auditCtx, models.AuditActionRefreshReuse, models.AuditTargetSession, session.Ref(sess.ID),
+				map[string]any{"ip_address": sess.IPAddress, "user_agent": sess.UserAgent}, nil)
+			models.RespondUnauthorized(c, err.Error())
+		case errors.Is(err, session.ErrInvalidRefreshToken):
+			models.RespondUnauthorized(c, err.Error())
+		default:
+			models.RespondInternalError(c, "Failed to refresh tokens", err)
+		}
+		return
+	}
+
+	models.RespondWithSuccess(c, http.StatusOK, "Tokens refreshed successfully", tokens)
+}
+
+// GetCSRFToken 重新取得目前 session 的 CSRF token（例如頁面重新載入後），並重設 CSRF Cookie
+// GET /api/v1/auth/csrf
+func (h *AuthHandler) GetCSRFToken(c *gin.Context) {
+	if h.tokenManager != nil {
+		models.RespondNotFound(c, "CSRF tokens are only used in cookie session mode")
+		return
+	}
+
+	sessionID, exists := c.Get("SessionID")
+	if !exists {
+		models.RespondUnauthorized(c, "Unauthorized")
+		return
+	}
+
+	sess, err := h.sessionManager.Get(c.Request.Context(), sessionID.(string))
+	if err != nil {
+		models.RespondUnauthorized(c, "Invalid or expired session")
+		return
+	}
+
+	policy := h.sessionManager.Policy()
+	csrfToken := policy.CSRFToken(sess.ID)
+	http.SetCookie(c.Writer, policy.CSRFCookie(csrfToken, sess.ExpiresAt))
+
+	models.RespondWithSuccess(c, http.StatusOK, "CSRF token issued", gin.H{"csrf_token": csrfToken})
+}
+
 // Logout 登出當前會話
 // POST /api/v1/auth/logout
 func (h *AuthHandler) Logout(c *gin.Context) {
@@ -273,13 +487,15 @@ func (h *AuthHandler) Logout(c *gin.Context) {
 	}
 
 	// 刪除 session
-	if err := h.sessionManager.Delete(sessionID.(string)); err != nil {
+	if err := h.sessionManager.Delete(c.Request.Context(), sessionID.(string)); err != nil {
 		models.RespondInternalError(c, "Failed to logout", err)
 		return
 	}
+	h.auditService.Record(c.Request.Context(), models.AuditActionLogout, models.AuditTargetSession, session.Ref(sessionID.(string)), nil, nil)
 
 	// 清除 Cookie
-	c.SetCookie("session_id", "", -1, "/", "", true, true)
+	http.SetCookie(c.Writer, h.sessionManager.Policy().ExpiredCookie())
+	http.SetCookie(c.Writer, h.sessionManager.Policy().ExpiredCSRFCookie())
 
 	models.RespondWithSuccess(c, http.StatusOK, "Logged out successfully", nil)
 }
@@ -287,20 +503,22 @@ func (h *AuthHandler) Logout(c *gin.Context) {
 // LogoutAll 登出所有裝置
 // POST /api/v1/auth/logout-all
 func (h *AuthHandler) LogoutAll(c *gin.Context) {
-	walletAddress, exists := c.Get("WalletAddress")
-	if !exists {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
 		models.RespondUnauthorized(c, "Unauthorized")
 		return
 	}
 
-	// 刪除所有 session
-	if err := h.sessionManager.DeleteAllUserSessions(walletAddress.(string)); err != nil {
+	// 刪除所有錢包（主錢包與綁定錢包）的 session
+	if err := h.sessionManager.DeleteAllByUserID(c.Request.Context(), userID); err != nil {
 		models.RespondInternalError(c, "Failed to logout all sessions", err)
 		return
 	}
+	h.auditService.Record(c.Request.Context(), models.AuditActionLogoutAll, models.AuditTargetUser, strconv.FormatInt(userID, 10), nil, nil)
 
 	// 清除當前 Cookie
-	c.SetCookie("session_id", "", -1, "/", "", true, true)
+	http.SetCookie(c.Writer, h.sessionManager.Policy().ExpiredCookie())
+	http.SetCookie(c.Writer, h.sessionManager.Policy().ExpiredCSRFCookie())
 
 	models.RespondWithSuccess(c, http.StatusOK, "Logged out from all devices", nil)
 }
@@ -308,13 +526,13 @@ func (h *AuthHandler) LogoutAll(c *gin.Context) {
 // GetActiveSessions 取得使用者的所有活躍會話
 // GET /api/v1/sessions
 func (h *AuthHandler) GetActiveSessions(c *gin.Context) {
-	walletAddress, exists := c.Get("WalletAddress")
-	if !exists {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
 		models.RespondUnauthorized(c, "Unauthorized")
 		return
 	}
 
-	sessions, err := h.sessionManager.GetUserSessions(walletAddress.(string))
+	sessions, err := h.sessionManager.GetUserSessionsByUserID(c.Request.Context(), userID)
 	if err != nil {
 		models.RespondInternalError(c, "Failed to get sessions", err)
 		return
@@ -332,48 +550,153 @@ func (h *AuthHandler) RevokeSession(c *gin.Context) {
 		return
 	}
 
-	walletAddress, exists := c.Get("WalletAddress")
-	if !exists {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
 		models.RespondUnauthorized(c, "Unauthorized")
 		return
 	}
 
-	// 驗證該 session 是否屬於當前使用者
-	session, err := h.sessionManager.Get(sessionIDToRevoke)
-	if err != nil || session == nil {
+	// 驗證該 session 是否屬於當前使用者（任一錢包）
+	sess, err := h.sessionManager.Get(c.Request.Context(), sessionIDToRevoke)
+	if err != nil || sess == nil {
 		models.RespondNotFound(c, "Session not found")
 		return
 	}
 
-	if session.WalletAddress != walletAddress.(string) {
+	if sess.UserID != userID {
 		models.RespondForbidden(c, "Cannot revoke another user's session")
 		return
 	}
 
 	// 刪除 session
-	if err := h.sessionManager.Delete(sessionIDToRevoke); err != nil {
+	if err := h.sessionManager.Delete(c.Request.Context(), sessionIDToRevoke); err != nil {
 		models.RespondInternalError(c, "Failed to revoke session", err)
 		return
 	}
+	h.auditService.Record(c.Request.Context(), models.AuditActionSessionRevoke, models.AuditTargetSession, session.Ref(sessionIDToRevoke),
+		map[string]any{"ip_address": sess.IPAddress, "user_agent": sess.UserAgent}, nil)
 
 	models.RespondWithSuccess(c, http.StatusOK, "Session revoked successfully", nil)
 }
 
-// verifySuiSignature 使用 SDK 的 models 套件驗證 Sui 簽名
-func (h *AuthHandler) verifySuiSignature(signatureB64, message string) (bool, string, error) {
-	// 使用 SDK 的 VerifyPersonalMessage 函數
-	// 這個函數接受:
-	// 1. message: 原始訊息字符串(不需要 base64 編碼)
-	// 2. signature: base64 編碼的序列化簽名 [flag_byte][signature_bytes][pubkey_bytes]
-	// 返回: (signer_address, pass, error)
+// GetChallengeMetrics 取得登入與錢包綁定挑戰的發出與失敗統計
+// GET /api/v1/admin/auth/challenge-metrics
+func (h *AuthHandler) GetChallengeMetrics(c *gin.Context) {
+	metrics := h.challengeMetrics.Snapshot()
 
-	signerAddress, pass, err := suiModels.VerifyPersonalMessage(message, signatureB64)
+	outstanding, err := h.nonceRepo.CountActive(c.Request.Context())
 	if err != nil {
-		return false, "", fmt.Errorf("signature verification failed: %w", err)
+		models.RespondInternalError(c, "Failed to count outstanding challenges", err)
+		return
+	}
+	metrics.Outstanding = outstanding
+
+	models.RespondWithSuccess(c, http.StatusOK, "Challenge metrics retrieved successfully", metrics)
+}
+
+// loginAttempt 取得登入嘗試的來源資訊（寫入登入紀錄）
+func (h *AuthHandler) loginAttempt(c *gin.Context, walletAddress string) services.LoginAttempt {
+	return services.LoginAttempt{
+		WalletAddress: walletAddress,
+		IPAddress:     c.ClientIP(),
+		Country:       h.loginHistory.ClientCountry(c.Request),
+		UserAgent:     c.Request.UserAgent(),
+	}
+}
+
+// credentialFailed 記錄已使用 nonce 後的簽名驗證失敗（登入紀錄與錢包/IP 失敗計數）
+func (h *AuthHandler) credentialFailed(c *gin.Context, walletAddress, reason string) {
+	h.loginHistory.RecordFailure(c.Request.Context(), h.loginAttempt(c, walletAddress), reason)
+	h.authGuard.RecordFailure(c.Request.Context(), walletAddress, c.ClientIP())
+}
+
+// requestFailed 記錄使用 nonce 前的驗證失敗（訊息無效或 nonce 不存在），只累計 IP 的失敗次數
+func (h *AuthHandler) requestFailed(c *gin.Context, walletAddress, reason string) {
+	h.loginHistory.RecordFailure(c.Request.Context(), h.loginAttempt(c, walletAddress), reason)
+	h.authGuard.RecordIPFailure(c.Request.Context(), c.ClientIP())
+}
+
+// guardChallenge 發出挑戰前檢查錢包與 IP 的鎖定、人機驗證與同時有效的挑戰數量（失敗時已回應）
+// 登入與錢包綁定/解除綁定的挑戰共用
+func guardChallenge(c *gin.Context, authGuard *services.AuthGuardService, walletAddress, humanCheck string) bool {
+	status, err := authGuard.CheckChallengeAttempt(c.Request.Context(), walletAddress, c.ClientIP())
+	if err != nil {
+		respondAuthGuardError(c, "Failed to check sign-in attempts", err)
+		return false
+	}
+	if !enforceAttempt(c, authGuard, status, humanCheck) {
+		return false
 	}
+	if err := authGuard.CheckChallengeCapacity(c.Request.Context(), c.ClientIP()); err != nil {
+		respondAuthGuardError(c, "Failed to issue challenge", err)
+		return false
+	}
+	return true
+}
 
-	if !pass {
-		return false, "", fmt.Errorf("signature verification failed: invalid signature")
+// enforceAttempt 鎖定中時拒絕，需要人機驗證時驗證解答（失敗時已回應）
+func enforceAttempt(c *gin.Context, authGuard *services.AuthGuardService, status *services.AuthAttemptStatus, humanCheck string) bool {
+	if status.LockedUntil != nil {
+		respondLocked(c, *status.LockedUntil)
+		return false
+	}
+	if status.HumanCheckRequired {
+		if err := authGuard.VerifyHumanCheck(c.Request.Context(), humanCheck, c.ClientIP()); err != nil {
+			respondAuthGuardError(c, "Human verification failed", err)
+			return false
+		}
+	}
+	return true
+}
+
+// respondNonceError 回應建立挑戰 nonce 的錯誤（錢包自此 IP 的有效挑戰達上限時回傳 429）
+func respondNonceError(c *gin.Context, err error) {
+	if errors.Is(err, repository.ErrTooManyNonces) {
+		models.RespondTooManyRequests(c, err.Error())
+		return
+	}
+	models.RespondInternalError(c, "Failed to store nonce", err)
+}
+
+// respondLocked 回應錢包或 IP 鎖定中（附 Retry-After）
+func respondLocked(c *gin.Context, lockedUntil time.Time) {
+	retryAfter := int(time.Until(lockedUntil).Seconds()) + 1
+	c.Header("Retry-After", strconv.Itoa(retryAfter))
+	models.RespondWithErrorDetails(c, http.StatusTooManyRequests, services.ErrAuthLocked.Error(),
+		fmt.Sprintf("locked until %s", lockedUntil.Format(time.RFC3339)))
+}
+
+// respondAuthGuardError 將暴力破解防護的錯誤轉換為對應的 HTTP 狀態碼
+func respondAuthGuardError(c *gin.Context, message string, err error) {
+	switch {
+	case errors.Is(err, services.ErrHumanCheckRequired):
+		models.RespondWithErrorDetails(c, http.StatusPreconditionRequired, err.Error(), "request a challenge from /auth/human-check")
+	case errors.Is(err, services.ErrHumanCheckFailed):
+		models.RespondForbidden(c, err.Error())
+	case errors.Is(err, services.ErrTooManyChallenges):
+		models.RespondTooManyRequests(c, err.Error())
+	case errors.Is(err, services.ErrChallengeCapacityFull):
+		models.RespondWithErrorDetails(c, http.StatusServiceUnavailable, message, err.Error())
+	default:
+		models.RespondInternalError(c, message, err)
+	}
+}
+
+// generateNonce 生成隨機 nonce（32 bytes base64 編碼）
+func generateNonce() (string, error) {
+	nonceBytes := make([]byte, 32)
+	if _, err := rand.Read(nonceBytes); err != nil {
+		return "", err
+	}
+	return base64.URLEncoding.EncodeToString(nonceBytes), nil
+}
+
+// verifySuiSignature 驗證 Sui personal message 簽名，回傳簽名者地址
+// 支援 Ed25519 / Secp256k1 / Secp256r1 單一金鑰簽名與 MultiSig（flag 0x03，回傳多簽地址）
+func verifySuiSignature(signatureB64, message string) (bool, string, error) {
+	signerAddress, err := suisig.VerifyPersonalMessage([]byte(message), signatureB64)
+	if err != nil {
+		return false, "", fmt.Errorf("signature verification failed: %w", err)
 	}
 
 	// 回傳驗證結果和簽名者地址
@@ -0,0 +1,111 @@
+package auth
+
+import (
+	"bluelink-backend/internal/models"
+	"bluelink-backend/internal/repository"
+	"errors"
+	"sync"
+	"time"
+)
+
+// 挑戰用途
+const (
+	challengePurposeLogin        = "login"
+	challengePurposeWalletLink   = "wallet_link"
+	challengePurposeWalletUnlink = "wallet_unlink"
+)
+
+// 挑戰驗證失敗原因
+const (
+	failureInvalidMessage   = "invalid_message"
+	failureNonceNotFound    = "nonce_not_found"
+	failureNonceExpired     = "nonce_expired"
+	failureMessageMismatch  = "message_mismatch"
+	failureNonceError       = "nonce_error"
+	failureInvalidSignature = "invalid_signature"
+	failureAddressMismatch  = "address_mismatch"
+)
+
+// ChallengeMetrics 記錄挑戰的發出、成功與失敗次數（僅保存在記憶體，服務重啟後歸零）
+type ChallengeMetrics struct {
+	mu       sync.Mutex
+	since    time.Time
+	purposes map[string]*models.ChallengePurposeMetrics
+}
+
+// NewChallengeMetrics 建立新的 ChallengeMetrics
+func NewChallengeMetrics() *ChallengeMetrics {
+	return &ChallengeMetrics{
+		since:    time.Now(),
+		purposes: make(map[string]*models.ChallengePurposeMetrics),
+	}
+}
+
+// Issued 記錄發出一個挑戰
+func (m *ChallengeMetrics) Issued(purpose string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.purpose(purpose).Issued++
+}
+
+// Verified 記錄一個挑戰驗證成功
+func (m *ChallengeMetrics) Verified(purpose string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.purpose(purpose).Verified++
+}
+
+// Failed 記錄一個挑戰驗證失敗及原因
+func (m *ChallengeMetrics) Failed(purpose, reason string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	metrics := m.purpose(purpose)
+	metrics.Failed++
+	metrics.Failures[reason]++
+}
+
+// Snapshot 取得目前的統計（outstanding 由呼叫端填入）
+func (m *ChallengeMetrics) Snapshot() *models.ChallengeMetrics {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	snapshot := &models.ChallengeMetrics{
+		Since:    m.since,
+		Purposes: make(map[string]*models.ChallengePurposeMetrics, len(m.purposes)),
+	}
+	for purpose, metrics := range m.purposes {
+		copied := *metrics
+		copied.Failures = make(map[string]int64, len(metrics.Failures))
+		for reason, count := range metrics.Failures {
+			copied.Failures[reason] = count
+		}
+		if attempts := copied.Verified + copied.Failed; attempts > 0 {
+			copied.FailureRate = float64(copied.Failed) / float64(attempts)
+		}
+		snapshot.Purposes[purpose] = &copied
+	}
+	return snapshot
+}
+
+func (m *ChallengeMetrics) purpose(purpose string) *models.ChallengePurposeMetrics {
+	metrics, ok := m.purposes[purpose]
+	if !ok {
+		metrics = &models.ChallengePurposeMetrics{Failures: make(map[string]int64)}
+		m.purposes[purpose] = metrics
+	}
+	return metrics
+}
+
+// nonceFailureReason 將 nonce 驗證錯誤轉為失敗原因
+func nonceFailureReason(err error) string {
+	switch {
+	case errors.Is(err, repository.ErrNonceNotFound):
+		return failureNonceNotFound
+	case errors.Is(err, repository.ErrNonceExpired):
+		return failureNonceExpired
+	case errors.Is(err, repository.ErrNonceMessageMismatch):
+		return failureMessageMismatch
+	default:
+		return failureNonceError
+	}
+}
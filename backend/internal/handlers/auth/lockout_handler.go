@@ -0,0 +1,61 @@
+package auth
+
+import (
+	"bluelink-backend/internal/models"
+	"bluelink-backend/internal/services"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// LockoutHandler 處理管理員查詢與解除登入鎖定的請求
+type LockoutHandler struct {
+	authGuard *services.AuthGuardService
+}
+
+// NewLockoutHandler 建立新的 LockoutHandler
+func NewLockoutHandler(authGuard *services.AuthGuardService) *LockoutHandler {
+	return &LockoutHandler{authGuard: authGuard}
+}
+
+// ListLockouts 列出目前鎖定中的錢包與 IP
+// GET /api/v1/admin/auth/lockouts
+func (h *LockoutHandler) ListLockouts(c *gin.Context) {
+	lockouts, err := h.authGuard.ListLocked(c.Request.Context())
+	if err != nil {
+		models.RespondInternalError(c, "Failed to fetch lockouts", err)
+		return
+	}
+
+	models.RespondWithSuccess(c, http.StatusOK, "Lockouts retrieved successfully", gin.H{
+		"lockouts": lockouts,
+		"count":    len(lockouts),
+	})
+}
+
+// UnlockWallet 解除錢包的鎖定並清除失敗計數
+// DELETE /api/v1/admin/auth/lockouts/wallets/:address
+func (h *LockoutHandler) UnlockWallet(c *gin.Context) {
+	h.unlock(c, models.AuthLockoutScopeWallet, c.Param("address"))
+}
+
+// UnlockIP 解除 IP 的鎖定並清除失敗計數
+// DELETE /api/v1/admin/auth/lockouts/ips/:ip
+func (h *LockoutHandler) UnlockIP(c *gin.Context) {
+	h.unlock(c, models.AuthLockoutScopeIP, c.Param("ip"))
+}
+
+func (h *LockoutHandler) unlock(c *gin.Context, scope, subject string) {
+	err := h.authGuard.Unlock(c.Request.Context(), scope, subject)
+	if errors.Is(err, services.ErrAuthLockoutNotFound) {
+		models.RespondNotFound(c, err.Error())
+		return
+	}
+	if err != nil {
+		models.RespondInternalError(c, "Failed to unlock", err)
+		return
+	}
+
+	models.RespondWithSuccess(c, http.StatusOK, "Unlocked successfully", nil)
+}
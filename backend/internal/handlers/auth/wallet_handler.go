@@ -0,0 +1,323 @@
+package auth
+
+import (
+	"bluelink-backend/internal/models"
+	"bluelink-backend/internal/repository"
+	"bluelink-backend/internal/services"
+	"bluelink-backend/internal/siws"
+	"bluelink-backend/internal/utils"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+/*
+   多錢包綁定流程：
+   1. 前端 → POST /wallets/challenge (wallet_address, action=link)
+      後端 → 以新錢包地址產生 nonce，回傳 Sign-In with Sui 格式的綁定訊息
+   2. 使用新錢包簽署訊息 → POST /wallets/link (wallet_address, message, signature, nonce)
+      後端 → 驗證訊息欄位、nonce 與 Sui 簽名、比對制裁/黑名單後綁定到目前帳戶
+
+   解除綁定：
+   1. 前端 → POST /wallets/challenge (wallet_address, action=unlink)
+      後端 → 以目前登入的錢包產生 nonce（需重新簽名確認）
+   2. 使用目前登入的錢包簽署 → POST /wallets/unlink (wallet_address, message, signature, nonce)
+      後端 → 驗證後解除綁定，並登出該錢包的所有裝置
+
+   訊息與登入訊息相同格式（網域、網路與有效期限檢查一致），Statement 註明動作、錢包與帳戶，
+   登入訊息與綁定/解除綁定訊息的簽名無法互相挪用
+
+   綁定後以任一錢包登入皆對應同一帳戶；投資組合、代幣與交易記錄彙總所有綁定錢包
+*/
+
+// 錢包挑戰動作
+const (
+	walletActionLink   = "link"
+	walletActionUnlink = "unlink"
+)
+
+// WalletHandler 處理使用者多錢包綁定的請求
+type WalletHandler struct {
+	walletService    *services.WalletService
+	userService      *services.UserService
+	nonceRepo        *repository.NonceRepository
+	screeningService *services.ScreeningService
+	authGuard        *services.AuthGuardService
+	siwsConfig       siws.Config
+	challengeMetrics *ChallengeMetrics
+}
+
+type WalletChallengeRequest struct {
+	WalletAddress string `json:"wallet_address" binding:"required"`
+	Action        string `json:"action" binding:"required,oneof=link unlink"`
+	HumanCheck    string `json:"human_check"` // 人機驗證解答（需要時）
+}
+
+type LinkWalletRequest struct {
+	WalletAddress string `json:"wallet_address" binding:"required"`
+	Message       string `json:"message" binding:"required"`   // 挑戰時取得的完整訊息
+	Signature     string `json:"signature" binding:"required"` // 由 wallet_address 簽署
+	Nonce         string `json:"nonce" binding:"required"`
+	Label         string `json:"label" binding:"omitempty,max=100"`
+}
+
+type UnlinkWalletRequest struct {
+	WalletAddress string `json:"wallet_address" binding:"required"`
+	Message       string `json:"message" binding:"required"`   // 挑戰時取得的完整訊息
+	Signature     string `json:"signature" binding:"required"` // 由目前登入的錢包簽署
+	Nonce         string `json:"nonce" binding:"required"`
+}
+
+// NewWalletHandler 建立新的 WalletHandler
+func NewWalletHandler(walletService *services.WalletService, userService *services.UserService, nonceRepo *repository.NonceRepository, screeningService *services.ScreeningService, authGuard *services.AuthGuardService, siwsConfig siws.Config, challengeMetrics *ChallengeMetrics) *WalletHandler {
+	return &WalletHandler{
+		walletService:    walletService,
+		userService:      userService,
+		nonceRepo:        nonceRepo,
+		screeningService: screeningService,
+		authGuard:        authGuard,
+		siwsConfig:       siwsConfig,
+		challengeMetrics: challengeMetrics,
+	}
+}
+
+// ListWallets 列出目前帳戶的主錢包與綁定錢包
+// GET /api/v1/wallets
+func (h *WalletHandler) ListWallets(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		models.RespondUnauthorized(c, err.Error())
+		return
+	}
+
+	wallets, err := h.walletService.ListWallets(c.Request.Context(), userID)
+	if err != nil {
+		respondWalletError(c, "Failed to fetch wallets", err)
+		return
+	}
+
+	models.RespondWithSuccess(c, http.StatusOK, "Wallets retrieved successfully", wallets)
+}
+
+// GenerateChallenge 產生綁定/解除綁定的挑戰訊息
+// POST /api/v1/wallets/challenge
+func (h *WalletHandler) GenerateChallenge(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		models.RespondUnauthorized(c, err.Error())
+		return
+	}
+
+	var req WalletChallengeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		models.RespondBadRequest(c, "Invalid request format", err)
+		return
+	}
+
+	// 綁定由新錢包簽署；解除綁定由目前登入的錢包簽署
+	signer := req.WalletAddress
+	if req.Action == walletActionUnlink {
+		signer, err = utils.GetWalletAddress(c)
+		if err != nil {
+			models.RespondUnauthorized(c, err.Error())
+			return
+		}
+	}
+
+	// 與登入挑戰相同的鎖定、人機驗證與挑戰數量限制
+	if !guardChallenge(c, h.authGuard, signer, req.HumanCheck) {
+		return
+	}
+
+	nonce, err := generateNonce()
+	if err != nil {
+		models.RespondInternalError(c, "Failed to generate nonce", err)
+		return
+	}
+
+	config := h.challengeConfig(req.Action, userID, req.WalletAddress)
+	msg := config.NewMessage(signer, nonce, utils.GetRequestID(c), time.Now())
+	message := msg.String()
+
+	if err := h.nonceRepo.Create(c.Request.Context(), signer, nonce, message, c.ClientIP(), config.TTL); err != nil {
+		respondNonceError(c, err)
+		return
+	}
+	h.challengeMetrics.Issued(walletChallengePurpose(req.Action))
+
+	c.JSON(http.StatusOK, ChallengeResponse{
+		Nonce:          nonce,
+		Message:        message,
+		Version:        msg.Version,
+		Domain:         msg.Domain,
+		URI:            msg.URI,
+		ChainID:        msg.ChainID,
+		IssuedAt:       msg.IssuedAt.Format(time.RFC3339),
+		ExpirationTime: msg.ExpirationTime.Format(time.RFC3339),
+		RequestID:      msg.RequestID,
+	})
+}
+
+// LinkWallet 驗證新錢包的簽名並綁定到目前帳戶
+// POST /api/v1/wallets/link
+func (h *WalletHandler) LinkWallet(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		models.RespondUnauthorized(c, err.Error())
+		return
+	}
+
+	var req LinkWalletRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		models.RespondBadRequest(c, "Invalid request format", err)
+		return
+	}
+
+	config := h.challengeConfig(walletActionLink, userID, req.WalletAddress)
+	if !h.verifyChallenge(c, config, walletActionLink, req.WalletAddress, req.Nonce, req.Signature, req.Message) {
+		return
+	}
+
+	user, err := h.userService.GetByID(c.Request.Context(), userID)
+	if err != nil {
+		respondWalletError(c, "Failed to fetch user", err)
+		return
+	}
+
+	// 新錢包同樣需通過制裁/黑名單比對
+	if err := h.screeningService.ScreenLogin(c.Request.Context(), req.WalletAddress, user); err != nil {
+		if errors.Is(err, services.ErrWalletBlocked) {
+			models.RespondForbidden(c, "Wallet address is not permitted")
+			return
+		}
+		models.RespondWithErrorDetails(c, http.StatusServiceUnavailable, "Compliance screening unavailable", err.Error())
+		return
+	}
+
+	var label *string
+	if trimmed := strings.TrimSpace(req.Label); trimmed != "" {
+		label = &trimmed
+	}
+
+	wallet, err := h.walletService.LinkWallet(c.Request.Context(), userID, req.WalletAddress, label)
+	if err != nil {
+		respondWalletError(c, "Failed to link wallet", err)
+		return
+	}
+
+	models.RespondWithSuccess(c, http.StatusCreated, "Wallet linked successfully", wallet)
+}
+
+// UnlinkWallet 以目前登入錢包的新簽名確認後解除綁定
+// POST /api/v1/wallets/unlink
+func (h *WalletHandler) UnlinkWallet(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		models.RespondUnauthorized(c, err.Error())
+		return
+	}
+
+	signer, err := utils.GetWalletAddress(c)
+	if err != nil {
+		models.RespondUnauthorized(c, err.Error())
+		return
+	}
+
+	var req UnlinkWalletRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		models.RespondBadRequest(c, "Invalid request format", err)
+		return
+	}
+
+	config := h.challengeConfig(walletActionUnlink, userID, req.WalletAddress)
+	if !h.verifyChallenge(c, config, walletActionUnlink, signer, req.Nonce, req.Signature, req.Message) {
+		return
+	}
+
+	if err := h.walletService.UnlinkWallet(c.Request.Context(), userID, req.WalletAddress); err != nil {
+		respondWalletError(c, "Failed to unlink wallet", err)
+		return
+	}
+
+	models.RespondWithSuccess(c, http.StatusOK, "Wallet unlinked successfully", nil)
+}
+
+// verifyChallenge 驗證訊息欄位、nonce 與簽名，並確認簽名者為指定地址（失敗時已回應）
+func (h *WalletHandler) verifyChallenge(c *gin.Context, config siws.Config, action, signer, nonce, signature, message string) bool {
+	purpose := walletChallengePurpose(action)
+
+	msg, err := siws.Parse(message)
+	if err == nil {
+		err = config.Validate(msg, signer, nonce, time.Now())
+	}
+	if err != nil {
+		h.challengeMetrics.Failed(purpose, failureInvalidMessage)
+		models.RespondWithErrorDetails(c, http.StatusUnauthorized, "Invalid challenge message", err.Error())
+		return false
+	}
+
+	isValid, err := h.nonceRepo.Verify(c.Request.Context(), signer, nonce, message)
+	if err != nil || !isValid {
+		h.challengeMetrics.Failed(purpose, nonceFailureReason(err))
+		models.RespondUnauthorized(c, fmt.Sprintf("Nonce verification failed: %v", err))
+		return false
+	}
+
+	isSigValid, signerAddress, err := verifySuiSignature(signature, message)
+	if err != nil || !isSigValid {
+		h.challengeMetrics.Failed(purpose, failureInvalidSignature)
+		models.RespondWithErrorDetails(c, http.StatusUnauthorized, "Invalid signature",
+			fmt.Sprintf("Verification failed: %v", err))
+		return false
+	}
+
+	if signerAddress != signer {
+		h.challengeMetrics.Failed(purpose, failureAddressMismatch)
+		models.RespondWithErrorDetails(c, http.StatusUnauthorized, "Address mismatch",
+			fmt.Sprintf("Expected %s, signed by %s", signer, signerAddress))
+		return false
+	}
+
+	h.challengeMetrics.Verified(purpose)
+	return true
+}
+
+// walletChallengePurpose 綁定/解除綁定動作對應的挑戰用途
+func walletChallengePurpose(action string) string {
+	if action == walletActionUnlink {
+		return challengePurposeWalletUnlink
+	}
+	return challengePurposeWalletLink
+}
+
+// challengeConfig 綁定/解除綁定訊息的設定：網域、網路與有效期限同登入訊息，
+// Statement 註明動作、錢包與帳戶（與登入訊息不同，避免簽名被挪用）
+func (h *WalletHandler) challengeConfig(action string, userID int64, walletAddress string) siws.Config {
+	config := h.siwsConfig
+	if action == walletActionUnlink {
+		config.Statement = fmt.Sprintf("Unlink wallet %s from BlueLink account %d", walletAddress, userID)
+	} else {
+		config.Statement = fmt.Sprintf("Link wallet %s to BlueLink account %d", walletAddress, userID)
+	}
+	return config
+}
+
+// respondWalletError 將服務層錯誤轉換為對應的 HTTP 狀態碼
+func respondWalletError(c *gin.Context, message string, err error) {
+	switch {
+	case errors.Is(err, services.ErrWalletNotLinked),
+		errors.Is(err, services.ErrUserNotFound):
+		models.RespondNotFound(c, err.Error())
+	case errors.Is(err, services.ErrWalletAlreadyLinked),
+		errors.Is(err, services.ErrLinkedWalletLimit):
+		models.RespondWithErrorDetails(c, http.StatusConflict, message, err.Error())
+	case errors.Is(err, services.ErrPrimaryWalletUnlink):
+		models.RespondBadRequest(c, message, err)
+	default:
+		models.RespondInternalError(c, message, err)
+	}
+}
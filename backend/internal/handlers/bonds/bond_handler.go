@@ -3,6 +3,7 @@ package bonds
 import (
 	"bluelink-backend/internal/models"
 	"bluelink-backend/internal/services"
+	"bluelink-backend/internal/utils"
 	"net/http"
 	"strconv"
 
@@ -13,20 +14,35 @@ type BondHandler struct {
 	bondService      *services.BondService
 	bondTokenService *services.BondTokenService
 	syncService      *services.SyncService
+	valuationService *services.ValuationService
+	priceService     *services.PriceService
+	walletService    *services.WalletService
+	auditService     *services.AuditService
 }
 
 func NewBondHandler(
 	bondService *services.BondService,
 	bondTokenService *services.BondTokenService,
 	syncService *services.SyncService,
+	valuationService *services.ValuationService,
+	priceService *services.PriceService,
+	walletService *services.WalletService,
+	auditService *services.AuditService,
 ) *BondHandler {
 	return &BondHandler{
 		bondService:      bondService,
 		bondTokenService: bondTokenService,
 		syncService:      syncService,
+		valuationService: valuationService,
+		priceService:     priceService,
+		walletService:    walletService,
+		auditService:     auditService,
 	}
 }
 
+// portfolioTokenLimit 投資組合一次載入的代幣上限
+const portfolioTokenLimit = 1000
+
 // GetAllBonds 獲取所有上架債券
 func (h *BondHandler) GetAllBonds(c *gin.Context) {
 	bonds, err := h.bondService.GetAllBonds(c.Request.Context())
@@ -38,6 +54,15 @@ func (h *BondHandler) GetAllBonds(c *gin.Context) {
 	// 轉換為前端需要的響應格式
 	responseData := ToBondResponseList(bonds)
 
+	if quote := c.Query("quote"); quote != "" {
+		for _, bond := range responseData {
+			if err := h.convertBond(c, bond, quote); err != nil {
+				respondPriceError(c, "Failed to convert bond amounts", err)
+				return
+			}
+		}
+	}
+
 	// 返回符合前端要求的格式: {code, message, data: Bond[]}
 	models.RespondWithSuccess(c, http.StatusOK, "success", responseData)
 }
@@ -65,6 +90,13 @@ func (h *BondHandler) GetBondByID(c *gin.Context) {
 	// 轉換為前端需要的響應格式
 	responseData := ToBondResponse(bond)
 
+	if quote := c.Query("quote"); quote != "" {
+		if err := h.convertBond(c, responseData, quote); err != nil {
+			respondPriceError(c, "Failed to convert bond amounts", err)
+			return
+		}
+	}
+
 	models.RespondWithSuccess(c, http.StatusOK, "success", responseData)
 }
 
@@ -88,6 +120,13 @@ func (h *BondHandler) GetBondTokenByID(c *gin.Context) {
 		return
 	}
 
+	if c.Query("include_valuation") == "true" {
+		if err := h.valuationService.ValueTokens(c.Request.Context(), []*models.BondToken{token}); err != nil {
+			models.RespondInternalError(c, "Failed to value bond token", err)
+			return
+		}
+	}
+
 	models.RespondWithSuccess(c, http.StatusOK, "Bond token retrieved successfully", gin.H{
 		"bond_token": token,
 	})
@@ -112,12 +151,42 @@ func (h *BondHandler) GetBondTokenByOnChainID(c *gin.Context) {
 		return
 	}
 
+	if c.Query("include_valuation") == "true" {
+		if err := h.valuationService.ValueTokens(c.Request.Context(), []*models.BondToken{token}); err != nil {
+			models.RespondInternalError(c, "Failed to value bond token", err)
+			return
+		}
+	}
+
 	models.RespondWithSuccess(c, http.StatusOK, "Bond token retrieved successfully", gin.H{
 		"bond_token": token,
 	})
 }
 
+// GetBondTokenHistory 取得債券代幣的持有歷史（購買、轉移、贖回）
+func (h *BondHandler) GetBondTokenHistory(c *gin.Context) {
+	onChainID := c.Param("on_chain_id")
+	if onChainID == "" {
+		models.RespondBadRequest(c, "On-chain ID is required", nil)
+		return
+	}
+
+	provenance, err := h.bondTokenService.GetBondTokenProvenance(c.Request.Context(), onChainID)
+	if err != nil {
+		models.RespondInternalError(c, "Failed to fetch bond token history", err)
+		return
+	}
+
+	if provenance == nil {
+		models.RespondNotFound(c, "Bond token not found")
+		return
+	}
+
+	models.RespondWithSuccess(c, http.StatusOK, "Bond token history retrieved successfully", provenance)
+}
+
 // 🆕 GetBondTokensByOwner 根據擁有者地址獲取債券代幣列表
+// 未指定 owner 時回傳目前使用者所有綁定錢包的代幣
 func (h *BondHandler) GetBondTokensByOwner(c *gin.Context) {
 	var req GetBondTokensByOwnerRequest
 	if err := c.ShouldBindQuery(&req); err != nil {
@@ -129,12 +198,28 @@ func (h *BondHandler) GetBondTokensByOwner(c *gin.Context) {
 		req.Limit = 100
 	}
 
-	tokens, err := h.bondTokenService.GetBondTokensByOwner(c.Request.Context(), req.Owner, req.Limit, req.Offset)
+	owners := []string{req.Owner}
+	if req.Owner == "" {
+		addresses, ok := h.myWallets(c)
+		if !ok {
+			return
+		}
+		owners = addresses
+	}
+
+	tokens, err := h.bondTokenService.GetBondTokensByOwners(c.Request.Context(), owners, req.Limit, req.Offset)
 	if err != nil {
 		models.RespondInternalError(c, "Failed to fetch bond tokens", err)
 		return
 	}
 
+	if req.IncludeValuation {
+		if err := h.valuationService.ValueTokens(c.Request.Context(), tokens); err != nil {
+			models.RespondInternalError(c, "Failed to value bond tokens", err)
+			return
+		}
+	}
+
 	models.RespondWithSuccess(c, http.StatusOK, "Bond tokens retrieved successfully", gin.H{
 		"bond_tokens": tokens,
 		"count":       len(tokens),
@@ -159,12 +244,143 @@ func (h *BondHandler) GetBondTokensByProject(c *gin.Context) {
 		return
 	}
 
+	if req.IncludeValuation {
+		if err := h.valuationService.ValueTokens(c.Request.Context(), tokens); err != nil {
+			models.RespondInternalError(c, "Failed to value bond tokens", err)
+			return
+		}
+	}
+
 	models.RespondWithSuccess(c, http.StatusOK, "Bond tokens retrieved successfully", gin.H{
 		"bond_tokens": tokens,
 		"count":       len(tokens),
 	})
 }
 
+// GetMyPortfolio 取得目前使用者所有綁定錢包持有的債券代幣及估值（方法與估值時間見各代幣的 valuation）
+func (h *BondHandler) GetMyPortfolio(c *gin.Context) {
+	walletAddress, err := utils.GetWalletAddress(c)
+	if err != nil {
+		models.RespondUnauthorized(c, err.Error())
+		return
+	}
+
+	wallets, ok := h.myWallets(c)
+	if !ok {
+		return
+	}
+
+	tokens, err := h.bondTokenService.GetBondTokensByOwners(c.Request.Context(), wallets, portfolioTokenLimit, 0)
+	if err != nil {
+		models.RespondInternalError(c, "Failed to fetch bond tokens", err)
+		return
+	}
+
+	portfolio, err := h.valuationService.ValuePortfolio(c.Request.Context(), walletAddress, tokens)
+	if err != nil {
+		models.RespondInternalError(c, "Failed to value portfolio", err)
+		return
+	}
+	portfolio.Wallets = wallets
+
+	if quote := c.Query("quote"); quote != "" {
+		if err := h.convertPortfolio(c, portfolio, quote); err != nil {
+			respondPriceError(c, "Failed to convert portfolio", err)
+			return
+		}
+	}
+
+	models.RespondWithSuccess(c, http.StatusOK, "Portfolio retrieved successfully", portfolio)
+}
+
+// GetMyTransactions 取得目前使用者所有綁定錢包的交易記錄
+// GET /api/v1/transactions
+func (h *BondHandler) GetMyTransactions(c *gin.Context) {
+	var req ListTransactionsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		models.RespondBadRequest(c, "Invalid request parameters", err)
+		return
+	}
+
+	wallets, ok := h.myWallets(c)
+	if !ok {
+		return
+	}
+
+	txs, err := h.bondTokenService.GetTransactionsByWallets(c.Request.Context(), wallets, req.Limit, req.Offset)
+	if err != nil {
+		models.RespondInternalError(c, "Failed to fetch transactions", err)
+		return
+	}
+
+	models.RespondWithSuccess(c, http.StatusOK, "Transactions retrieved successfully", gin.H{
+		"transactions": txs,
+		"count":        len(txs),
+	})
+}
+
+// myWallets 取得目前使用者的主錢包與綁定錢包地址
+func (h *BondHandler) myWallets(c *gin.Context) ([]string, bool) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		models.RespondUnauthorized(c, err.Error())
+		return nil, false
+	}
+
+	wallets, err := h.walletService.WalletAddresses(c.Request.Context(), userID)
+	if err != nil {
+		models.RespondInternalError(c, "Failed to fetch linked wallets", err)
+		return nil, false
+	}
+	return wallets, true
+}
+
+// convertBond 將債券金額換算為指定法幣
+func (h *BondHandler) convertBond(c *gin.Context, bond *BondResponse, quote string) error {
+	fiat, err := h.priceService.ConvertAmounts(c.Request.Context(), bond.CoinType, quote, map[string]int64{
+		"total_amount":    bond.TotalAmount,
+		"amount_raised":   bond.AmountRaised,
+		"amount_redeemed": bond.AmountRedeemed,
+	})
+	if err != nil {
+		return err
+	}
+
+	bond.Fiat = fiat
+	return nil
+}
+
+// convertPortfolio 將各幣種持倉與總額換算為指定法幣
+func (h *BondHandler) convertPortfolio(c *gin.Context, portfolio *models.Portfolio, quote string) error {
+	faceValues := make(map[string]int64)
+	values := make(map[string]int64)
+
+	for _, total := range portfolio.Totals {
+		fiat, err := h.priceService.ConvertAmounts(c.Request.Context(), total.CoinType, quote, map[string]int64{
+			"face_value": total.FaceValue,
+			"value":      total.Value,
+		})
+		if err != nil {
+			return err
+		}
+		total.Fiat = fiat
+
+		faceValues[total.CoinType] = total.FaceValue
+		values[total.CoinType] = total.Value
+	}
+
+	fiat, err := h.priceService.ConvertMixed(c.Request.Context(), quote, map[string]map[string]int64{
+		"face_value": faceValues,
+		"value":      values,
+	})
+	if err != nil {
+		return err
+	}
+
+	portfolio.Fiat = fiat
+	return nil
+}
+
 // SyncTransaction 同步鏈上交易
 func (h *BondHandler) SyncTransaction(c *gin.Context) {
 	var req SyncTransactionRequest
@@ -182,12 +398,9 @@ func (h *BondHandler) SyncTransaction(c *gin.Context) {
 		err = h.syncService.SyncBondPurchased(c.Request.Context(), req.TransactionDigest)
 	case "bond_redeemed":
 		err = h.syncService.SyncBondRedeemed(c.Request.Context(), req.TransactionDigest)
-	case "funds_withdrawn":
-		// 暫時返回成功,由事件監聽器處理
-		models.RespondWithSuccess(c, http.StatusOK, "Transaction will be indexed by event listener", nil)
-		return
-	case "redemption_deposited":
+	case "funds_withdrawn", "redemption_deposited":
 		// 暫時返回成功,由事件監聽器處理
+		h.recordSync(c, req, "deferred")
 		models.RespondWithSuccess(c, http.StatusOK, "Transaction will be indexed by event listener", nil)
 		return
 	default:
@@ -196,9 +409,17 @@ func (h *BondHandler) SyncTransaction(c *gin.Context) {
 	}
 
 	if err != nil {
+		h.recordSync(c, req, "failed")
 		models.RespondInternalError(c, "Failed to sync transaction", err)
 		return
 	}
 
+	h.recordSync(c, req, "indexed")
 	models.RespondWithSuccess(c, http.StatusOK, "Transaction indexed successfully", nil)
 }
+
+// recordSync 記錄同步請求的稽核事件
+func (h *BondHandler) recordSync(c *gin.Context, req SyncTransactionRequest, result string) {
+	h.auditService.Record(c.Request.Context(), models.AuditActionSyncRequest, models.AuditTargetTransaction, req.TransactionDigest,
+		nil, map[string]any{"event_type": req.EventType, "result": result})
+}
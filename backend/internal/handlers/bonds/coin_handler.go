@@ -0,0 +1,51 @@
+package bonds
+
+import (
+	"bluelink-backend/internal/models"
+	"bluelink-backend/internal/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CoinHandler 處理債券計價幣種相關的請求
+type CoinHandler struct {
+	coinService *services.CoinService
+}
+
+// NewCoinHandler 建立新的 CoinHandler
+func NewCoinHandler(coinService *services.CoinService) *CoinHandler {
+	return &CoinHandler{
+		coinService: coinService,
+	}
+}
+
+// ListCoins 列出已登錄的幣種（前端以 decimals 格式化金額）
+// GET /api/v1/coins
+func (h *CoinHandler) ListCoins(c *gin.Context) {
+	coins, err := h.coinService.ListCoins(c.Request.Context())
+	if err != nil {
+		models.RespondInternalError(c, "Failed to fetch coins", err)
+		return
+	}
+
+	models.RespondWithSuccess(c, http.StatusOK, "success", coins)
+}
+
+// GetCoinMetadata 取得單一幣種元數據
+// GET /api/v1/coins/metadata?coin_type=0x2::sui::SUI
+func (h *CoinHandler) GetCoinMetadata(c *gin.Context) {
+	coinType := c.Query("coin_type")
+	if coinType == "" {
+		models.RespondBadRequest(c, "coin_type is required", nil)
+		return
+	}
+
+	meta, err := h.coinService.GetCoin(c.Request.Context(), coinType)
+	if err != nil {
+		models.RespondNotFound(c, "Coin metadata not found")
+		return
+	}
+
+	models.RespondWithSuccess(c, http.StatusOK, "success", meta)
+}
@@ -0,0 +1,254 @@
+package bonds
+
+import (
+	"bluelink-backend/internal/models"
+	"bluelink-backend/internal/services"
+	"bluelink-backend/internal/utils"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ImpactHandler 處理債券影響力相關的請求
+type ImpactHandler struct {
+	impactService *services.ImpactService
+	priceService  *services.PriceService
+}
+
+// NewImpactHandler 建立新的 ImpactHandler
+func NewImpactHandler(impactService *services.ImpactService, priceService *services.PriceService) *ImpactHandler {
+	return &ImpactHandler{
+		impactService: impactService,
+		priceService:  priceService,
+	}
+}
+
+// GetBondImpact 取得債券影響力資料與 KPI
+// GET /api/v1/bonds/:id/impact
+func (h *ImpactHandler) GetBondImpact(c *gin.Context) {
+	bondID, ok := parseBondID(c)
+	if !ok {
+		return
+	}
+
+	impact, err := h.impactService.GetBondImpact(c.Request.Context(), bondID)
+	if err != nil {
+		respondImpactError(c, "Failed to fetch bond impact", err)
+		return
+	}
+
+	models.RespondWithSuccess(c, http.StatusOK, "success", impact)
+}
+
+// UpsertBondImpact 設定債券影響力資料（僅該債券發行者）
+// PUT /api/v1/bonds/:id/impact
+func (h *ImpactHandler) UpsertBondImpact(c *gin.Context) {
+	walletAddress, err := utils.GetWalletAddress(c)
+	if err != nil {
+		models.RespondUnauthorized(c, err.Error())
+		return
+	}
+
+	bondID, ok := parseBondID(c)
+	if !ok {
+		return
+	}
+
+	var req UpsertImpactRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		models.RespondBadRequest(c, "Invalid request format", err)
+		return
+	}
+
+	impact := &models.BondImpact{
+		BondID:     bondID,
+		Category:   req.Category,
+		SDGTargets: req.SDGTargets,
+		Location:   req.Location,
+		KPIs:       make([]*models.ImpactKPI, 0, len(req.KPIs)),
+	}
+	if impact.SDGTargets == nil {
+		impact.SDGTargets = []int64{}
+	}
+	if string(impact.Location) == "null" {
+		impact.Location = nil
+	}
+	for _, k := range req.KPIs {
+		kpi := &models.ImpactKPI{
+			ID:       k.ID,
+			Name:     k.Name,
+			Unit:     k.Unit,
+			Baseline: k.Baseline,
+			Target:   k.Target,
+		}
+		if k.Description != "" {
+			description := k.Description
+			kpi.Description = &description
+		}
+		impact.KPIs = append(impact.KPIs, kpi)
+	}
+
+	if err := h.impactService.UpsertBondImpact(c.Request.Context(), walletAddress, impact); err != nil {
+		respondImpactError(c, "Failed to save bond impact", err)
+		return
+	}
+
+	updated, err := h.impactService.GetBondImpact(c.Request.Context(), bondID)
+	if err != nil {
+		respondImpactError(c, "Failed to fetch bond impact", err)
+		return
+	}
+
+	models.RespondWithSuccess(c, http.StatusOK, "Bond impact saved successfully", updated)
+}
+
+// GetImpactReports 取得債券的影響力報告
+// GET /api/v1/bonds/:id/impact-reports
+func (h *ImpactHandler) GetImpactReports(c *gin.Context) {
+	bondID, ok := parseBondID(c)
+	if !ok {
+		return
+	}
+
+	limit, _ := strconv.Atoi(c.Query("limit"))
+	offset, _ := strconv.Atoi(c.Query("offset"))
+
+	reports, err := h.impactService.ListReports(c.Request.Context(), bondID, limit, offset)
+	if err != nil {
+		models.RespondInternalError(c, "Failed to fetch impact reports", err)
+		return
+	}
+
+	models.RespondWithSuccess(c, http.StatusOK, "success", gin.H{
+		"reports": reports,
+		"count":   len(reports),
+	})
+}
+
+// SubmitImpactReport 提交影響力報告（僅該債券發行者）
+// POST /api/v1/bonds/:id/impact-reports
+func (h *ImpactHandler) SubmitImpactReport(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		models.RespondUnauthorized(c, err.Error())
+		return
+	}
+
+	walletAddress, err := utils.GetWalletAddress(c)
+	if err != nil {
+		models.RespondUnauthorized(c, err.Error())
+		return
+	}
+
+	bondID, ok := parseBondID(c)
+	if !ok {
+		return
+	}
+
+	var req SubmitImpactReportRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		models.RespondBadRequest(c, "Invalid request format", err)
+		return
+	}
+
+	report := &models.ImpactReport{
+		BondID:      bondID,
+		SubmittedBy: &userID,
+		PeriodStart: req.PeriodStart,
+		PeriodEnd:   req.PeriodEnd,
+		Values:      make([]*models.ImpactReportValue, 0, len(req.Values)),
+	}
+	if req.Summary != "" {
+		summary := req.Summary
+		report.Summary = &summary
+	}
+	if req.AttachmentURL != "" {
+		attachmentURL := req.AttachmentURL
+		report.AttachmentURL = &attachmentURL
+	}
+	for _, v := range req.Values {
+		value := &models.ImpactReportValue{
+			KPIID: v.KPIID,
+			Value: v.Value,
+		}
+		if v.Note != "" {
+			note := v.Note
+			value.Note = &note
+		}
+		report.Values = append(report.Values, value)
+	}
+
+	if err := h.impactService.SubmitReport(c.Request.Context(), walletAddress, report); err != nil {
+		respondImpactError(c, "Failed to submit impact report", err)
+		return
+	}
+
+	models.RespondWithSuccess(c, http.StatusCreated, "Impact report submitted successfully", report)
+}
+
+// GetPlatformImpact 取得全平台影響力彙總
+// GET /api/v1/impact/summary
+func (h *ImpactHandler) GetPlatformImpact(c *gin.Context) {
+	summary, err := h.impactService.GetPlatformImpact(c.Request.Context())
+	if err != nil {
+		models.RespondInternalError(c, "Failed to fetch platform impact", err)
+		return
+	}
+
+	// ?quote=USD 時將各類別及全平台的募集金額換算為法幣
+	if quote := c.Query("quote"); quote != "" {
+		totals := make(map[string]int64)
+		for _, category := range summary.ByCategory {
+			fiat, err := h.priceService.ConvertMixed(c.Request.Context(), quote, map[string]map[string]int64{
+				"amount_raised": category.AmountRaised,
+			})
+			if err != nil {
+				respondPriceError(c, "Failed to convert platform impact", err)
+				return
+			}
+			category.Fiat = fiat
+
+			for coinType, amount := range category.AmountRaised {
+				totals[coinType] += amount
+			}
+		}
+
+		fiat, err := h.priceService.ConvertMixed(c.Request.Context(), quote, map[string]map[string]int64{
+			"amount_raised": totals,
+		})
+		if err != nil {
+			respondPriceError(c, "Failed to convert platform impact", err)
+			return
+		}
+		summary.Fiat = fiat
+	}
+
+	models.RespondWithSuccess(c, http.StatusOK, "success", summary)
+}
+
+func parseBondID(c *gin.Context) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		models.RespondBadRequest(c, "Invalid bond ID", err)
+		return 0, false
+	}
+	return id, true
+}
+
+// respondImpactError 將服務層錯誤轉換為對應的 HTTP 狀態碼
+func respondImpactError(c *gin.Context, message string, err error) {
+	switch {
+	case errors.Is(err, services.ErrBondNotFound):
+		models.RespondNotFound(c, "Bond not found")
+	case errors.Is(err, services.ErrImpactNotConfigured):
+		models.RespondNotFound(c, "Bond impact profile not found")
+	case errors.Is(err, services.ErrNotBondIssuer):
+		models.RespondForbidden(c, err.Error())
+	case errors.Is(err, services.ErrInvalidImpactData):
+		models.RespondBadRequest(c, message, err)
+	default:
+		models.RespondInternalError(c, message, err)
+	}
+}
@@ -0,0 +1,87 @@
+package bonds
+
+import (
+	"bluelink-backend/internal/models"
+	"bluelink-backend/internal/pricing"
+	"bluelink-backend/internal/services"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PriceHandler 處理匯率相關的請求
+type PriceHandler struct {
+	priceService *services.PriceService
+}
+
+// NewPriceHandler 建立新的 PriceHandler
+func NewPriceHandler(priceService *services.PriceService) *PriceHandler {
+	return &PriceHandler{
+		priceService: priceService,
+	}
+}
+
+// GetPrice 取得目前匯率
+// GET /api/v1/prices?base=SUI&quote=USD
+func (h *PriceHandler) GetPrice(c *gin.Context) {
+	var req GetPriceRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		models.RespondBadRequest(c, "Invalid request parameters", err)
+		return
+	}
+
+	price, err := h.priceService.GetPrice(c.Request.Context(), req.Base, req.Quote)
+	if err != nil {
+		respondPriceError(c, "Failed to fetch price", err)
+		return
+	}
+
+	models.RespondWithSuccess(c, http.StatusOK, "success", price)
+}
+
+// GetPriceHistory 取得歷史匯率（預設最近 30 天）
+// GET /api/v1/prices/history?base=SUI&quote=USD&from=...&to=...
+func (h *PriceHandler) GetPriceHistory(c *gin.Context) {
+	var req GetPriceHistoryRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		models.RespondBadRequest(c, "Invalid request parameters", err)
+		return
+	}
+
+	to := time.Now()
+	if req.To != nil {
+		to = *req.To
+	}
+	from := to.AddDate(0, 0, -30)
+	if req.From != nil {
+		from = *req.From
+	}
+	if req.Limit <= 0 || req.Limit > 1000 {
+		req.Limit = 1000
+	}
+
+	history, err := h.priceService.GetPriceHistory(c.Request.Context(), req.Base, req.Quote, from, to, req.Limit)
+	if err != nil {
+		respondPriceError(c, "Failed to fetch price history", err)
+		return
+	}
+
+	models.RespondWithSuccess(c, http.StatusOK, "success", gin.H{
+		"prices": history,
+		"count":  len(history),
+	})
+}
+
+// respondPriceError 將匯率服務錯誤轉換為 HTTP 回應
+func respondPriceError(c *gin.Context, message string, err error) {
+	switch {
+	case errors.Is(err, services.ErrUnsupportedQuote):
+		models.RespondBadRequest(c, message, err)
+	case errors.Is(err, services.ErrPriceUnavailable), errors.Is(err, pricing.ErrPriceNotFound):
+		models.RespondWithErrorDetails(c, http.StatusServiceUnavailable, message, err.Error())
+	default:
+		models.RespondInternalError(c, message, err)
+	}
+}
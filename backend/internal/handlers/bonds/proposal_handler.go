@@ -0,0 +1,374 @@
+package bonds
+
+import (
+	"bluelink-backend/internal/models"
+	"bluelink-backend/internal/services"
+	"bluelink-backend/internal/utils"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+/*
+   債券發行申請流程：
+   1. 發行者 → POST /bond-proposals 建立草稿（條款、圖片、文件）
+   2. 發行者 → POST /bond-proposals/:id/submit 送出審核
+   3. 管理員 → POST /admin/bond-proposals/:id/approve 或 /reject（附意見）
+      被退回的申請可修改後重新送出
+   4. 發行者在鏈上建立 BondProject
+      事件監聽器 → 以 issuer + bond_name 比對已核准申請並關聯
+      沒有對應申請的鏈上債券 → 標記 needs_review，供管理員檢視
+*/
+
+// ProposalHandler 處理債券發行申請相關的請求
+type ProposalHandler struct {
+	proposalService *services.BondProposalService
+}
+
+// NewProposalHandler 建立新的 ProposalHandler
+func NewProposalHandler(proposalService *services.BondProposalService) *ProposalHandler {
+	return &ProposalHandler{
+		proposalService: proposalService,
+	}
+}
+
+// CreateProposal 建立債券發行申請草稿
+// POST /api/v1/bond-proposals
+func (h *ProposalHandler) CreateProposal(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		models.RespondUnauthorized(c, err.Error())
+		return
+	}
+
+	walletAddress, err := utils.GetWalletAddress(c)
+	if err != nil {
+		models.RespondUnauthorized(c, err.Error())
+		return
+	}
+
+	var req CreateBondRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		models.RespondBadRequest(c, "Invalid request format", err)
+		return
+	}
+
+	proposal := req.toProposal()
+	proposal.IssuerUserID = userID
+	proposal.IssuerAddress = walletAddress
+
+	if err := h.proposalService.CreateProposal(c.Request.Context(), proposal); err != nil {
+		models.RespondInternalError(c, "Failed to create bond proposal", err)
+		return
+	}
+
+	models.RespondWithSuccess(c, http.StatusCreated, "Bond proposal created successfully", proposal)
+}
+
+// GetMyProposals 取得當前發行者的申請列表
+// GET /api/v1/bond-proposals
+func (h *ProposalHandler) GetMyProposals(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		models.RespondUnauthorized(c, err.Error())
+		return
+	}
+
+	var req ListProposalsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		models.RespondBadRequest(c, "Invalid request parameters", err)
+		return
+	}
+
+	proposals, err := h.proposalService.ListMyProposals(c.Request.Context(), userID, req.Limit, req.Offset)
+	if err != nil {
+		models.RespondInternalError(c, "Failed to fetch bond proposals", err)
+		return
+	}
+
+	models.RespondWithSuccess(c, http.StatusOK, "Bond proposals retrieved successfully", gin.H{
+		"proposals": proposals,
+		"count":     len(proposals),
+	})
+}
+
+// GetProposal 取得單一申請（發行者只能看自己的）
+// GET /api/v1/bond-proposals/:id
+func (h *ProposalHandler) GetProposal(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		models.RespondUnauthorized(c, err.Error())
+		return
+	}
+
+	id, ok := parseProposalID(c)
+	if !ok {
+		return
+	}
+
+	proposal, err := h.proposalService.GetProposal(c.Request.Context(), id, userID)
+	if err != nil {
+		respondProposalError(c, "Failed to fetch bond proposal", err)
+		return
+	}
+
+	models.RespondWithSuccess(c, http.StatusOK, "Bond proposal retrieved successfully", proposal)
+}
+
+// UpdateProposal 更新申請內容
+// PUT /api/v1/bond-proposals/:id
+func (h *ProposalHandler) UpdateProposal(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		models.RespondUnauthorized(c, err.Error())
+		return
+	}
+
+	id, ok := parseProposalID(c)
+	if !ok {
+		return
+	}
+
+	var req CreateBondRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		models.RespondBadRequest(c, "Invalid request format", err)
+		return
+	}
+
+	proposal := req.toProposal()
+	proposal.ID = id
+
+	if err := h.proposalService.UpdateProposal(c.Request.Context(), userID, proposal); err != nil {
+		respondProposalError(c, "Failed to update bond proposal", err)
+		return
+	}
+
+	updated, err := h.proposalService.GetProposal(c.Request.Context(), id, userID)
+	if err != nil {
+		respondProposalError(c, "Failed to fetch bond proposal", err)
+		return
+	}
+
+	models.RespondWithSuccess(c, http.StatusOK, "Bond proposal updated successfully", updated)
+}
+
+// SubmitProposal 送出申請給管理員審核
+// POST /api/v1/bond-proposals/:id/submit
+func (h *ProposalHandler) SubmitProposal(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		models.RespondUnauthorized(c, err.Error())
+		return
+	}
+
+	id, ok := parseProposalID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.proposalService.SubmitProposal(c.Request.Context(), id, userID); err != nil {
+		respondProposalError(c, "Failed to submit bond proposal", err)
+		return
+	}
+
+	models.RespondWithSuccess(c, http.StatusOK, "Bond proposal submitted for review", nil)
+}
+
+// DeleteProposal 刪除尚未送審的申請
+// DELETE /api/v1/bond-proposals/:id
+func (h *ProposalHandler) DeleteProposal(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		models.RespondUnauthorized(c, err.Error())
+		return
+	}
+
+	id, ok := parseProposalID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.proposalService.DeleteProposal(c.Request.Context(), id, userID); err != nil {
+		respondProposalError(c, "Failed to delete bond proposal", err)
+		return
+	}
+
+	models.RespondWithSuccess(c, http.StatusOK, "Bond proposal deleted successfully", nil)
+}
+
+// ===== 管理員功能 =====
+
+// ListProposals 依狀態列出申請
+// GET /api/v1/admin/bond-proposals?status=submitted
+func (h *ProposalHandler) ListProposals(c *gin.Context) {
+	var req ListProposalsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		models.RespondBadRequest(c, "Invalid request parameters", err)
+		return
+	}
+
+	proposals, err := h.proposalService.ListProposals(c.Request.Context(), req.Status, req.Limit, req.Offset)
+	if err != nil {
+		models.RespondInternalError(c, "Failed to fetch bond proposals", err)
+		return
+	}
+
+	models.RespondWithSuccess(c, http.StatusOK, "Bond proposals retrieved successfully", gin.H{
+		"proposals": proposals,
+		"count":     len(proposals),
+	})
+}
+
+// GetProposalForReview 取得任一申請詳情
+// GET /api/v1/admin/bond-proposals/:id
+func (h *ProposalHandler) GetProposalForReview(c *gin.Context) {
+	id, ok := parseProposalID(c)
+	if !ok {
+		return
+	}
+
+	proposal, err := h.proposalService.GetProposal(c.Request.Context(), id, 0)
+	if err != nil {
+		respondProposalError(c, "Failed to fetch bond proposal", err)
+		return
+	}
+
+	models.RespondWithSuccess(c, http.StatusOK, "Bond proposal retrieved successfully", proposal)
+}
+
+// ApproveProposal 核准申請
+// POST /api/v1/admin/bond-proposals/:id/approve
+func (h *ProposalHandler) ApproveProposal(c *gin.Context) {
+	h.reviewProposal(c, true)
+}
+
+// RejectProposal 退回申請（需附意見）
+// POST /api/v1/admin/bond-proposals/:id/reject
+func (h *ProposalHandler) RejectProposal(c *gin.Context) {
+	h.reviewProposal(c, false)
+}
+
+func (h *ProposalHandler) reviewProposal(c *gin.Context, approve bool) {
+	reviewerID, err := utils.GetUserID(c)
+	if err != nil {
+		models.RespondUnauthorized(c, err.Error())
+		return
+	}
+
+	id, ok := parseProposalID(c)
+	if !ok {
+		return
+	}
+
+	var req ReviewProposalRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !approve {
+		models.RespondBadRequest(c, "Invalid request format", err)
+		return
+	}
+
+	if approve {
+		err = h.proposalService.ApproveProposal(c.Request.Context(), id, reviewerID, req.Comment)
+	} else {
+		err = h.proposalService.RejectProposal(c.Request.Context(), id, reviewerID, req.Comment)
+	}
+	if err != nil {
+		respondProposalError(c, "Failed to review bond proposal", err)
+		return
+	}
+
+	message := "Bond proposal rejected"
+	if approve {
+		message = "Bond proposal approved"
+	}
+	models.RespondWithSuccess(c, http.StatusOK, message, nil)
+}
+
+// GetBondsNeedingReview 列出鏈上創建但沒有已核准申請的債券
+// GET /api/v1/admin/bonds/needs-review
+func (h *ProposalHandler) GetBondsNeedingReview(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.Query("limit"))
+	offset, _ := strconv.Atoi(c.Query("offset"))
+
+	bonds, err := h.proposalService.ListBondsNeedingReview(c.Request.Context(), limit, offset)
+	if err != nil {
+		models.RespondInternalError(c, "Failed to fetch bonds", err)
+		return
+	}
+
+	models.RespondWithSuccess(c, http.StatusOK, "success", ToBondResponseList(bonds))
+}
+
+// ClearBondReviewFlag 清除債券的審核標記
+// POST /api/v1/admin/bonds/:id/clear-review
+func (h *ProposalHandler) ClearBondReviewFlag(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		models.RespondBadRequest(c, "Invalid bond ID", err)
+		return
+	}
+
+	if err := h.proposalService.ClearBondReviewFlag(c.Request.Context(), id); err != nil {
+		models.RespondNotFound(c, "Bond not found")
+		return
+	}
+
+	models.RespondWithSuccess(c, http.StatusOK, "Bond review flag cleared", nil)
+}
+
+// toProposal 將請求轉換為申請模型
+func (req *CreateBondRequest) toProposal() *models.BondProposal {
+	proposal := &models.BondProposal{
+		IssuerName:         req.IssuerName,
+		BondName:           req.Name,
+		TotalAmount:        req.TotalAmount,
+		AnnualInterestRate: req.AnnualInterestRate,
+		MaturityDate:       req.MaturityDate,
+		BondImageUrl:       req.BondImageUrl,
+		TokenImageUrl:      req.TokenImageUrl,
+		MetadataUrl:        req.MetadataUrl,
+		Documents:          make([]models.ProposalDocument, 0, len(req.Documents)),
+	}
+
+	if req.Description != "" {
+		description := req.Description
+		proposal.Description = &description
+	}
+
+	for _, doc := range req.Documents {
+		proposal.Documents = append(proposal.Documents, models.ProposalDocument{
+			Name: doc.Name,
+			URL:  doc.URL,
+			Type: doc.Type,
+		})
+	}
+
+	return proposal
+}
+
+func parseProposalID(c *gin.Context) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		models.RespondBadRequest(c, "Invalid bond proposal ID", err)
+		return 0, false
+	}
+	return id, true
+}
+
+// respondProposalError 將服務層錯誤轉換為對應的 HTTP 狀態碼
+func respondProposalError(c *gin.Context, message string, err error) {
+	switch {
+	case errors.Is(err, services.ErrProposalNotFound):
+		models.RespondNotFound(c, "Bond proposal not found")
+	case errors.Is(err, services.ErrProposalForbidden):
+		models.RespondForbidden(c, "Cannot access another issuer's proposal")
+	case errors.Is(err, services.ErrProposalNotEditable),
+		errors.Is(err, services.ErrProposalNotInReview):
+		models.RespondWithErrorDetails(c, http.StatusConflict, message, err.Error())
+	case errors.Is(err, services.ErrReviewCommentMissing):
+		models.RespondBadRequest(c, message, err)
+	default:
+		models.RespondInternalError(c, message, err)
+	}
+}
@@ -1,15 +1,41 @@
 package bonds
 
+import (
+	"encoding/json"
+	"time"
+)
+
+// CreateBondRequest 建立債券發行申請（鏈下草稿）
 type CreateBondRequest struct {
-	ID            int64   `json:"id" binding:"required"`
-	Name          string  `json:"name" binding:"required"`
-	IssuerName    string  `json:"issuer_name" binding:"required"`
-	IssuerAddress string  `json:"issuer_address" binding:"required"`
-	BondImageUrl  string  `json:"bond_image_url" binding:"required"`  // 🆕 專案展示圖片 URL
-	TokenImageUrl string  `json:"token_image_url" binding:"required"` // 🆕 NFT 代幣圖片 URL
-	MetadataUrl   string  `json:"metadata_url" binding:"required"`    // 🆕 完整元數據 URL
-	FaceValue     float64 `json:"face_value" binding:"required,gt=0"`
-	Currency      string  `json:"currency" binding:"required"`
+	Name               string                    `json:"name" binding:"required"`
+	IssuerName         string                    `json:"issuer_name" binding:"required"`
+	Description        string                    `json:"description"`
+	BondImageUrl       string                    `json:"bond_image_url" binding:"required"`                      // 🆕 專案展示圖片 URL
+	TokenImageUrl      string                    `json:"token_image_url" binding:"required"`                     // 🆕 NFT 代幣圖片 URL
+	MetadataUrl        string                    `json:"metadata_url" binding:"required"`                        // 🆕 完整元數據 URL
+	TotalAmount        int64                     `json:"total_amount" binding:"required,gt=0"`                   // 計價幣種最小單位
+	AnnualInterestRate int64                     `json:"annual_interest_rate" binding:"required,gt=0,lte=10000"` // 基點 (5% = 500)
+	MaturityDate       string                    `json:"maturity_date" binding:"required,datetime=2006-01-02"`   // YYYY-MM-DD
+	Documents          []ProposalDocumentRequest `json:"documents" binding:"omitempty,dive"`
+}
+
+// ProposalDocumentRequest 申請附件
+type ProposalDocumentRequest struct {
+	Name string `json:"name" binding:"required"`
+	URL  string `json:"url" binding:"required,url"`
+	Type string `json:"type"`
+}
+
+// ReviewProposalRequest 管理員審核申請
+type ReviewProposalRequest struct {
+	Comment string `json:"comment"`
+}
+
+// ListProposalsRequest 查詢申請列表
+type ListProposalsRequest struct {
+	Status string `form:"status" binding:"omitempty,oneof=draft submitted approved rejected linked"`
+	Limit  int    `form:"limit"`
+	Offset int    `form:"offset"`
 }
 
 type BuyBondRequest struct {
@@ -38,15 +64,17 @@ type GetBondTokenByOnChainIDRequest struct {
 }
 
 type GetBondTokensByOwnerRequest struct {
-	Owner  string `form:"owner" binding:"required"`
-	Limit  int    `form:"limit"`
-	Offset int    `form:"offset"`
+	Owner            string `form:"owner"` // 未指定時為目前使用者所有綁定錢包
+	Limit            int    `form:"limit"`
+	Offset           int    `form:"offset"`
+	IncludeValuation bool   `form:"include_valuation"` // 是否附帶估值
 }
 
 type GetBondTokensByProjectRequest struct {
-	ProjectID string `form:"project_id" binding:"required"`
-	Limit     int    `form:"limit"`
-	Offset    int    `form:"offset"`
+	ProjectID        string `form:"project_id" binding:"required"`
+	Limit            int    `form:"limit"`
+	Offset           int    `form:"offset"`
+	IncludeValuation bool   `form:"include_valuation"` // 是否附帶估值
 }
 
 type CalculateRedemptionRequest struct {
@@ -58,3 +86,58 @@ type SyncTransactionRequest struct {
 	TransactionDigest string `json:"transaction_digest" binding:"required"`
 	EventType         string `json:"event_type" binding:"required,oneof=bond_created bond_purchased bond_redeemed funds_withdrawn redemption_deposited"`
 }
+
+// UpsertImpactRequest 設定債券影響力資料
+type UpsertImpactRequest struct {
+	Category   string             `json:"category" binding:"required,oneof=fisheries coral plastics mangroves wastewater coastal_resilience marine_protected_areas shipping other"`
+	SDGTargets []int64            `json:"sdg_targets" binding:"omitempty,dive,min=1,max=17"` // 聯合國 SDG 編號
+	Location   json.RawMessage    `json:"location"`                                          // GeoJSON
+	KPIs       []ImpactKPIRequest `json:"kpis" binding:"omitempty,dive"`
+}
+
+// ImpactKPIRequest 影響力指標（帶 ID 表示更新既有指標）
+type ImpactKPIRequest struct {
+	ID          int64   `json:"id"`
+	Name        string  `json:"name" binding:"required"`
+	Unit        string  `json:"unit" binding:"required"`
+	Description string  `json:"description"`
+	Baseline    float64 `json:"baseline"`
+	Target      float64 `json:"target"`
+}
+
+// SubmitImpactReportRequest 提交影響力報告
+type SubmitImpactReportRequest struct {
+	PeriodStart   string                     `json:"period_start" binding:"required,datetime=2006-01-02"`
+	PeriodEnd     string                     `json:"period_end" binding:"required,datetime=2006-01-02"`
+	Summary       string                     `json:"summary"`
+	AttachmentURL string                     `json:"attachment_url" binding:"omitempty,url"`
+	Values        []ImpactReportValueRequest `json:"values" binding:"required,min=1,dive"`
+}
+
+// ImpactReportValueRequest 報告中單一 KPI 數值
+type ImpactReportValueRequest struct {
+	KPIID int64   `json:"kpi_id" binding:"required"`
+	Value float64 `json:"value"`
+	Note  string  `json:"note"`
+}
+
+// GetPriceRequest 查詢匯率
+type GetPriceRequest struct {
+	Base  string `form:"base" binding:"required"`  // 幣種符號，例如 SUI
+	Quote string `form:"quote" binding:"required"` // 法幣代碼，例如 USD
+}
+
+// GetPriceHistoryRequest 查詢歷史匯率
+type GetPriceHistoryRequest struct {
+	Base  string     `form:"base" binding:"required"`
+	Quote string     `form:"quote" binding:"required"`
+	From  *time.Time `form:"from" time_format:"2006-01-02T15:04:05Z07:00"`
+	To    *time.Time `form:"to" time_format:"2006-01-02T15:04:05Z07:00"`
+	Limit int        `form:"limit"`
+}
+
+// ListTransactionsRequest 查詢交易記錄的請求參數
+type ListTransactionsRequest struct {
+	Limit  int `form:"limit"`
+	Offset int `form:"offset"`
+}
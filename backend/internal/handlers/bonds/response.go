@@ -14,9 +14,11 @@ type BondResponse struct {
 	BondName           string `json:"bond_name"`
 	BondImageURL       string `json:"bond_image_url"`
 	TokenImageURL      string `json:"token_image_url"`
-	TotalAmount        int64  `json:"total_amount"`         // MIST 單位
-	AmountRaised       int64  `json:"amount_raised"`        // MIST 單位
-	AmountRedeemed     int64  `json:"amount_redeemed"`      // MIST 單位
+	CoinType           string `json:"coin_type"`            // 計價幣種
+	CoinDecimals       int    `json:"coin_decimals"`        // 計價幣種小數位數
+	TotalAmount        int64  `json:"total_amount"`         // 計價幣種最小單位
+	AmountRaised       int64  `json:"amount_raised"`        // 計價幣種最小單位
+	AmountRedeemed     int64  `json:"amount_redeemed"`      // 計價幣種最小單位
 	TokensIssued       int64  `json:"tokens_issued"`        // 已發行代幣數量
 	TokensRedeemed     int64  `json:"tokens_redeemed"`      // 已贖回代幣數量
 	AnnualInterestRate int64  `json:"annual_interest_rate"` // 基點 (5% = 500)
@@ -27,6 +29,8 @@ type BondResponse struct {
 	MetadataURL        string `json:"metadata_url"`
 	CreatedAt          string `json:"created_at"` // ISO 8601 格式
 	UpdatedAt          string `json:"updated_at"` // ISO 8601 格式
+
+	Fiat *models.FiatConversion `json:"fiat,omitempty"` // 金額換算法幣（?quote= 時）
 }
 
 // ToBondResponse 將 Bond 模型轉換為 API 響應格式
@@ -47,6 +51,8 @@ func ToBondResponse(bond *models.Bond) *BondResponse {
 		BondName:           bond.BondName,
 		BondImageURL:       bond.BondImageUrl,
 		TokenImageURL:      bond.TokenImageUrl,
+		CoinType:           bond.CoinType,
+		CoinDecimals:       bond.CoinDecimals,
 		TotalAmount:        bond.TotalAmount,
 		AmountRaised:       bond.AmountRaised,
 		AmountRedeemed:     bond.AmountRedeemed,
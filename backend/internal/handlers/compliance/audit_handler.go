@@ -0,0 +1,143 @@
+package compliance
+
+import (
+	"bluelink-backend/internal/models"
+	"bluelink-backend/internal/services"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+/*
+   稽核紀錄：
+   1. 登入/登出、Session 撤銷、個人資料與錢包變更、角色變更、管理員審核與同步請求皆寫入 audit_events
+   2. 每筆記錄操作者、IP、UserAgent、Request ID、對象與變動前後的欄位
+   3. audit_events 僅可新增（資料庫觸發器禁止修改或刪除）
+   4. 管理員 → GET /admin/audit-events 查詢，GET /admin/audit-events/export 匯出 CSV / JSON
+*/
+
+// AuditHandler 處理稽核事件查詢與匯出的請求
+type AuditHandler struct {
+	auditService *services.AuditService
+}
+
+// NewAuditHandler 建立新的 AuditHandler
+func NewAuditHandler(auditService *services.AuditService) *AuditHandler {
+	return &AuditHandler{
+		auditService: auditService,
+	}
+}
+
+// ListEvents 依條件查詢稽核事件
+// GET /api/v1/admin/audit-events?actor_id=1&action=admin.&target_type=user&target_id=42&from=...&to=...
+func (h *AuditHandler) ListEvents(c *gin.Context) {
+	var req ListAuditEventsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		models.RespondBadRequest(c, "Invalid request parameters", err)
+		return
+	}
+
+	events, total, err := h.auditService.Search(c.Request.Context(), req.toFilter(), req.Limit, req.Offset)
+	if err != nil {
+		models.RespondInternalError(c, "Failed to fetch audit events", err)
+		return
+	}
+
+	models.RespondWithSuccess(c, http.StatusOK, "Audit events retrieved successfully", gin.H{
+		"events": events,
+		"count":  len(events),
+		"total":  total,
+	})
+}
+
+// ExportEvents 匯出符合條件的稽核事件（CSV 或 JSON 檔案）
+// GET /api/v1/admin/audit-events/export?format=csv
+func (h *AuditHandler) ExportEvents(c *gin.Context) {
+	var req ListAuditEventsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		models.RespondBadRequest(c, "Invalid request parameters", err)
+		return
+	}
+
+	events, err := h.auditService.Export(c.Request.Context(), req.toFilter())
+	if err != nil {
+		models.RespondInternalError(c, "Failed to export audit events", err)
+		return
+	}
+
+	fileName := fmt.Sprintf("audit-events-%s", time.Now().UTC().Format("20060102T150405Z"))
+	c.Header("Cache-Control", "no-store")
+
+	if req.Format == "json" {
+		c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.json"`, fileName))
+		c.JSON(http.StatusOK, events)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.csv"`, fileName))
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Status(http.StatusOK)
+
+	writer := csv.NewWriter(c.Writer)
+	writer.Write([]string{
+		"id", "created_at", "actor_id", "actor_wallet", "actor_role", "action",
+		"target_type", "target_id", "ip_address", "user_agent", "request_id", "before", "after",
+	})
+	for _, event := range events {
+		writer.Write([]string{
+			strconv.FormatInt(event.ID, 10),
+			event.CreatedAt.UTC().Format(time.RFC3339),
+			formatOptionalID(event.ActorID),
+			deref(event.ActorWallet),
+			deref(event.ActorRole),
+			event.Action,
+			event.TargetType,
+			deref(event.TargetID),
+			deref(event.IPAddress),
+			deref(event.UserAgent),
+			deref(event.RequestID),
+			formatJSON(event.Before),
+			formatJSON(event.After),
+		})
+	}
+	writer.Flush()
+}
+
+// toFilter 將請求轉換為查詢條件
+func (req *ListAuditEventsRequest) toFilter() models.AuditFilter {
+	return models.AuditFilter{
+		ActorID:    req.ActorID,
+		Action:     req.Action,
+		TargetType: req.TargetType,
+		TargetID:   req.TargetID,
+		RequestID:  req.RequestID,
+		From:       req.From,
+		To:         req.To,
+	}
+}
+
+func formatOptionalID(id *int64) string {
+	if id == nil {
+		return ""
+	}
+	return strconv.FormatInt(*id, 10)
+}
+
+func deref(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
+func formatJSON(data json.RawMessage) string {
+	if len(data) == 0 {
+		return ""
+	}
+	return string(data)
+}
@@ -0,0 +1,152 @@
+package compliance
+
+import (
+	"bluelink-backend/internal/models"
+	"bluelink-backend/internal/services"
+	"bluelink-backend/internal/utils"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+/*
+   投資限額：
+   1. 每位使用者適用每日/每月投資金額上限與單一債券最高持有比例
+      預設值依 KYC 等級（INVESTMENT_LEVEL_LIMITS），管理員可針對個別使用者覆寫
+   2. 已投資金額由 transactions（一級市場購買、二級市場買入）統計，換算為 INVESTMENT_LIMIT_CURRENCY
+   3. 前端 → POST /limits/check 在簽署交易前確認是否超出限額
+   4. 事件監聽器與二級市場成交後重新檢查，違規時建立合規警示待審查
+*/
+
+// LimitHandler 處理投資限額與限額違規警示的請求
+type LimitHandler struct {
+	limitService *services.InvestmentLimitService
+}
+
+// NewLimitHandler 建立新的 LimitHandler
+func NewLimitHandler(limitService *services.InvestmentLimitService) *LimitHandler {
+	return &LimitHandler{
+		limitService: limitService,
+	}
+}
+
+// GetMyLimits 取得當前使用者的限額與用量
+// GET /api/v1/limits
+func (h *LimitHandler) GetMyLimits(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		models.RespondUnauthorized(c, err.Error())
+		return
+	}
+
+	limits, usage, err := h.limitService.GetMyLimits(c.Request.Context(), userID)
+	if err != nil {
+		respondComplianceError(c, "Failed to fetch investment limits", err)
+		return
+	}
+
+	models.RespondWithSuccess(c, http.StatusOK, "Investment limits retrieved successfully", gin.H{
+		"limits": limits,
+		"usage":  usage,
+	})
+}
+
+// PreTradeCheck 交易前檢查
+// POST /api/v1/limits/check
+func (h *LimitHandler) PreTradeCheck(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		models.RespondUnauthorized(c, err.Error())
+		return
+	}
+
+	var req PreTradeCheckRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		models.RespondBadRequest(c, "Invalid request format", err)
+		return
+	}
+
+	check, err := h.limitService.PreTradeCheck(c.Request.Context(), userID, req.BondID, req.Amount, req.FaceValue)
+	if err != nil {
+		respondComplianceError(c, "Failed to check investment limits", err)
+		return
+	}
+
+	models.RespondWithSuccess(c, http.StatusOK, "Pre-trade check completed", check)
+}
+
+// ===== 管理員功能 =====
+
+// SetUserLimits 設定使用者個別限額
+// PUT /api/v1/admin/users/:id/limits
+func (h *LimitHandler) SetUserLimits(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		models.RespondBadRequest(c, "Invalid user ID", err)
+		return
+	}
+
+	var req SetUserLimitsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		models.RespondBadRequest(c, "Invalid request format", err)
+		return
+	}
+
+	if err := h.limitService.SetUserLimits(c.Request.Context(), id, req.DailyLimit, req.MonthlyLimit, req.MaxBondShare); err != nil {
+		respondComplianceError(c, "Failed to update investment limits", err)
+		return
+	}
+
+	models.RespondWithSuccess(c, http.StatusOK, "Investment limits updated", nil)
+}
+
+// ListAlerts 依狀態列出限額違規警示
+// GET /api/v1/admin/compliance/alerts?status=open
+func (h *LimitHandler) ListAlerts(c *gin.Context) {
+	var req ListComplianceAlertsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		models.RespondBadRequest(c, "Invalid request parameters", err)
+		return
+	}
+
+	alerts, err := h.limitService.ListAlerts(c.Request.Context(), req.Status, req.Limit, req.Offset)
+	if err != nil {
+		models.RespondInternalError(c, "Failed to fetch compliance alerts", err)
+		return
+	}
+
+	models.RespondWithSuccess(c, http.StatusOK, "Compliance alerts retrieved successfully", gin.H{
+		"alerts": alerts,
+		"count":  len(alerts),
+	})
+}
+
+// ResolveAlert 結案限額違規警示（需附處理說明）
+// POST /api/v1/admin/compliance/alerts/:id/resolve
+func (h *LimitHandler) ResolveAlert(c *gin.Context) {
+	reviewerID, err := utils.GetUserID(c)
+	if err != nil {
+		models.RespondUnauthorized(c, err.Error())
+		return
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		models.RespondBadRequest(c, "Invalid compliance alert ID", err)
+		return
+	}
+
+	var req ResolveFlagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		models.RespondBadRequest(c, "Invalid request format", err)
+		return
+	}
+
+	if err := h.limitService.ResolveAlert(c.Request.Context(), id, reviewerID, req.Note); err != nil {
+		respondComplianceError(c, "Failed to resolve compliance alert", err)
+		return
+	}
+
+	models.RespondWithSuccess(c, http.StatusOK, "Compliance alert resolved", nil)
+}
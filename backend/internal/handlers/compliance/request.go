@@ -0,0 +1,63 @@
+package compliance
+
+import "time"
+
+// CheckAddressRequest 管理員手動比對地址
+type CheckAddressRequest struct {
+	WalletAddress string `json:"wallet_address" binding:"required"`
+}
+
+// ListScreeningHistoryRequest 查詢比對紀錄
+type ListScreeningHistoryRequest struct {
+	Wallet    string `form:"wallet"`
+	MatchOnly bool   `form:"match_only"`
+	Limit     int    `form:"limit"`
+	Offset    int    `form:"offset"`
+}
+
+// ListComplianceFlagsRequest 查詢合規標記
+type ListComplianceFlagsRequest struct {
+	Status string `form:"status" binding:"omitempty,oneof=open resolved"`
+	Limit  int    `form:"limit"`
+	Offset int    `form:"offset"`
+}
+
+// ResolveFlagRequest 結案合規標記或限額違規警示
+type ResolveFlagRequest struct {
+	Note string `json:"note" binding:"required"`
+}
+
+// PreTradeCheckRequest 交易前檢查（金額皆為債券幣種最小單位）
+type PreTradeCheckRequest struct {
+	BondID    int64 `json:"bond_id" binding:"required"`
+	Amount    int64 `json:"amount" binding:"required,gt=0"`      // 投資金額（一級市場購買金額或二級市場成交價）
+	FaceValue int64 `json:"face_value" binding:"omitempty,gt=0"` // 取得的代幣面額，未填時等於 amount
+}
+
+// SetUserLimitsRequest 設定使用者個別限額（未填表示沿用 KYC 等級預設）
+type SetUserLimitsRequest struct {
+	DailyLimit   *float64 `json:"daily_limit" binding:"omitempty,gte=0"`
+	MonthlyLimit *float64 `json:"monthly_limit" binding:"omitempty,gte=0"`
+	MaxBondShare *float64 `json:"max_bond_share" binding:"omitempty,gte=0,lte=1"`
+}
+
+// ListComplianceAlertsRequest 查詢限額違規警示
+type ListComplianceAlertsRequest struct {
+	Status string `form:"status" binding:"omitempty,oneof=open resolved"`
+	Limit  int    `form:"limit"`
+	Offset int    `form:"offset"`
+}
+
+// ListAuditEventsRequest 查詢稽核事件（action 以 "." 結尾時為前綴比對，例如 admin.）
+type ListAuditEventsRequest struct {
+	ActorID    *int64     `form:"actor_id"`
+	Action     string     `form:"action"`
+	TargetType string     `form:"target_type"`
+	TargetID   string     `form:"target_id"`
+	RequestID  string     `form:"request_id"`
+	From       *time.Time `form:"from" time_format:"2006-01-02T15:04:05Z07:00"`
+	To         *time.Time `form:"to" time_format:"2006-01-02T15:04:05Z07:00"`
+	Limit      int        `form:"limit"`
+	Offset     int        `form:"offset"`
+	Format     string     `form:"format" binding:"omitempty,oneof=csv json"` // 匯出格式，預設 csv
+}
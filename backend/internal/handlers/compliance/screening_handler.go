@@ -0,0 +1,189 @@
+package compliance
+
+import (
+	"bluelink-backend/internal/models"
+	"bluelink-backend/internal/services"
+	"bluelink-backend/internal/utils"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+/*
+   名單比對流程：
+   1. 啟動時載入 SCREENING_LIST_PATHS 中的名單（CSV / JSON），檔案變更時自動重新載入
+   2. 登入時比對錢包地址，命中則拒絕登入並將使用者列入黑名單
+   3. 鏈上購買與轉移事件比對參與地址，命中則建立合規標記待審查
+   4. 定期重新比對所有持有者（SCREENING_RESCAN_INTERVAL）
+   5. 管理員 → /admin/screening/* 查看名單狀態、手動比對、查詢紀錄
+      管理員 → /admin/compliance/flags 審查並結案合規標記
+*/
+
+// ScreeningHandler 處理名單比對與合規標記的請求
+type ScreeningHandler struct {
+	screeningService *services.ScreeningService
+}
+
+// NewScreeningHandler 建立新的 ScreeningHandler
+func NewScreeningHandler(screeningService *services.ScreeningService) *ScreeningHandler {
+	return &ScreeningHandler{
+		screeningService: screeningService,
+	}
+}
+
+// GetListStatus 取得目前載入的名單概況
+// GET /api/v1/admin/screening/status
+func (h *ScreeningHandler) GetListStatus(c *gin.Context) {
+	models.RespondWithSuccess(c, http.StatusOK, "Deny list status retrieved successfully", h.screeningService.ListStatus())
+}
+
+// ReloadLists 強制重新載入名單
+// POST /api/v1/admin/screening/reload
+func (h *ScreeningHandler) ReloadLists(c *gin.Context) {
+	status, err := h.screeningService.ReloadLists(c.Request.Context())
+	if err != nil {
+		respondComplianceError(c, "Failed to reload deny lists", err)
+		return
+	}
+
+	models.RespondWithSuccess(c, http.StatusOK, "Deny lists reloaded", status)
+}
+
+// CheckAddress 手動比對地址
+// POST /api/v1/admin/screening/check
+func (h *ScreeningHandler) CheckAddress(c *gin.Context) {
+	adminID, err := utils.GetUserID(c)
+	if err != nil {
+		models.RespondUnauthorized(c, err.Error())
+		return
+	}
+
+	var req CheckAddressRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		models.RespondBadRequest(c, "Invalid request format", err)
+		return
+	}
+
+	result, err := h.screeningService.ScreenAddress(c.Request.Context(), req.WalletAddress, adminID)
+	if err != nil {
+		respondComplianceError(c, "Failed to screen wallet address", err)
+		return
+	}
+
+	models.RespondWithSuccess(c, http.StatusOK, "Wallet address screened", result)
+}
+
+// RescanHolders 立即重新比對所有持有者
+// POST /api/v1/admin/screening/rescan
+func (h *ScreeningHandler) RescanHolders(c *gin.Context) {
+	screened, matched, err := h.screeningService.RescanHolders(c.Request.Context())
+	if err != nil {
+		respondComplianceError(c, "Failed to rescan holders", err)
+		return
+	}
+
+	models.RespondWithSuccess(c, http.StatusOK, "Holders rescanned", gin.H{
+		"screened": screened,
+		"matched":  matched,
+	})
+}
+
+// ListHistory 查詢比對紀錄
+// GET /api/v1/admin/screening/history?wallet=0x...&match_only=true
+func (h *ScreeningHandler) ListHistory(c *gin.Context) {
+	var req ListScreeningHistoryRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		models.RespondBadRequest(c, "Invalid request parameters", err)
+		return
+	}
+
+	results, err := h.screeningService.ListHistory(c.Request.Context(), req.Wallet, req.MatchOnly, req.Limit, req.Offset)
+	if err != nil {
+		respondComplianceError(c, "Failed to fetch screening history", err)
+		return
+	}
+
+	models.RespondWithSuccess(c, http.StatusOK, "Screening history retrieved successfully", gin.H{
+		"results": results,
+		"count":   len(results),
+	})
+}
+
+// ListFlags 依狀態列出合規標記
+// GET /api/v1/admin/compliance/flags?status=open
+func (h *ScreeningHandler) ListFlags(c *gin.Context) {
+	var req ListComplianceFlagsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		models.RespondBadRequest(c, "Invalid request parameters", err)
+		return
+	}
+
+	flags, err := h.screeningService.ListFlags(c.Request.Context(), req.Status, req.Limit, req.Offset)
+	if err != nil {
+		models.RespondInternalError(c, "Failed to fetch compliance flags", err)
+		return
+	}
+
+	models.RespondWithSuccess(c, http.StatusOK, "Compliance flags retrieved successfully", gin.H{
+		"flags": flags,
+		"count": len(flags),
+	})
+}
+
+// ResolveFlag 結案合規標記（需附處理說明）
+// POST /api/v1/admin/compliance/flags/:id/resolve
+func (h *ScreeningHandler) ResolveFlag(c *gin.Context) {
+	reviewerID, err := utils.GetUserID(c)
+	if err != nil {
+		models.RespondUnauthorized(c, err.Error())
+		return
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		models.RespondBadRequest(c, "Invalid compliance flag ID", err)
+		return
+	}
+
+	var req ResolveFlagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		models.RespondBadRequest(c, "Invalid request format", err)
+		return
+	}
+
+	if err := h.screeningService.ResolveFlag(c.Request.Context(), id, reviewerID, req.Note); err != nil {
+		respondComplianceError(c, "Failed to resolve compliance flag", err)
+		return
+	}
+
+	models.RespondWithSuccess(c, http.StatusOK, "Compliance flag resolved", nil)
+}
+
+// respondComplianceError 將服務層錯誤轉換為對應的 HTTP 狀態碼
+func respondComplianceError(c *gin.Context, message string, err error) {
+	switch {
+	case errors.Is(err, services.ErrComplianceFlagNotFound):
+		models.RespondNotFound(c, "Compliance flag not found")
+	case errors.Is(err, services.ErrComplianceAlertNotFound):
+		models.RespondNotFound(c, "Compliance alert not found")
+	case errors.Is(err, services.ErrUserNotFound):
+		models.RespondNotFound(c, "User not found")
+	case errors.Is(err, services.ErrBondNotFound):
+		models.RespondNotFound(c, "Bond not found")
+	case errors.Is(err, services.ErrComplianceFlagResolved),
+		errors.Is(err, services.ErrComplianceAlertResolved):
+		models.RespondWithErrorDetails(c, http.StatusConflict, message, err.Error())
+	case errors.Is(err, services.ErrInvalidWalletAddress),
+		errors.Is(err, services.ErrResolutionNoteMissing),
+		errors.Is(err, services.ErrInvalidTradeAmount),
+		errors.Is(err, services.ErrInvalidLimitValue):
+		models.RespondBadRequest(c, message, err)
+	case errors.Is(err, services.ErrScreeningListsUnavailable),
+		errors.Is(err, services.ErrPriceUnavailable):
+		models.RespondWithErrorDetails(c, http.StatusServiceUnavailable, message, err.Error())
+	default:
+		models.RespondInternalError(c, message, err)
+	}
+}
@@ -0,0 +1,88 @@
+package documents
+
+import (
+	"bluelink-backend/internal/models"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Upload multipart 表單上傳的文件（呼叫端需 Close）
+type Upload struct {
+	multipart.File
+	FileName    string // 已去除路徑與控制字元
+	ContentType string
+	Size        int64
+}
+
+// ParseID 解析路徑參數中的 ID，格式錯誤時回應 400（name 用於錯誤訊息，例如 "KYC document"）
+func ParseID(c *gin.Context, param, name string) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param(param), 10, 64)
+	if err != nil {
+		models.RespondBadRequest(c, "Invalid "+name+" ID", err)
+		return 0, false
+	}
+	return id, true
+}
+
+// OpenUpload 開啟 multipart 表單的 file 欄位，失敗時回應 400
+func OpenUpload(c *gin.Context) (*Upload, bool) {
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		models.RespondBadRequest(c, "Missing document file", err)
+		return nil, false
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		models.RespondBadRequest(c, "Failed to read document file", err)
+		return nil, false
+	}
+
+	return &Upload{
+		File:        file,
+		FileName:    SanitizeFileName(fileHeader.Filename),
+		ContentType: fileHeader.Header.Get("Content-Type"),
+		Size:        fileHeader.Size,
+	}, true
+}
+
+// Serve 以附件形式串流輸出文件（不快取，並禁止瀏覽器猜測內容類型）
+func Serve(c *gin.Context, fileName, contentType string, size int64, content io.Reader) {
+	c.DataFromReader(http.StatusOK, size, contentType, content, map[string]string{
+		"Content-Disposition":    ContentDisposition(fileName),
+		"Cache-Control":          "no-store",
+		"X-Content-Type-Options": "nosniff",
+	})
+}
+
+// ContentDisposition 產生附件的 Content-Disposition，檔名經過跳脫（非 ASCII 檔名以 RFC 2231 編碼）
+func ContentDisposition(fileName string) string {
+	disposition := mime.FormatMediaType("attachment", map[string]string{"filename": SanitizeFileName(fileName)})
+	if disposition == "" {
+		return "attachment"
+	}
+	return disposition
+}
+
+// SanitizeFileName 去除路徑（含 Windows 分隔符）與控制字元，只保留檔名
+func SanitizeFileName(name string) string {
+	name = name[strings.LastIndexAny(name, `/\`)+1:]
+	name = strings.TrimSpace(strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, name))
+
+	if name == "" || name == "." || name == ".." {
+		return "document"
+	}
+	return name
+}
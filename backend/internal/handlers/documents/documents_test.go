@@ -0,0 +1,26 @@
+package documents
+
+import "testing"
+
+func TestContentDisposition(t *testing.T) {
+	tests := []struct {
+		name     string
+		fileName string
+		want     string
+	}{
+		{"plain", "passport.pdf", `attachment; filename=passport.pdf`},
+		{"quotes are escaped", `a".pdf`, `attachment; filename="a\".pdf"`},
+		{"header injection", "a.pdf\r\nSet-Cookie: x=1", `attachment; filename="a.pdfSet-Cookie: x=1"`},
+		{"path is dropped", `..\..\etc/passwd`, `attachment; filename=passwd`},
+		{"non-ASCII", "登記證.pdf", `attachment; filename*=utf-8''%E7%99%BB%E8%A8%98%E8%AD%89.pdf`},
+		{"empty", "", `attachment; filename=document`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ContentDisposition(tt.fileName); got != tt.want {
+				t.Fatalf("ContentDisposition(%q) = %s, want %s", tt.fileName, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,430 @@
+package kyc
+
+import (
+	"bluelink-backend/internal/handlers/documents"
+	"bluelink-backend/internal/models"
+	"bluelink-backend/internal/services"
+	"bluelink-backend/internal/utils"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+/*
+   KYC 流程：
+   1. 使用者 → POST /kyc/applications 建立草稿（申請等級、身分資料）
+   2. 使用者 → POST /kyc/applications/:id/documents 上傳證件（存放於 Blob Store）
+   3. 使用者 → POST /kyc/applications/:id/submit 送出審核，使用者狀態改為 pending
+   4. 管理員 → POST /admin/kyc/applications/:id/approve（可指定等級）或 /reject（附意見）
+      審核結果寫入 users 並同步到該使用者所有 Session
+   5. RequireKYCLevelMiddleware 依 Session 中的 KYC 等級限制發行與交易相關端點
+*/
+
+// KYCHandler 處理 KYC 相關的請求
+type KYCHandler struct {
+	kycService *services.KYCService
+}
+
+// NewKYCHandler 建立新的 KYCHandler
+func NewKYCHandler(kycService *services.KYCService) *KYCHandler {
+	return &KYCHandler{
+		kycService: kycService,
+	}
+}
+
+// GetMyKYC 取得當前使用者的 KYC 狀態與申請紀錄
+// GET /api/v1/kyc
+func (h *KYCHandler) GetMyKYC(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		models.RespondUnauthorized(c, err.Error())
+		return
+	}
+
+	user, applications, err := h.kycService.GetMyKYC(c.Request.Context(), userID)
+	if err != nil {
+		models.RespondInternalError(c, "Failed to fetch KYC status", err)
+		return
+	}
+
+	models.RespondWithSuccess(c, http.StatusOK, "KYC status retrieved successfully", gin.H{
+		"kyc_status":      user.KYCStatus,
+		"kyc_level":       user.KYCLevel,
+		"kyc_verified_at": user.KYCVerifiedAt,
+		"country":         user.Country,
+		"applications":    applications,
+	})
+}
+
+// CreateApplication 建立 KYC 申請草稿
+// POST /api/v1/kyc/applications
+func (h *KYCHandler) CreateApplication(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		models.RespondUnauthorized(c, err.Error())
+		return
+	}
+
+	walletAddress, err := utils.GetWalletAddress(c)
+	if err != nil {
+		models.RespondUnauthorized(c, err.Error())
+		return
+	}
+
+	var req KYCApplicationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		models.RespondBadRequest(c, "Invalid request format", err)
+		return
+	}
+
+	app := req.toApplication()
+	app.UserID = userID
+	app.WalletAddress = walletAddress
+
+	if err := h.kycService.CreateApplication(c.Request.Context(), app); err != nil {
+		respondKYCError(c, "Failed to create KYC application", err)
+		return
+	}
+
+	models.RespondWithSuccess(c, http.StatusCreated, "KYC application created successfully", app)
+}
+
+// GetApplication 取得自己的 KYC 申請
+// GET /api/v1/kyc/applications/:id
+func (h *KYCHandler) GetApplication(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		models.RespondUnauthorized(c, err.Error())
+		return
+	}
+
+	id, ok := parseApplicationID(c)
+	if !ok {
+		return
+	}
+
+	app, err := h.kycService.GetApplication(c.Request.Context(), id, userID)
+	if err != nil {
+		respondKYCError(c, "Failed to fetch KYC application", err)
+		return
+	}
+
+	models.RespondWithSuccess(c, http.StatusOK, "KYC application retrieved successfully", app)
+}
+
+// UpdateApplication 更新草稿申請
+// PUT /api/v1/kyc/applications/:id
+func (h *KYCHandler) UpdateApplication(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		models.RespondUnauthorized(c, err.Error())
+		return
+	}
+
+	id, ok := parseApplicationID(c)
+	if !ok {
+		return
+	}
+
+	var req KYCApplicationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		models.RespondBadRequest(c, "Invalid request format", err)
+		return
+	}
+
+	app := req.toApplication()
+	app.ID = id
+
+	if err := h.kycService.UpdateApplication(c.Request.Context(), userID, app); err != nil {
+		respondKYCError(c, "Failed to update KYC application", err)
+		return
+	}
+
+	updated, err := h.kycService.GetApplication(c.Request.Context(), id, userID)
+	if err != nil {
+		respondKYCError(c, "Failed to fetch KYC application", err)
+		return
+	}
+
+	models.RespondWithSuccess(c, http.StatusOK, "KYC application updated successfully", updated)
+}
+
+// UploadDocument 上傳 KYC 文件
+// POST /api/v1/kyc/applications/:id/documents
+func (h *KYCHandler) UploadDocument(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		models.RespondUnauthorized(c, err.Error())
+		return
+	}
+
+	id, ok := parseApplicationID(c)
+	if !ok {
+		return
+	}
+
+	var req UploadDocumentRequest
+	if err := c.ShouldBind(&req); err != nil {
+		models.RespondBadRequest(c, "Invalid request format", err)
+		return
+	}
+
+	upload, ok := documents.OpenUpload(c)
+	if !ok {
+		return
+	}
+	defer upload.Close()
+
+	doc := &models.KYCDocument{
+		DocType:     req.DocType,
+		FileName:    upload.FileName,
+		ContentType: upload.ContentType,
+		SizeBytes:   upload.Size,
+	}
+
+	if err := h.kycService.UploadDocument(c.Request.Context(), userID, id, doc, upload); err != nil {
+		respondKYCError(c, "Failed to upload KYC document", err)
+		return
+	}
+
+	models.RespondWithSuccess(c, http.StatusCreated, "KYC document uploaded successfully", doc)
+}
+
+// DeleteDocument 刪除草稿申請的文件
+// DELETE /api/v1/kyc/applications/:id/documents/:document_id
+func (h *KYCHandler) DeleteDocument(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		models.RespondUnauthorized(c, err.Error())
+		return
+	}
+
+	id, ok := parseApplicationID(c)
+	if !ok {
+		return
+	}
+
+	documentID, ok := parseDocumentID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.kycService.DeleteDocument(c.Request.Context(), userID, id, documentID); err != nil {
+		respondKYCError(c, "Failed to delete KYC document", err)
+		return
+	}
+
+	models.RespondWithSuccess(c, http.StatusOK, "KYC document deleted successfully", nil)
+}
+
+// DownloadDocument 下載自己的 KYC 文件
+// GET /api/v1/kyc/applications/:id/documents/:document_id
+func (h *KYCHandler) DownloadDocument(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		models.RespondUnauthorized(c, err.Error())
+		return
+	}
+
+	h.serveDocument(c, userID)
+}
+
+// SubmitApplication 送出申請給管理員審核
+// POST /api/v1/kyc/applications/:id/submit
+func (h *KYCHandler) SubmitApplication(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		models.RespondUnauthorized(c, err.Error())
+		return
+	}
+
+	id, ok := parseApplicationID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.kycService.SubmitApplication(c.Request.Context(), userID, id); err != nil {
+		respondKYCError(c, "Failed to submit KYC application", err)
+		return
+	}
+
+	models.RespondWithSuccess(c, http.StatusOK, "KYC application submitted for review", nil)
+}
+
+// ===== 管理員功能 =====
+
+// ListApplications 依狀態列出申請
+// GET /api/v1/admin/kyc/applications?status=pending
+func (h *KYCHandler) ListApplications(c *gin.Context) {
+	var req ListKYCApplicationsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		models.RespondBadRequest(c, "Invalid request parameters", err)
+		return
+	}
+
+	applications, err := h.kycService.ListApplications(c.Request.Context(), req.Status, req.Limit, req.Offset)
+	if err != nil {
+		models.RespondInternalError(c, "Failed to fetch KYC applications", err)
+		return
+	}
+
+	models.RespondWithSuccess(c, http.StatusOK, "KYC applications retrieved successfully", gin.H{
+		"applications": applications,
+		"count":        len(applications),
+	})
+}
+
+// GetApplicationForReview 取得任一申請詳情
+// GET /api/v1/admin/kyc/applications/:id
+func (h *KYCHandler) GetApplicationForReview(c *gin.Context) {
+	id, ok := parseApplicationID(c)
+	if !ok {
+		return
+	}
+
+	app, err := h.kycService.GetApplication(c.Request.Context(), id, 0)
+	if err != nil {
+		respondKYCError(c, "Failed to fetch KYC application", err)
+		return
+	}
+
+	models.RespondWithSuccess(c, http.StatusOK, "KYC application retrieved successfully", app)
+}
+
+// DownloadDocumentForReview 下載任一申請的文件
+// GET /api/v1/admin/kyc/applications/:id/documents/:document_id
+func (h *KYCHandler) DownloadDocumentForReview(c *gin.Context) {
+	h.serveDocument(c, 0)
+}
+
+// ApproveApplication 核准申請
+// POST /api/v1/admin/kyc/applications/:id/approve
+func (h *KYCHandler) ApproveApplication(c *gin.Context) {
+	reviewerID, err := utils.GetUserID(c)
+	if err != nil {
+		models.RespondUnauthorized(c, err.Error())
+		return
+	}
+
+	id, ok := parseApplicationID(c)
+	if !ok {
+		return
+	}
+
+	var req ApproveKYCRequest
+	if err := c.ShouldBindJSON(&req); err != nil && c.Request.ContentLength > 0 {
+		models.RespondBadRequest(c, "Invalid request format", err)
+		return
+	}
+
+	if err := h.kycService.ApproveApplication(c.Request.Context(), id, reviewerID, req.Level, req.Comment); err != nil {
+		respondKYCError(c, "Failed to approve KYC application", err)
+		return
+	}
+
+	models.RespondWithSuccess(c, http.StatusOK, "KYC application approved", nil)
+}
+
+// RejectApplication 退回申請（需附意見）
+// POST /api/v1/admin/kyc/applications/:id/reject
+func (h *KYCHandler) RejectApplication(c *gin.Context) {
+	reviewerID, err := utils.GetUserID(c)
+	if err != nil {
+		models.RespondUnauthorized(c, err.Error())
+		return
+	}
+
+	id, ok := parseApplicationID(c)
+	if !ok {
+		return
+	}
+
+	var req RejectKYCRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		models.RespondBadRequest(c, "Invalid request format", err)
+		return
+	}
+
+	if err := h.kycService.RejectApplication(c.Request.Context(), id, reviewerID, req.Comment); err != nil {
+		respondKYCError(c, "Failed to reject KYC application", err)
+		return
+	}
+
+	models.RespondWithSuccess(c, http.StatusOK, "KYC application rejected", nil)
+}
+
+// serveDocument 串流輸出文件內容（userID 為 0 時不檢查擁有者）
+func (h *KYCHandler) serveDocument(c *gin.Context, userID int64) {
+	id, ok := parseApplicationID(c)
+	if !ok {
+		return
+	}
+
+	documentID, ok := parseDocumentID(c)
+	if !ok {
+		return
+	}
+
+	doc, content, err := h.kycService.OpenDocument(c.Request.Context(), userID, id, documentID)
+	if err != nil {
+		respondKYCError(c, "Failed to fetch KYC document", err)
+		return
+	}
+	defer content.Close()
+
+	documents.Serve(c, doc.FileName, doc.ContentType, doc.SizeBytes, content)
+}
+
+// toApplication 將請求轉換為申請模型
+func (req *KYCApplicationRequest) toApplication() *models.KYCApplication {
+	app := &models.KYCApplication{
+		RequestedLevel: req.RequestedLevel,
+		FullName:       req.FullName,
+		DateOfBirth:    req.DateOfBirth,
+		Country:        strings.ToUpper(req.Country),
+	}
+
+	if req.Address != "" {
+		address := req.Address
+		app.Address = &address
+	}
+
+	return app
+}
+
+func parseApplicationID(c *gin.Context) (int64, bool) {
+	return documents.ParseID(c, "id", "KYC application")
+}
+
+func parseDocumentID(c *gin.Context) (int64, bool) {
+	return documents.ParseID(c, "document_id", "KYC document")
+}
+
+// respondKYCError 將服務層錯誤轉換為對應的 HTTP 狀態碼
+func respondKYCError(c *gin.Context, message string, err error) {
+	switch {
+	case errors.Is(err, services.ErrKYCApplicationNotFound):
+		models.RespondNotFound(c, "KYC application not found")
+	case errors.Is(err, services.ErrKYCDocumentNotFound):
+		models.RespondNotFound(c, "KYC document not found")
+	case errors.Is(err, services.ErrKYCApplicationForbidden):
+		models.RespondForbidden(c, "Cannot access another user's KYC application")
+	case errors.Is(err, services.ErrKYCApplicationExists),
+		errors.Is(err, services.ErrKYCApplicationNotEditable),
+		errors.Is(err, services.ErrKYCApplicationNotInReview),
+		errors.Is(err, services.ErrKYCLevelAlreadyGranted):
+		models.RespondWithErrorDetails(c, http.StatusConflict, message, err.Error())
+	case errors.Is(err, services.ErrKYCDocumentTooLarge):
+		models.RespondWithErrorDetails(c, http.StatusRequestEntityTooLarge, message, err.Error())
+	case errors.Is(err, services.ErrKYCDocumentTypeUnsupported):
+		models.RespondWithErrorDetails(c, http.StatusUnsupportedMediaType, message, err.Error())
+	case errors.Is(err, services.ErrKYCInvalidLevel),
+		errors.Is(err, services.ErrKYCDocumentsMissing),
+		errors.Is(err, services.ErrReviewCommentMissing):
+		models.RespondBadRequest(c, message, err)
+	default:
+		models.RespondInternalError(c, message, err)
+	}
+}
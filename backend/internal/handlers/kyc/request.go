@@ -0,0 +1,33 @@
+package kyc
+
+// KYCApplicationRequest 建立或更新 KYC 申請
+type KYCApplicationRequest struct {
+	RequestedLevel int    `json:"requested_level" binding:"required,oneof=1 2"`
+	FullName       string `json:"full_name" binding:"required,max=255"`
+	DateOfBirth    string `json:"date_of_birth" binding:"required,datetime=2006-01-02"`
+	Country        string `json:"country" binding:"required,len=2,alpha"` // ISO 3166-1 alpha-2
+	Address        string `json:"address"`
+}
+
+// UploadDocumentRequest 上傳 KYC 文件（multipart/form-data，檔案欄位為 file）
+type UploadDocumentRequest struct {
+	DocType string `form:"doc_type" binding:"required,oneof=passport national_id drivers_license proof_of_address business_registration other"`
+}
+
+// ApproveKYCRequest 管理員核准申請
+type ApproveKYCRequest struct {
+	Level   int    `json:"level" binding:"omitempty,oneof=1 2"` // 未填時採用申請的等級
+	Comment string `json:"comment"`
+}
+
+// RejectKYCRequest 管理員退回申請
+type RejectKYCRequest struct {
+	Comment string `json:"comment"`
+}
+
+// ListKYCApplicationsRequest 查詢申請列表
+type ListKYCApplicationsRequest struct {
+	Status string `form:"status" binding:"omitempty,oneof=draft pending approved rejected"`
+	Limit  int    `form:"limit"`
+	Offset int    `form:"offset"`
+}
@@ -0,0 +1,263 @@
+package market
+
+import (
+	"bluelink-backend/internal/models"
+	"bluelink-backend/internal/services"
+	"bluelink-backend/internal/utils"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+/*
+   二級市場流程（鏈下訂單簿，鏈上結算）：
+   1. 掛單者 → POST /market/orders/message 取得待簽署訊息
+   2. 掛單者 → 使用錢包簽署訊息，POST /market/orders 提交掛單與簽名
+      賣單需持有該 BondToken（依 bond_tokens.owner 驗證）
+   3. 買賣雙方 → GET /market/bonds/:id/orderbook 瀏覽買賣盤
+   4. 吃單方與掛單方在鏈上完成交割（賣方轉移代幣、買方付款）
+   5. 吃單方 → POST /market/orders/:id/fill 提交交易 digest
+      後端確認代幣已由賣方轉給買方後，記錄 bond_transferred 交易並更新持倉
+   代幣被贖回或轉移時，事件監聽器會自動取消其賣單
+*/
+
+// MarketHandler 處理二級市場相關的請求
+type MarketHandler struct {
+	marketService *services.MarketService
+}
+
+// NewMarketHandler 建立新的 MarketHandler
+func NewMarketHandler(marketService *services.MarketService) *MarketHandler {
+	return &MarketHandler{
+		marketService: marketService,
+	}
+}
+
+// GetOrderMessage 取得待簽署的掛單訊息
+// POST /api/v1/market/orders/message
+func (h *MarketHandler) GetOrderMessage(c *gin.Context) {
+	var req PlaceOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		models.RespondBadRequest(c, "Invalid request format", err)
+		return
+	}
+
+	message, err := h.marketService.GetOrderMessage(c.Request.Context(), req.toInput())
+	if err != nil {
+		respondMarketError(c, "Failed to build order message", err)
+		return
+	}
+
+	models.RespondWithSuccess(c, http.StatusOK, "Order message generated", gin.H{
+		"message": message,
+	})
+}
+
+// PlaceOrder 建立經簽名的掛單
+// POST /api/v1/market/orders
+func (h *MarketHandler) PlaceOrder(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		models.RespondUnauthorized(c, err.Error())
+		return
+	}
+
+	walletAddress, err := utils.GetWalletAddress(c)
+	if err != nil {
+		models.RespondUnauthorized(c, err.Error())
+		return
+	}
+
+	var req PlaceOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		models.RespondBadRequest(c, "Invalid request format", err)
+		return
+	}
+	if req.Signature == "" {
+		models.RespondBadRequest(c, "Signature is required", nil)
+		return
+	}
+
+	input := req.toInput()
+	input.MakerUserID = userID
+	input.MakerAddress = walletAddress
+
+	order, err := h.marketService.PlaceOrder(c.Request.Context(), input)
+	if err != nil {
+		respondMarketError(c, "Failed to place order", err)
+		return
+	}
+
+	models.RespondWithSuccess(c, http.StatusCreated, "Order placed successfully", order)
+}
+
+// GetMyOrders 取得自己的掛單
+// GET /api/v1/market/orders?status=open
+func (h *MarketHandler) GetMyOrders(c *gin.Context) {
+	walletAddress, err := utils.GetWalletAddress(c)
+	if err != nil {
+		models.RespondUnauthorized(c, err.Error())
+		return
+	}
+
+	var req ListOrdersRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		models.RespondBadRequest(c, "Invalid request parameters", err)
+		return
+	}
+
+	orders, err := h.marketService.ListMyOrders(c.Request.Context(), walletAddress, req.Status, req.Limit, req.Offset)
+	if err != nil {
+		models.RespondInternalError(c, "Failed to fetch orders", err)
+		return
+	}
+
+	models.RespondWithSuccess(c, http.StatusOK, "Orders retrieved successfully", gin.H{
+		"orders": orders,
+		"count":  len(orders),
+	})
+}
+
+// CancelOrder 取消自己的掛單
+// DELETE /api/v1/market/orders/:id
+func (h *MarketHandler) CancelOrder(c *gin.Context) {
+	walletAddress, err := utils.GetWalletAddress(c)
+	if err != nil {
+		models.RespondUnauthorized(c, err.Error())
+		return
+	}
+
+	id, ok := parseID(c, "Invalid order ID")
+	if !ok {
+		return
+	}
+
+	if err := h.marketService.CancelOrder(c.Request.Context(), id, walletAddress); err != nil {
+		respondMarketError(c, "Failed to cancel order", err)
+		return
+	}
+
+	models.RespondWithSuccess(c, http.StatusOK, "Order cancelled successfully", nil)
+}
+
+// FillOrder 提交鏈上結算交易以完成掛單
+// POST /api/v1/market/orders/:id/fill
+func (h *MarketHandler) FillOrder(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		models.RespondUnauthorized(c, err.Error())
+		return
+	}
+
+	walletAddress, err := utils.GetWalletAddress(c)
+	if err != nil {
+		models.RespondUnauthorized(c, err.Error())
+		return
+	}
+
+	id, ok := parseID(c, "Invalid order ID")
+	if !ok {
+		return
+	}
+
+	var req FillOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		models.RespondBadRequest(c, "Invalid request format", err)
+		return
+	}
+
+	order, err := h.marketService.FillOrder(c.Request.Context(), id, userID, walletAddress, req.TransactionDigest, req.TokenID)
+	if err != nil {
+		respondMarketError(c, "Failed to fill order", err)
+		return
+	}
+
+	models.RespondWithSuccess(c, http.StatusOK, "Order filled successfully", order)
+}
+
+// GetOrderBook 取得債券的買賣盤
+// GET /api/v1/market/bonds/:id/orderbook
+func (h *MarketHandler) GetOrderBook(c *gin.Context) {
+	bondID, ok := parseID(c, "Invalid bond ID")
+	if !ok {
+		return
+	}
+
+	book, err := h.marketService.GetOrderBook(c.Request.Context(), bondID)
+	if err != nil {
+		respondMarketError(c, "Failed to fetch order book", err)
+		return
+	}
+
+	models.RespondWithSuccess(c, http.StatusOK, "success", book)
+}
+
+// GetTrades 取得債券的成交記錄（價格歷史）
+// GET /api/v1/market/bonds/:id/trades
+func (h *MarketHandler) GetTrades(c *gin.Context) {
+	bondID, ok := parseID(c, "Invalid bond ID")
+	if !ok {
+		return
+	}
+
+	limit, _ := strconv.Atoi(c.Query("limit"))
+	offset, _ := strconv.Atoi(c.Query("offset"))
+
+	trades, err := h.marketService.GetTradeHistory(c.Request.Context(), bondID, limit, offset)
+	if err != nil {
+		models.RespondInternalError(c, "Failed to fetch trades", err)
+		return
+	}
+
+	models.RespondWithSuccess(c, http.StatusOK, "success", gin.H{
+		"trades": trades,
+		"count":  len(trades),
+	})
+}
+
+// toInput 將請求轉換為服務層參數
+func (req *PlaceOrderRequest) toInput() *services.PlaceOrderInput {
+	return &services.PlaceOrderInput{
+		BondID:    req.BondID,
+		Side:      req.Side,
+		TokenID:   req.TokenID,
+		Price:     req.Price,
+		Nonce:     req.Nonce,
+		ExpiresAt: req.ExpiresAt,
+		Signature: req.Signature,
+	}
+}
+
+func parseID(c *gin.Context, message string) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		models.RespondBadRequest(c, message, err)
+		return 0, false
+	}
+	return id, true
+}
+
+// respondMarketError 將服務層錯誤轉換為對應的 HTTP 狀態碼
+func respondMarketError(c *gin.Context, message string, err error) {
+	switch {
+	case errors.Is(err, services.ErrBondNotFound):
+		models.RespondNotFound(c, "Bond not found")
+	case errors.Is(err, services.ErrOrderNotFound):
+		models.RespondNotFound(c, "Market order not found")
+	case errors.Is(err, services.ErrTokenNotOwned),
+		errors.Is(err, services.ErrInvalidOrderSignature):
+		models.RespondForbidden(c, err.Error())
+	case errors.Is(err, services.ErrOrderNotOpen),
+		errors.Is(err, services.ErrOrderExpired),
+		errors.Is(err, services.ErrTokenAlreadyListed):
+		models.RespondWithErrorDetails(c, http.StatusConflict, message, err.Error())
+	case errors.Is(err, services.ErrInvalidOrder),
+		errors.Is(err, services.ErrSelfTrade),
+		errors.Is(err, services.ErrSettlementMismatch):
+		models.RespondBadRequest(c, message, err)
+	default:
+		models.RespondInternalError(c, message, err)
+	}
+}
@@ -0,0 +1,27 @@
+package market
+
+import "time"
+
+// PlaceOrderRequest 建立掛單（message 由後端依欄位重建後驗證簽名）
+type PlaceOrderRequest struct {
+	BondID    int64      `json:"bond_id" binding:"required"`
+	Side      string     `json:"side" binding:"required,oneof=ask bid"`
+	TokenID   string     `json:"token_id"`                      // 賣單必填
+	Price     int64      `json:"price" binding:"required,gt=0"` // 計價幣種最小單位
+	Nonce     string     `json:"nonce" binding:"required,max=64"`
+	ExpiresAt *time.Time `json:"expires_at"`
+	Signature string     `json:"signature"`
+}
+
+// FillOrderRequest 以鏈上結算交易完成掛單
+type FillOrderRequest struct {
+	TransactionDigest string `json:"transaction_digest" binding:"required"`
+	TokenID           string `json:"token_id"` // 吃買單時必填
+}
+
+// ListOrdersRequest 查詢自己的掛單
+type ListOrdersRequest struct {
+	Status string `form:"status" binding:"omitempty,oneof=open filled cancelled"`
+	Limit  int    `form:"limit"`
+	Offset int    `form:"offset"`
+}
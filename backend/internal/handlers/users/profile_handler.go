@@ -4,6 +4,7 @@ import (
 	"bluelink-backend/internal/models"
 	"bluelink-backend/internal/services"
 	"bluelink-backend/internal/utils"
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -90,18 +91,13 @@ func (h *ProfileHandler) UpdateProfile(c *gin.Context) {
 		return
 	}
 
-	// 先取得現有使用者資料
-	user, err := h.userService.GetByID(c.Request.Context(), userID)
-	if err != nil {
+	// 更新使用者名稱
+	user, err := h.userService.UpdateProfile(c.Request.Context(), userID, req.Name)
+	if errors.Is(err, services.ErrUserNotFound) {
 		models.RespondNotFound(c, "User not found")
 		return
 	}
-
-	// 更新使用者名稱
-	user.Name = &req.Name
-
-	// 儲存更新
-	if err := h.userService.Update(c.Request.Context(), user); err != nil {
+	if err != nil {
 		models.RespondInternalError(c, "Failed to update profile", err)
 		return
 	}
@@ -1 +1,14 @@
 package users
+
+// ListLoginHistoryRequest 查詢登入紀錄
+type ListLoginHistoryRequest struct {
+	Limit  int `form:"limit"`
+	Offset int `form:"offset"`
+}
+
+// ListNotificationsRequest 查詢通知
+type ListNotificationsRequest struct {
+	Unread bool `form:"unread"` // 只列出未讀通知
+	Limit  int  `form:"limit"`
+	Offset int  `form:"offset"`
+}
@@ -0,0 +1,129 @@
+package users
+
+import (
+	"bluelink-backend/internal/models"
+	"bluelink-backend/internal/services"
+	"bluelink-backend/internal/utils"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SecurityHandler 處理登入紀錄與安全通知相關的請求
+type SecurityHandler struct {
+	loginHistory  *services.LoginHistoryService
+	notifications *services.NotificationService
+}
+
+// NewSecurityHandler 建立新的 SecurityHandler
+func NewSecurityHandler(loginHistory *services.LoginHistoryService, notifications *services.NotificationService) *SecurityHandler {
+	return &SecurityHandler{
+		loginHistory:  loginHistory,
+		notifications: notifications,
+	}
+}
+
+// GetLoginHistory 取得當前使用者的登入紀錄（含失敗的嘗試）
+// GET /api/v1/login-history?limit=50&offset=0
+func (h *SecurityHandler) GetLoginHistory(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		models.RespondUnauthorized(c, err.Error())
+		return
+	}
+
+	var req ListLoginHistoryRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		models.RespondBadRequest(c, "Invalid request parameters", err)
+		return
+	}
+
+	events, total, err := h.loginHistory.ListHistory(c.Request.Context(), userID, req.Limit, req.Offset)
+	if err != nil {
+		models.RespondInternalError(c, "Failed to fetch login history", err)
+		return
+	}
+
+	models.RespondWithSuccess(c, http.StatusOK, "Login history retrieved successfully", gin.H{
+		"events": events,
+		"count":  len(events),
+		"total":  total,
+	})
+}
+
+// ListNotifications 取得當前使用者的通知
+// GET /api/v1/notifications?unread=true
+func (h *SecurityHandler) ListNotifications(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		models.RespondUnauthorized(c, err.Error())
+		return
+	}
+
+	var req ListNotificationsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		models.RespondBadRequest(c, "Invalid request parameters", err)
+		return
+	}
+
+	notifications, total, unread, err := h.notifications.ListNotifications(c.Request.Context(), userID, req.Unread, req.Limit, req.Offset)
+	if err != nil {
+		models.RespondInternalError(c, "Failed to fetch notifications", err)
+		return
+	}
+
+	models.RespondWithSuccess(c, http.StatusOK, "Notifications retrieved successfully", gin.H{
+		"notifications": notifications,
+		"count":         len(notifications),
+		"total":         total,
+		"unread":        unread,
+	})
+}
+
+// MarkNotificationRead 將一則通知標記為已讀
+// POST /api/v1/notifications/:id/read
+func (h *SecurityHandler) MarkNotificationRead(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		models.RespondUnauthorized(c, err.Error())
+		return
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		models.RespondBadRequest(c, "Invalid notification ID", err)
+		return
+	}
+
+	err = h.notifications.MarkRead(c.Request.Context(), userID, id)
+	if errors.Is(err, services.ErrNotificationNotFound) {
+		models.RespondNotFound(c, "Notification not found")
+		return
+	}
+	if err != nil {
+		models.RespondInternalError(c, "Failed to mark notification read", err)
+		return
+	}
+
+	models.RespondWithSuccess(c, http.StatusOK, "Notification marked as read", nil)
+}
+
+// MarkAllNotificationsRead 將所有未讀通知標記為已讀
+// POST /api/v1/notifications/read-all
+func (h *SecurityHandler) MarkAllNotificationsRead(c *gin.Context) {
+	userID, err := utils.GetUserID(c)
+	if err != nil {
+		models.RespondUnauthorized(c, err.Error())
+		return
+	}
+
+	updated, err := h.notifications.MarkAllRead(c.Request.Context(), userID)
+	if err != nil {
+		models.RespondInternalError(c, "Failed to mark notifications read", err)
+		return
+	}
+
+	models.RespondWithSuccess(c, http.StatusOK, "Notifications marked as read", gin.H{"updated": updated})
+}
@@ -0,0 +1,79 @@
+package humancheck
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// CaptchaVerifier 以 siteverify API 驗證 CAPTCHA（相容 Cloudflare Turnstile、hCaptcha、reCAPTCHA）
+type CaptchaVerifier struct {
+	verifyURL string
+	secret    string
+	siteKey   string
+	client    *http.Client
+}
+
+type siteverifyResponse struct {
+	Success    bool     `json:"success"`
+	ErrorCodes []string `json:"error-codes"`
+}
+
+// NewCaptchaVerifier 建立 CAPTCHA 人機驗證
+func NewCaptchaVerifier(verifyURL, secret, siteKey string) *CaptchaVerifier {
+	return &CaptchaVerifier{
+		verifyURL: verifyURL,
+		secret:    secret,
+		siteKey:   siteKey,
+		client:    &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Challenge 回傳前端顯示 CAPTCHA 所需的 site key
+func (v *CaptchaVerifier) Challenge(ctx context.Context) (*Challenge, error) {
+	return &Challenge{Type: "captcha", SiteKey: v.siteKey}, nil
+}
+
+// Verify 向 CAPTCHA 服務驗證前端取得的 response token
+func (v *CaptchaVerifier) Verify(ctx context.Context, solution, remoteIP string) error {
+	if solution == "" {
+		return fmt.Errorf("%w: missing captcha response", ErrVerificationFailed)
+	}
+
+	form := url.Values{
+		"secret":   {v.secret},
+		"response": {solution},
+	}
+	if remoteIP != "" {
+		form.Set("remoteip", remoteIP)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, v.verifyURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("failed to build captcha request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to verify captcha: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("captcha service returned status %d", resp.StatusCode)
+	}
+
+	var result siteverifyResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("failed to decode captcha response: %w", err)
+	}
+	if !result.Success {
+		return fmt.Errorf("%w: %s", ErrVerificationFailed, strings.Join(result.ErrorCodes, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+package humancheck
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math/bits"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+/*
+   Proof-of-work（hashcash）：
+   - 題目 token = <salt>.<difficulty>.<expires>.<HMAC>，伺服器不需保存題目
+   - 前端尋找 counter 使 SHA-256("<token>:<counter>") 的前導零位元數 ≥ difficulty，
+     並提交 "<token>:<counter>"
+   - 已使用的題目保存在記憶體直到過期（多個實例時，同一題目最多在每個實例各使用一次）
+*/
+
+// PoWVerifier proof-of-work 人機驗證
+type PoWVerifier struct {
+	key        []byte
+	difficulty int
+	ttl        time.Duration
+
+	mu    sync.Mutex
+	spent map[string]time.Time // 已使用的題目 → 過期時間
+}
+
+// NewPoWVerifier 建立 proof-of-work 人機驗證
+func NewPoWVerifier(secret string, difficulty int, ttl time.Duration) *PoWVerifier {
+	key := sha256.Sum256([]byte("humancheck:pow:" + secret))
+	return &PoWVerifier{
+		key:        key[:],
+		difficulty: difficulty,
+		ttl:        ttl,
+		spent:      make(map[string]time.Time),
+	}
+}
+
+// Challenge 產生簽章過的題目
+func (v *PoWVerifier) Challenge(ctx context.Context) (*Challenge, error) {
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("failed to generate proof-of-work salt: %w", err)
+	}
+
+	expiresAt := time.Now().Add(v.ttl)
+	payload := fmt.Sprintf("%s.%d.%d", hex.EncodeToString(salt), v.difficulty, expiresAt.Unix())
+
+	return &Challenge{
+		Type:       "pow",
+		Token:      payload + "." + v.sign(payload),
+		Difficulty: v.difficulty,
+		ExpiresAt:  &expiresAt,
+	}, nil
+}
+
+// Verify 驗證題目簽章、有效期限與雜湊難度，每個題目只能使用一次
+func (v *PoWVerifier) Verify(ctx context.Context, solution, remoteIP string) error {
+	sep := strings.LastIndex(solution, ":")
+	if sep <= 0 {
+		return fmt.Errorf("%w: malformed proof-of-work solution", ErrVerificationFailed)
+	}
+	token := solution[:sep]
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 4 {
+		return fmt.Errorf("%w: malformed proof-of-work token", ErrVerificationFailed)
+	}
+	payload := strings.Join(parts[:3], ".")
+	if !hmac.Equal([]byte(parts[3]), []byte(v.sign(payload))) {
+		return fmt.Errorf("%w: invalid proof-of-work token", ErrVerificationFailed)
+	}
+
+	difficulty, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return fmt.Errorf("%w: invalid proof-of-work difficulty", ErrVerificationFailed)
+	}
+	expires, err := strconv.ParseInt(parts[2], 10, 64)
+	if err != nil {
+		return fmt.Errorf("%w: invalid proof-of-work expiry", ErrVerificationFailed)
+	}
+	expiresAt := time.Unix(expires, 0)
+	if time.Now().After(expiresAt) {
+		return fmt.Errorf("%w: proof-of-work token expired", ErrVerificationFailed)
+	}
+
+	hash := sha256.Sum256([]byte(solution))
+	if leadingZeroBits(hash[:]) < difficulty {
+		return fmt.Errorf("%w: insufficient proof-of-work", ErrVerificationFailed)
+	}
+
+	if !v.spend(token, expiresAt) {
+		return fmt.Errorf("%w: proof-of-work token already used", ErrVerificationFailed)
+	}
+	return nil
+}
+
+func (v *PoWVerifier) sign(payload string) string {
+	mac := hmac.New(sha256.New, v.key)
+	mac.Write([]byte(payload))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// spend 標記題目已使用（已使用過時回傳 false），並清除過期的紀錄
+func (v *PoWVerifier) spend(token string, expiresAt time.Time) bool {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	now := time.Now()
+	for spentToken, expiry := range v.spent {
+		if now.After(expiry) {
+			delete(v.spent, spentToken)
+		}
+	}
+
+	if _, used := v.spent[token]; used {
+		return false
+	}
+	v.spent[token] = expiresAt
+	return true
+}
+
+func leadingZeroBits(hash []byte) int {
+	count := 0
+	for _, b := range hash {
+		if b != 0 {
+			return count + bits.LeadingZeros8(b)
+		}
+		count += 8
+	}
+	return count
+}
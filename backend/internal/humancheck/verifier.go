@@ -0,0 +1,47 @@
+package humancheck
+
+import (
+	"bluelink-backend/internal/config"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrVerificationFailed 人機驗證的解答無效、過期或已使用
+var ErrVerificationFailed = errors.New("human verification failed")
+
+// Challenge 前端完成人機驗證所需的參數
+type Challenge struct {
+	Type       string     `json:"type"`                 // pow | captcha
+	Token      string     `json:"token,omitempty"`      // proof-of-work 題目
+	Difficulty int        `json:"difficulty,omitempty"` // proof-of-work 需要的前導零位元數
+	SiteKey    string     `json:"site_key,omitempty"`   // CAPTCHA site key
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+}
+
+// Verifier 定義人機驗證介面（proof-of-work、CAPTCHA）
+type Verifier interface {
+	// Challenge 產生前端需要的驗證參數
+	Challenge(ctx context.Context) (*Challenge, error)
+
+	// Verify 驗證前端提交的解答，解答無效時回傳 ErrVerificationFailed
+	Verify(ctx context.Context, solution, remoteIP string) error
+}
+
+// NewVerifier 依設定建立人機驗證（HUMAN_CHECK=none 時回傳 nil，表示不需要驗證）
+func NewVerifier(cfg *config.Config) (Verifier, error) {
+	switch cfg.HumanCheck {
+	case "", "none":
+		return nil, nil
+	case "pow":
+		return NewPoWVerifier(cfg.JWTSecret, cfg.PoWDifficulty, 2*time.Minute), nil
+	case "captcha":
+		if cfg.CaptchaVerifyURL == "" || cfg.CaptchaSecret == "" {
+			return nil, fmt.Errorf("CAPTCHA_VERIFY_URL and CAPTCHA_SECRET are required for the captcha human check")
+		}
+		return NewCaptchaVerifier(cfg.CaptchaVerifyURL, cfg.CaptchaSecret, cfg.CaptchaSiteKey), nil
+	default:
+		return nil, fmt.Errorf("unknown human check: %s", cfg.HumanCheck)
+	}
+}
@@ -0,0 +1,202 @@
+package limits
+
+import (
+	"bluelink-backend/internal/config"
+	"bluelink-backend/internal/logger"
+	"bluelink-backend/internal/models"
+	"bluelink-backend/internal/repository"
+	"context"
+	"math"
+	"time"
+)
+
+// Converter 將不同幣種的金額換算為法幣（由 services.PriceService 實作）
+type Converter interface {
+	ConvertMixed(ctx context.Context, quote string, amounts map[string]map[string]int64) (*models.FiatConversion, error)
+}
+
+// Checker 計算使用者的投資額度與持有集中度，並判斷交易是否超出限額
+// 由交易前檢查 API、事件監聽器與二級市場成交共用
+type Checker struct {
+	repo      *repository.InvestmentLimitRepository
+	converter Converter
+	currency  string
+	levels    map[int]config.InvestmentLevelLimit
+}
+
+// Trade 一筆預計或已成交的投資
+type Trade struct {
+	User      *models.User
+	Wallet    string       // 取得代幣的地址
+	Bond      *models.Bond // 交易的債券
+	Amount    int64        // 投資金額（債券幣種最小單位）；0 表示不計入投資額度，例如錢包間直接轉移
+	FaceValue int64        // 取得的代幣面額（最小單位）
+}
+
+// NewChecker 建立限額檢查器
+func NewChecker(repo *repository.InvestmentLimitRepository, converter Converter, cfg *config.Config) *Checker {
+	return &Checker{
+		repo:      repo,
+		converter: converter,
+		currency:  cfg.InvestmentLimitCurrency,
+		levels:    cfg.InvestmentLevelLimits,
+	}
+}
+
+// Limits 使用者適用的限額：個別設定優先，否則採用 KYC 等級預設值
+func (c *Checker) Limits(user *models.User) *models.InvestmentLimits {
+	level := c.levels[user.KYCLevel]
+	limits := &models.InvestmentLimits{
+		Currency:     c.currency,
+		KYCLevel:     user.KYCLevel,
+		Daily:        level.Daily,
+		Monthly:      level.Monthly,
+		MaxBondShare: level.MaxBondShare,
+	}
+
+	if user.DailyLimit != nil {
+		limits.Daily = user.DailyLimit
+	}
+	if user.MonthlyLimit != nil {
+		limits.Monthly = user.MonthlyLimit
+	}
+	if user.MaxBondShare != nil {
+		limits.MaxBondShare = user.MaxBondShare
+	}
+	return limits
+}
+
+// Usage 使用者當日與當月（UTC）已投資的金額
+func (c *Checker) Usage(ctx context.Context, userID int64) (*models.InvestmentUsage, error) {
+	usage, _, err := c.usage(ctx, userID, nil)
+	return usage, err
+}
+
+// Evaluate 檢查交易後是否超出限額
+// pending 為 true 表示交易尚未寫入 transactions / bond_tokens（交易前檢查），需另外計入本次交易
+func (c *Checker) Evaluate(ctx context.Context, trade Trade, pending bool) (*models.PreTradeCheck, error) {
+	var tradeAmounts map[string]int64
+	if trade.Amount > 0 {
+		tradeAmounts = map[string]int64{trade.Bond.CoinType: trade.Amount}
+	}
+
+	usage, tradeValue, err := c.usage(ctx, trade.User.ID, tradeAmounts)
+	if err != nil {
+		return nil, err
+	}
+
+	held, err := c.repo.GetHeldFaceValue(ctx, trade.User.ID, trade.Wallet, trade.Bond.OnChainID)
+	if err != nil {
+		return nil, err
+	}
+
+	dailyAfter, monthlyAfter := usage.DailyUsed, usage.MonthlyUsed
+	if pending {
+		dailyAfter = roundAmount(dailyAfter + tradeValue)
+		monthlyAfter = roundAmount(monthlyAfter + tradeValue)
+		held += trade.FaceValue
+	}
+
+	check := &models.PreTradeCheck{
+		BondID:     trade.Bond.ID,
+		TradeValue: tradeValue,
+		Limits:     c.Limits(trade.User),
+		Usage:      usage,
+		Violations: []models.LimitViolation{},
+	}
+	if trade.Bond.TotalAmount > 0 {
+		check.HoldingShare = math.Round(float64(held)/float64(trade.Bond.TotalAmount)*10000) / 10000
+	}
+
+	// 不計入投資額度的交易（錢包轉移）只檢查持有集中度
+	if trade.Amount > 0 {
+		check.Violations = appendViolation(check.Violations, models.LimitDaily, check.Limits.Daily, dailyAfter)
+		check.Violations = appendViolation(check.Violations, models.LimitMonthly, check.Limits.Monthly, monthlyAfter)
+	}
+	if trade.FaceValue > 0 {
+		check.Violations = appendViolation(check.Violations, models.LimitBondConcentration, check.Limits.MaxBondShare, check.HoldingShare)
+	}
+
+	check.Allowed = len(check.Violations) == 0
+	return check, nil
+}
+
+// Review 交易成交後重新檢查限額，違規時建立合規警示
+func (c *Checker) Review(ctx context.Context, trade Trade, txHash string) error {
+	check, err := c.Evaluate(ctx, trade, false)
+	if err != nil {
+		return err
+	}
+
+	for _, violation := range check.Violations {
+		alert := &models.ComplianceAlert{
+			UserID:        trade.User.ID,
+			WalletAddress: trade.Wallet,
+			TxHash:        txHash,
+			BondID:        &trade.Bond.ID,
+			AlertType:     violation.Type,
+			LimitValue:    violation.Limit,
+			ActualValue:   violation.Actual,
+			Status:        models.ComplianceAlertOpen,
+		}
+		if violation.Type != models.LimitBondConcentration {
+			alert.Currency = &c.currency
+		}
+
+		created, err := c.repo.CreateAlert(ctx, alert)
+		if err != nil {
+			return err
+		}
+		if created {
+			logger.Warn("⚠️ Compliance alert %d: user %d exceeded %s (%.4f > %.4f, tx %s)",
+				alert.ID, alert.UserID, alert.AlertType, alert.ActualValue, alert.LimitValue, txHash)
+		}
+	}
+	return nil
+}
+
+// usage 統計已投資金額並換算為法幣；tradeAmounts 為本次交易金額，一併換算後回傳
+func (c *Checker) usage(ctx context.Context, userID int64, tradeAmounts map[string]int64) (*models.InvestmentUsage, float64, error) {
+	now := time.Now().UTC()
+	dayStart := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	daily, err := c.repo.SumInvestedByCoin(ctx, userID, dayStart)
+	if err != nil {
+		return nil, 0, err
+	}
+	monthly, err := c.repo.SumInvestedByCoin(ctx, userID, monthStart)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	conversion, err := c.converter.ConvertMixed(ctx, c.currency, map[string]map[string]int64{
+		"daily":   daily,
+		"monthly": monthly,
+		"trade":   tradeAmounts,
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	usage := &models.InvestmentUsage{
+		Currency:      conversion.Currency,
+		DailyUsed:     conversion.Values["daily"],
+		MonthlyUsed:   conversion.Values["monthly"],
+		RateTimestamp: conversion.RateTimestamp,
+	}
+	return usage, conversion.Values["trade"], nil
+}
+
+// appendViolation 實際值超過上限時加入違規項目（上限為 nil 表示不限）
+func appendViolation(violations []models.LimitViolation, limitType string, limit *float64, actual float64) []models.LimitViolation {
+	if limit == nil || actual <= *limit {
+		return violations
+	}
+	return append(violations, models.LimitViolation{Type: limitType, Limit: *limit, Actual: actual})
+}
+
+// roundAmount 法幣金額取到小數第二位
+func roundAmount(value float64) float64 {
+	return math.Round(value*100) / 100
+}
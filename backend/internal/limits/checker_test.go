@@ -0,0 +1,153 @@
+package limits
+
+import (
+	"bluelink-backend/internal/config"
+	"bluelink-backend/internal/models"
+	"bluelink-backend/internal/repository"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+const testCoin = "0x2::sui::SUI"
+
+// fixedConverter 每 100 個最小單位換算為 1 元法幣
+type fixedConverter struct{}
+
+func (fixedConverter) ConvertMixed(ctx context.Context, quote string, amounts map[string]map[string]int64) (*models.FiatConversion, error) {
+	values := make(map[string]float64, len(amounts))
+	for key, byCoin := range amounts {
+		for _, amount := range byCoin {
+			values[key] += float64(amount) / 100
+		}
+	}
+	return &models.FiatConversion{Currency: quote, RateTimestamp: time.Now(), Values: values}, nil
+}
+
+func limit(value float64) *float64 {
+	return &value
+}
+
+func newTestChecker(t *testing.T) (*Checker, sqlmock.Sqlmock) {
+	t.Helper()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	cfg := &config.Config{
+		InvestmentLimitCurrency: "USD",
+		InvestmentLevelLimits: map[int]config.InvestmentLevelLimit{
+			1: {Daily: limit(1000), Monthly: limit(5000), MaxBondShare: limit(0.25)},
+		},
+	}
+	return NewChecker(repository.NewInvestmentLimitRepository(db), fixedConverter{}, cfg), mock
+}
+
+// expectUsage 當日與當月已投資金額（最小單位）及已持有面額
+func expectUsage(mock sqlmock.Sqlmock, daily, monthly, held int64) {
+	mock.ExpectQuery(`FROM transactions t`).
+		WillReturnRows(sqlmock.NewRows([]string{"coin_type", "total"}).AddRow(testCoin, daily))
+	mock.ExpectQuery(`FROM transactions t`).
+		WillReturnRows(sqlmock.NewRows([]string{"coin_type", "total"}).AddRow(testCoin, monthly))
+	mock.ExpectQuery(`FROM bond_tokens`).
+		WillReturnRows(sqlmock.NewRows([]string{"sum"}).AddRow(held))
+}
+
+func TestLimitsPreferUserOverrides(t *testing.T) {
+	checker, _ := newTestChecker(t)
+
+	limits := checker.Limits(&models.User{KYCLevel: 1, DailyLimit: limit(200)})
+	if *limits.Daily != 200 || *limits.Monthly != 5000 || *limits.MaxBondShare != 0.25 {
+		t.Fatalf("Limits() = daily %v, monthly %v, share %v", *limits.Daily, *limits.Monthly, *limits.MaxBondShare)
+	}
+
+	if limits := checker.Limits(&models.User{KYCLevel: 0}); limits.Daily != nil || limits.MaxBondShare != nil {
+		t.Fatal("levels without configured limits should be unlimited")
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	bond := &models.Bond{ID: 3, OnChainID: "0xbond", CoinType: testCoin, TotalAmount: 1_000_000}
+
+	tests := []struct {
+		name           string
+		daily, monthly int64 // 已投資（最小單位，100 = 1 元）
+		held           int64 // 已持有面額
+		amount         int64
+		faceValue      int64
+		want           []string
+	}{
+		{"within limits", 50_000, 50_000, 0, 20_000, 20_000, nil},
+		{"daily limit exceeded", 90_000, 90_000, 0, 20_000, 20_000, []string{models.LimitDaily}},
+		{"daily and monthly limits exceeded", 90_000, 490_000, 0, 20_000, 20_000, []string{models.LimitDaily, models.LimitMonthly}},
+		{"bond concentration exceeded", 0, 0, 240_000, 20_000, 20_000, []string{models.LimitBondConcentration}},
+		{"transfer only checks concentration", 200_000, 600_000, 240_000, 0, 20_000, []string{models.LimitBondConcentration}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checker, mock := newTestChecker(t)
+			expectUsage(mock, tt.daily, tt.monthly, tt.held)
+
+			check, err := checker.Evaluate(context.Background(), Trade{
+				User:      &models.User{ID: 7, KYCLevel: 1},
+				Wallet:    "0xabc",
+				Bond:      bond,
+				Amount:    tt.amount,
+				FaceValue: tt.faceValue,
+			}, true)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := []string{}
+			for _, violation := range check.Violations {
+				got = append(got, violation.Type)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("violations = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("violations = %v, want %v", got, tt.want)
+				}
+			}
+			if check.Allowed != (len(tt.want) == 0) {
+				t.Fatalf("Allowed = %v with violations %v", check.Allowed, got)
+			}
+		})
+	}
+}
+
+func TestReviewCreatesAlertPerViolation(t *testing.T) {
+	checker, mock := newTestChecker(t)
+	bond := &models.Bond{ID: 3, OnChainID: "0xbond", CoinType: testCoin, TotalAmount: 1_000_000}
+
+	// 成交後已計入：當日 1,100 元、持有 30%
+	expectUsage(mock, 110_000, 110_000, 300_000)
+	mock.ExpectQuery(`INSERT INTO compliance_alerts`).
+		WithArgs(int64(7), "0xabc", "0xtx", sqlmock.AnyArg(), models.LimitDaily, 1000.0, 1100.0, sqlmock.AnyArg(), models.ComplianceAlertOpen, sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(1, time.Now()))
+	mock.ExpectQuery(`INSERT INTO compliance_alerts`).
+		WithArgs(int64(7), "0xabc", "0xtx", sqlmock.AnyArg(), models.LimitBondConcentration, 0.25, 0.3, nil, models.ComplianceAlertOpen, sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(2, time.Now()))
+
+	err := checker.Review(context.Background(), Trade{
+		User:      &models.User{ID: 7, KYCLevel: 1},
+		Wallet:    "0xabc",
+		Bond:      bond,
+		Amount:    20_000,
+		FaceValue: 20_000,
+	}, "0xtx")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,37 @@
+package middleware
+
+import (
+	"bluelink-backend/internal/audit"
+	"bluelink-backend/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AuditContextMiddleware 將請求來源（IP、UserAgent、Request ID）放入 request context 供稽核記錄使用
+// 需註冊在 RequestIDMiddleware 之後；SessionAuthMiddleware 驗證後會補上使用者資訊
+func AuditContextMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		setAuditActor(c)
+		c.Next()
+	}
+}
+
+// setAuditActor 依 gin context 目前的資訊重建稽核主體
+func setAuditActor(c *gin.Context) {
+	actor := &audit.Actor{
+		IPAddress: c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
+		RequestID: utils.GetRequestID(c),
+	}
+	if userID, err := utils.GetUserID(c); err == nil {
+		actor.UserID = &userID
+	}
+	if wallet, err := utils.GetWalletAddress(c); err == nil {
+		actor.WalletAddress = wallet
+	}
+	if role, err := utils.GetUserRole(c); err == nil {
+		actor.Role = role
+	}
+
+	c.Request = c.Request.WithContext(audit.WithActor(c.Request.Context(), actor))
+}
@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"bluelink-backend/internal/models"
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequireKYCLevelMiddleware 檢查使用者 KYC 等級是否達到門檻的 middleware（需在 SessionAuth 之後）
+func RequireKYCLevelMiddleware(minLevel int) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if minLevel <= 0 {
+			c.Next()
+			return
+		}
+
+		status, _ := c.Get("KYCStatus")
+		level, _ := c.Get("KYCLevel")
+		kycLevel, _ := level.(int)
+
+		if status != models.KYCStatusVerified || kycLevel < minLevel {
+			models.RespondForbidden(c, fmt.Sprintf("Forbidden: KYC level %d or above is required", minLevel))
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
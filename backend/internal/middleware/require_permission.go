@@ -0,0 +1,21 @@
+package middleware
+
+import (
+	"bluelink-backend/internal/models"
+	"bluelink-backend/internal/services"
+	"bluelink-backend/internal/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequirePermissionMiddleware 檢查使用者的任一角色（主要角色或額外授予的角色）是否具有指定權限的 middleware
+func RequirePermissionMiddleware(roleService *services.RoleService, permission string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !roleService.HasPermission(c.Request.Context(), utils.GetUserRoles(c), permission) {
+			models.RespondForbidden(c, "Forbidden: missing permission "+permission)
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
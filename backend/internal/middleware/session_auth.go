@@ -2,8 +2,12 @@ package middleware
 
 import (
 	"bluelink-backend/internal/models"
+	"bluelink-backend/internal/services"
 	"bluelink-backend/internal/session"
+	"errors"
+	"net/http"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -32,57 +36,176 @@ import (
    前端 → POST /auth/logout
    後端 → 刪除 session，清除 Cookie
 
+   Token 模式（SESSION_MODE=token）：
+   前端 → 請求時帶上 Authorization: Bearer <access_token>
+   後端 → 驗證簽章與到期時間後直接由 token 取得使用者資訊（不查資料庫、不更新活躍時間）
+   前端 → access token 到期前以 POST /auth/refresh 換發（refresh token 每次輪替）
+
+   API key（程式化存取）：
+   整合端 → 請求時帶上 X-API-Key: blk_<prefix>_<secret>
+   後端 → 驗證 key、IP 白名單與到期時間，並檢查路由要求的 scope；
+          僅在路由以 SessionAuthMiddleware(..., scope) 標示 scope 時接受 API key
+
+   CSRF（cookie 模式）：
+   前端 → 登入回應的 csrf_token（或 GET /auth/csrf）於狀態變更請求帶上 X-CSRF-Token
+   後端 → 以 Cookie 驗證的 POST/PUT/PATCH/DELETE 需通過 Origin/Referer 與 double-submit token 檢查；
+          Bearer token 與 API key 請求不受影響
+
    安全機制：
    - Nonce 5 分鐘過期，使用後立即刪除（防重放攻擊）
-   - Session 24 小時過期，30 分鐘閒置自動登出
-   - 最多 3 個裝置同時登入
+   - Session 有效期、閒置逾時、滑動到期與裝置上限由 Session 政策決定
+     （SESSION_TIMEOUT / SESSION_IDLE_TIMEOUT / SESSION_SLIDING_EXPIRY / SESSION_MAX_DEVICES / SESSION_EVICTION）
    - HttpOnly Cookie（防 XSS）
    - Secure Cookie（HTTPS only）
    - 記錄 IP 和 UserAgent（審計追蹤）
 */
 
-// SessionAuthMiddleware 驗證 Session
-func SessionAuthMiddleware(sessionManager session.SessionManager) gin.HandlerFunc {
+// APIKeyHeader 帶有 API key 的 Header
+const APIKeyHeader = "X-API-Key"
+
+// SessionAuthMiddleware 驗證 Session；tokenManager 不為 nil 時（token 模式）改為驗證 access token
+// 帶有 X-API-Key 時改為驗證 API key，且 key 必須具有所有 scopes（未指定 scopes 的路由不接受 API key）
+func SessionAuthMiddleware(sessionManager session.SessionManager, tokenManager *session.TokenManager, apiKeyService *services.APIKeyService, scopes ...string) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		sessionID := getSessionID(c)
+		if rawKey := c.GetHeader(APIKeyHeader); rawKey != "" {
+			authenticateAPIKey(c, apiKeyService, scopes)
+			return
+		}
+
+		if tokenManager != nil {
+			authenticateAccessToken(c, tokenManager)
+			return
+		}
+
+		sessionID, fromCookie := getSessionID(c)
 		if sessionID == "" {
 			models.RespondUnauthorized(c, "Missing session")
 			c.Abort()
 			return
 		}
 
-		sess, err := sessionManager.Get(sessionID)
+		ctx := c.Request.Context()
+		sess, err := sessionManager.Get(ctx, sessionID)
 		if err != nil {
 			models.RespondUnauthorized(c, "Invalid or expired session")
 			c.Abort()
 			return
 		}
 
-		// 更新活躍時間
-		sessionManager.Touch(sessionID)
+		// 以 Cookie 驗證的狀態變更請求需通過來源與 CSRF token 檢查
+		policy := sessionManager.Policy()
+		if fromCookie && !session.IsSafeMethod(c.Request.Method) {
+			if err := policy.VerifyCSRF(c.Request, sessionID); err != nil {
+				models.RespondForbidden(c, err.Error())
+				c.Abort()
+				return
+			}
+		}
+
+		// 更新活躍時間；滑動到期時同步延長 Cookie 有效期
+		if err := sessionManager.Touch(ctx, sessionID); err == nil && policy.SlidingExpiry && fromCookie {
+			expiresAt := policy.ExpiresAt(sess.CreatedAt, time.Now())
+			http.SetCookie(c.Writer, policy.Cookie(sessionID, expiresAt))
+			http.SetCookie(c.Writer, policy.CSRFCookie(policy.CSRFToken(sessionID), expiresAt))
+		}
 
 		// 存入 context (包含所有需要的使用者資訊)
 		c.Set("SessionID", sessionID)
 		c.Set("WalletAddress", sess.WalletAddress)
 		c.Set("UserID", sess.UserID)
 		c.Set("Role", sess.Role)
+		c.Set("Roles", sess.Roles)
+		c.Set("KYCStatus", sess.KYCStatus)
+		c.Set("KYCLevel", sess.KYCLevel)
+		setAuditActor(c)
 
 		c.Next()
 	}
 }
 
-func getSessionID(c *gin.Context) string {
+// authenticateAccessToken 驗證 Bearer access token 並將 token 中的使用者資訊存入 context
+func authenticateAccessToken(c *gin.Context, tokenManager *session.TokenManager) {
+	authHeader := c.GetHeader("Authorization")
+	if !strings.HasPrefix(authHeader, "Bearer ") {
+		models.RespondUnauthorized(c, "Missing access token")
+		c.Abort()
+		return
+	}
+
+	claims, err := tokenManager.ParseAccessToken(strings.TrimPrefix(authHeader, "Bearer "))
+	if err != nil {
+		models.RespondUnauthorized(c, err.Error())
+		c.Abort()
+		return
+	}
+
+	c.Set("SessionID", claims.SessionID)
+	c.Set("WalletAddress", claims.WalletAddress)
+	c.Set("UserID", claims.UserID)
+	c.Set("Role", claims.Role)
+	c.Set("Roles", claims.Roles)
+	c.Set("KYCStatus", claims.KYCStatus)
+	c.Set("KYCLevel", claims.KYCLevel)
+	setAuditActor(c)
+
+	c.Next()
+}
+
+// authenticateAPIKey 驗證 API key 與路由要求的 scope，並將 key 擁有者的資訊存入 context
+func authenticateAPIKey(c *gin.Context, apiKeyService *services.APIKeyService, scopes []string) {
+	if len(scopes) == 0 {
+		models.RespondForbidden(c, "API keys are not accepted on this route")
+		c.Abort()
+		return
+	}
+
+	key, user, err := apiKeyService.Authenticate(c.Request.Context(), c.GetHeader(APIKeyHeader), c.ClientIP())
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrInvalidAPIKey), errors.Is(err, services.ErrAPIKeyOwnerNotAllowed):
+			models.RespondUnauthorized(c, err.Error())
+		case errors.Is(err, services.ErrAPIKeyIPNotAllowed):
+			models.RespondForbidden(c, err.Error())
+		default:
+			models.RespondInternalError(c, "Failed to verify api key", err)
+		}
+		c.Abort()
+		return
+	}
+
+	for _, scope := range scopes {
+		if !key.HasScope(scope) {
+			models.RespondForbidden(c, "API key is missing required scope: "+scope)
+			c.Abort()
+			return
+		}
+	}
+
+	c.Set("APIKeyID", key.ID)
+	c.Set("WalletAddress", user.WalletAddress)
+	c.Set("UserID", user.ID)
+	c.Set("Role", user.Role)
+	c.Set("Roles", user.Roles)
+	c.Set("KYCStatus", user.KYCStatus)
+	c.Set("KYCLevel", user.KYCLevel)
+	setAuditActor(c)
+
+	c.Next()
+}
+
+// getSessionID 取得 session ID 與是否來自 Cookie
+func getSessionID(c *gin.Context) (string, bool) {
 	// 優先從 Cookie
-	sessionID, err := c.Cookie("session_id")
+	sessionID, err := c.Cookie(session.CookieName)
 	if err == nil && sessionID != "" {
-		return sessionID
+		return sessionID, true
 	}
 
 	// 其次從 Header
 	authHeader := c.GetHeader("Authorization")
 	if strings.HasPrefix(authHeader, "Bearer ") {
-		return strings.TrimPrefix(authHeader, "Bearer ")
+		return strings.TrimPrefix(authHeader, "Bearer "), false
 	}
 
-	return ""
+	return "", false
 }
@@ -0,0 +1,63 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// API key 權限範圍
+const (
+	APIKeyScopeReadBonds        = "read:bonds"        // 債券與債券代幣資料
+	APIKeyScopeReadPortfolio    = "read:portfolio"    // 投資組合、持有代幣與交易記錄
+	APIKeyScopeSyncTransactions = "sync:transactions" // 同步鏈上交易
+	APIKeyScopeAdmin            = "admin:*"           // 所有管理員 API（需具有 "*" 權限的角色）
+)
+
+// APIKeyScopes 所有可授予的權限範圍
+var APIKeyScopes = []string{
+	APIKeyScopeReadBonds,
+	APIKeyScopeReadPortfolio,
+	APIKeyScopeSyncTransactions,
+	APIKeyScopeAdmin,
+}
+
+// APIKey 供程式化存取使用的 API key（僅保存雜湊，明文只在建立或輪替時回傳一次）
+type APIKey struct {
+	ID          int64      `json:"id" db:"id"`
+	UserID      int64      `json:"user_id" db:"user_id"`
+	Name        string     `json:"name" db:"name"`
+	Prefix      string     `json:"prefix" db:"prefix"` // 明文 key 的識別前綴，用於查找與辨識
+	KeyHash     string     `json:"-" db:"key_hash"`
+	Scopes      []string   `json:"scopes" db:"scopes"`
+	IPAllowlist []string   `json:"ip_allowlist,omitempty" db:"ip_allowlist"` // IP 或 CIDR，空值表示不限制
+	ExpiresAt   *time.Time `json:"expires_at,omitempty" db:"expires_at"`
+	LastUsedAt  *time.Time `json:"last_used_at,omitempty" db:"last_used_at"`
+	LastUsedIP  *string    `json:"last_used_ip,omitempty" db:"last_used_ip"`
+	RotatedAt   *time.Time `json:"rotated_at,omitempty" db:"rotated_at"`
+	RevokedAt   *time.Time `json:"revoked_at,omitempty" db:"revoked_at"`
+	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
+}
+
+// IssuedAPIKey 建立或輪替後回傳的 API key（含明文）
+type IssuedAPIKey struct {
+	*APIKey
+	Key string `json:"key"`
+}
+
+// IsActive 未撤銷且未過期
+func (k *APIKey) IsActive(now time.Time) bool {
+	return k.RevokedAt == nil && (k.ExpiresAt == nil || now.Before(*k.ExpiresAt))
+}
+
+// HasScope 是否具有指定權限範圍（admin:* 涵蓋所有 admin: 開頭的範圍）
+func (k *APIKey) HasScope(scope string) bool {
+	for _, granted := range k.Scopes {
+		if granted == scope {
+			return true
+		}
+		if granted == APIKeyScopeAdmin && strings.HasPrefix(scope, "admin:") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,90 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// AuditEvent 稽核事件（僅新增，不可修改或刪除）
+type AuditEvent struct {
+	ID          int64           `json:"id" db:"id"`
+	ActorID     *int64          `json:"actor_id,omitempty" db:"actor_id"`
+	ActorWallet *string         `json:"actor_wallet,omitempty" db:"actor_wallet"`
+	ActorRole   *string         `json:"actor_role,omitempty" db:"actor_role"`
+	Action      string          `json:"action" db:"action"`
+	TargetType  string          `json:"target_type" db:"target_type"`
+	TargetID    *string         `json:"target_id,omitempty" db:"target_id"`
+	IPAddress   *string         `json:"ip_address,omitempty" db:"ip_address"`
+	UserAgent   *string         `json:"user_agent,omitempty" db:"user_agent"`
+	RequestID   *string         `json:"request_id,omitempty" db:"request_id"`
+	Before      json.RawMessage `json:"before,omitempty" db:"before"` // 變動前的欄位
+	After       json.RawMessage `json:"after,omitempty" db:"after"`   // 變動後的欄位
+	CreatedAt   time.Time       `json:"created_at" db:"created_at"`
+}
+
+// AuditFilter 管理員查詢稽核事件的條件（零值表示不篩選）
+type AuditFilter struct {
+	ActorID    *int64
+	Action     string // 完全符合，或以 "." 結尾表示前綴，例如 "auth."
+	TargetType string
+	TargetID   string
+	RequestID  string
+	From       *time.Time
+	To         *time.Time
+}
+
+// AuditAction 常量
+const (
+	AuditActionLogin            = "auth.login"
+	AuditActionLoginDenied      = "auth.login_denied"
+	AuditActionLogout           = "auth.logout"
+	AuditActionLogoutAll        = "auth.logout_all"
+	AuditActionSessionRevoke    = "auth.session_revoke"
+	AuditActionRefreshReuse     = "auth.refresh_reuse"
+	AuditActionAuthUnlock       = "admin.auth_unlock"
+	AuditActionWalletLink       = "wallet.link"
+	AuditActionWalletUnlink     = "wallet.unlink"
+	AuditActionAPIKeyCreate     = "api_key.create"
+	AuditActionAPIKeyRotate     = "api_key.rotate"
+	AuditActionAPIKeyRevoke     = "api_key.revoke"
+	AuditActionProfileUpdate    = "user.profile_update"
+	AuditActionRoleChange       = "admin.user_role_change"
+	AuditActionUserRolesChange  = "admin.user_roles_change"
+	AuditActionRoleCreate       = "admin.role_create"
+	AuditActionRoleUpdate       = "admin.role_update"
+	AuditActionRoleDelete       = "admin.role_delete"
+	AuditActionUserDelete       = "admin.user_delete"
+	AuditActionUserRestore      = "admin.user_restore"
+	AuditActionUserForceLogout  = "admin.user_force_logout"
+	AuditActionUserLimitsUpdate = "admin.user_limits_update"
+	AuditActionKYCApprove       = "admin.kyc_approve"
+	AuditActionKYCReject        = "admin.kyc_reject"
+	AuditActionIssuerApprove    = "admin.issuer_application_approve"
+	AuditActionIssuerDeny       = "admin.issuer_application_deny"
+	AuditActionProposalApprove  = "admin.bond_proposal_approve"
+	AuditActionProposalReject   = "admin.bond_proposal_reject"
+	AuditActionBondClearReview  = "admin.bond_clear_review"
+	AuditActionScreeningReload  = "admin.screening_reload"
+	AuditActionScreeningRescan  = "admin.screening_rescan"
+	AuditActionFlagResolve      = "admin.compliance_flag_resolve"
+	AuditActionAlertResolve     = "admin.compliance_alert_resolve"
+	AuditActionSyncRequest      = "sync.request"
+)
+
+// AuditTarget 常量
+const (
+	AuditTargetUser              = "user"
+	AuditTargetSession           = "session"
+	AuditTargetWallet            = "wallet"
+	AuditTargetIP                = "ip"
+	AuditTargetAPIKey            = "api_key"
+	AuditTargetRole              = "role"
+	AuditTargetKYCApplication    = "kyc_application"
+	AuditTargetIssuerApplication = "issuer_application"
+	AuditTargetBondProposal      = "bond_proposal"
+	AuditTargetBond              = "bond"
+	AuditTargetScreeningList     = "screening_list"
+	AuditTargetComplianceFlag    = "compliance_flag"
+	AuditTargetComplianceAlert   = "compliance_alert"
+	AuditTargetTransaction       = "transaction"
+)
@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+// 登入失敗計數的範圍
+const (
+	AuthLockoutScopeWallet = "wallet"
+	AuthLockoutScopeIP     = "ip"
+)
+
+// AuthLockout 錢包或 IP 的登入失敗計數與鎖定狀態
+type AuthLockout struct {
+	Scope           string     `json:"scope" db:"scope"`     // wallet | ip
+	Subject         string     `json:"subject" db:"subject"` // 錢包地址|IP 或 IP
+	Failures        int        `json:"failures" db:"failures"`
+	Lockouts        int        `json:"lockouts" db:"lockouts"` // 連續鎖定次數，每次鎖定時長加倍
+	WindowStartedAt time.Time  `json:"window_started_at" db:"window_started_at"`
+	LastFailureAt   time.Time  `json:"last_failure_at" db:"last_failure_at"`
+	LockedUntil     *time.Time `json:"locked_until" db:"locked_until"`
+}
+
+// AuthLockoutWalletSubject 錢包範圍的計數鍵（錢包地址|IP）
+// 以錢包與 IP 共同計數，他人以同一錢包地址從其他 IP 送出的失敗不會鎖定錢包擁有者
+func AuthLockoutWalletSubject(walletAddress, ipAddress string) string {
+	return walletAddress + "|" + ipAddress
+}
+
+// IsLocked 目前是否處於鎖定中
+func (l *AuthLockout) IsLocked(now time.Time) bool {
+	return l.LockedUntil != nil && l.LockedUntil.After(now)
+}
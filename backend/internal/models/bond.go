@@ -18,7 +18,11 @@ type Bond struct {
 	TokenImageUrl string `json:"token_image_url" db:"token_image_url"` // NFT 代幣圖片 URL
 	MetadataUrl   string `json:"metadata_url" db:"metadata_url"`       // 完整元數據 URL (Arweave)
 
-	// 金額相關（使用 int64 對應 u64，單位：MIST，1 SUI = 1,000,000,000 MIST）
+	// 計價幣種（對應 BondProject<T> 的 T，金額皆以該幣種最小單位計）
+	CoinType     string `json:"coin_type" db:"coin_type"`         // 例如 0x2::sui::SUI
+	CoinDecimals int    `json:"coin_decimals" db:"coin_decimals"` // 幣種小數位數（SUI 為 9）
+
+	// 金額相關（使用 int64 對應 u64，單位：計價幣種最小單位，SUI 即 MIST）
 	TotalAmount    int64 `json:"total_amount" db:"total_amount"`       // 對應 total_amount (募集總額度)
 	AmountRaised   int64 `json:"amount_raised" db:"amount_raised"`     // 對應 amount_raised (已募集金額)
 	AmountRedeemed int64 `json:"amount_redeemed" db:"amount_redeemed"` // 對應 amount_redeemed (已贖回金額)
@@ -36,12 +40,20 @@ type Bond struct {
 	Active     bool `json:"active" db:"active"`         // 對應 active (債券是否活躍)
 	Redeemable bool `json:"redeemable" db:"redeemable"` // 對應 redeemable (是否可贖回)
 
-	// 資金池餘額快照（使用 int64 對應 Balance<SUI>，單位：MIST）
+	// 資金池餘額快照（使用 int64 對應 Balance<T>，單位：計價幣種最小單位）
 	RaisedFundsBalance    int64 `json:"raised_funds_balance" db:"raised_funds_balance"`       // 對應 raised_funds 的餘額快照
 	RedemptionPoolBalance int64 `json:"redemption_pool_balance" db:"redemption_pool_balance"` // 對應 redemption_pool 的餘額快照
 
+	// 審核標記（鏈上創建但沒有對應已核准申請的債券）
+	NeedsReview bool `json:"needs_review" db:"needs_review"`
+
 	// 資料庫管理欄位
 	CreatedAt time.Time  `json:"created_at" db:"created_at"`
 	UpdatedAt time.Time  `json:"updated_at" db:"updated_at"`
 	DeletedAt *time.Time `json:"deleted_at,omitempty" db:"deleted_at"` // 軟刪除
 }
+
+// FormatAmount 以債券計價幣種的小數位數格式化金額
+func (b *Bond) FormatAmount(amount int64) string {
+	return FormatAmount(amount, b.CoinDecimals) + " " + CoinSymbolFromType(b.CoinType)
+}
@@ -0,0 +1,55 @@
+package models
+
+import "time"
+
+// BondProposal 債券發行申請（鏈下草稿，經管理員審核後再上鏈）
+type BondProposal struct {
+	ID            int64   `json:"id" db:"id"`
+	IssuerUserID  int64   `json:"issuer_user_id" db:"issuer_user_id"`
+	IssuerAddress string  `json:"issuer_address" db:"issuer_address"`
+	IssuerName    string  `json:"issuer_name" db:"issuer_name"`
+	BondName      string  `json:"bond_name" db:"bond_name"`
+	Description   *string `json:"description,omitempty" db:"description"`
+
+	// 債券條款
+	TotalAmount        int64  `json:"total_amount" db:"total_amount"`                 // 單位：計價幣種最小單位
+	AnnualInterestRate int64  `json:"annual_interest_rate" db:"annual_interest_rate"` // basis points
+	MaturityDate       string `json:"maturity_date" db:"maturity_date"`               // 格式: YYYY-MM-DD
+
+	// 圖片與文件
+	BondImageUrl  string             `json:"bond_image_url" db:"bond_image_url"`
+	TokenImageUrl string             `json:"token_image_url" db:"token_image_url"`
+	MetadataUrl   string             `json:"metadata_url" db:"metadata_url"`
+	Documents     []ProposalDocument `json:"documents" db:"documents"` // JSONB
+
+	// 審核流程
+	Status        string     `json:"status" db:"status"`
+	ReviewComment *string    `json:"review_comment,omitempty" db:"review_comment"`
+	ReviewedBy    *int64     `json:"reviewed_by,omitempty" db:"reviewed_by"`
+	ReviewedAt    *time.Time `json:"reviewed_at,omitempty" db:"reviewed_at"`
+	SubmittedAt   *time.Time `json:"submitted_at,omitempty" db:"submitted_at"`
+
+	// 鏈上關聯（上鏈後由事件監聽器填入）
+	BondID    *int64  `json:"bond_id,omitempty" db:"bond_id"`
+	OnChainID *string `json:"on_chain_id,omitempty" db:"on_chain_id"`
+
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at" db:"updated_at"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty" db:"deleted_at"`
+}
+
+// ProposalDocument 申請附件（公開說明書、審計報告等）
+type ProposalDocument struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+	Type string `json:"type,omitempty"` // e.g. "prospectus", "audit", "license"
+}
+
+// ProposalStatus 常量
+const (
+	ProposalStatusDraft     = "draft"
+	ProposalStatusSubmitted = "submitted"
+	ProposalStatusApproved  = "approved"
+	ProposalStatusRejected  = "rejected"
+	ProposalStatusLinked    = "linked"
+)
@@ -20,12 +20,25 @@ type BondToken struct {
 	// 代幣資訊
 	TokenNumber  int64  `json:"token_number" db:"token_number"`   // 對應 token_number
 	Owner        string `json:"owner" db:"owner"`                 // 對應 owner
-	Amount       int64  `json:"amount" db:"amount"`               // 投資金額（單位：MIST）
+	Amount       int64  `json:"amount" db:"amount"`               // 投資金額（單位：計價幣種最小單位）
 	PurchaseDate int64  `json:"purchase_date" db:"purchase_date"` // 購買日期 (timestamp ms)
 	IsRedeemed   bool   `json:"is_redeemed" db:"is_redeemed"`     // 對應 is_redeemed
 
+	// 計價幣種（與所屬債券相同）
+	CoinType     string `json:"coin_type" db:"coin_type"`
+	CoinDecimals int    `json:"coin_decimals" db:"coin_decimals"`
+
 	// 資料庫管理欄位
 	CreatedAt time.Time  `json:"created_at" db:"created_at"`
 	UpdatedAt time.Time  `json:"updated_at" db:"updated_at"`
 	DeletedAt *time.Time `json:"deleted_at,omitempty" db:"deleted_at"` // 軟刪除
+
+	// 估值（僅在請求時計算，不存入資料庫）
+	Valuation *TokenValuation `json:"valuation,omitempty" db:"-"`
+}
+
+// TokenProvenance 代幣的持有歷史（購買、轉移、贖回）
+type TokenProvenance struct {
+	Token   *BondToken     `json:"token"`
+	History []*Transaction `json:"history"`
 }
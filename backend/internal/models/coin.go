@@ -0,0 +1,64 @@
+package models
+
+import (
+	"math/big"
+	"strings"
+	"time"
+)
+
+// SUI 原生幣種（既有債券的預設計價幣種）
+const (
+	SuiCoinType = "0x2::sui::SUI"
+	SuiDecimals = 9
+)
+
+// CoinMetadata 幣種元數據（快取自 suix_getCoinMetadata）
+type CoinMetadata struct {
+	CoinType    string    `json:"coin_type" db:"coin_type"`
+	MetadataID  *string   `json:"metadata_id,omitempty" db:"metadata_id"` // CoinMetadata 對象 ID
+	Decimals    int       `json:"decimals" db:"decimals"`
+	Symbol      string    `json:"symbol" db:"symbol"`
+	Name        string    `json:"name" db:"name"`
+	Description string    `json:"description" db:"description"`
+	IconUrl     string    `json:"icon_url" db:"icon_url"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// NormalizeCoinType 將 SUI 的完整地址寫法（0x000...02::sui::SUI）統一為 0x2::sui::SUI
+func NormalizeCoinType(coinType string) string {
+	coinType = strings.TrimSpace(coinType)
+	if coinType == "" {
+		return SuiCoinType
+	}
+
+	parts := strings.SplitN(coinType, "::", 2)
+	if len(parts) == 2 && strings.HasPrefix(parts[0], "0x") {
+		if addr := strings.TrimLeft(parts[0][2:], "0"); addr == "2" && parts[1] == "sui::SUI" {
+			return SuiCoinType
+		}
+	}
+	return coinType
+}
+
+// CoinSymbolFromType 從幣種型別取出結構名稱作為簡短符號（例如 0x..::usdc::USDC → USDC）
+func CoinSymbolFromType(coinType string) string {
+	if idx := strings.LastIndex(coinType, "::"); idx >= 0 {
+		return coinType[idx+2:]
+	}
+	return coinType
+}
+
+// FormatAmount 依幣種小數位數將最小單位金額格式化為十進位字串（例如 1500000000, 9 → "1.5"）
+func FormatAmount(amount int64, decimals int) string {
+	if decimals <= 0 {
+		return big.NewInt(amount).String()
+	}
+
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
+	value := new(big.Rat).SetFrac(big.NewInt(amount), scale)
+
+	formatted := value.FloatString(decimals)
+	formatted = strings.TrimRight(formatted, "0")
+	return strings.TrimSuffix(formatted, ".")
+}
@@ -0,0 +1,96 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// BondImpact 債券的藍色經濟影響力資料
+type BondImpact struct {
+	ID         int64           `json:"id" db:"id"`
+	BondID     int64           `json:"bond_id" db:"bond_id"`
+	Category   string          `json:"category" db:"category"`       // 專案類別，見 ImpactCategory 常量
+	SDGTargets []int64         `json:"sdg_targets" db:"sdg_targets"` // 聯合國永續發展目標編號 (1-17)
+	Location   json.RawMessage `json:"location,omitempty" db:"location"`
+	KPIs       []*ImpactKPI    `json:"kpis"`
+	CreatedAt  time.Time       `json:"created_at" db:"created_at"`
+	UpdatedAt  time.Time       `json:"updated_at" db:"updated_at"`
+}
+
+// ImpactKPI 影響力指標（含基準值與目標值）
+type ImpactKPI struct {
+	ID          int64    `json:"id" db:"id"`
+	BondID      int64    `json:"bond_id" db:"bond_id"`
+	Name        string   `json:"name" db:"name"`
+	Unit        string   `json:"unit" db:"unit"` // e.g. "ha", "tonnes", "m3"
+	Description *string  `json:"description,omitempty" db:"description"`
+	Baseline    float64  `json:"baseline" db:"baseline"`
+	Target      float64  `json:"target" db:"target"`
+	LatestValue *float64 `json:"latest_value,omitempty"` // 最近一期報告的數值
+}
+
+// ImpactReport 發行者定期提交的影響力報告
+type ImpactReport struct {
+	ID            int64                `json:"id" db:"id"`
+	BondID        int64                `json:"bond_id" db:"bond_id"`
+	SubmittedBy   *int64               `json:"submitted_by,omitempty" db:"submitted_by"`
+	PeriodStart   string               `json:"period_start" db:"period_start"` // YYYY-MM-DD
+	PeriodEnd     string               `json:"period_end" db:"period_end"`     // YYYY-MM-DD
+	Summary       *string              `json:"summary,omitempty" db:"summary"`
+	AttachmentURL *string              `json:"attachment_url,omitempty" db:"attachment_url"`
+	Values        []*ImpactReportValue `json:"values"`
+	CreatedAt     time.Time            `json:"created_at" db:"created_at"`
+}
+
+// ImpactReportValue 報告中單一 KPI 的實際數值
+type ImpactReportValue struct {
+	KPIID int64   `json:"kpi_id" db:"kpi_id"`
+	Value float64 `json:"value" db:"value"`
+	Note  *string `json:"note,omitempty" db:"note"`
+}
+
+// PlatformImpact 全平台影響力彙總
+type PlatformImpact struct {
+	BondCount  int64               `json:"bond_count"`
+	ByCategory []*CategoryImpact   `json:"by_category"`
+	BySDG      []*SDGImpact        `json:"by_sdg"`
+	KPITotals  []*KPIImpactSummary `json:"kpi_totals"`
+	Fiat       *FiatConversion     `json:"fiat,omitempty"` // 全平台已募集金額換算法幣（?quote= 時）
+}
+
+// CategoryImpact 依專案類別彙總
+type CategoryImpact struct {
+	Category     string           `json:"category"`
+	BondCount    int64            `json:"bond_count"`
+	AmountRaised map[string]int64 `json:"amount_raised"`  // 計價幣種 → 已募集金額（最小單位）
+	Fiat         *FiatConversion  `json:"fiat,omitempty"` // 已募集金額換算法幣（?quote= 時）
+}
+
+// SDGImpact 依 SDG 目標彙總
+type SDGImpact struct {
+	SDG       int64 `json:"sdg"`
+	BondCount int64 `json:"bond_count"`
+}
+
+// KPIImpactSummary 相同名稱與單位的 KPI 跨債券加總（取各 KPI 最新報告值）
+type KPIImpactSummary struct {
+	Name      string  `json:"name"`
+	Unit      string  `json:"unit"`
+	BondCount int64   `json:"bond_count"`
+	Baseline  float64 `json:"baseline"`
+	Target    float64 `json:"target"`
+	Achieved  float64 `json:"achieved"`
+}
+
+// ImpactCategory 常量
+const (
+	ImpactCategoryFisheries            = "fisheries"
+	ImpactCategoryCoral                = "coral"
+	ImpactCategoryPlastics             = "plastics"
+	ImpactCategoryMangroves            = "mangroves"
+	ImpactCategoryWastewater           = "wastewater"
+	ImpactCategoryCoastalResilience    = "coastal_resilience"
+	ImpactCategoryMarineProtectedAreas = "marine_protected_areas"
+	ImpactCategoryShipping             = "shipping"
+	ImpactCategoryOther                = "other"
+)
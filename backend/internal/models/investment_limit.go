@@ -0,0 +1,71 @@
+package models
+
+import "time"
+
+// InvestmentLimits 使用者實際適用的投資限額（個別設定優先於 KYC 等級預設），nil 表示不限
+type InvestmentLimits struct {
+	Currency     string   `json:"currency"`
+	KYCLevel     int      `json:"kyc_level"`
+	Daily        *float64 `json:"daily,omitempty"`
+	Monthly      *float64 `json:"monthly,omitempty"`
+	MaxBondShare *float64 `json:"max_bond_share,omitempty"`
+}
+
+// InvestmentUsage 當日/當月已投資金額（UTC 曆日與曆月），以限額法幣計
+type InvestmentUsage struct {
+	Currency      string    `json:"currency"`
+	DailyUsed     float64   `json:"daily_used"`
+	MonthlyUsed   float64   `json:"monthly_used"`
+	RateTimestamp time.Time `json:"rate_timestamp"` // 換算所用匯率中最舊的時間
+}
+
+// LimitViolation 一項未通過的限額檢查
+type LimitViolation struct {
+	Type   string  `json:"type"`
+	Limit  float64 `json:"limit"`
+	Actual float64 `json:"actual"` // 含本次交易後的數值
+}
+
+// PreTradeCheck 交易前檢查結果
+type PreTradeCheck struct {
+	Allowed      bool              `json:"allowed"`
+	BondID       int64             `json:"bond_id"`
+	TradeValue   float64           `json:"trade_value"`   // 本次交易金額（法幣）
+	HoldingShare float64           `json:"holding_share"` // 交易後持有該債券的比例
+	Limits       *InvestmentLimits `json:"limits"`
+	Usage        *InvestmentUsage  `json:"usage"`
+	Violations   []LimitViolation  `json:"violations"`
+}
+
+// ComplianceAlert 事後發現的限額違規，待合規審查
+type ComplianceAlert struct {
+	ID             int64      `json:"id" db:"id"`
+	UserID         int64      `json:"user_id" db:"user_id"`
+	WalletAddress  string     `json:"wallet_address" db:"wallet_address"`
+	TxHash         string     `json:"tx_hash" db:"tx_hash"`
+	BondID         *int64     `json:"bond_id,omitempty" db:"bond_id"`
+	AlertType      string     `json:"alert_type" db:"alert_type"`
+	LimitValue     float64    `json:"limit_value" db:"limit_value"`
+	ActualValue    float64    `json:"actual_value" db:"actual_value"`
+	Currency       *string    `json:"currency,omitempty" db:"currency"`
+	Status         string     `json:"status" db:"status"`
+	ResolutionNote *string    `json:"resolution_note,omitempty" db:"resolution_note"`
+	ResolvedBy     *int64     `json:"resolved_by,omitempty" db:"resolved_by"`
+	ResolvedAt     *time.Time `json:"resolved_at,omitempty" db:"resolved_at"`
+	CreatedAt      time.Time  `json:"created_at" db:"created_at"`
+}
+
+// LimitViolation / ComplianceAlert 類型常量
+const (
+	LimitDaily             = "daily_limit"
+	LimitMonthly           = "monthly_limit"
+	LimitBondConcentration = "bond_concentration"
+	LimitKYCLevel          = "kyc_level"   // 僅用於交易前檢查
+	LimitBlacklisted       = "blacklisted" // 僅用於交易前檢查
+)
+
+// ComplianceAlertStatus 常量
+const (
+	ComplianceAlertOpen     = "open"
+	ComplianceAlertResolved = "resolved"
+)
@@ -0,0 +1,50 @@
+package models
+
+import "time"
+
+// IssuerApplication 買方申請升級為發行者
+type IssuerApplication struct {
+	ID            int64  `json:"id" db:"id"`
+	UserID        int64  `json:"user_id" db:"user_id"`
+	WalletAddress string `json:"wallet_address" db:"wallet_address"`
+
+	// 機構與聯絡人資料
+	InstitutionName    string  `json:"institution_name" db:"institution_name"`
+	RegistrationNumber string  `json:"registration_number" db:"registration_number"` // 公司登記/統一編號
+	ContactName        string  `json:"contact_name" db:"contact_name"`
+	ContactEmail       string  `json:"contact_email" db:"contact_email"`
+	ContactPhone       *string `json:"contact_phone,omitempty" db:"contact_phone"`
+
+	// 審核流程
+	Status        string     `json:"status" db:"status"`
+	ReviewComment *string    `json:"review_comment,omitempty" db:"review_comment"`
+	ReviewedBy    *int64     `json:"reviewed_by,omitempty" db:"reviewed_by"`
+	ReviewedAt    *time.Time `json:"reviewed_at,omitempty" db:"reviewed_at"`
+	SubmittedAt   *time.Time `json:"submitted_at,omitempty" db:"submitted_at"`
+
+	Documents []*IssuerApplicationDocument `json:"documents" db:"-"`
+
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// IssuerApplicationDocument 發行者申請附件（登記文件，內容存放於 Blob Store）
+type IssuerApplicationDocument struct {
+	ID            int64     `json:"id" db:"id"`
+	ApplicationID int64     `json:"application_id" db:"application_id"`
+	DocType       string    `json:"doc_type" db:"doc_type"`
+	FileName      string    `json:"file_name" db:"file_name"`
+	ContentType   string    `json:"content_type" db:"content_type"`
+	SizeBytes     int64     `json:"size_bytes" db:"size_bytes"`
+	StorageKey    string    `json:"-" db:"storage_key"`
+	SHA256        string    `json:"sha256" db:"sha256"`
+	UploadedAt    time.Time `json:"uploaded_at" db:"uploaded_at"`
+}
+
+// IssuerApplicationStatus 常量
+const (
+	IssuerApplicationDraft    = "draft"
+	IssuerApplicationPending  = "pending"
+	IssuerApplicationApproved = "approved"
+	IssuerApplicationDenied   = "denied"
+)
@@ -0,0 +1,66 @@
+package models
+
+import "time"
+
+// KYCApplication 使用者提交的 KYC 申請
+type KYCApplication struct {
+	ID             int64  `json:"id" db:"id"`
+	UserID         int64  `json:"user_id" db:"user_id"`
+	WalletAddress  string `json:"wallet_address" db:"wallet_address"`
+	RequestedLevel int    `json:"requested_level" db:"requested_level"` // 1: 基本, 2: 進階
+
+	// 申請人資料
+	FullName    string  `json:"full_name" db:"full_name"`
+	DateOfBirth string  `json:"date_of_birth" db:"date_of_birth"` // 格式: YYYY-MM-DD
+	Country     string  `json:"country" db:"country"`             // ISO 3166-1 alpha-2
+	Address     *string `json:"address,omitempty" db:"address"`
+
+	// 審核流程
+	Status        string     `json:"status" db:"status"`
+	ApprovedLevel *int       `json:"approved_level,omitempty" db:"approved_level"`
+	ReviewComment *string    `json:"review_comment,omitempty" db:"review_comment"`
+	ReviewedBy    *int64     `json:"reviewed_by,omitempty" db:"reviewed_by"`
+	ReviewedAt    *time.Time `json:"reviewed_at,omitempty" db:"reviewed_at"`
+	SubmittedAt   *time.Time `json:"submitted_at,omitempty" db:"submitted_at"`
+
+	Documents []*KYCDocument `json:"documents" db:"-"`
+
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// KYCDocument KYC 申請附件（檔案內容存放於 Blob Store）
+type KYCDocument struct {
+	ID            int64     `json:"id" db:"id"`
+	ApplicationID int64     `json:"application_id" db:"application_id"`
+	DocType       string    `json:"doc_type" db:"doc_type"`
+	FileName      string    `json:"file_name" db:"file_name"`
+	ContentType   string    `json:"content_type" db:"content_type"`
+	SizeBytes     int64     `json:"size_bytes" db:"size_bytes"`
+	StorageKey    string    `json:"-" db:"storage_key"`
+	SHA256        string    `json:"sha256" db:"sha256"`
+	UploadedAt    time.Time `json:"uploaded_at" db:"uploaded_at"`
+}
+
+// KYCStatus 使用者 KYC 狀態常量
+const (
+	KYCStatusNone     = "none"
+	KYCStatusPending  = "pending"
+	KYCStatusVerified = "verified"
+	KYCStatusRejected = "rejected"
+)
+
+// KYCLevel 常量
+const (
+	KYCLevelNone     = 0
+	KYCLevelBasic    = 1
+	KYCLevelAdvanced = 2
+)
+
+// KYCApplicationStatus 常量
+const (
+	KYCApplicationDraft    = "draft"
+	KYCApplicationPending  = "pending"
+	KYCApplicationApproved = "approved"
+	KYCApplicationRejected = "rejected"
+)
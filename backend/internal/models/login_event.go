@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+// 登入失敗原因
+const (
+	LoginFailureInvalidMessage   = "invalid_message"
+	LoginFailureNonce            = "nonce_failed"
+	LoginFailureInvalidSignature = "invalid_signature"
+	LoginFailureAddressMismatch  = "address_mismatch"
+	LoginFailureAccountDeleted   = "account_deleted"
+	LoginFailureWalletBlocked    = "wallet_blocked"
+	LoginFailureMaxDevices       = "max_devices"
+)
+
+// LoginEvent 登入紀錄（成功與失敗）
+type LoginEvent struct {
+	ID            int64     `json:"id" db:"id"`
+	UserID        *int64    `json:"user_id,omitempty" db:"user_id"` // 錢包尚未註冊時為 nil
+	WalletAddress string    `json:"wallet_address" db:"wallet_address"`
+	Success       bool      `json:"success" db:"success"`
+	FailureReason *string   `json:"failure_reason,omitempty" db:"failure_reason"`
+	IPAddress     *string   `json:"ip_address,omitempty" db:"ip_address"`
+	IPNetwork     *string   `json:"ip_network,omitempty" db:"ip_network"` // IPv4 /24、IPv6 /48
+	Country       *string   `json:"country,omitempty" db:"country"`       // ISO 3166-1 alpha-2
+	UserAgent     *string   `json:"user_agent,omitempty" db:"user_agent"`
+	Device        *string   `json:"device,omitempty" db:"device"` // 例如 "Chrome on macOS"
+	SessionRef    *string   `json:"session_ref,omitempty" db:"session_ref"`
+	CreatedAt     time.Time `json:"created_at" db:"created_at"`
+}
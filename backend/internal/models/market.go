@@ -0,0 +1,73 @@
+package models
+
+import "time"
+
+// MarketOrder 二級市場掛單（鏈下訂單簿，鏈上結算）
+type MarketOrder struct {
+	ID           int64      `json:"id" db:"id"`
+	BondID       int64      `json:"bond_id" db:"bond_id"`
+	Side         string     `json:"side" db:"side"`                   // ask（賣）/ bid（買）
+	TokenID      *string    `json:"token_id,omitempty" db:"token_id"` // 賣單對應的 BondToken on_chain_id
+	MakerUserID  int64      `json:"maker_user_id" db:"maker_user_id"`
+	MakerAddress string     `json:"maker_address" db:"maker_address"`
+	Price        int64      `json:"price" db:"price"` // 計價幣種最小單位
+	Nonce        string     `json:"nonce" db:"nonce"`
+	ExpiresAt    *time.Time `json:"expires_at,omitempty" db:"expires_at"`
+	Message      string     `json:"message" db:"message"`     // 掛單者簽署的原始訊息
+	Signature    string     `json:"signature" db:"signature"` // 錢包簽名，買方可自行驗證
+
+	Status        string     `json:"status" db:"status"`
+	CancelReason  *string    `json:"cancel_reason,omitempty" db:"cancel_reason"`
+	TakerAddress  *string    `json:"taker_address,omitempty" db:"taker_address"`
+	FilledTokenID *string    `json:"filled_token_id,omitempty" db:"filled_token_id"`
+	FilledTxHash  *string    `json:"filled_tx_hash,omitempty" db:"filled_tx_hash"`
+	FilledAt      *time.Time `json:"filled_at,omitempty" db:"filled_at"`
+
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// OrderBook 單一債券的買賣盤
+type OrderBook struct {
+	BondID int64          `json:"bond_id"`
+	Asks   []*MarketOrder `json:"asks"` // 價格由低到高
+	Bids   []*MarketOrder `json:"bids"` // 價格由高到低
+}
+
+// TradeFill 成交資訊（用於寫入交易記錄與更新持倉）
+type TradeFill struct {
+	OrderID      int64
+	BondID       int64
+	TokenID      string
+	Price        int64
+	SellerUserID int64
+	SellerAddr   string
+	BuyerUserID  int64
+	BuyerAddr    string
+	TakerAddr    string // 吃單方：賣單由買方吃單，買單由賣方吃單
+	TxHash       string
+	Timestamp    time.Time
+
+	AlreadyIndexed bool // 轉移已由索引器記錄，只需補上成交價
+}
+
+// OrderSide 常量
+const (
+	OrderSideAsk = "ask"
+	OrderSideBid = "bid"
+)
+
+// OrderStatus 常量
+const (
+	OrderStatusOpen      = "open"
+	OrderStatusFilled    = "filled"
+	OrderStatusCancelled = "cancelled"
+)
+
+// 掛單取消原因
+const (
+	CancelReasonMaker       = "cancelled_by_maker"
+	CancelReasonRedeemed    = "token_redeemed"
+	CancelReasonTransferred = "token_transferred"
+	CancelReasonSold        = "token_sold"
+)
@@ -5,8 +5,26 @@ import "time"
 // Nonce 用於認證挑戰的一次性隨機數
 type Nonce struct {
 	ID            int64     `json:"id" db:"id"`
-	WalletAddress string    `json:"wallet_address" db:"wallet_address"` // 用作存儲的 key
-	Nonce         string    `json:"nonce" db:"nonce"`                   // Base64 編碼的隨機數
+	WalletAddress string    `json:"wallet_address" db:"wallet_address"` // 簽署者地址（可同時有多個有效 nonce）
+	Nonce         string    `json:"nonce" db:"nonce"`                   // Base64 編碼的隨機數（唯一鍵）
+	Message       *string   `json:"message" db:"message"`               // 挑戰時發出的完整訊息
+	IPAddress     *string   `json:"ip_address" db:"ip_address"`         // 請求挑戰的 IP
 	CreatedAt     time.Time `json:"created_at" db:"created_at"`
 	ExpiresAt     time.Time `json:"expires_at" db:"expires_at"`
 }
+
+// ChallengeMetrics 自服務啟動以來的挑戰發出與驗證統計
+type ChallengeMetrics struct {
+	Since       time.Time                           `json:"since"`
+	Outstanding int64                               `json:"outstanding"` // 目前尚未過期的 nonce 數
+	Purposes    map[string]*ChallengePurposeMetrics `json:"purposes"`    // login / wallet_link / wallet_unlink
+}
+
+// ChallengePurposeMetrics 單一用途的挑戰統計
+type ChallengePurposeMetrics struct {
+	Issued      int64            `json:"issued"`
+	Verified    int64            `json:"verified"`
+	Failed      int64            `json:"failed"`
+	Failures    map[string]int64 `json:"failures"`     // 失敗原因 → 次數
+	FailureRate float64          `json:"failure_rate"` // failed / (verified + failed)
+}
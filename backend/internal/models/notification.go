@@ -0,0 +1,25 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// 通知類型
+const (
+	NotificationLoginNewLocation      = "security.login_new_location"
+	NotificationLoginFailureBurst     = "security.login_failure_burst"
+	NotificationConcurrentSessionsFar = "security.concurrent_sessions"
+)
+
+// Notification 使用者通知
+type Notification struct {
+	ID        int64           `json:"id" db:"id"`
+	UserID    int64           `json:"user_id" db:"user_id"`
+	Type      string          `json:"type" db:"type"`
+	Title     string          `json:"title" db:"title"`
+	Message   string          `json:"message" db:"message"`
+	Data      json.RawMessage `json:"data,omitempty" db:"data"`
+	ReadAt    *time.Time      `json:"read_at,omitempty" db:"read_at"`
+	CreatedAt time.Time       `json:"created_at" db:"created_at"`
+}
@@ -0,0 +1,58 @@
+package models
+
+import "time"
+
+// Organization 發行機構（可擁有多個成員錢包）
+type Organization struct {
+	ID                 int64     `json:"id" db:"id"`
+	Name               string    `json:"name" db:"name"`
+	RegistrationNumber *string   `json:"registration_number,omitempty" db:"registration_number"`
+	CreatedBy          *int64    `json:"created_by,omitempty" db:"created_by"`
+	MemberCount        int       `json:"member_count" db:"-"`
+	CreatedAt          time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt          time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// OrganizationMember 機構成員（含使用者錢包與平台角色）
+type OrganizationMember struct {
+	ID             int64     `json:"id" db:"id"`
+	OrganizationID int64     `json:"organization_id" db:"organization_id"`
+	UserID         int64     `json:"user_id" db:"user_id"`
+	WalletAddress  string    `json:"wallet_address" db:"wallet_address"`
+	Name           *string   `json:"name,omitempty" db:"name"`
+	Role           string    `json:"role" db:"role"`         // 平台角色 "buyer", "issuer", "admin"
+	OrgRole        string    `json:"org_role" db:"org_role"` // 機構內角色 "owner", "manager", "viewer"
+	AddedBy        *int64    `json:"added_by,omitempty" db:"added_by"`
+	CreatedAt      time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// OrganizationDetail 機構資料與成員列表
+type OrganizationDetail struct {
+	*Organization
+	Members []*OrganizationMember `json:"members"`
+}
+
+// OrganizationDashboard 機構發行者儀表板（以任一成員的發行地址關聯債券）
+type OrganizationDashboard struct {
+	Organization *Organization   `json:"organization"`
+	Bonds        []*Bond         `json:"bonds"`
+	Proposals    []*BondProposal `json:"proposals"`
+	Totals       *IssuanceTotals `json:"totals"`
+}
+
+// IssuanceTotals 機構發行概況（金額依計價幣種分別加總，單位為最小單位）
+type IssuanceTotals struct {
+	BondCount      int              `json:"bond_count"`
+	ActiveBonds    int              `json:"active_bonds"`
+	TotalAmount    map[string]int64 `json:"total_amount"`
+	AmountRaised   map[string]int64 `json:"amount_raised"`
+	AmountRedeemed map[string]int64 `json:"amount_redeemed"`
+}
+
+// OrgRole 常量
+const (
+	OrgRoleOwner   = "owner"
+	OrgRoleManager = "manager"
+	OrgRoleViewer  = "viewer"
+)
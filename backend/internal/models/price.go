@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+// PriceQuote 幣種兌法幣的匯率（1 單位幣種 = Rate 單位法幣）
+type PriceQuote struct {
+	ID        int64     `json:"id,omitempty" db:"id"`
+	Base      string    `json:"base" db:"base"`   // 幣種符號，例如 SUI
+	Quote     string    `json:"quote" db:"quote"` // 法幣代碼，例如 USD
+	Rate      float64   `json:"rate" db:"rate"`
+	Source    string    `json:"source" db:"source"`         // 匯率來源（static / file / http）
+	Timestamp time.Time `json:"timestamp" db:"observed_at"` // 匯率觀測時間
+}
+
+// FiatConversion 金額換算為法幣的結果
+// 單一幣種時附帶匯率；多幣種加總時 Rate 為 0，RateTimestamp 取所用匯率中最舊的時間
+type FiatConversion struct {
+	Currency      string             `json:"currency"`
+	Rate          float64            `json:"rate,omitempty"`
+	RateTimestamp time.Time          `json:"rate_timestamp"`
+	Source        string             `json:"source,omitempty"`
+	Values        map[string]float64 `json:"values"`
+}
@@ -0,0 +1,60 @@
+package models
+
+import (
+	"slices"
+	"time"
+)
+
+// 權限（路由以 RequirePermissionMiddleware 檢查）
+const (
+	PermissionAll                      = "*"                          // 所有權限
+	PermissionUsersManage              = "users:manage"               // 使用者管理、強制登出、還原
+	PermissionRolesManage              = "roles:manage"               // 角色定義與使用者角色授予
+	PermissionBondsPropose             = "bonds:propose"              // 提交債券發行申請
+	PermissionBondsModerate            = "bonds:moderate"             // 審核發行申請與鏈上未核准債券
+	PermissionImpactManage             = "impact:manage"              // 維護自己債券的影響力資料與報告
+	PermissionKYCReview                = "kyc:review"                 // KYC 審核
+	PermissionIssuerApplicationsReview = "issuer_applications:review" // 發行者升級申請審核
+	PermissionOrganizationsRead        = "organizations:read"         // 查看機構帳戶
+	PermissionComplianceManage         = "compliance:manage"          // 制裁名單、合規標記與限額警示
+	PermissionLimitsManage             = "limits:manage"              // 設定使用者投資限額
+	PermissionAuditRead                = "audit:read"                 // 稽核紀錄與登入挑戰統計
+)
+
+// Permissions 所有可授予的權限
+var Permissions = []string{
+	PermissionAll,
+	PermissionUsersManage,
+	PermissionRolesManage,
+	PermissionBondsPropose,
+	PermissionBondsModerate,
+	PermissionImpactManage,
+	PermissionKYCReview,
+	PermissionIssuerApplicationsReview,
+	PermissionOrganizationsRead,
+	PermissionComplianceManage,
+	PermissionLimitsManage,
+	PermissionAuditRead,
+}
+
+// Role 角色定義（角色對應一組權限）
+type Role struct {
+	Name        string    `json:"name" db:"name"`
+	Description *string   `json:"description,omitempty" db:"description"`
+	Permissions []string  `json:"permissions" db:"permissions"`
+	IsSystem    bool      `json:"is_system" db:"is_system"` // buyer / issuer / admin，不可刪除
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// HasPermission 角色是否具有指定權限
+func (r *Role) HasPermission(permission string) bool {
+	return slices.Contains(r.Permissions, PermissionAll) || slices.Contains(r.Permissions, permission)
+}
+
+// UserRoles 使用者的主要角色與額外授予的角色
+type UserRoles struct {
+	UserID  int64    `json:"user_id"`
+	Primary string   `json:"primary"`
+	Roles   []string `json:"roles"`
+}
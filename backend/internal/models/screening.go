@@ -0,0 +1,76 @@
+package models
+
+import "time"
+
+// DenyListEntry 制裁/黑名單中的一筆地址
+type DenyListEntry struct {
+	Address  string `json:"address"`
+	ListName string `json:"list_name"`
+	Reason   string `json:"reason,omitempty"`
+}
+
+// ScreeningResult 一次名單比對的紀錄
+type ScreeningResult struct {
+	ID            int64     `json:"id" db:"id"`
+	WalletAddress string    `json:"wallet_address" db:"wallet_address"`
+	UserID        *int64    `json:"user_id,omitempty" db:"user_id"`
+	Trigger       string    `json:"trigger" db:"trigger"`
+	Result        string    `json:"result" db:"result"`
+	ListName      *string   `json:"list_name,omitempty" db:"list_name"`
+	Reason        *string   `json:"reason,omitempty" db:"reason"`
+	ListVersion   string    `json:"list_version" db:"list_version"`
+	TxHash        *string   `json:"tx_hash,omitempty" db:"tx_hash"`
+	ScreenedBy    *int64    `json:"screened_by,omitempty" db:"screened_by"`
+	CreatedAt     time.Time `json:"created_at" db:"created_at"`
+}
+
+// IsMatch 是否命中名單
+func (r *ScreeningResult) IsMatch() bool {
+	return r.Result == ScreeningResultMatch
+}
+
+// ComplianceFlag 名單地址的鏈上活動，待合規審查
+type ComplianceFlag struct {
+	ID             int64      `json:"id" db:"id"`
+	WalletAddress  string     `json:"wallet_address" db:"wallet_address"`
+	UserID         *int64     `json:"user_id,omitempty" db:"user_id"`
+	TxHash         string     `json:"tx_hash" db:"tx_hash"`
+	EventType      string     `json:"event_type" db:"event_type"`
+	BondID         *int64     `json:"bond_id,omitempty" db:"bond_id"`
+	ListName       string     `json:"list_name" db:"list_name"`
+	Reason         *string    `json:"reason,omitempty" db:"reason"`
+	Status         string     `json:"status" db:"status"`
+	ResolutionNote *string    `json:"resolution_note,omitempty" db:"resolution_note"`
+	ResolvedBy     *int64     `json:"resolved_by,omitempty" db:"resolved_by"`
+	ResolvedAt     *time.Time `json:"resolved_at,omitempty" db:"resolved_at"`
+	CreatedAt      time.Time  `json:"created_at" db:"created_at"`
+}
+
+// DenyListStatus 目前載入的名單概況
+type DenyListStatus struct {
+	Version  string         `json:"version"`
+	Entries  int            `json:"entries"`
+	Lists    map[string]int `json:"lists"` // 名單名稱 → 地址數
+	LoadedAt time.Time      `json:"loaded_at"`
+}
+
+// ScreeningTrigger 常量
+const (
+	ScreeningTriggerLogin    = "login"
+	ScreeningTriggerPurchase = "purchase"
+	ScreeningTriggerTransfer = "transfer"
+	ScreeningTriggerPeriodic = "periodic"
+	ScreeningTriggerManual   = "manual"
+)
+
+// ScreeningResult 常量
+const (
+	ScreeningResultClear = "clear"
+	ScreeningResultMatch = "match"
+)
+
+// ComplianceFlagStatus 常量
+const (
+	ComplianceFlagOpen     = "open"
+	ComplianceFlagResolved = "resolved"
+)
@@ -8,6 +8,9 @@ type DBSession struct {
 	UserID        int64     `json:"user_id" db:"user_id"`
 	WalletAddress string    `json:"wallet_address" db:"wallet_address"`
 	Role          string    `json:"role" db:"role"`
+	Roles         []string  `json:"roles" db:"roles"` // 額外授予的角色（登入時快取，授予或移除時同步更新）
+	KYCStatus     string    `json:"kyc_status" db:"kyc_status"`
+	KYCLevel      int       `json:"kyc_level" db:"kyc_level"`
 	IPAddress     string    `json:"ip_address" db:"ip_address"`
 	UserAgent     string    `json:"user_agent" db:"user_agent"`
 	CreatedAt     time.Time `json:"created_at" db:"created_at"`
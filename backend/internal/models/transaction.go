@@ -58,4 +58,6 @@ type UserBondWithDetails struct {
 	MaturityDate       string  `json:"maturity_date" db:"maturity_date"`
 	Active             bool    `json:"active" db:"active"`
 	Redeemable         bool    `json:"redeemable" db:"redeemable"`
+	CoinType           string  `json:"coin_type" db:"coin_type"`
+	CoinDecimals       int     `json:"coin_decimals" db:"coin_decimals"`
 }
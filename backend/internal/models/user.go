@@ -2,11 +2,12 @@ package models
 
 import "time"
 
-// User 使用者資料模型 (TODO: blacklist, KYC, 2FA, lastLoginAt, LastLoginIP...)
+// User 使用者資料模型 (TODO: 2FA, lastLoginAt, LastLoginIP...)
 type User struct {
 	ID              int64      `json:"id" db:"id"`
 	WalletAddress   string     `json:"wallet_address" db:"wallet_address"`
 	Role            string     `json:"role" db:"role"`
+	Roles           []string   `json:"roles,omitempty" db:"-"`                           // 額外授予的角色（僅在需要時載入）
 	InstitutionName *string    `json:"institution_name,omitempty" db:"institution_name"` // 發行者機構名稱
 	Name            *string    `json:"name,omitempty" db:"name"`                         // 其他用戶的名稱
 	Timezone        string     `json:"timezone,omitempty" db:"timezone"`
@@ -15,16 +16,17 @@ type User struct {
 	UpdatedAt       time.Time  `json:"updated_at" db:"updated_at"`
 	DeletedAt       *time.Time `json:"deleted_at,omitempty" db:"deleted_at"`
 
-	// // ===== KYC/AML =====
-	// KYCStatus     string    `json:"kyc_status" db:"kyc_status"`         // "pending", "verified", "rejected"
-	// KYCLevel      int       `json:"kyc_level" db:"kyc_level"`           // 0: 未認證, 1: 基本, 2: 進階
-	// KYCVerifiedAt *time.Time `json:"kyc_verified_at,omitempty" db:"kyc_verified_at"`
-	// Country       string    `json:"country,omitempty" db:"country"`     // 國家/地區（合規需求）
+	// ===== KYC/AML =====
+	KYCStatus     string     `json:"kyc_status" db:"kyc_status"` // "none", "pending", "verified", "rejected"
+	KYCLevel      int        `json:"kyc_level" db:"kyc_level"`   // 0: 未認證, 1: 基本, 2: 進階
+	KYCVerifiedAt *time.Time `json:"kyc_verified_at,omitempty" db:"kyc_verified_at"`
+	Country       string     `json:"country,omitempty" db:"country"` // 國家/地區（合規需求）
 
-	// // ===== 交易限制 =====
-	// DailyLimit    *float64  `json:"daily_limit,omitempty" db:"daily_limit"`     // 每日交易限額
-	// MonthlyLimit  *float64  `json:"monthly_limit,omitempty" db:"monthly_limit"` // 每月交易限額
-	// IsBlacklisted bool      `json:"is_blacklisted" db:"is_blacklisted"`         // 黑名單
+	// ===== 交易限制 =====
+	DailyLimit    *float64 `json:"daily_limit,omitempty" db:"daily_limit"`       // 每日投資限額（nil 沿用 KYC 等級預設）
+	MonthlyLimit  *float64 `json:"monthly_limit,omitempty" db:"monthly_limit"`   // 每月投資限額
+	MaxBondShare  *float64 `json:"max_bond_share,omitempty" db:"max_bond_share"` // 單一債券最高持有比例（0~1）
+	IsBlacklisted bool     `json:"is_blacklisted" db:"is_blacklisted"`           // 黑名單（制裁名單命中或人工封鎖）
 
 	// // ===== 安全相關 =====
 	// TwoFactorEnabled bool   `json:"two_factor_enabled" db:"two_factor_enabled"` // 是否啟用 2FA
@@ -50,9 +52,32 @@ type UserBasicInfo struct {
 	Role          string `json:"role"` // "buyer", "issuer", "admin"
 }
 
-type InstitutionProfile struct {
-	ID              int64  `json:"id"`
-	WalletAddress   string `json:"wallet_address"`
-	Role            string `json:"role"` // "issuer", "admin"
-	InstitutionName string `json:"institution_name"`
+// UserFilter 管理員查詢使用者的條件（空字串表示不篩選）
+type UserFilter struct {
+	Role   string // "buyer", "issuer", "admin"
+	Wallet string // 錢包地址前綴
+	Name   string // 名稱或機構名稱（部分符合）
+	Status string // "active"（預設）, "deleted", "all"
+}
+
+// UserStatus 常量（管理員查詢用）
+const (
+	UserStatusActive  = "active"
+	UserStatusDeleted = "deleted"
+	UserStatusAll     = "all"
+)
+
+// UserSessionInfo 管理員檢視的登入裝置（不含 Session ID）
+type UserSessionInfo struct {
+	CreatedAt    time.Time `json:"created_at"`
+	LastActiveAt time.Time `json:"last_active_at"`
+	IPAddress    string    `json:"ip_address"`
+	UserAgent    string    `json:"user_agent"`
+}
+
+// AdminUserDetail 管理員檢視的使用者詳情
+type AdminUserDetail struct {
+	User     *User                  `json:"user"`
+	Sessions []*UserSessionInfo     `json:"sessions"`
+	Holdings []*UserBondWithDetails `json:"holdings"`
 }
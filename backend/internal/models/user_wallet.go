@@ -0,0 +1,27 @@
+package models
+
+import "time"
+
+// LinkedWallet 使用者綁定的額外錢包
+type LinkedWallet struct {
+	ID            int64     `json:"id" db:"id"`
+	UserID        int64     `json:"user_id" db:"user_id"`
+	WalletAddress string    `json:"wallet_address" db:"wallet_address"`
+	Label         *string   `json:"label,omitempty" db:"label"`
+	LinkedAt      time.Time `json:"linked_at" db:"linked_at"`
+}
+
+// UserWallets 使用者的主錢包與已綁定錢包
+type UserWallets struct {
+	Primary string          `json:"primary"`
+	Linked  []*LinkedWallet `json:"linked"`
+}
+
+// Addresses 回傳主錢包與所有已綁定錢包的地址
+func (w *UserWallets) Addresses() []string {
+	addresses := []string{w.Primary}
+	for _, wallet := range w.Linked {
+		addresses = append(addresses, wallet.WalletAddress)
+	}
+	return addresses
+}
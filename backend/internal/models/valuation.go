@@ -0,0 +1,52 @@
+package models
+
+import "time"
+
+// 估值方法
+const (
+	ValuationMethodDiscountedCashFlow = "discounted_cash_flow" // 以殖利率曲線加信用利差折現剩餘現金流
+	ValuationMethodLastTrade          = "last_trade"           // 以二級市場最後成交價推算
+	ValuationMethodMatured            = "matured"              // 已到期，以到期應付金額計
+)
+
+// TokenValuation 債券代幣的估值結果（金額單位：代幣計價幣種最小單位）
+type TokenValuation struct {
+	TokenID         string    `json:"token_id"`
+	FaceValue       int64     `json:"face_value"`
+	Value           int64     `json:"value"`
+	Method          string    `json:"method"`
+	AsOf            time.Time `json:"as_of"`
+	MaturityPayment int64     `json:"maturity_payment"`             // 到期應付本金加利息
+	YearsToMaturity float64   `json:"years_to_maturity"`            // 剩餘年期
+	DiscountRate    *float64  `json:"discount_rate,omitempty"`      // 折現率（殖利率 + 信用利差）
+	LastTradeTxHash string    `json:"last_trade_tx_hash,omitempty"` // 最後成交交易
+}
+
+// LastTrade 二級市場最後一筆成交
+type LastTrade struct {
+	TxHash    string
+	TokenID   string
+	Price     float64 // 成交價（計價幣種最小單位）
+	FaceValue int64   // 成交代幣的面額（計價幣種最小單位）
+	Timestamp time.Time
+}
+
+// Portfolio 錢包持有的債券代幣及其估值彙總
+type Portfolio struct {
+	Owner   string            `json:"owner"`
+	Wallets []string          `json:"wallets,omitempty"` // 彙總的所有綁定錢包
+	Tokens  []*BondToken      `json:"bond_tokens"`
+	Count   int               `json:"count"`
+	Totals  []*PortfolioTotal `json:"totals"` // 依計價幣種分別加總
+	AsOf    time.Time         `json:"as_of"`
+	Fiat    *FiatConversion   `json:"fiat,omitempty"` // 全部持倉換算法幣（?quote= 時）
+}
+
+// PortfolioTotal 單一計價幣種的持倉加總
+type PortfolioTotal struct {
+	CoinType     string          `json:"coin_type"`
+	CoinDecimals int             `json:"coin_decimals"`
+	FaceValue    int64           `json:"face_value"`
+	Value        int64           `json:"value"`
+	Fiat         *FiatConversion `json:"fiat,omitempty"`
+}
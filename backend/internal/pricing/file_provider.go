@@ -0,0 +1,98 @@
+package pricing
+
+import (
+	"bluelink-backend/internal/models"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FileProvider 從 JSON 檔案讀取匯率，檔案修改後自動重新載入
+// 檔案格式：{"updated_at": "2026-01-01T00:00:00Z", "rates": {"SUI/USD": 1.25, "SUI/TWD": 40}}
+type FileProvider struct {
+	path string
+
+	mu        sync.Mutex
+	modTime   time.Time
+	updatedAt time.Time
+	rates     map[string]float64
+}
+
+type priceFile struct {
+	UpdatedAt *time.Time         `json:"updated_at"`
+	Rates     map[string]float64 `json:"rates"`
+}
+
+// NewFileProvider 建立檔案匯率來源
+func NewFileProvider(path string) *FileProvider {
+	return &FileProvider{path: path}
+}
+
+// Name 匯率來源名稱
+func (p *FileProvider) Name() string {
+	return "file"
+}
+
+// GetPrice 取得檔案中的匯率
+func (p *FileProvider) GetPrice(ctx context.Context, base, quote string) (*models.PriceQuote, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if err := p.reload(); err != nil {
+		return nil, err
+	}
+
+	rate, ok := p.rates[pairKey(base, quote)]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrPriceNotFound, pairKey(base, quote))
+	}
+
+	return &models.PriceQuote{
+		Base:      strings.ToUpper(base),
+		Quote:     strings.ToUpper(quote),
+		Rate:      rate,
+		Source:    p.Name(),
+		Timestamp: p.updatedAt,
+	}, nil
+}
+
+// reload 檔案修改時間改變時重新讀取（需持有鎖）
+func (p *FileProvider) reload() error {
+	info, err := os.Stat(p.path)
+	if err != nil {
+		return fmt.Errorf("failed to stat price file: %w", err)
+	}
+	if p.rates != nil && info.ModTime().Equal(p.modTime) {
+		return nil
+	}
+
+	data, err := os.ReadFile(p.path)
+	if err != nil {
+		return fmt.Errorf("failed to read price file: %w", err)
+	}
+
+	var file priceFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return fmt.Errorf("failed to parse price file: %w", err)
+	}
+
+	rates := make(map[string]float64, len(file.Rates))
+	for pair, rate := range file.Rates {
+		if rate > 0 {
+			rates[strings.ToUpper(pair)] = rate
+		}
+	}
+
+	p.rates = rates
+	p.modTime = info.ModTime()
+	p.updatedAt = info.ModTime().UTC()
+	if file.UpdatedAt != nil {
+		p.updatedAt = file.UpdatedAt.UTC()
+	}
+
+	return nil
+}
@@ -0,0 +1,105 @@
+package pricing
+
+import (
+	"bluelink-backend/internal/models"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// HTTPProvider 從外部 API 取得匯率
+// URL 中的 {base} 與 {quote} 會被替換，回應需為 {"rate": 1.25, "timestamp": "RFC3339 或 Unix 秒"}
+type HTTPProvider struct {
+	urlTemplate string
+	client      *http.Client
+}
+
+type priceResponse struct {
+	Rate      *float64    `json:"rate"`
+	Price     *float64    `json:"price"` // 部分 API 以 price 命名
+	Timestamp interface{} `json:"timestamp"`
+}
+
+// NewHTTPProvider 建立 HTTP 匯率來源
+func NewHTTPProvider(urlTemplate string) *HTTPProvider {
+	return &HTTPProvider{
+		urlTemplate: urlTemplate,
+		client:      &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Name 匯率來源名稱
+func (p *HTTPProvider) Name() string {
+	return "http"
+}
+
+// GetPrice 向外部 API 查詢匯率
+func (p *HTTPProvider) GetPrice(ctx context.Context, base, quote string) (*models.PriceQuote, error) {
+	base = strings.ToUpper(base)
+	quote = strings.ToUpper(quote)
+
+	requestURL := strings.NewReplacer(
+		"{base}", url.QueryEscape(base),
+		"{quote}", url.QueryEscape(quote),
+	).Replace(p.urlTemplate)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build price request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to request price: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: %s", ErrPriceNotFound, pairKey(base, quote))
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("price API returned status %d", resp.StatusCode)
+	}
+
+	var body priceResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("failed to decode price response: %w", err)
+	}
+
+	rate := body.Rate
+	if rate == nil {
+		rate = body.Price
+	}
+	if rate == nil || *rate <= 0 {
+		return nil, fmt.Errorf("%w: %s", ErrPriceNotFound, pairKey(base, quote))
+	}
+
+	return &models.PriceQuote{
+		Base:      base,
+		Quote:     quote,
+		Rate:      *rate,
+		Source:    p.Name(),
+		Timestamp: parseResponseTimestamp(body.Timestamp),
+	}, nil
+}
+
+// parseResponseTimestamp 解析 RFC3339 字串或 Unix 秒（毫秒）時間，無法解析時使用目前時間
+func parseResponseTimestamp(value interface{}) time.Time {
+	switch v := value.(type) {
+	case string:
+		if t, err := time.Parse(time.RFC3339, v); err == nil {
+			return t.UTC()
+		}
+	case float64:
+		if v > 1e12 {
+			return time.UnixMilli(int64(v)).UTC()
+		}
+		return time.Unix(int64(v), 0).UTC()
+	}
+	return time.Now().UTC()
+}
@@ -0,0 +1,47 @@
+package pricing
+
+import (
+	"bluelink-backend/internal/config"
+	"bluelink-backend/internal/models"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrPriceNotFound 匯率來源沒有該幣種對法幣的匯率
+var ErrPriceNotFound = errors.New("price not found")
+
+// PriceProvider 定義匯率來源介面
+type PriceProvider interface {
+	// GetPrice 取得 1 單位 base 幣種兌 quote 法幣的匯率
+	GetPrice(ctx context.Context, base, quote string) (*models.PriceQuote, error)
+
+	// Name 匯率來源名稱（記錄於快照的 source）
+	Name() string
+}
+
+// NewProvider 依設定建立匯率來源
+func NewProvider(cfg *config.Config) (PriceProvider, error) {
+	switch cfg.PriceProvider {
+	case "", "static":
+		return NewStaticProvider(cfg.PriceStaticRates), nil
+	case "file":
+		if cfg.PriceFilePath == "" {
+			return nil, fmt.Errorf("PRICE_FILE_PATH is required for the file price provider")
+		}
+		return NewFileProvider(cfg.PriceFilePath), nil
+	case "http":
+		if cfg.PriceHTTPURL == "" {
+			return nil, fmt.Errorf("PRICE_HTTP_URL is required for the http price provider")
+		}
+		return NewHTTPProvider(cfg.PriceHTTPURL), nil
+	default:
+		return nil, fmt.Errorf("unknown price provider: %s", cfg.PriceProvider)
+	}
+}
+
+// pairKey 幣種對的鍵值，例如 SUI/USD
+func pairKey(base, quote string) string {
+	return strings.ToUpper(base) + "/" + strings.ToUpper(quote)
+}
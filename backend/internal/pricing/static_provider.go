@@ -0,0 +1,49 @@
+package pricing
+
+import (
+	"bluelink-backend/internal/models"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// StaticProvider 以設定檔中的固定匯率提供報價，時間為服務啟動時間
+type StaticProvider struct {
+	rates    map[string]float64
+	loadedAt time.Time
+}
+
+// NewStaticProvider 建立固定匯率來源，rates 的鍵為 "SUI/USD" 形式
+func NewStaticProvider(rates map[string]float64) *StaticProvider {
+	normalized := make(map[string]float64, len(rates))
+	for pair, rate := range rates {
+		normalized[strings.ToUpper(pair)] = rate
+	}
+
+	return &StaticProvider{
+		rates:    normalized,
+		loadedAt: time.Now().UTC(),
+	}
+}
+
+// Name 匯率來源名稱
+func (p *StaticProvider) Name() string {
+	return "static"
+}
+
+// GetPrice 取得固定匯率
+func (p *StaticProvider) GetPrice(ctx context.Context, base, quote string) (*models.PriceQuote, error) {
+	rate, ok := p.rates[pairKey(base, quote)]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrPriceNotFound, pairKey(base, quote))
+	}
+
+	return &models.PriceQuote{
+		Base:      strings.ToUpper(base),
+		Quote:     strings.ToUpper(quote),
+		Rate:      rate,
+		Source:    p.Name(),
+		Timestamp: p.loadedAt,
+	}, nil
+}
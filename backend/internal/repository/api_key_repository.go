@@ -0,0 +1,211 @@
+package repository
+
+import (
+	"bluelink-backend/internal/models"
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// APIKeyRepository 處理 API key 的資料庫操作
+type APIKeyRepository struct {
+	db *sql.DB
+}
+
+// NewAPIKeyRepository 建立新的 APIKeyRepository
+func NewAPIKeyRepository(db *sql.DB) *APIKeyRepository {
+	return &APIKeyRepository{db: db}
+}
+
+const apiKeyColumns = `id, user_id, name, prefix, key_hash, scopes, ip_allowlist, expires_at,
+	last_used_at, last_used_ip, rotated_at, revoked_at, created_at`
+
+// Create 建立 API key
+func (r *APIKeyRepository) Create(ctx context.Context, key *models.APIKey) error {
+	query := `
+		INSERT INTO api_keys (user_id, name, prefix, key_hash, scopes, ip_allowlist, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id, created_at
+	`
+
+	err := r.db.QueryRowContext(ctx, query,
+		key.UserID,
+		key.Name,
+		key.Prefix,
+		key.KeyHash,
+		pq.Array(key.Scopes),
+		pq.Array(key.IPAllowlist),
+		key.ExpiresAt,
+		time.Now(),
+	).Scan(&key.ID, &key.CreatedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to create api key: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID 根據 ID 查詢 API key（不存在時回傳 nil）
+func (r *APIKeyRepository) GetByID(ctx context.Context, id int64) (*models.APIKey, error) {
+	query := `SELECT ` + apiKeyColumns + ` FROM api_keys WHERE id = $1`
+
+	key, err := scanAPIKey(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get api key: %w", err)
+	}
+
+	return key, nil
+}
+
+// GetByPrefix 根據識別前綴查詢 API key（不存在時回傳 nil）
+func (r *APIKeyRepository) GetByPrefix(ctx context.Context, prefix string) (*models.APIKey, error) {
+	query := `SELECT ` + apiKeyColumns + ` FROM api_keys WHERE prefix = $1`
+
+	key, err := scanAPIKey(r.db.QueryRowContext(ctx, query, prefix))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get api key: %w", err)
+	}
+
+	return key, nil
+}
+
+// ListByUser 查詢使用者的所有 API key（含已撤銷）
+func (r *APIKeyRepository) ListByUser(ctx context.Context, userID int64) ([]*models.APIKey, error) {
+	query := `SELECT ` + apiKeyColumns + `
+		FROM api_keys
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list api keys: %w", err)
+	}
+	defer rows.Close()
+
+	keys := []*models.APIKey{}
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan api key: %w", err)
+		}
+		keys = append(keys, key)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return keys, nil
+}
+
+// CountActiveByUser 計算使用者未撤銷且未過期的 API key 數量
+func (r *APIKeyRepository) CountActiveByUser(ctx context.Context, userID int64) (int, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM api_keys
+		WHERE user_id = $1 AND revoked_at IS NULL AND (expires_at IS NULL OR expires_at > NOW())
+	`
+
+	var count int
+	if err := r.db.QueryRowContext(ctx, query, userID).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count api keys: %w", err)
+	}
+
+	return count, nil
+}
+
+// Rotate 替換 API key 的前綴與雜湊（舊 key 立即失效），設定值保持不變
+func (r *APIKeyRepository) Rotate(ctx context.Context, id int64, prefix, keyHash string) error {
+	query := `
+		UPDATE api_keys
+		SET prefix = $1, key_hash = $2, rotated_at = $3
+		WHERE id = $4 AND revoked_at IS NULL
+	`
+
+	result, err := r.db.ExecContext(ctx, query, prefix, keyHash, time.Now(), id)
+	if err != nil {
+		return fmt.Errorf("failed to rotate api key: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("api key not found or revoked")
+	}
+
+	return nil
+}
+
+// Revoke 撤銷 API key
+func (r *APIKeyRepository) Revoke(ctx context.Context, id int64) error {
+	query := `UPDATE api_keys SET revoked_at = $1 WHERE id = $2 AND revoked_at IS NULL`
+
+	result, err := r.db.ExecContext(ctx, query, time.Now(), id)
+	if err != nil {
+		return fmt.Errorf("failed to revoke api key: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("api key not found or already revoked")
+	}
+
+	return nil
+}
+
+// TouchLastUsed 記錄 API key 最後使用的時間與來源 IP
+func (r *APIKeyRepository) TouchLastUsed(ctx context.Context, id int64, ipAddress string) error {
+	query := `UPDATE api_keys SET last_used_at = $1, last_used_ip = $2 WHERE id = $3`
+
+	if _, err := r.db.ExecContext(ctx, query, time.Now(), ipAddress, id); err != nil {
+		return fmt.Errorf("failed to update api key last used: %w", err)
+	}
+
+	return nil
+}
+
+func scanAPIKey(row rowScanner) (*models.APIKey, error) {
+	key := &models.APIKey{}
+	var scopes, ipAllowlist pq.StringArray
+
+	err := row.Scan(
+		&key.ID,
+		&key.UserID,
+		&key.Name,
+		&key.Prefix,
+		&key.KeyHash,
+		&scopes,
+		&ipAllowlist,
+		&key.ExpiresAt,
+		&key.LastUsedAt,
+		&key.LastUsedIP,
+		&key.RotatedAt,
+		&key.RevokedAt,
+		&key.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	key.Scopes = []string(scopes)
+	key.IPAllowlist = []string(ipAllowlist)
+	return key, nil
+}
@@ -0,0 +1,138 @@
+package repository
+
+import (
+	"bluelink-backend/internal/models"
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// AuditRepository 處理稽核事件的資料庫操作（僅新增與查詢）
+type AuditRepository struct {
+	db *sql.DB
+}
+
+// NewAuditRepository 建立新的 AuditRepository
+func NewAuditRepository(db *sql.DB) *AuditRepository {
+	return &AuditRepository{db: db}
+}
+
+const auditEventColumns = `
+	id, actor_id, actor_wallet, actor_role, action, target_type, target_id,
+	ip_address, user_agent, request_id, before, after, created_at
+`
+
+// Create 新增稽核事件
+func (r *AuditRepository) Create(ctx context.Context, event *models.AuditEvent) error {
+	query := `
+		INSERT INTO audit_events (
+			actor_id, actor_wallet, actor_role, action, target_type, target_id,
+			ip_address, user_agent, request_id, before, after
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		RETURNING id, created_at
+	`
+
+	err := r.db.QueryRowContext(ctx, query,
+		event.ActorID,
+		event.ActorWallet,
+		event.ActorRole,
+		event.Action,
+		event.TargetType,
+		event.TargetID,
+		event.IPAddress,
+		event.UserAgent,
+		event.RequestID,
+		nullableJSON(event.Before),
+		nullableJSON(event.After),
+	).Scan(&event.ID, &event.CreatedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to create audit event: %w", err)
+	}
+
+	return nil
+}
+
+// Search 依條件查詢稽核事件（新到舊），回傳事件與總筆數
+func (r *AuditRepository) Search(ctx context.Context, filter models.AuditFilter, limit, offset int) ([]*models.AuditEvent, int, error) {
+	// action 以 "." 結尾時視為前綴比對
+	action, actionPrefix := filter.Action, ""
+	if strings.HasSuffix(action, ".") {
+		action, actionPrefix = "", escapeLike(filter.Action)
+	}
+
+	query := `SELECT ` + auditEventColumns + `, COUNT(*) OVER()
+		FROM audit_events
+		WHERE ($1::BIGINT IS NULL OR actor_id = $1)
+		  AND ($2 = '' OR action = $2)
+		  AND ($3 = '' OR action LIKE $3 || '%')
+		  AND ($4 = '' OR target_type = $4)
+		  AND ($5 = '' OR target_id = $5)
+		  AND ($6 = '' OR request_id = $6)
+		  AND ($7::TIMESTAMP IS NULL OR created_at >= $7)
+		  AND ($8::TIMESTAMP IS NULL OR created_at < $8)
+		ORDER BY created_at DESC, id DESC
+		LIMIT $9 OFFSET $10
+	`
+
+	rows, err := r.db.QueryContext(ctx, query,
+		filter.ActorID,
+		action,
+		actionPrefix,
+		filter.TargetType,
+		filter.TargetID,
+		filter.RequestID,
+		filter.From,
+		filter.To,
+		limit,
+		offset,
+	)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to search audit events: %w", err)
+	}
+	defer rows.Close()
+
+	events := []*models.AuditEvent{}
+	total := 0
+	for rows.Next() {
+		event := &models.AuditEvent{}
+		var before, after []byte
+		err := rows.Scan(
+			&event.ID,
+			&event.ActorID,
+			&event.ActorWallet,
+			&event.ActorRole,
+			&event.Action,
+			&event.TargetType,
+			&event.TargetID,
+			&event.IPAddress,
+			&event.UserAgent,
+			&event.RequestID,
+			&before,
+			&after,
+			&event.CreatedAt,
+			&total,
+		)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan audit event: %w", err)
+		}
+		event.Before = before
+		event.After = after
+		events = append(events, event)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("rows error: %w", err)
+	}
+
+	return events, total, nil
+}
+
+// nullableJSON 空的 JSON 欄位寫入 NULL
+func nullableJSON(data []byte) any {
+	if len(data) == 0 {
+		return nil
+	}
+	return string(data)
+}
@@ -0,0 +1,175 @@
+package repository
+
+import (
+	"bluelink-backend/internal/models"
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// AuthLockoutRepository 處理登入失敗計數與鎖定的資料庫操作
+type AuthLockoutRepository struct {
+	db *sql.DB
+}
+
+// NewAuthLockoutRepository 建立新的 AuthLockoutRepository
+func NewAuthLockoutRepository(db *sql.DB) *AuthLockoutRepository {
+	return &AuthLockoutRepository{db: db}
+}
+
+const authLockoutColumns = `scope, subject, failures, lockouts, window_started_at, last_failure_at, locked_until`
+
+// RecordFailure 累加一次失敗並回傳更新後的狀態
+// 時間窗在 windowStart 之前開始時重新計數；最後一次失敗早於 decayBefore 時連續鎖定次數歸零
+func (r *AuthLockoutRepository) RecordFailure(ctx context.Context, scope, subject string, windowStart, decayBefore time.Time) (*models.AuthLockout, error) {
+	query := `
+		INSERT INTO auth_lockouts (scope, subject, failures, lockouts, window_started_at, last_failure_at)
+		VALUES ($1, $2, 1, 0, $3, $3)
+		ON CONFLICT (scope, subject) DO UPDATE SET
+			failures = CASE WHEN auth_lockouts.window_started_at < $4 THEN 1 ELSE auth_lockouts.failures + 1 END,
+			window_started_at = CASE WHEN auth_lockouts.window_started_at < $4 THEN $3 ELSE auth_lockouts.window_started_at END,
+			lockouts = CASE WHEN auth_lockouts.last_failure_at < $5 THEN 0 ELSE auth_lockouts.lockouts END,
+			last_failure_at = $3
+		RETURNING ` + authLockoutColumns
+
+	lockout, err := scanAuthLockout(r.db.QueryRowContext(ctx, query, scope, subject, time.Now(), windowStart, decayBefore))
+	if err != nil {
+		return nil, fmt.Errorf("failed to record auth failure: %w", err)
+	}
+
+	return lockout, nil
+}
+
+// Lock 鎖定至指定時間，連續鎖定次數加一並重新開始計數
+func (r *AuthLockoutRepository) Lock(ctx context.Context, scope, subject string, until time.Time) error {
+	query := `
+		UPDATE auth_lockouts
+		SET locked_until = $3, lockouts = lockouts + 1, failures = 0, window_started_at = $4
+		WHERE scope = $1 AND subject = $2
+	`
+
+	if _, err := r.db.ExecContext(ctx, query, scope, subject, until, time.Now()); err != nil {
+		return fmt.Errorf("failed to lock %s %s: %w", scope, subject, err)
+	}
+
+	return nil
+}
+
+// GetForAttempt 取得錢包（錢包地址|IP）與 IP 的失敗計數（沒有紀錄的範圍不回傳）
+func (r *AuthLockoutRepository) GetForAttempt(ctx context.Context, walletSubject, ipAddress string) ([]*models.AuthLockout, error) {
+	query := `SELECT ` + authLockoutColumns + `
+		FROM auth_lockouts
+		WHERE (scope = $1 AND subject = $2) OR (scope = $3 AND subject = $4)
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, models.AuthLockoutScopeWallet, walletSubject, models.AuthLockoutScopeIP, ipAddress)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get auth lockouts: %w", err)
+	}
+	defer rows.Close()
+
+	return scanAuthLockouts(rows)
+}
+
+// ListLocked 取得目前鎖定中的錢包與 IP
+func (r *AuthLockoutRepository) ListLocked(ctx context.Context) ([]*models.AuthLockout, error) {
+	query := `SELECT ` + authLockoutColumns + `
+		FROM auth_lockouts
+		WHERE locked_until > $1
+		ORDER BY locked_until DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list auth lockouts: %w", err)
+	}
+	defer rows.Close()
+
+	return scanAuthLockouts(rows)
+}
+
+// Delete 清除失敗計數與鎖定，回傳是否有紀錄
+func (r *AuthLockoutRepository) Delete(ctx context.Context, scope, subject string) (bool, error) {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM auth_lockouts WHERE scope = $1 AND subject = $2`, scope, subject)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete auth lockout: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	return affected > 0, nil
+}
+
+// DeleteByWallet 清除錢包在所有 IP 的失敗計數與鎖定，回傳是否有紀錄
+func (r *AuthLockoutRepository) DeleteByWallet(ctx context.Context, walletAddress string) (bool, error) {
+	result, err := r.db.ExecContext(ctx, `
+		DELETE FROM auth_lockouts
+		WHERE scope = $1 AND left(subject, length($2) + 1) = $2 || '|'
+	`, models.AuthLockoutScopeWallet, walletAddress)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete auth lockouts of wallet: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	return affected > 0, nil
+}
+
+// DeleteStale 刪除最後一次失敗早於 before 且未鎖定的紀錄（定期清理）
+func (r *AuthLockoutRepository) DeleteStale(ctx context.Context, before time.Time) (int64, error) {
+	result, err := r.db.ExecContext(ctx, `
+		DELETE FROM auth_lockouts
+		WHERE last_failure_at < $1 AND (locked_until IS NULL OR locked_until < $2)
+	`, before, time.Now())
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete stale auth lockouts: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	return affected, nil
+}
+
+func scanAuthLockout(row rowScanner) (*models.AuthLockout, error) {
+	var lockout models.AuthLockout
+	err := row.Scan(
+		&lockout.Scope,
+		&lockout.Subject,
+		&lockout.Failures,
+		&lockout.Lockouts,
+		&lockout.WindowStartedAt,
+		&lockout.LastFailureAt,
+		&lockout.LockedUntil,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &lockout, nil
+}
+
+func scanAuthLockouts(rows *sql.Rows) ([]*models.AuthLockout, error) {
+	lockouts := []*models.AuthLockout{}
+	for rows.Next() {
+		lockout, err := scanAuthLockout(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan auth lockout: %w", err)
+		}
+		lockouts = append(lockouts, lockout)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating auth lockouts: %w", err)
+	}
+
+	return lockouts, nil
+}
@@ -0,0 +1,372 @@
+package repository
+
+import (
+	"bluelink-backend/internal/models"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// BondProposalRepository 處理債券發行申請的資料庫操作
+type BondProposalRepository struct {
+	db *sql.DB
+}
+
+// NewBondProposalRepository 建立新的 BondProposalRepository
+func NewBondProposalRepository(db *sql.DB) *BondProposalRepository {
+	return &BondProposalRepository{db: db}
+}
+
+const bondProposalColumns = `
+	id, issuer_user_id, issuer_address, issuer_name, bond_name, description,
+	total_amount, annual_interest_rate, maturity_date,
+	COALESCE(bond_image_url, ''), COALESCE(token_image_url, ''), COALESCE(metadata_url, ''), documents,
+	status, review_comment, reviewed_by, reviewed_at, submitted_at,
+	bond_id, on_chain_id,
+	created_at, updated_at, deleted_at
+`
+
+// Create 建立新的債券申請（草稿狀態）
+func (r *BondProposalRepository) Create(ctx context.Context, p *models.BondProposal) error {
+	documents, err := json.Marshal(p.Documents)
+	if err != nil {
+		return fmt.Errorf("failed to marshal documents: %w", err)
+	}
+
+	query := `
+		INSERT INTO bond_proposals (
+			issuer_user_id, issuer_address, issuer_name, bond_name, description,
+			total_amount, annual_interest_rate, maturity_date,
+			bond_image_url, token_image_url, metadata_url, documents,
+			status, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+		RETURNING id, created_at, updated_at
+	`
+
+	now := time.Now()
+	err = r.db.QueryRowContext(ctx, query,
+		p.IssuerUserID,
+		p.IssuerAddress,
+		p.IssuerName,
+		p.BondName,
+		p.Description,
+		p.TotalAmount,
+		p.AnnualInterestRate,
+		p.MaturityDate,
+		p.BondImageUrl,
+		p.TokenImageUrl,
+		p.MetadataUrl,
+		documents,
+		p.Status,
+		now,
+		now,
+	).Scan(&p.ID, &p.CreatedAt, &p.UpdatedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to create bond proposal: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID 根據 ID 查詢債券申請
+func (r *BondProposalRepository) GetByID(ctx context.Context, id int64) (*models.BondProposal, error) {
+	query := `SELECT ` + bondProposalColumns + `
+		FROM bond_proposals
+		WHERE id = $1 AND deleted_at IS NULL
+	`
+
+	p, err := scanBondProposal(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bond proposal by ID: %w", err)
+	}
+
+	return p, nil
+}
+
+// ListByIssuer 查詢發行者的所有申請
+func (r *BondProposalRepository) ListByIssuer(ctx context.Context, issuerUserID int64, limit, offset int) ([]*models.BondProposal, error) {
+	query := `SELECT ` + bondProposalColumns + `
+		FROM bond_proposals
+		WHERE issuer_user_id = $1 AND deleted_at IS NULL
+		ORDER BY created_at DESC
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, issuerUserID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list bond proposals by issuer: %w", err)
+	}
+	defer rows.Close()
+
+	return r.scanBondProposals(rows)
+}
+
+// ListByIssuers 查詢多位發行者的申請（機構儀表板用）
+func (r *BondProposalRepository) ListByIssuers(ctx context.Context, issuerUserIDs []int64, limit, offset int) ([]*models.BondProposal, error) {
+	query := `SELECT ` + bondProposalColumns + `
+		FROM bond_proposals
+		WHERE issuer_user_id = ANY($1) AND deleted_at IS NULL
+		ORDER BY created_at DESC
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(issuerUserIDs), limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list bond proposals by issuers: %w", err)
+	}
+	defer rows.Close()
+
+	return r.scanBondProposals(rows)
+}
+
+// ListByStatus 根據狀態查詢申請（管理員審核用）；status 為空時回傳全部
+func (r *BondProposalRepository) ListByStatus(ctx context.Context, status string, limit, offset int) ([]*models.BondProposal, error) {
+	query := `SELECT ` + bondProposalColumns + `
+		FROM bond_proposals
+		WHERE ($1 = '' OR status = $1) AND deleted_at IS NULL
+		ORDER BY COALESCE(submitted_at, created_at) ASC
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, status, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list bond proposals by status: %w", err)
+	}
+	defer rows.Close()
+
+	return r.scanBondProposals(rows)
+}
+
+// Update 更新申請內容（僅限草稿或被退回的申請）
+func (r *BondProposalRepository) Update(ctx context.Context, p *models.BondProposal) error {
+	documents, err := json.Marshal(p.Documents)
+	if err != nil {
+		return fmt.Errorf("failed to marshal documents: %w", err)
+	}
+
+	query := `
+		UPDATE bond_proposals
+		SET issuer_name = $1, bond_name = $2, description = $3,
+		    total_amount = $4, annual_interest_rate = $5, maturity_date = $6,
+		    bond_image_url = $7, token_image_url = $8, metadata_url = $9, documents = $10,
+		    updated_at = $11
+		WHERE id = $12 AND status IN ('draft', 'rejected') AND deleted_at IS NULL
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		p.IssuerName,
+		p.BondName,
+		p.Description,
+		p.TotalAmount,
+		p.AnnualInterestRate,
+		p.MaturityDate,
+		p.BondImageUrl,
+		p.TokenImageUrl,
+		p.MetadataUrl,
+		documents,
+		time.Now(),
+		p.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update bond proposal: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("bond proposal not found or not editable")
+	}
+
+	return nil
+}
+
+// Submit 送出申請（draft/rejected → submitted）
+func (r *BondProposalRepository) Submit(ctx context.Context, id int64) error {
+	query := `
+		UPDATE bond_proposals
+		SET status = 'submitted', submitted_at = $1, review_comment = NULL,
+		    reviewed_by = NULL, reviewed_at = NULL, updated_at = $1
+		WHERE id = $2 AND status IN ('draft', 'rejected') AND deleted_at IS NULL
+	`
+
+	result, err := r.db.ExecContext(ctx, query, time.Now(), id)
+	if err != nil {
+		return fmt.Errorf("failed to submit bond proposal: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("bond proposal not found or not in a submittable state")
+	}
+
+	return nil
+}
+
+// Review 審核申請（submitted → approved/rejected）
+func (r *BondProposalRepository) Review(ctx context.Context, id int64, status string, reviewerID int64, comment *string) error {
+	query := `
+		UPDATE bond_proposals
+		SET status = $1, review_comment = $2, reviewed_by = $3, reviewed_at = $4, updated_at = $4
+		WHERE id = $5 AND status = 'submitted' AND deleted_at IS NULL
+	`
+
+	result, err := r.db.ExecContext(ctx, query, status, comment, reviewerID, time.Now(), id)
+	if err != nil {
+		return fmt.Errorf("failed to review bond proposal: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("bond proposal not found or not awaiting review")
+	}
+
+	return nil
+}
+
+// LinkApproved 將鏈上債券關聯到發行者與名稱相符的已核准申請
+// 回傳被關聯的申請 ID；沒有相符申請時回傳 nil
+func (r *BondProposalRepository) LinkApproved(ctx context.Context, bond *models.Bond) (*int64, error) {
+	query := `
+		UPDATE bond_proposals
+		SET status = 'linked', bond_id = $1, on_chain_id = $2, updated_at = $3
+		WHERE id = (
+			SELECT id
+			FROM bond_proposals
+			WHERE issuer_address = $4 AND bond_name = $5
+			  AND status = 'approved' AND deleted_at IS NULL
+			ORDER BY reviewed_at ASC
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id
+	`
+
+	var id int64
+	err := r.db.QueryRowContext(ctx, query,
+		bond.ID,
+		bond.OnChainID,
+		time.Now(),
+		bond.IssuerAddress,
+		bond.BondName,
+	).Scan(&id)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to link bond proposal: %w", err)
+	}
+
+	return &id, nil
+}
+
+// Delete 軟刪除申請（僅限尚未送審的草稿）
+func (r *BondProposalRepository) Delete(ctx context.Context, id int64) error {
+	query := `
+		UPDATE bond_proposals
+		SET deleted_at = $1
+		WHERE id = $2 AND status IN ('draft', 'rejected') AND deleted_at IS NULL
+	`
+
+	result, err := r.db.ExecContext(ctx, query, time.Now(), id)
+	if err != nil {
+		return fmt.Errorf("failed to delete bond proposal: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("bond proposal not found or not deletable")
+	}
+
+	return nil
+}
+
+// scanBondProposals 掃描申請列表
+func (r *BondProposalRepository) scanBondProposals(rows *sql.Rows) ([]*models.BondProposal, error) {
+	var proposals []*models.BondProposal
+
+	for rows.Next() {
+		p, err := scanBondProposal(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan bond proposal: %w", err)
+		}
+		proposals = append(proposals, p)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return proposals, nil
+}
+
+// rowScanner 抽象 *sql.Row 與 *sql.Rows 的 Scan
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanBondProposal(row rowScanner) (*models.BondProposal, error) {
+	p := &models.BondProposal{}
+	var documents []byte
+
+	err := row.Scan(
+		&p.ID,
+		&p.IssuerUserID,
+		&p.IssuerAddress,
+		&p.IssuerName,
+		&p.BondName,
+		&p.Description,
+		&p.TotalAmount,
+		&p.AnnualInterestRate,
+		&p.MaturityDate,
+		&p.BondImageUrl,
+		&p.TokenImageUrl,
+		&p.MetadataUrl,
+		&documents,
+		&p.Status,
+		&p.ReviewComment,
+		&p.ReviewedBy,
+		&p.ReviewedAt,
+		&p.SubmittedAt,
+		&p.BondID,
+		&p.OnChainID,
+		&p.CreatedAt,
+		&p.UpdatedAt,
+		&p.DeletedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	p.Documents = []models.ProposalDocument{}
+	if len(documents) > 0 {
+		if err := json.Unmarshal(documents, &p.Documents); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal documents: %w", err)
+		}
+	}
+
+	return p, nil
+}
@@ -5,7 +5,10 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strings"
 	"time"
+
+	"github.com/lib/pq"
 )
 
 type BondRepository struct {
@@ -27,8 +30,9 @@ func (r *BondRepository) Create(ctx context.Context, bond *models.Bond) error {
 			annual_interest_rate, maturity_date, issue_date,
 			active, redeemable,
 			raised_funds_balance, redemption_pool_balance,
+			coin_type, coin_decimals,
 			created_at, updated_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21)
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23)
 		RETURNING id, created_at, updated_at
 	`
 
@@ -54,6 +58,8 @@ func (r *BondRepository) Create(ctx context.Context, bond *models.Bond) error {
 		bond.Redeemable,
 		bond.RaisedFundsBalance,
 		bond.RedemptionPoolBalance,
+		bond.CoinType,
+		bond.CoinDecimals,
 		now,
 		now,
 	).Scan(&bond.ID, &bond.CreatedAt, &bond.UpdatedAt)
@@ -77,6 +83,8 @@ func (r *BondRepository) GetByID(ctx context.Context, id int64) (*models.Bond, e
 		       annual_interest_rate, maturity_date, issue_date,
 		       active, redeemable,
 		       raised_funds_balance, redemption_pool_balance,
+		       coin_type, coin_decimals,
+		       needs_review,
 		       created_at, updated_at, deleted_at
 		FROM bonds
 		WHERE id = $1 AND deleted_at IS NULL
@@ -103,6 +111,9 @@ func (r *BondRepository) GetByID(ctx context.Context, id int64) (*models.Bond, e
 		&bond.Redeemable,
 		&bond.RaisedFundsBalance,
 		&bond.RedemptionPoolBalance,
+		&bond.CoinType,
+		&bond.CoinDecimals,
+		&bond.NeedsReview,
 		&bond.CreatedAt,
 		&bond.UpdatedAt,
 		&bond.DeletedAt,
@@ -130,6 +141,8 @@ func (r *BondRepository) GetByOnChainID(ctx context.Context, onChainID string) (
 		       annual_interest_rate, maturity_date, issue_date,
 		       active, redeemable,
 		       raised_funds_balance, redemption_pool_balance,
+		       coin_type, coin_decimals,
+		       needs_review,
 		       created_at, updated_at, deleted_at
 		FROM bonds
 		WHERE on_chain_id = $1 AND deleted_at IS NULL
@@ -156,6 +169,9 @@ func (r *BondRepository) GetByOnChainID(ctx context.Context, onChainID string) (
 		&bond.Redeemable,
 		&bond.RaisedFundsBalance,
 		&bond.RedemptionPoolBalance,
+		&bond.CoinType,
+		&bond.CoinDecimals,
+		&bond.NeedsReview,
 		&bond.CreatedAt,
 		&bond.UpdatedAt,
 		&bond.DeletedAt,
@@ -181,6 +197,8 @@ func (r *BondRepository) List(ctx context.Context, limit, offset int) ([]*models
 		       annual_interest_rate, maturity_date, issue_date,
 		       active, redeemable,
 		       raised_funds_balance, redemption_pool_balance,
+		       coin_type, coin_decimals,
+		       needs_review,
 		       created_at, updated_at, deleted_at
 		FROM bonds
 		WHERE deleted_at IS NULL
@@ -194,47 +212,39 @@ func (r *BondRepository) List(ctx context.Context, limit, offset int) ([]*models
 	}
 	defer rows.Close()
 
-	var bonds []*models.Bond
-	for rows.Next() {
-		bond := &models.Bond{}
+	return r.scanBonds(rows)
+}
 
-		err := rows.Scan(
-			&bond.ID,
-			&bond.OnChainID,
-			&bond.IssuerAddress,
-			&bond.IssuerName,
-			&bond.BondName,
-			&bond.BondImageUrl,
-			&bond.TokenImageUrl,
-			&bond.MetadataUrl,
-			&bond.TotalAmount,
-			&bond.AmountRaised,
-			&bond.AmountRedeemed,
-			&bond.TokensIssued,
-			&bond.TokensRedeemed,
-			&bond.AnnualInterestRate,
-			&bond.MaturityDate,
-			&bond.IssueDate,
-			&bond.Active,
-			&bond.Redeemable,
-			&bond.RaisedFundsBalance,
-			&bond.RedemptionPoolBalance,
-			&bond.CreatedAt,
-			&bond.UpdatedAt,
-			&bond.DeletedAt,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("failed to scan bond: %w", err)
-		}
+// ListByIssuerAddresses 查詢由任一指定地址發行的債券（地址不分大小寫）
+func (r *BondRepository) ListByIssuerAddresses(ctx context.Context, addresses []string) ([]*models.Bond, error) {
+	query := `
+		SELECT id, on_chain_id, issuer_address, issuer_name, bond_name,
+		       bond_image_url, token_image_url, metadata_url,
+		       total_amount, amount_raised, amount_redeemed,
+		       tokens_issued, tokens_redeemed,
+		       annual_interest_rate, maturity_date, issue_date,
+		       active, redeemable,
+		       raised_funds_balance, redemption_pool_balance,
+		       coin_type, coin_decimals,
+		       needs_review,
+		       created_at, updated_at, deleted_at
+		FROM bonds
+		WHERE LOWER(issuer_address) = ANY($1) AND deleted_at IS NULL
+		ORDER BY created_at DESC
+	`
 
-		bonds = append(bonds, bond)
+	lowered := make([]string, len(addresses))
+	for i, address := range addresses {
+		lowered[i] = strings.ToLower(address)
 	}
 
-	if err = rows.Err(); err != nil {
-		return nil, fmt.Errorf("rows error: %w", err)
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(lowered))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list bonds by issuer addresses: %w", err)
 	}
+	defer rows.Close()
 
-	return bonds, nil
+	return r.scanBonds(rows)
 }
 
 // UpdateStatus 更新債券狀態（active/redeemable）
@@ -340,3 +350,104 @@ func (r *BondRepository) IncrementAmountRedeemed(ctx context.Context, onChainID
 
 	return nil
 }
+
+// scanBonds 掃描債券列表
+func (r *BondRepository) scanBonds(rows *sql.Rows) ([]*models.Bond, error) {
+	var bonds []*models.Bond
+	for rows.Next() {
+		bond := &models.Bond{}
+
+		err := rows.Scan(
+			&bond.ID,
+			&bond.OnChainID,
+			&bond.IssuerAddress,
+			&bond.IssuerName,
+			&bond.BondName,
+			&bond.BondImageUrl,
+			&bond.TokenImageUrl,
+			&bond.MetadataUrl,
+			&bond.TotalAmount,
+			&bond.AmountRaised,
+			&bond.AmountRedeemed,
+			&bond.TokensIssued,
+			&bond.TokensRedeemed,
+			&bond.AnnualInterestRate,
+			&bond.MaturityDate,
+			&bond.IssueDate,
+			&bond.Active,
+			&bond.Redeemable,
+			&bond.RaisedFundsBalance,
+			&bond.RedemptionPoolBalance,
+			&bond.CoinType,
+			&bond.CoinDecimals,
+			&bond.NeedsReview,
+			&bond.CreatedAt,
+			&bond.UpdatedAt,
+			&bond.DeletedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan bond: %w", err)
+		}
+
+		bonds = append(bonds, bond)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return bonds, nil
+}
+
+// ListNeedsReview 查詢被標記需審核的債券（鏈上創建但無已核准申請）
+func (r *BondRepository) ListNeedsReview(ctx context.Context, limit, offset int) ([]*models.Bond, error) {
+	query := `
+		SELECT id, on_chain_id, issuer_address, issuer_name, bond_name,
+		       bond_image_url, token_image_url, metadata_url,
+		       total_amount, amount_raised, amount_redeemed,
+		       tokens_issued, tokens_redeemed,
+		       annual_interest_rate, maturity_date, issue_date,
+		       active, redeemable,
+		       raised_funds_balance, redemption_pool_balance,
+		       coin_type, coin_decimals,
+		       needs_review,
+		       created_at, updated_at, deleted_at
+		FROM bonds
+		WHERE needs_review = true AND deleted_at IS NULL
+		ORDER BY created_at DESC
+		LIMIT $1 OFFSET $2
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list bonds needing review: %w", err)
+	}
+	defer rows.Close()
+
+	return r.scanBonds(rows)
+}
+
+// SetNeedsReview 設定債券的審核標記
+func (r *BondRepository) SetNeedsReview(ctx context.Context, id int64, needsReview bool) error {
+	query := `
+		UPDATE bonds
+		SET needs_review = $1, updated_at = $2
+		WHERE id = $3 AND deleted_at IS NULL
+	`
+
+	result, err := r.db.ExecContext(ctx, query, needsReview, time.Now(), id)
+	if err != nil {
+		return fmt.Errorf("failed to update bond review flag: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("bond not found or already deleted")
+	}
+
+	return nil
+}
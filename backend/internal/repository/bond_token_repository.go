@@ -6,6 +6,8 @@ import (
 	"database/sql"
 	"fmt"
 	"time"
+
+	"github.com/lib/pq"
 )
 
 type BondTokenRepository struct {
@@ -23,8 +25,9 @@ func (r *BondTokenRepository) Create(ctx context.Context, token *models.BondToke
 			on_chain_id, project_id,
 			bond_name, token_image_url, maturity_date, annual_interest_rate,
 			token_number, owner, amount, purchase_date, is_redeemed,
+			coin_type, coin_decimals,
 			created_at, updated_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
 		RETURNING id, created_at, updated_at
 	`
 
@@ -42,6 +45,8 @@ func (r *BondTokenRepository) Create(ctx context.Context, token *models.BondToke
 		token.Amount,
 		token.PurchaseDate,
 		token.IsRedeemed,
+		token.CoinType,
+		token.CoinDecimals,
 		now,
 		now,
 	).Scan(&token.ID, &token.CreatedAt, &token.UpdatedAt)
@@ -61,6 +66,7 @@ func (r *BondTokenRepository) GetByID(ctx context.Context, id int64) (*models.Bo
 		SELECT id, on_chain_id, project_id,
 		       bond_name, token_image_url, maturity_date, annual_interest_rate,
 		       token_number, owner, amount, purchase_date, is_redeemed,
+		       coin_type, coin_decimals,
 		       created_at, updated_at, deleted_at
 		FROM bond_tokens
 		WHERE id = $1 AND deleted_at IS NULL
@@ -79,6 +85,8 @@ func (r *BondTokenRepository) GetByID(ctx context.Context, id int64) (*models.Bo
 		&token.Amount,
 		&token.PurchaseDate,
 		&token.IsRedeemed,
+		&token.CoinType,
+		&token.CoinDecimals,
 		&token.CreatedAt,
 		&token.UpdatedAt,
 		&token.DeletedAt,
@@ -102,6 +110,7 @@ func (r *BondTokenRepository) GetByOnChainID(ctx context.Context, onChainID stri
 		SELECT id, on_chain_id, project_id,
 		       bond_name, token_image_url, maturity_date, annual_interest_rate,
 		       token_number, owner, amount, purchase_date, is_redeemed,
+		       coin_type, coin_decimals,
 		       created_at, updated_at, deleted_at
 		FROM bond_tokens
 		WHERE on_chain_id = $1 AND deleted_at IS NULL
@@ -120,6 +129,8 @@ func (r *BondTokenRepository) GetByOnChainID(ctx context.Context, onChainID stri
 		&token.Amount,
 		&token.PurchaseDate,
 		&token.IsRedeemed,
+		&token.CoinType,
+		&token.CoinDecimals,
 		&token.CreatedAt,
 		&token.UpdatedAt,
 		&token.DeletedAt,
@@ -137,18 +148,24 @@ func (r *BondTokenRepository) GetByOnChainID(ctx context.Context, onChainID stri
 
 // GetByOwner 根據擁有者地址查詢債券代幣
 func (r *BondTokenRepository) GetByOwner(ctx context.Context, owner string, limit, offset int) ([]*models.BondToken, error) {
+	return r.GetByOwners(ctx, []string{owner}, limit, offset)
+}
+
+// GetByOwners 查詢多個擁有者地址（同一使用者的綁定錢包）持有的債券代幣
+func (r *BondTokenRepository) GetByOwners(ctx context.Context, owners []string, limit, offset int) ([]*models.BondToken, error) {
 	query := `
 		SELECT id, on_chain_id, project_id,
 		       bond_name, token_image_url, maturity_date, annual_interest_rate,
 		       token_number, owner, amount, purchase_date, is_redeemed,
+		       coin_type, coin_decimals,
 		       created_at, updated_at, deleted_at
 		FROM bond_tokens
-		WHERE owner = $1 AND deleted_at IS NULL
+		WHERE owner = ANY($1) AND deleted_at IS NULL
 		ORDER BY purchase_date DESC
 		LIMIT $2 OFFSET $3
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, owner, limit, offset)
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(owners), limit, offset)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list bond tokens by owner: %w", err)
 	}
@@ -171,6 +188,8 @@ func (r *BondTokenRepository) GetByOwner(ctx context.Context, owner string, limi
 			&token.Amount,
 			&token.PurchaseDate,
 			&token.IsRedeemed,
+			&token.CoinType,
+			&token.CoinDecimals,
 			&token.CreatedAt,
 			&token.UpdatedAt,
 			&token.DeletedAt,
@@ -195,6 +214,7 @@ func (r *BondTokenRepository) GetByProjectID(ctx context.Context, projectID stri
 		SELECT id, on_chain_id, project_id,
 		       bond_name, token_image_url, maturity_date, annual_interest_rate,
 		       token_number, owner, amount, purchase_date, is_redeemed,
+		       coin_type, coin_decimals,
 		       created_at, updated_at, deleted_at
 		FROM bond_tokens
 		WHERE project_id = $1 AND deleted_at IS NULL
@@ -225,6 +245,65 @@ func (r *BondTokenRepository) GetByProjectID(ctx context.Context, projectID stri
 			&token.Amount,
 			&token.PurchaseDate,
 			&token.IsRedeemed,
+			&token.CoinType,
+			&token.CoinDecimals,
+			&token.CreatedAt,
+			&token.UpdatedAt,
+			&token.DeletedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan bond token: %w", err)
+		}
+
+		tokens = append(tokens, token)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return tokens, nil
+}
+
+// ListActive 查詢尚未贖回的代幣（轉移索引用）
+func (r *BondTokenRepository) ListActive(ctx context.Context, limit, offset int) ([]*models.BondToken, error) {
+	query := `
+		SELECT id, on_chain_id, project_id,
+		       bond_name, token_image_url, maturity_date, annual_interest_rate,
+		       token_number, owner, amount, purchase_date, is_redeemed,
+		       coin_type, coin_decimals,
+		       created_at, updated_at, deleted_at
+		FROM bond_tokens
+		WHERE is_redeemed = false AND deleted_at IS NULL
+		ORDER BY id ASC
+		LIMIT $1 OFFSET $2
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list active bond tokens: %w", err)
+	}
+	defer rows.Close()
+
+	var tokens []*models.BondToken
+	for rows.Next() {
+		token := &models.BondToken{}
+
+		err := rows.Scan(
+			&token.ID,
+			&token.OnChainID,
+			&token.ProjectID,
+			&token.BondName,
+			&token.TokenImageUrl,
+			&token.MaturityDate,
+			&token.AnnualInterestRate,
+			&token.TokenNumber,
+			&token.Owner,
+			&token.Amount,
+			&token.PurchaseDate,
+			&token.IsRedeemed,
+			&token.CoinType,
+			&token.CoinDecimals,
 			&token.CreatedAt,
 			&token.UpdatedAt,
 			&token.DeletedAt,
@@ -243,6 +322,54 @@ func (r *BondTokenRepository) GetByProjectID(ctx context.Context, projectID stri
 	return tokens, nil
 }
 
+// ListHolders 查詢持有未贖回代幣的地址（定期名單比對用）
+func (r *BondTokenRepository) ListHolders(ctx context.Context, limit, offset int) ([]string, error) {
+	query := `
+		SELECT DISTINCT owner
+		FROM bond_tokens
+		WHERE is_redeemed = false AND deleted_at IS NULL
+		ORDER BY owner ASC
+		LIMIT $1 OFFSET $2
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list bond token holders: %w", err)
+	}
+	defer rows.Close()
+
+	var holders []string
+	for rows.Next() {
+		var owner string
+		if err := rows.Scan(&owner); err != nil {
+			return nil, fmt.Errorf("failed to scan bond token holder: %w", err)
+		}
+		holders = append(holders, owner)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return holders, nil
+}
+
+// UpdateOwner 更新代幣持有者
+func (r *BondTokenRepository) UpdateOwner(ctx context.Context, onChainID, owner string) error {
+	query := `
+		UPDATE bond_tokens
+		SET owner = $1, updated_at = $2
+		WHERE on_chain_id = $3 AND deleted_at IS NULL
+	`
+
+	_, err := r.db.ExecContext(ctx, query, owner, time.Now(), onChainID)
+	if err != nil {
+		return fmt.Errorf("failed to update bond token owner: %w", err)
+	}
+
+	return nil
+}
+
 // UpdateRedeemed 更新代幣贖回狀態
 func (r *BondTokenRepository) UpdateRedeemed(ctx context.Context, id int64, isRedeemed bool) error {
 	query := `
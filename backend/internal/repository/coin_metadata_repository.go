@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"bluelink-backend/internal/models"
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+type CoinMetadataRepository struct {
+	db *sql.DB
+}
+
+func NewCoinMetadataRepository(db *sql.DB) *CoinMetadataRepository {
+	return &CoinMetadataRepository{db: db}
+}
+
+// GetByCoinType 根據幣種型別查詢元數據
+func (r *CoinMetadataRepository) GetByCoinType(ctx context.Context, coinType string) (*models.CoinMetadata, error) {
+	meta := &models.CoinMetadata{}
+
+	query := `
+		SELECT coin_type, metadata_id, decimals, symbol, name, description, icon_url, created_at, updated_at
+		FROM coin_metadata
+		WHERE coin_type = $1
+	`
+
+	err := r.db.QueryRowContext(ctx, query, coinType).Scan(
+		&meta.CoinType,
+		&meta.MetadataID,
+		&meta.Decimals,
+		&meta.Symbol,
+		&meta.Name,
+		&meta.Description,
+		&meta.IconUrl,
+		&meta.CreatedAt,
+		&meta.UpdatedAt,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get coin metadata: %w", err)
+	}
+
+	return meta, nil
+}
+
+// Upsert 新增或更新幣種元數據
+func (r *CoinMetadataRepository) Upsert(ctx context.Context, meta *models.CoinMetadata) error {
+	query := `
+		INSERT INTO coin_metadata (coin_type, metadata_id, decimals, symbol, name, description, icon_url, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $8)
+		ON CONFLICT (coin_type) DO UPDATE SET
+			metadata_id = EXCLUDED.metadata_id,
+			decimals = EXCLUDED.decimals,
+			symbol = EXCLUDED.symbol,
+			name = EXCLUDED.name,
+			description = EXCLUDED.description,
+			icon_url = EXCLUDED.icon_url,
+			updated_at = EXCLUDED.updated_at
+		RETURNING created_at, updated_at
+	`
+
+	err := r.db.QueryRowContext(ctx, query,
+		meta.CoinType,
+		meta.MetadataID,
+		meta.Decimals,
+		meta.Symbol,
+		meta.Name,
+		meta.Description,
+		meta.IconUrl,
+		time.Now(),
+	).Scan(&meta.CreatedAt, &meta.UpdatedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to upsert coin metadata: %w", err)
+	}
+
+	return nil
+}
+
+// List 查詢所有已快取的幣種元數據
+func (r *CoinMetadataRepository) List(ctx context.Context) ([]*models.CoinMetadata, error) {
+	query := `
+		SELECT coin_type, metadata_id, decimals, symbol, name, description, icon_url, created_at, updated_at
+		FROM coin_metadata
+		ORDER BY symbol ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list coin metadata: %w", err)
+	}
+	defer rows.Close()
+
+	coins := []*models.CoinMetadata{}
+	for rows.Next() {
+		meta := &models.CoinMetadata{}
+		if err := rows.Scan(
+			&meta.CoinType,
+			&meta.MetadataID,
+			&meta.Decimals,
+			&meta.Symbol,
+			&meta.Name,
+			&meta.Description,
+			&meta.IconUrl,
+			&meta.CreatedAt,
+			&meta.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan coin metadata: %w", err)
+		}
+		coins = append(coins, meta)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return coins, nil
+}
@@ -0,0 +1,417 @@
+package repository
+
+import (
+	"bluelink-backend/internal/models"
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// ImpactRepository 處理債券影響力資料與報告的資料庫操作
+type ImpactRepository struct {
+	db *sql.DB
+}
+
+// NewImpactRepository 建立新的 ImpactRepository
+func NewImpactRepository(db *sql.DB) *ImpactRepository {
+	return &ImpactRepository{db: db}
+}
+
+// UpsertImpact 建立或更新債券影響力資料及 KPI（事務處理）
+// KPI 帶有 ID 時更新既有指標，否則新增；未列出的既有 KPI 保持不變
+func (r *ImpactRepository) UpsertImpact(ctx context.Context, impact *models.BondImpact) error {
+	dbTx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer dbTx.Rollback()
+
+	now := time.Now()
+
+	var location interface{}
+	if len(impact.Location) > 0 {
+		location = []byte(impact.Location)
+	}
+
+	err = dbTx.QueryRowContext(ctx, `
+		INSERT INTO bond_impact (bond_id, category, sdg_targets, location, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $5)
+		ON CONFLICT (bond_id)
+		DO UPDATE SET
+			category = EXCLUDED.category,
+			sdg_targets = EXCLUDED.sdg_targets,
+			location = EXCLUDED.location,
+			updated_at = EXCLUDED.updated_at
+		RETURNING id, created_at, updated_at
+	`,
+		impact.BondID,
+		impact.Category,
+		pq.Array(impact.SDGTargets),
+		location,
+		now,
+	).Scan(&impact.ID, &impact.CreatedAt, &impact.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to upsert bond impact: %w", err)
+	}
+
+	for _, kpi := range impact.KPIs {
+		kpi.BondID = impact.BondID
+
+		if kpi.ID != 0 {
+			result, err := dbTx.ExecContext(ctx, `
+				UPDATE impact_kpis
+				SET name = $1, unit = $2, description = $3, baseline = $4, target = $5, updated_at = $6
+				WHERE id = $7 AND bond_id = $8
+			`, kpi.Name, kpi.Unit, kpi.Description, kpi.Baseline, kpi.Target, now, kpi.ID, impact.BondID)
+			if err != nil {
+				return fmt.Errorf("failed to update impact KPI: %w", err)
+			}
+
+			rows, err := result.RowsAffected()
+			if err != nil {
+				return fmt.Errorf("failed to get affected rows: %w", err)
+			}
+			if rows == 0 {
+				return fmt.Errorf("impact KPI %d not found for bond %d", kpi.ID, impact.BondID)
+			}
+			continue
+		}
+
+		err = dbTx.QueryRowContext(ctx, `
+			INSERT INTO impact_kpis (bond_id, name, unit, description, baseline, target, created_at, updated_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $7)
+			RETURNING id
+		`, impact.BondID, kpi.Name, kpi.Unit, kpi.Description, kpi.Baseline, kpi.Target, now).Scan(&kpi.ID)
+		if err != nil {
+			return fmt.Errorf("failed to create impact KPI: %w", err)
+		}
+	}
+
+	if err := dbTx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// GetByBondID 取得債券影響力資料（含 KPI 與各 KPI 最新報告值）
+func (r *ImpactRepository) GetByBondID(ctx context.Context, bondID int64) (*models.BondImpact, error) {
+	impact := &models.BondImpact{}
+	var sdgTargets pq.Int64Array
+	var location []byte
+
+	err := r.db.QueryRowContext(ctx, `
+		SELECT id, bond_id, category, sdg_targets, location, created_at, updated_at
+		FROM bond_impact
+		WHERE bond_id = $1
+	`, bondID).Scan(
+		&impact.ID,
+		&impact.BondID,
+		&impact.Category,
+		&sdgTargets,
+		&location,
+		&impact.CreatedAt,
+		&impact.UpdatedAt,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bond impact: %w", err)
+	}
+
+	impact.SDGTargets = []int64(sdgTargets)
+	if len(location) > 0 {
+		impact.Location = location
+	}
+
+	impact.KPIs, err = r.ListKPIs(ctx, bondID)
+	if err != nil {
+		return nil, err
+	}
+
+	return impact, nil
+}
+
+// ListKPIs 取得債券所有 KPI 及最新報告值
+func (r *ImpactRepository) ListKPIs(ctx context.Context, bondID int64) ([]*models.ImpactKPI, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT k.id, k.bond_id, k.name, k.unit, k.description, k.baseline, k.target, latest.value
+		FROM impact_kpis k
+		LEFT JOIN LATERAL (
+			SELECT v.value
+			FROM impact_report_values v
+			JOIN impact_reports rep ON rep.id = v.report_id
+			WHERE v.kpi_id = k.id
+			ORDER BY rep.period_end DESC, rep.id DESC
+			LIMIT 1
+		) latest ON true
+		WHERE k.bond_id = $1
+		ORDER BY k.id ASC
+	`, bondID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list impact KPIs: %w", err)
+	}
+	defer rows.Close()
+
+	kpis := []*models.ImpactKPI{}
+	for rows.Next() {
+		kpi := &models.ImpactKPI{}
+		if err := rows.Scan(
+			&kpi.ID,
+			&kpi.BondID,
+			&kpi.Name,
+			&kpi.Unit,
+			&kpi.Description,
+			&kpi.Baseline,
+			&kpi.Target,
+			&kpi.LatestValue,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan impact KPI: %w", err)
+		}
+		kpis = append(kpis, kpi)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return kpis, nil
+}
+
+// CreateReport 建立影響力報告及各 KPI 數值（事務處理）
+func (r *ImpactRepository) CreateReport(ctx context.Context, report *models.ImpactReport) error {
+	dbTx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer dbTx.Rollback()
+
+	err = dbTx.QueryRowContext(ctx, `
+		INSERT INTO impact_reports (bond_id, submitted_by, period_start, period_end, summary, attachment_url, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, created_at
+	`,
+		report.BondID,
+		report.SubmittedBy,
+		report.PeriodStart,
+		report.PeriodEnd,
+		report.Summary,
+		report.AttachmentURL,
+		time.Now(),
+	).Scan(&report.ID, &report.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create impact report: %w", err)
+	}
+
+	for _, v := range report.Values {
+		// 僅允許回報屬於該債券的 KPI
+		result, err := dbTx.ExecContext(ctx, `
+			INSERT INTO impact_report_values (report_id, kpi_id, value, note)
+			SELECT $1, k.id, $3, $4
+			FROM impact_kpis k
+			WHERE k.id = $2 AND k.bond_id = $5
+		`, report.ID, v.KPIID, v.Value, v.Note, report.BondID)
+		if err != nil {
+			return fmt.Errorf("failed to create impact report value: %w", err)
+		}
+
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get affected rows: %w", err)
+		}
+		if rows == 0 {
+			return fmt.Errorf("impact KPI %d does not belong to bond %d", v.KPIID, report.BondID)
+		}
+	}
+
+	if err := dbTx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// ListReports 查詢債券的影響力報告（含數值）
+func (r *ImpactRepository) ListReports(ctx context.Context, bondID int64, limit, offset int) ([]*models.ImpactReport, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, bond_id, submitted_by, period_start, period_end, summary, attachment_url, created_at
+		FROM impact_reports
+		WHERE bond_id = $1
+		ORDER BY period_end DESC, id DESC
+		LIMIT $2 OFFSET $3
+	`, bondID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list impact reports: %w", err)
+	}
+	defer rows.Close()
+
+	reports := []*models.ImpactReport{}
+	byID := make(map[int64]*models.ImpactReport)
+	ids := []int64{}
+	for rows.Next() {
+		report := &models.ImpactReport{Values: []*models.ImpactReportValue{}}
+		if err := rows.Scan(
+			&report.ID,
+			&report.BondID,
+			&report.SubmittedBy,
+			&report.PeriodStart,
+			&report.PeriodEnd,
+			&report.Summary,
+			&report.AttachmentURL,
+			&report.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan impact report: %w", err)
+		}
+		reports = append(reports, report)
+		byID[report.ID] = report
+		ids = append(ids, report.ID)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	if len(ids) == 0 {
+		return reports, nil
+	}
+
+	valueRows, err := r.db.QueryContext(ctx, `
+		SELECT report_id, kpi_id, value, note
+		FROM impact_report_values
+		WHERE report_id = ANY($1)
+		ORDER BY kpi_id ASC
+	`, pq.Array(ids))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list impact report values: %w", err)
+	}
+	defer valueRows.Close()
+
+	for valueRows.Next() {
+		var reportID int64
+		v := &models.ImpactReportValue{}
+		if err := valueRows.Scan(&reportID, &v.KPIID, &v.Value, &v.Note); err != nil {
+			return nil, fmt.Errorf("failed to scan impact report value: %w", err)
+		}
+		if report, ok := byID[reportID]; ok {
+			report.Values = append(report.Values, v)
+		}
+	}
+
+	if err := valueRows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return reports, nil
+}
+
+// GetPlatformImpact 彙總全平台影響力資料
+func (r *ImpactRepository) GetPlatformImpact(ctx context.Context) (*models.PlatformImpact, error) {
+	summary := &models.PlatformImpact{
+		ByCategory: []*models.CategoryImpact{},
+		BySDG:      []*models.SDGImpact{},
+		KPITotals:  []*models.KPIImpactSummary{},
+	}
+
+	// 1. 依類別彙總（募集金額依計價幣種分開加總）
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT bi.category, b.coin_type, COUNT(*), COALESCE(SUM(b.amount_raised), 0)
+		FROM bond_impact bi
+		JOIN bonds b ON b.id = bi.bond_id
+		WHERE b.deleted_at IS NULL
+		GROUP BY bi.category, b.coin_type
+		ORDER BY SUM(COUNT(*)) OVER (PARTITION BY bi.category) DESC, bi.category ASC
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate impact by category: %w", err)
+	}
+	defer rows.Close()
+
+	byCategory := make(map[string]*models.CategoryImpact)
+	for rows.Next() {
+		var category, coinType string
+		var bondCount, amountRaised int64
+		if err := rows.Scan(&category, &coinType, &bondCount, &amountRaised); err != nil {
+			return nil, fmt.Errorf("failed to scan category impact: %w", err)
+		}
+
+		c, ok := byCategory[category]
+		if !ok {
+			c = &models.CategoryImpact{Category: category, AmountRaised: map[string]int64{}}
+			byCategory[category] = c
+			summary.ByCategory = append(summary.ByCategory, c)
+		}
+		c.BondCount += bondCount
+		c.AmountRaised[coinType] = amountRaised
+		summary.BondCount += bondCount
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	// 2. 依 SDG 彙總
+	sdgRows, err := r.db.QueryContext(ctx, `
+		SELECT sdg, COUNT(DISTINCT bi.bond_id)
+		FROM bond_impact bi
+		JOIN bonds b ON b.id = bi.bond_id
+		CROSS JOIN LATERAL unnest(bi.sdg_targets) AS sdg
+		WHERE b.deleted_at IS NULL
+		GROUP BY sdg
+		ORDER BY sdg ASC
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate impact by SDG: %w", err)
+	}
+	defer sdgRows.Close()
+
+	for sdgRows.Next() {
+		s := &models.SDGImpact{}
+		if err := sdgRows.Scan(&s.SDG, &s.BondCount); err != nil {
+			return nil, fmt.Errorf("failed to scan SDG impact: %w", err)
+		}
+		summary.BySDG = append(summary.BySDG, s)
+	}
+	if err := sdgRows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	// 3. 相同名稱與單位的 KPI 加總（每個 KPI 取最新報告值）
+	kpiRows, err := r.db.QueryContext(ctx, `
+		SELECT MIN(k.name), k.unit, COUNT(DISTINCT k.bond_id),
+		       COALESCE(SUM(k.baseline), 0), COALESCE(SUM(k.target), 0), COALESCE(SUM(latest.value), 0)
+		FROM impact_kpis k
+		JOIN bonds b ON b.id = k.bond_id
+		LEFT JOIN LATERAL (
+			SELECT v.value
+			FROM impact_report_values v
+			JOIN impact_reports rep ON rep.id = v.report_id
+			WHERE v.kpi_id = k.id
+			ORDER BY rep.period_end DESC, rep.id DESC
+			LIMIT 1
+		) latest ON true
+		WHERE b.deleted_at IS NULL
+		GROUP BY LOWER(k.name), k.unit
+		ORDER BY COUNT(DISTINCT k.bond_id) DESC, MIN(k.name) ASC
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate KPI totals: %w", err)
+	}
+	defer kpiRows.Close()
+
+	for kpiRows.Next() {
+		k := &models.KPIImpactSummary{}
+		if err := kpiRows.Scan(&k.Name, &k.Unit, &k.BondCount, &k.Baseline, &k.Target, &k.Achieved); err != nil {
+			return nil, fmt.Errorf("failed to scan KPI total: %w", err)
+		}
+		summary.KPITotals = append(summary.KPITotals, k)
+	}
+	if err := kpiRows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return summary, nil
+}
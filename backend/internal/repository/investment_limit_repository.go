@@ -0,0 +1,220 @@
+package repository
+
+import (
+	"bluelink-backend/internal/models"
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// InvestmentLimitRepository 處理投資額度統計與限額違規警示的資料庫操作
+type InvestmentLimitRepository struct {
+	db *sql.DB
+}
+
+// NewInvestmentLimitRepository 建立新的 InvestmentLimitRepository
+func NewInvestmentLimitRepository(db *sql.DB) *InvestmentLimitRepository {
+	return &InvestmentLimitRepository{db: db}
+}
+
+// SumInvestedByCoin 統計使用者自 since 起的投資金額（一級市場購買與二級市場買入），依幣種分組（最小單位）
+func (r *InvestmentLimitRepository) SumInvestedByCoin(ctx context.Context, userID int64, since time.Time) (map[string]int64, error) {
+	query := `
+		SELECT b.coin_type, COALESCE(SUM(t.amount), 0)::BIGINT
+		FROM transactions t
+		JOIN bonds b ON b.id = t.bond_id
+		WHERE t.user_id = $1
+		  AND t.timestamp >= $2
+		  AND t.status = $3
+		  AND t.amount IS NOT NULL
+		  AND (t.event_type = $4
+		       OR (t.event_type = $5 AND t.metadata->>'source' = 'secondary_market'))
+		GROUP BY b.coin_type
+	`
+
+	rows, err := r.db.QueryContext(ctx, query,
+		userID,
+		since,
+		models.TxStatusConfirmed,
+		models.EventBondPurchased,
+		models.EventBondTransferred,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sum invested amounts: %w", err)
+	}
+	defer rows.Close()
+
+	totals := make(map[string]int64)
+	for rows.Next() {
+		var coinType string
+		var total int64
+		if err := rows.Scan(&coinType, &total); err != nil {
+			return nil, fmt.Errorf("failed to scan invested amount: %w", err)
+		}
+		totals[coinType] = total
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return totals, nil
+}
+
+// GetHeldFaceValue 查詢地址及該使用者所有綁定錢包持有某債券未贖回代幣的面額總和
+func (r *InvestmentLimitRepository) GetHeldFaceValue(ctx context.Context, userID int64, owner, projectID string) (int64, error) {
+	query := `
+		SELECT COALESCE(SUM(amount), 0)
+		FROM bond_tokens
+		WHERE (owner = $1
+		       OR owner IN (SELECT wallet_address FROM users WHERE id = $3)
+		       OR owner IN (SELECT wallet_address FROM user_wallets WHERE user_id = $3))
+		  AND project_id = $2 AND is_redeemed = false AND deleted_at IS NULL
+	`
+
+	var total int64
+	if err := r.db.QueryRowContext(ctx, query, owner, projectID, userID).Scan(&total); err != nil {
+		return 0, fmt.Errorf("failed to get held face value: %w", err)
+	}
+
+	return total, nil
+}
+
+// CreateAlert 建立限額違規警示；同一筆交易的同一類違規只建立一次
+// 回傳 false 表示已存在
+func (r *InvestmentLimitRepository) CreateAlert(ctx context.Context, alert *models.ComplianceAlert) (bool, error) {
+	query := `
+		INSERT INTO compliance_alerts (
+			user_id, wallet_address, tx_hash, bond_id, alert_type,
+			limit_value, actual_value, currency, status, created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		ON CONFLICT (tx_hash, wallet_address, alert_type) DO NOTHING
+		RETURNING id, created_at
+	`
+
+	err := r.db.QueryRowContext(ctx, query,
+		alert.UserID,
+		alert.WalletAddress,
+		alert.TxHash,
+		alert.BondID,
+		alert.AlertType,
+		alert.LimitValue,
+		alert.ActualValue,
+		alert.Currency,
+		alert.Status,
+		time.Now(),
+	).Scan(&alert.ID, &alert.CreatedAt)
+
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to create compliance alert: %w", err)
+	}
+
+	return true, nil
+}
+
+// GetAlertByID 根據 ID 查詢限額違規警示
+func (r *InvestmentLimitRepository) GetAlertByID(ctx context.Context, id int64) (*models.ComplianceAlert, error) {
+	query := `SELECT ` + complianceAlertColumns + `
+		FROM compliance_alerts
+		WHERE id = $1
+	`
+
+	alert, err := scanComplianceAlert(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get compliance alert: %w", err)
+	}
+
+	return alert, nil
+}
+
+// ListAlerts 根據狀態查詢限額違規警示；status 為空時回傳全部
+func (r *InvestmentLimitRepository) ListAlerts(ctx context.Context, status string, limit, offset int) ([]*models.ComplianceAlert, error) {
+	query := `SELECT ` + complianceAlertColumns + `
+		FROM compliance_alerts
+		WHERE ($1 = '' OR status = $1)
+		ORDER BY created_at ASC
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, status, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list compliance alerts: %w", err)
+	}
+	defer rows.Close()
+
+	alerts := []*models.ComplianceAlert{}
+	for rows.Next() {
+		alert, err := scanComplianceAlert(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan compliance alert: %w", err)
+		}
+		alerts = append(alerts, alert)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return alerts, nil
+}
+
+// ResolveAlert 結案限額違規警示（open → resolved）
+func (r *InvestmentLimitRepository) ResolveAlert(ctx context.Context, id, resolvedBy int64, note string) error {
+	query := `
+		UPDATE compliance_alerts
+		SET status = 'resolved', resolution_note = $1, resolved_by = $2, resolved_at = $3
+		WHERE id = $4 AND status = 'open'
+	`
+
+	result, err := r.db.ExecContext(ctx, query, note, resolvedBy, time.Now(), id)
+	if err != nil {
+		return fmt.Errorf("failed to resolve compliance alert: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("compliance alert not found or already resolved")
+	}
+
+	return nil
+}
+
+const complianceAlertColumns = `
+	id, user_id, wallet_address, tx_hash, bond_id, alert_type, limit_value, actual_value,
+	currency, status, resolution_note, resolved_by, resolved_at, created_at
+`
+
+func scanComplianceAlert(row rowScanner) (*models.ComplianceAlert, error) {
+	alert := &models.ComplianceAlert{}
+	err := row.Scan(
+		&alert.ID,
+		&alert.UserID,
+		&alert.WalletAddress,
+		&alert.TxHash,
+		&alert.BondID,
+		&alert.AlertType,
+		&alert.LimitValue,
+		&alert.ActualValue,
+		&alert.Currency,
+		&alert.Status,
+		&alert.ResolutionNote,
+		&alert.ResolvedBy,
+		&alert.ResolvedAt,
+		&alert.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return alert, nil
+}
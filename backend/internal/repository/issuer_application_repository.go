@@ -0,0 +1,438 @@
+package repository
+
+import (
+	"bluelink-backend/internal/models"
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// IssuerApplicationRepository 處理發行者升級申請與附件的資料庫操作
+type IssuerApplicationRepository struct {
+	db *sql.DB
+}
+
+// NewIssuerApplicationRepository 建立新的 IssuerApplicationRepository
+func NewIssuerApplicationRepository(db *sql.DB) *IssuerApplicationRepository {
+	return &IssuerApplicationRepository{db: db}
+}
+
+const issuerApplicationColumns = `
+	id, user_id, wallet_address,
+	institution_name, registration_number, contact_name, contact_email, contact_phone,
+	status, review_comment, reviewed_by, reviewed_at, submitted_at,
+	created_at, updated_at
+`
+
+const issuerDocumentColumns = `
+	id, application_id, doc_type, file_name, content_type,
+	size_bytes, storage_key, sha256, uploaded_at
+`
+
+// Create 建立新的發行者申請（草稿狀態）
+func (r *IssuerApplicationRepository) Create(ctx context.Context, app *models.IssuerApplication) error {
+	query := `
+		INSERT INTO issuer_applications (
+			user_id, wallet_address,
+			institution_name, registration_number, contact_name, contact_email, contact_phone,
+			status, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING id, created_at, updated_at
+	`
+
+	now := time.Now()
+	err := r.db.QueryRowContext(ctx, query,
+		app.UserID,
+		app.WalletAddress,
+		app.InstitutionName,
+		app.RegistrationNumber,
+		app.ContactName,
+		app.ContactEmail,
+		app.ContactPhone,
+		app.Status,
+		now,
+		now,
+	).Scan(&app.ID, &app.CreatedAt, &app.UpdatedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to create issuer application: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID 根據 ID 查詢發行者申請
+func (r *IssuerApplicationRepository) GetByID(ctx context.Context, id int64) (*models.IssuerApplication, error) {
+	query := `SELECT ` + issuerApplicationColumns + `
+		FROM issuer_applications
+		WHERE id = $1
+	`
+
+	app, err := scanIssuerApplication(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get issuer application by ID: %w", err)
+	}
+
+	return app, nil
+}
+
+// GetOpen 查詢使用者進行中（草稿或審核中）的申請
+func (r *IssuerApplicationRepository) GetOpen(ctx context.Context, userID int64) (*models.IssuerApplication, error) {
+	query := `SELECT ` + issuerApplicationColumns + `
+		FROM issuer_applications
+		WHERE user_id = $1 AND status IN ('draft', 'pending')
+	`
+
+	app, err := scanIssuerApplication(r.db.QueryRowContext(ctx, query, userID))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get open issuer application: %w", err)
+	}
+
+	return app, nil
+}
+
+// ListByUser 查詢使用者的所有申請（含歷史紀錄）
+func (r *IssuerApplicationRepository) ListByUser(ctx context.Context, userID int64) ([]*models.IssuerApplication, error) {
+	query := `SELECT ` + issuerApplicationColumns + `
+		FROM issuer_applications
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list issuer applications by user: %w", err)
+	}
+	defer rows.Close()
+
+	return r.scanIssuerApplications(rows)
+}
+
+// ListByStatus 根據狀態查詢申請（管理員審核用）；status 為空時回傳全部
+func (r *IssuerApplicationRepository) ListByStatus(ctx context.Context, status string, limit, offset int) ([]*models.IssuerApplication, error) {
+	query := `SELECT ` + issuerApplicationColumns + `
+		FROM issuer_applications
+		WHERE ($1 = '' OR status = $1)
+		ORDER BY COALESCE(submitted_at, created_at) ASC
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, status, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list issuer applications by status: %w", err)
+	}
+	defer rows.Close()
+
+	return r.scanIssuerApplications(rows)
+}
+
+// Update 更新機構與聯絡人資料（僅限草稿）
+func (r *IssuerApplicationRepository) Update(ctx context.Context, app *models.IssuerApplication) error {
+	query := `
+		UPDATE issuer_applications
+		SET institution_name = $1, registration_number = $2, contact_name = $3,
+		    contact_email = $4, contact_phone = $5, updated_at = $6
+		WHERE id = $7 AND status = 'draft'
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		app.InstitutionName,
+		app.RegistrationNumber,
+		app.ContactName,
+		app.ContactEmail,
+		app.ContactPhone,
+		time.Now(),
+		app.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update issuer application: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("issuer application not found or not editable")
+	}
+
+	return nil
+}
+
+// Submit 送出申請（draft → pending）
+func (r *IssuerApplicationRepository) Submit(ctx context.Context, id int64) error {
+	query := `
+		UPDATE issuer_applications
+		SET status = 'pending', submitted_at = $1, updated_at = $1
+		WHERE id = $2 AND status = 'draft'
+	`
+
+	result, err := r.db.ExecContext(ctx, query, time.Now(), id)
+	if err != nil {
+		return fmt.Errorf("failed to submit issuer application: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("issuer application not found or not in a submittable state")
+	}
+
+	return nil
+}
+
+// Approve 核准申請並將使用者的主要角色由 fromRole 升級為 toRole（事務處理）
+func (r *IssuerApplicationRepository) Approve(ctx context.Context, app *models.IssuerApplication, reviewerID int64, comment *string, fromRole, toRole string) error {
+	dbTx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer dbTx.Rollback()
+
+	now := time.Now()
+
+	result, err := dbTx.ExecContext(ctx, `
+		UPDATE issuer_applications
+		SET status = 'approved', review_comment = $1, reviewed_by = $2, reviewed_at = $3, updated_at = $3
+		WHERE id = $4 AND status = 'pending'
+	`, comment, reviewerID, now, app.ID)
+	if err != nil {
+		return fmt.Errorf("failed to approve issuer application: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("issuer application not found or not awaiting review")
+	}
+
+	// 僅升級角色仍為 fromRole 的使用者，避免覆寫期間被管理員變更的角色
+	result, err = dbTx.ExecContext(ctx, `
+		UPDATE users
+		SET role = $1, institution_name = $2, updated_at = $3
+		WHERE id = $4 AND role = $5 AND deleted_at IS NULL
+	`, toRole, app.InstitutionName, now, app.UserID, fromRole)
+	if err != nil {
+		return fmt.Errorf("failed to upgrade user role: %w", err)
+	}
+
+	rows, err = result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("user not found or role changed during review")
+	}
+
+	if err := dbTx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// Deny 駁回申請（pending → denied）
+func (r *IssuerApplicationRepository) Deny(ctx context.Context, id, reviewerID int64, comment string) error {
+	query := `
+		UPDATE issuer_applications
+		SET status = 'denied', review_comment = $1, reviewed_by = $2, reviewed_at = $3, updated_at = $3
+		WHERE id = $4 AND status = 'pending'
+	`
+
+	result, err := r.db.ExecContext(ctx, query, comment, reviewerID, time.Now(), id)
+	if err != nil {
+		return fmt.Errorf("failed to deny issuer application: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("issuer application not found or not awaiting review")
+	}
+
+	return nil
+}
+
+// CreateDocument 新增申請附件記錄
+func (r *IssuerApplicationRepository) CreateDocument(ctx context.Context, doc *models.IssuerApplicationDocument) error {
+	query := `
+		INSERT INTO issuer_application_documents (
+			application_id, doc_type, file_name, content_type,
+			size_bytes, storage_key, sha256, uploaded_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id, uploaded_at
+	`
+
+	err := r.db.QueryRowContext(ctx, query,
+		doc.ApplicationID,
+		doc.DocType,
+		doc.FileName,
+		doc.ContentType,
+		doc.SizeBytes,
+		doc.StorageKey,
+		doc.SHA256,
+		time.Now(),
+	).Scan(&doc.ID, &doc.UploadedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to create issuer application document: %w", err)
+	}
+
+	return nil
+}
+
+// GetDocument 查詢申請的單一附件
+func (r *IssuerApplicationRepository) GetDocument(ctx context.Context, applicationID, documentID int64) (*models.IssuerApplicationDocument, error) {
+	query := `SELECT ` + issuerDocumentColumns + `
+		FROM issuer_application_documents
+		WHERE id = $1 AND application_id = $2
+	`
+
+	doc, err := scanIssuerDocument(r.db.QueryRowContext(ctx, query, documentID, applicationID))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get issuer application document: %w", err)
+	}
+
+	return doc, nil
+}
+
+// ListDocuments 查詢申請的所有附件
+func (r *IssuerApplicationRepository) ListDocuments(ctx context.Context, applicationID int64) ([]*models.IssuerApplicationDocument, error) {
+	query := `SELECT ` + issuerDocumentColumns + `
+		FROM issuer_application_documents
+		WHERE application_id = $1
+		ORDER BY uploaded_at ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, applicationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list issuer application documents: %w", err)
+	}
+	defer rows.Close()
+
+	docs := []*models.IssuerApplicationDocument{}
+	for rows.Next() {
+		doc, err := scanIssuerDocument(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan issuer application document: %w", err)
+		}
+		docs = append(docs, doc)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return docs, nil
+}
+
+// DeleteDocument 刪除附件記錄（僅限草稿申請）
+func (r *IssuerApplicationRepository) DeleteDocument(ctx context.Context, applicationID, documentID int64) error {
+	query := `
+		DELETE FROM issuer_application_documents
+		WHERE id = $1 AND application_id = $2
+		  AND EXISTS (SELECT 1 FROM issuer_applications WHERE id = $2 AND status = 'draft')
+	`
+
+	result, err := r.db.ExecContext(ctx, query, documentID, applicationID)
+	if err != nil {
+		return fmt.Errorf("failed to delete issuer application document: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("issuer application document not found or not deletable")
+	}
+
+	return nil
+}
+
+// scanIssuerApplications 掃描申請列表
+func (r *IssuerApplicationRepository) scanIssuerApplications(rows *sql.Rows) ([]*models.IssuerApplication, error) {
+	apps := []*models.IssuerApplication{}
+
+	for rows.Next() {
+		app, err := scanIssuerApplication(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan issuer application: %w", err)
+		}
+		apps = append(apps, app)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return apps, nil
+}
+
+func scanIssuerApplication(row rowScanner) (*models.IssuerApplication, error) {
+	app := &models.IssuerApplication{}
+
+	err := row.Scan(
+		&app.ID,
+		&app.UserID,
+		&app.WalletAddress,
+		&app.InstitutionName,
+		&app.RegistrationNumber,
+		&app.ContactName,
+		&app.ContactEmail,
+		&app.ContactPhone,
+		&app.Status,
+		&app.ReviewComment,
+		&app.ReviewedBy,
+		&app.ReviewedAt,
+		&app.SubmittedAt,
+		&app.CreatedAt,
+		&app.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return app, nil
+}
+
+func scanIssuerDocument(row rowScanner) (*models.IssuerApplicationDocument, error) {
+	doc := &models.IssuerApplicationDocument{}
+
+	err := row.Scan(
+		&doc.ID,
+		&doc.ApplicationID,
+		&doc.DocType,
+		&doc.FileName,
+		&doc.ContentType,
+		&doc.SizeBytes,
+		&doc.StorageKey,
+		&doc.SHA256,
+		&doc.UploadedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return doc, nil
+}
@@ -0,0 +1,395 @@
+package repository
+
+import (
+	"bluelink-backend/internal/models"
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// KYCRepository 處理 KYC 申請與文件的資料庫操作
+type KYCRepository struct {
+	db *sql.DB
+}
+
+// NewKYCRepository 建立新的 KYCRepository
+func NewKYCRepository(db *sql.DB) *KYCRepository {
+	return &KYCRepository{db: db}
+}
+
+const kycApplicationColumns = `
+	id, user_id, wallet_address, requested_level,
+	full_name, date_of_birth, country, address,
+	status, approved_level, review_comment, reviewed_by, reviewed_at, submitted_at,
+	created_at, updated_at
+`
+
+// CreateApplication 建立新的 KYC 申請（草稿狀態）
+func (r *KYCRepository) CreateApplication(ctx context.Context, app *models.KYCApplication) error {
+	query := `
+		INSERT INTO kyc_applications (
+			user_id, wallet_address, requested_level,
+			full_name, date_of_birth, country, address,
+			status, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING id, created_at, updated_at
+	`
+
+	now := time.Now()
+	err := r.db.QueryRowContext(ctx, query,
+		app.UserID,
+		app.WalletAddress,
+		app.RequestedLevel,
+		app.FullName,
+		app.DateOfBirth,
+		app.Country,
+		app.Address,
+		app.Status,
+		now,
+		now,
+	).Scan(&app.ID, &app.CreatedAt, &app.UpdatedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to create KYC application: %w", err)
+	}
+
+	return nil
+}
+
+// GetApplicationByID 根據 ID 查詢 KYC 申請
+func (r *KYCRepository) GetApplicationByID(ctx context.Context, id int64) (*models.KYCApplication, error) {
+	query := `SELECT ` + kycApplicationColumns + `
+		FROM kyc_applications
+		WHERE id = $1
+	`
+
+	app, err := scanKYCApplication(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get KYC application by ID: %w", err)
+	}
+
+	return app, nil
+}
+
+// GetOpenApplication 查詢使用者進行中（草稿或審核中）的申請
+func (r *KYCRepository) GetOpenApplication(ctx context.Context, userID int64) (*models.KYCApplication, error) {
+	query := `SELECT ` + kycApplicationColumns + `
+		FROM kyc_applications
+		WHERE user_id = $1 AND status IN ('draft', 'pending')
+	`
+
+	app, err := scanKYCApplication(r.db.QueryRowContext(ctx, query, userID))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get open KYC application: %w", err)
+	}
+
+	return app, nil
+}
+
+// ListByUser 查詢使用者的所有申請
+func (r *KYCRepository) ListByUser(ctx context.Context, userID int64) ([]*models.KYCApplication, error) {
+	query := `SELECT ` + kycApplicationColumns + `
+		FROM kyc_applications
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list KYC applications by user: %w", err)
+	}
+	defer rows.Close()
+
+	return r.scanKYCApplications(rows)
+}
+
+// ListByStatus 根據狀態查詢申請（管理員審核用）；status 為空時回傳全部
+func (r *KYCRepository) ListByStatus(ctx context.Context, status string, limit, offset int) ([]*models.KYCApplication, error) {
+	query := `SELECT ` + kycApplicationColumns + `
+		FROM kyc_applications
+		WHERE ($1 = '' OR status = $1)
+		ORDER BY COALESCE(submitted_at, created_at) ASC
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, status, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list KYC applications by status: %w", err)
+	}
+	defer rows.Close()
+
+	return r.scanKYCApplications(rows)
+}
+
+// UpdateApplication 更新申請人資料（僅限草稿）
+func (r *KYCRepository) UpdateApplication(ctx context.Context, app *models.KYCApplication) error {
+	query := `
+		UPDATE kyc_applications
+		SET requested_level = $1, full_name = $2, date_of_birth = $3,
+		    country = $4, address = $5, updated_at = $6
+		WHERE id = $7 AND status = 'draft'
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		app.RequestedLevel,
+		app.FullName,
+		app.DateOfBirth,
+		app.Country,
+		app.Address,
+		time.Now(),
+		app.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update KYC application: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("KYC application not found or not editable")
+	}
+
+	return nil
+}
+
+// Submit 送出申請（draft → pending）
+func (r *KYCRepository) Submit(ctx context.Context, id int64) error {
+	query := `
+		UPDATE kyc_applications
+		SET status = 'pending', submitted_at = $1, updated_at = $1
+		WHERE id = $2 AND status = 'draft'
+	`
+
+	result, err := r.db.ExecContext(ctx, query, time.Now(), id)
+	if err != nil {
+		return fmt.Errorf("failed to submit KYC application: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("KYC application not found or not in a submittable state")
+	}
+
+	return nil
+}
+
+// Review 審核申請（pending → approved/rejected）
+func (r *KYCRepository) Review(ctx context.Context, id int64, status string, approvedLevel *int, reviewerID int64, comment *string) error {
+	query := `
+		UPDATE kyc_applications
+		SET status = $1, approved_level = $2, review_comment = $3,
+		    reviewed_by = $4, reviewed_at = $5, updated_at = $5
+		WHERE id = $6 AND status = 'pending'
+	`
+
+	result, err := r.db.ExecContext(ctx, query, status, approvedLevel, comment, reviewerID, time.Now(), id)
+	if err != nil {
+		return fmt.Errorf("failed to review KYC application: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("KYC application not found or not awaiting review")
+	}
+
+	return nil
+}
+
+// CreateDocument 新增申請附件記錄
+func (r *KYCRepository) CreateDocument(ctx context.Context, doc *models.KYCDocument) error {
+	query := `
+		INSERT INTO kyc_documents (
+			application_id, doc_type, file_name, content_type,
+			size_bytes, storage_key, sha256, uploaded_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id, uploaded_at
+	`
+
+	err := r.db.QueryRowContext(ctx, query,
+		doc.ApplicationID,
+		doc.DocType,
+		doc.FileName,
+		doc.ContentType,
+		doc.SizeBytes,
+		doc.StorageKey,
+		doc.SHA256,
+		time.Now(),
+	).Scan(&doc.ID, &doc.UploadedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to create KYC document: %w", err)
+	}
+
+	return nil
+}
+
+// GetDocument 查詢申請的單一附件
+func (r *KYCRepository) GetDocument(ctx context.Context, applicationID, documentID int64) (*models.KYCDocument, error) {
+	query := `
+		SELECT id, application_id, doc_type, file_name, content_type,
+		       size_bytes, storage_key, sha256, uploaded_at
+		FROM kyc_documents
+		WHERE id = $1 AND application_id = $2
+	`
+
+	doc := &models.KYCDocument{}
+	err := r.db.QueryRowContext(ctx, query, documentID, applicationID).Scan(
+		&doc.ID,
+		&doc.ApplicationID,
+		&doc.DocType,
+		&doc.FileName,
+		&doc.ContentType,
+		&doc.SizeBytes,
+		&doc.StorageKey,
+		&doc.SHA256,
+		&doc.UploadedAt,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get KYC document: %w", err)
+	}
+
+	return doc, nil
+}
+
+// ListDocuments 查詢申請的所有附件
+func (r *KYCRepository) ListDocuments(ctx context.Context, applicationID int64) ([]*models.KYCDocument, error) {
+	query := `
+		SELECT id, application_id, doc_type, file_name, content_type,
+		       size_bytes, storage_key, sha256, uploaded_at
+		FROM kyc_documents
+		WHERE application_id = $1
+		ORDER BY uploaded_at ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, applicationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list KYC documents: %w", err)
+	}
+	defer rows.Close()
+
+	docs := []*models.KYCDocument{}
+	for rows.Next() {
+		doc := &models.KYCDocument{}
+		err := rows.Scan(
+			&doc.ID,
+			&doc.ApplicationID,
+			&doc.DocType,
+			&doc.FileName,
+			&doc.ContentType,
+			&doc.SizeBytes,
+			&doc.StorageKey,
+			&doc.SHA256,
+			&doc.UploadedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan KYC document: %w", err)
+		}
+		docs = append(docs, doc)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return docs, nil
+}
+
+// DeleteDocument 刪除附件記錄（僅限草稿申請）
+func (r *KYCRepository) DeleteDocument(ctx context.Context, applicationID, documentID int64) error {
+	query := `
+		DELETE FROM kyc_documents
+		WHERE id = $1 AND application_id = $2
+		  AND EXISTS (SELECT 1 FROM kyc_applications WHERE id = $2 AND status = 'draft')
+	`
+
+	result, err := r.db.ExecContext(ctx, query, documentID, applicationID)
+	if err != nil {
+		return fmt.Errorf("failed to delete KYC document: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("KYC document not found or not deletable")
+	}
+
+	return nil
+}
+
+// scanKYCApplications 掃描申請列表
+func (r *KYCRepository) scanKYCApplications(rows *sql.Rows) ([]*models.KYCApplication, error) {
+	apps := []*models.KYCApplication{}
+
+	for rows.Next() {
+		app, err := scanKYCApplication(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan KYC application: %w", err)
+		}
+		apps = append(apps, app)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return apps, nil
+}
+
+func scanKYCApplication(row rowScanner) (*models.KYCApplication, error) {
+	app := &models.KYCApplication{}
+	var approvedLevel sql.NullInt64
+
+	err := row.Scan(
+		&app.ID,
+		&app.UserID,
+		&app.WalletAddress,
+		&app.RequestedLevel,
+		&app.FullName,
+		&app.DateOfBirth,
+		&app.Country,
+		&app.Address,
+		&app.Status,
+		&approvedLevel,
+		&app.ReviewComment,
+		&app.ReviewedBy,
+		&app.ReviewedAt,
+		&app.SubmittedAt,
+		&app.CreatedAt,
+		&app.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if approvedLevel.Valid {
+		level := int(approvedLevel.Int64)
+		app.ApprovedLevel = &level
+	}
+
+	return app, nil
+}
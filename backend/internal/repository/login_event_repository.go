@@ -0,0 +1,169 @@
+package repository
+
+import (
+	"bluelink-backend/internal/models"
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// LoginEventRepository 處理登入紀錄的資料庫操作（僅新增與查詢）
+type LoginEventRepository struct {
+	db *sql.DB
+}
+
+// NewLoginEventRepository 建立新的 LoginEventRepository
+func NewLoginEventRepository(db *sql.DB) *LoginEventRepository {
+	return &LoginEventRepository{db: db}
+}
+
+const loginEventColumns = `id, user_id, wallet_address, success, failure_reason, ip_address, ip_network,
+	country, user_agent, device, session_ref, created_at`
+
+// Create 新增登入紀錄
+func (r *LoginEventRepository) Create(ctx context.Context, event *models.LoginEvent) error {
+	query := `
+		INSERT INTO login_events (
+			user_id, wallet_address, success, failure_reason, ip_address, ip_network,
+			country, user_agent, device, session_ref, created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		RETURNING id, created_at
+	`
+
+	err := r.db.QueryRowContext(ctx, query,
+		event.UserID,
+		event.WalletAddress,
+		event.Success,
+		event.FailureReason,
+		event.IPAddress,
+		event.IPNetwork,
+		event.Country,
+		event.UserAgent,
+		event.Device,
+		event.SessionRef,
+		time.Now(),
+	).Scan(&event.ID, &event.CreatedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to create login event: %w", err)
+	}
+
+	return nil
+}
+
+// ListByUser 查詢使用者的登入紀錄（新到舊），回傳紀錄與總筆數
+func (r *LoginEventRepository) ListByUser(ctx context.Context, userID int64, limit, offset int) ([]*models.LoginEvent, int, error) {
+	query := `SELECT ` + loginEventColumns + `, COUNT(*) OVER()
+		FROM login_events
+		WHERE user_id = $1
+		ORDER BY created_at DESC, id DESC
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list login events: %w", err)
+	}
+	defer rows.Close()
+
+	events := []*models.LoginEvent{}
+	total := 0
+	for rows.Next() {
+		event := &models.LoginEvent{}
+		if err := rows.Scan(append(loginEventFields(event), &total)...); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan login event: %w", err)
+		}
+		events = append(events, event)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("rows error: %w", err)
+	}
+
+	return events, total, nil
+}
+
+// CountFailuresSince 計算錢包地址自指定時間起的登入失敗次數
+func (r *LoginEventRepository) CountFailuresSince(ctx context.Context, walletAddress string, since time.Time) (int, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM login_events
+		WHERE wallet_address = $1 AND success = FALSE AND created_at >= $2
+	`
+
+	var count int
+	if err := r.db.QueryRowContext(ctx, query, walletAddress, since).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count login failures: %w", err)
+	}
+
+	return count, nil
+}
+
+// CountSuccessesByLocation 計算使用者過去成功登入的總次數，以及來自相同國家與相同 IP 網段的次數
+func (r *LoginEventRepository) CountSuccessesByLocation(ctx context.Context, userID int64, country, network string) (int, int, int, error) {
+	query := `
+		SELECT
+			COUNT(*),
+			COUNT(*) FILTER (WHERE country = $2),
+			COUNT(*) FILTER (WHERE ip_network = $3)
+		FROM login_events
+		WHERE user_id = $1 AND success = TRUE
+	`
+
+	var total, sameCountry, sameNetwork int
+	if err := r.db.QueryRowContext(ctx, query, userID, country, network).Scan(&total, &sameCountry, &sameNetwork); err != nil {
+		return 0, 0, 0, fmt.Errorf("failed to count login locations: %w", err)
+	}
+
+	return total, sameCountry, sameNetwork, nil
+}
+
+// GetBySessionRefs 查詢建立指定 Session 的成功登入紀錄
+func (r *LoginEventRepository) GetBySessionRefs(ctx context.Context, sessionRefs []string) ([]*models.LoginEvent, error) {
+	query := `SELECT ` + loginEventColumns + `
+		FROM login_events
+		WHERE session_ref = ANY($1) AND success = TRUE
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(sessionRefs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get login events by session: %w", err)
+	}
+	defer rows.Close()
+
+	events := []*models.LoginEvent{}
+	for rows.Next() {
+		event := &models.LoginEvent{}
+		if err := rows.Scan(loginEventFields(event)...); err != nil {
+			return nil, fmt.Errorf("failed to scan login event: %w", err)
+		}
+		events = append(events, event)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return events, nil
+}
+
+// loginEventFields 對應 loginEventColumns 的掃描目標
+func loginEventFields(event *models.LoginEvent) []any {
+	return []any{
+		&event.ID,
+		&event.UserID,
+		&event.WalletAddress,
+		&event.Success,
+		&event.FailureReason,
+		&event.IPAddress,
+		&event.IPNetwork,
+		&event.Country,
+		&event.UserAgent,
+		&event.Device,
+		&event.SessionRef,
+		&event.CreatedAt,
+	}
+}
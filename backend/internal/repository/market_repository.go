@@ -0,0 +1,398 @@
+package repository
+
+import (
+	"bluelink-backend/internal/models"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// MarketRepository 處理二級市場掛單的資料庫操作
+type MarketRepository struct {
+	db *sql.DB
+}
+
+// NewMarketRepository 建立新的 MarketRepository
+func NewMarketRepository(db *sql.DB) *MarketRepository {
+	return &MarketRepository{db: db}
+}
+
+const marketOrderColumns = `
+	id, bond_id, side, token_id, maker_user_id, maker_address, price, nonce, expires_at,
+	message, signature, status, cancel_reason, taker_address, filled_token_id, filled_tx_hash,
+	filled_at, created_at, updated_at
+`
+
+// Create 建立掛單
+func (r *MarketRepository) Create(ctx context.Context, order *models.MarketOrder) error {
+	query := `
+		INSERT INTO market_orders (
+			bond_id, side, token_id, maker_user_id, maker_address, price, nonce, expires_at,
+			message, signature, status, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $12)
+		RETURNING id, created_at, updated_at
+	`
+
+	err := r.db.QueryRowContext(ctx, query,
+		order.BondID,
+		order.Side,
+		order.TokenID,
+		order.MakerUserID,
+		order.MakerAddress,
+		order.Price,
+		order.Nonce,
+		order.ExpiresAt,
+		order.Message,
+		order.Signature,
+		order.Status,
+		time.Now(),
+	).Scan(&order.ID, &order.CreatedAt, &order.UpdatedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to create market order: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID 根據 ID 查詢掛單
+func (r *MarketRepository) GetByID(ctx context.Context, id int64) (*models.MarketOrder, error) {
+	query := `SELECT ` + marketOrderColumns + ` FROM market_orders WHERE id = $1`
+
+	order, err := scanMarketOrder(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get market order: %w", err)
+	}
+
+	return order, nil
+}
+
+// ListOpenByBond 查詢債券的有效掛單（未過期）
+func (r *MarketRepository) ListOpenByBond(ctx context.Context, bondID int64, side string) ([]*models.MarketOrder, error) {
+	order := "price ASC"
+	if side == models.OrderSideBid {
+		order = "price DESC"
+	}
+
+	query := `
+		SELECT ` + marketOrderColumns + `
+		FROM market_orders
+		WHERE bond_id = $1 AND side = $2 AND status = 'open'
+		  AND (expires_at IS NULL OR expires_at > $3)
+		ORDER BY ` + order + `, created_at ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, bondID, side, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list market orders: %w", err)
+	}
+	defer rows.Close()
+
+	return scanMarketOrders(rows)
+}
+
+// GetOpenAskByToken 查詢代幣目前的有效賣單
+func (r *MarketRepository) GetOpenAskByToken(ctx context.Context, tokenID string) (*models.MarketOrder, error) {
+	query := `
+		SELECT ` + marketOrderColumns + `
+		FROM market_orders
+		WHERE token_id = $1 AND side = 'ask' AND status = 'open'
+	`
+
+	order, err := scanMarketOrder(r.db.QueryRowContext(ctx, query, tokenID))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get open ask: %w", err)
+	}
+
+	return order, nil
+}
+
+// ListByMaker 查詢使用者自己的掛單（status 為空時回傳全部）
+func (r *MarketRepository) ListByMaker(ctx context.Context, makerAddress, status string, limit, offset int) ([]*models.MarketOrder, error) {
+	query := `
+		SELECT ` + marketOrderColumns + `
+		FROM market_orders
+		WHERE maker_address = $1 AND ($2 = '' OR status = $2)
+		ORDER BY created_at DESC
+		LIMIT $3 OFFSET $4
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, makerAddress, status, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list market orders by maker: %w", err)
+	}
+	defer rows.Close()
+
+	return scanMarketOrders(rows)
+}
+
+// Cancel 掛單者取消自己的有效掛單
+func (r *MarketRepository) Cancel(ctx context.Context, id int64, makerAddress string) error {
+	query := `
+		UPDATE market_orders
+		SET status = 'cancelled', cancel_reason = $1, updated_at = $2
+		WHERE id = $3 AND maker_address = $4 AND status = 'open'
+	`
+
+	result, err := r.db.ExecContext(ctx, query, models.CancelReasonMaker, time.Now(), id, makerAddress)
+	if err != nil {
+		return fmt.Errorf("failed to cancel market order: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("market order not found or not open")
+	}
+
+	return nil
+}
+
+// CancelOpenAsksForToken 取消某代幣的所有有效賣單，回傳取消筆數
+func (r *MarketRepository) CancelOpenAsksForToken(ctx context.Context, tokenID, reason string) (int64, error) {
+	query := `
+		UPDATE market_orders
+		SET status = 'cancelled', cancel_reason = $1, updated_at = $2
+		WHERE token_id = $3 AND side = 'ask' AND status = 'open'
+	`
+
+	result, err := r.db.ExecContext(ctx, query, reason, time.Now(), tokenID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to cancel market orders for token: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	return rows, nil
+}
+
+// ListOpenAskTokens 取得所有有效賣單的代幣與掛單者（token_id → maker_address）
+func (r *MarketRepository) ListOpenAskTokens(ctx context.Context) (map[string]string, error) {
+	query := `
+		SELECT token_id, maker_address
+		FROM market_orders
+		WHERE side = 'ask' AND status = 'open'
+	`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list open ask tokens: %w", err)
+	}
+	defer rows.Close()
+
+	tokens := make(map[string]string)
+	for rows.Next() {
+		var tokenID, maker string
+		if err := rows.Scan(&tokenID, &maker); err != nil {
+			return nil, fmt.Errorf("failed to scan open ask token: %w", err)
+		}
+		tokens[tokenID] = maker
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return tokens, nil
+}
+
+// FillOrder 成交掛單（事務處理）
+// 1. 標記掛單成交 2. 取消該代幣其他賣單 3. 更新代幣持有者
+// 4. 寫入 bond_transferred 交易記錄 5. 更新買賣雙方持倉
+func (r *MarketRepository) FillOrder(ctx context.Context, fill *models.TradeFill) error {
+	dbTx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer dbTx.Rollback()
+
+	now := time.Now()
+
+	// 1. 標記成交（僅限仍為 open 的掛單，避免重複成交）
+	result, err := dbTx.ExecContext(ctx, `
+		UPDATE market_orders
+		SET status = 'filled', taker_address = $1, filled_token_id = $2, filled_tx_hash = $3,
+		    filled_at = $4, updated_at = $4
+		WHERE id = $5 AND status = 'open'
+	`, fill.TakerAddr, fill.TokenID, fill.TxHash, now, fill.OrderID)
+	if err != nil {
+		return fmt.Errorf("failed to fill market order: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("market order not found or not open")
+	}
+
+	// 2. 取消該代幣的其他賣單
+	if _, err := dbTx.ExecContext(ctx, `
+		UPDATE market_orders
+		SET status = 'cancelled', cancel_reason = $1, updated_at = $2
+		WHERE token_id = $3 AND side = 'ask' AND status = 'open' AND id <> $4
+	`, models.CancelReasonSold, now, fill.TokenID, fill.OrderID); err != nil {
+		return fmt.Errorf("failed to cancel remaining orders: %w", err)
+	}
+
+	metadata, err := json.Marshal(map[string]interface{}{
+		"from":     fill.SellerAddr,
+		"to":       fill.BuyerAddr,
+		"token_id": fill.TokenID,
+		"order_id": fill.OrderID,
+		"source":   "secondary_market",
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal metadata: %w", err)
+	}
+
+	price := float64(fill.Price)
+	quantity := int64(1)
+
+	// 轉移索引已記錄此交易並更新持有者與持倉，只需補上成交價
+	if fill.AlreadyIndexed {
+		if _, err := dbTx.ExecContext(ctx, `
+			UPDATE transactions
+			SET amount = $1, price = $1, metadata = $2
+			WHERE tx_hash = $3 AND metadata->>'token_id' = $4
+		`, price, string(metadata), fill.TxHash, fill.TokenID); err != nil {
+			return fmt.Errorf("failed to update transaction: %w", err)
+		}
+
+		if err := dbTx.Commit(); err != nil {
+			return fmt.Errorf("failed to commit transaction: %w", err)
+		}
+		return nil
+	}
+
+	// 3. 更新代幣持有者
+	if _, err := dbTx.ExecContext(ctx, `
+		UPDATE bond_tokens
+		SET owner = $1, updated_at = $2
+		WHERE on_chain_id = $3 AND deleted_at IS NULL
+	`, fill.BuyerAddr, now, fill.TokenID); err != nil {
+		return fmt.Errorf("failed to update bond token owner: %w", err)
+	}
+
+	// 4. 寫入交易記錄
+	if _, err := dbTx.ExecContext(ctx, `
+		INSERT INTO transactions (
+			tx_hash, event_type, bond_id, user_id, wallet_address,
+			amount, quantity, price, status, timestamp, metadata, created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+	`,
+		fill.TxHash,
+		models.EventBondTransferred,
+		fill.BondID,
+		fill.BuyerUserID,
+		fill.BuyerAddr,
+		price,
+		quantity,
+		price,
+		models.TxStatusConfirmed,
+		fill.Timestamp,
+		string(metadata),
+		now,
+	); err != nil {
+		return fmt.Errorf("failed to create transaction: %w", err)
+	}
+
+	// 5. 更新持倉：賣方 -1、買方 +1（以成交價計入平均成本）
+	if err := adjustUserBond(ctx, dbTx, fill.SellerUserID, fill.BondID, -quantity, 0, now); err != nil {
+		return err
+	}
+	if err := adjustUserBond(ctx, dbTx, fill.BuyerUserID, fill.BondID, quantity, price, now); err != nil {
+		return err
+	}
+
+	if err := dbTx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// adjustUserBond 在事務中更新使用者持倉
+func adjustUserBond(ctx context.Context, dbTx *sql.Tx, userID, bondID, quantityChange int64, price float64, now time.Time) error {
+	_, err := dbTx.ExecContext(ctx, `
+		INSERT INTO user_bonds (user_id, bond_id, wallet_address, quantity, average_purchase_price, created_at, updated_at)
+		SELECT $1, $2, u.wallet_address, $3, $4, $5, $5
+		FROM users u WHERE u.id = $1
+		ON CONFLICT (user_id, bond_id)
+		DO UPDATE SET
+			quantity = user_bonds.quantity + $3,
+			average_purchase_price =
+				CASE
+					WHEN $3 > 0 THEN
+						((user_bonds.quantity * COALESCE(user_bonds.average_purchase_price, 0)) + ($3 * $4)) / (user_bonds.quantity + $3)
+					ELSE
+						user_bonds.average_purchase_price
+				END,
+			updated_at = $5
+	`, userID, bondID, quantityChange, price, now)
+	if err != nil {
+		return fmt.Errorf("failed to update user bond: %w", err)
+	}
+	return nil
+}
+
+func scanMarketOrder(row rowScanner) (*models.MarketOrder, error) {
+	order := &models.MarketOrder{}
+	err := row.Scan(
+		&order.ID,
+		&order.BondID,
+		&order.Side,
+		&order.TokenID,
+		&order.MakerUserID,
+		&order.MakerAddress,
+		&order.Price,
+		&order.Nonce,
+		&order.ExpiresAt,
+		&order.Message,
+		&order.Signature,
+		&order.Status,
+		&order.CancelReason,
+		&order.TakerAddress,
+		&order.FilledTokenID,
+		&order.FilledTxHash,
+		&order.FilledAt,
+		&order.CreatedAt,
+		&order.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+func scanMarketOrders(rows *sql.Rows) ([]*models.MarketOrder, error) {
+	orders := []*models.MarketOrder{}
+	for rows.Next() {
+		order, err := scanMarketOrder(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan market order: %w", err)
+		}
+		orders = append(orders, order)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return orders, nil
+}
@@ -4,10 +4,21 @@ import (
 	"bluelink-backend/internal/models"
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"time"
 )
 
+// maxOutstandingNonces 同一地址自同一 IP 同時有效的 nonce 上限
+const maxOutstandingNonces = 5
+
+var (
+	ErrNonceNotFound        = errors.New("nonce not found")
+	ErrNonceExpired         = errors.New("nonce expired")
+	ErrNonceMessageMismatch = errors.New("message mismatch")
+	ErrTooManyNonces        = errors.New("too many outstanding challenges for this wallet from this IP")
+)
+
 type NonceRepository struct {
 	db *sql.DB
 }
@@ -40,66 +51,92 @@ func (r *NonceRepository) cleanupExpiredNonces() {
 	}
 }
 
-// Create 創建新的 nonce
-// 如果該 wallet_address 已有 nonce，會先刪除舊的（確保一個地址只有一個有效 nonce）
-func (r *NonceRepository) Create(ctx context.Context, walletAddress, nonce string, ttl time.Duration) error {
-	// 先刪除該地址的舊 nonce
-	_, err := r.db.ExecContext(ctx, `
-		DELETE FROM nonces WHERE wallet_address = $1
-	`, walletAddress)
+// Create 創建新的 nonce，並保存挑戰時發出的完整訊息與請求的 IP
+// 同一地址可同時有多個有效 nonce（多分頁、重試），同一 IP 達 maxOutstandingNonces 時回傳 ErrTooManyNonces
+// （以地址與 IP 共同計數，他人從其他 IP 大量請求挑戰不會擋下使用者；也不刪除既有的 nonce，避免使用者手上的挑戰失效）
+func (r *NonceRepository) Create(ctx context.Context, walletAddress, nonce, message, ipAddress string, ttl time.Duration) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// 同一地址的挑戰依序建立，確保有效 nonce 數量不超過上限
+	if _, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock(hashtext($1))`, walletAddress); err != nil {
+		return fmt.Errorf("failed to lock nonces: %w", err)
+	}
+
+	// 刪除該地址過期的 nonce 後檢查此 IP 的上限
+	_, err = tx.ExecContext(ctx, `DELETE FROM nonces WHERE wallet_address = $1 AND expires_at < $2`, walletAddress, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to delete expired nonces: %w", err)
+	}
+
+	var outstanding int
+	err = tx.QueryRowContext(ctx, `SELECT COUNT(*) FROM nonces WHERE wallet_address = $1 AND ip_address = $2`, walletAddress, ipAddress).Scan(&outstanding)
 	if err != nil {
-		return fmt.Errorf("failed to delete old nonce: %w", err)
+		return fmt.Errorf("failed to count nonces: %w", err)
+	}
+	if outstanding >= maxOutstandingNonces {
+		return ErrTooManyNonces
 	}
 
 	// 插入新 nonce
 	expiresAt := time.Now().Add(ttl)
-	_, err = r.db.ExecContext(ctx, `
-		INSERT INTO nonces (wallet_address, nonce, created_at, expires_at)
-		VALUES ($1, $2, $3, $4)
-	`, walletAddress, nonce, time.Now(), expiresAt)
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO nonces (wallet_address, nonce, message, ip_address, created_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`, walletAddress, nonce, message, ipAddress, time.Now(), expiresAt)
 	if err != nil {
 		return fmt.Errorf("failed to create nonce: %w", err)
 	}
 
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit nonce: %w", err)
+	}
+
 	return nil
 }
 
-// Get 根據 wallet_address 取得 nonce
-// 會自動檢查是否過期，如果過期會刪除並返回 not found
-func (r *NonceRepository) Get(ctx context.Context, walletAddress string) (*models.Nonce, error) {
+// Consume 取出並刪除指定地址的 nonce（單次使用，找不到時回傳 nil）
+func (r *NonceRepository) Consume(ctx context.Context, walletAddress, nonce string) (*models.Nonce, error) {
 	var n models.Nonce
 	err := r.db.QueryRowContext(ctx, `
-		SELECT id, wallet_address, nonce, created_at, expires_at
-		FROM nonces
-		WHERE wallet_address = $1
-	`, walletAddress).Scan(&n.ID, &n.WalletAddress, &n.Nonce, &n.CreatedAt, &n.ExpiresAt)
+		DELETE FROM nonces
+		WHERE nonce = $1 AND wallet_address = $2
+		RETURNING id, wallet_address, nonce, message, ip_address, created_at, expires_at
+	`, nonce, walletAddress).Scan(&n.ID, &n.WalletAddress, &n.Nonce, &n.Message, &n.IPAddress, &n.CreatedAt, &n.ExpiresAt)
 
 	if err == sql.ErrNoRows {
-		return nil, fmt.Errorf("nonce not found")
+		return nil, nil
 	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to get nonce: %w", err)
+		return nil, fmt.Errorf("failed to consume nonce: %w", err)
 	}
 
-	// 檢查是否過期
-	if time.Now().After(n.ExpiresAt) {
-		// 刪除過期的 nonce
-		r.Delete(ctx, walletAddress)
-		return nil, fmt.Errorf("nonce expired")
+	return &n, nil
+}
+
+// CountActive 計算目前尚未過期的 nonce 數量
+func (r *NonceRepository) CountActive(ctx context.Context) (int64, error) {
+	var count int64
+	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM nonces WHERE expires_at >= $1`, time.Now()).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count nonces: %w", err)
 	}
 
-	return &n, nil
+	return count, nil
 }
 
-// Delete 根據 wallet_address 刪除 nonce（防止重放攻擊）
-func (r *NonceRepository) Delete(ctx context.Context, walletAddress string) error {
-	_, err := r.db.ExecContext(ctx, `
-		DELETE FROM nonces WHERE wallet_address = $1
-	`, walletAddress)
+// CountActiveByIP 計算指定 IP 目前尚未過期的 nonce 數量
+func (r *NonceRepository) CountActiveByIP(ctx context.Context, ipAddress string) (int64, error) {
+	var count int64
+	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM nonces WHERE ip_address = $1 AND expires_at >= $2`, ipAddress, time.Now()).Scan(&count)
 	if err != nil {
-		return fmt.Errorf("failed to delete nonce: %w", err)
+		return 0, fmt.Errorf("failed to count nonces by ip: %w", err)
 	}
-	return nil
+
+	return count, nil
 }
 
 // DeleteExpired 刪除所有過期的 nonce（定期清理）
@@ -119,21 +156,25 @@ func (r *NonceRepository) DeleteExpired(ctx context.Context) (int64, error) {
 	return affected, nil
 }
 
-// Verify 驗證 nonce 是否匹配（原子操作：查詢、比對、刪除）
-func (r *NonceRepository) Verify(ctx context.Context, walletAddress, nonce string) (bool, error) {
-	storedNonce, err := r.Get(ctx, walletAddress)
+// Verify 驗證 nonce 與簽署的訊息是否匹配
+// nonce 以 DELETE ... RETURNING 原子取出，無論驗證結果為何都不能再次使用（防止重放攻擊）
+func (r *NonceRepository) Verify(ctx context.Context, walletAddress, nonce, message string) (bool, error) {
+	storedNonce, err := r.Consume(ctx, walletAddress, nonce)
 	if err != nil {
 		return false, err
 	}
+	if storedNonce == nil {
+		return false, ErrNonceNotFound
+	}
 
-	// 比對 nonce
-	if storedNonce.Nonce != nonce {
-		return false, fmt.Errorf("nonce mismatch")
+	// 檢查是否過期
+	if time.Now().After(storedNonce.ExpiresAt) {
+		return false, ErrNonceExpired
 	}
 
-	// 刪除已使用的 nonce（防止重放攻擊）
-	if err := r.Delete(ctx, walletAddress); err != nil {
-		return false, fmt.Errorf("failed to delete used nonce: %w", err)
+	// 比對訊息（不可竄改 issued-at、expiration 等欄位）
+	if storedNonce.Message == nil || *storedNonce.Message != message {
+		return false, ErrNonceMessageMismatch
 	}
 
 	return true, nil
@@ -0,0 +1,156 @@
+package repository
+
+import (
+	"bluelink-backend/internal/models"
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// NotificationRepository 處理使用者通知的資料庫操作
+type NotificationRepository struct {
+	db *sql.DB
+}
+
+// NewNotificationRepository 建立新的 NotificationRepository
+func NewNotificationRepository(db *sql.DB) *NotificationRepository {
+	return &NotificationRepository{db: db}
+}
+
+// Create 新增通知
+func (r *NotificationRepository) Create(ctx context.Context, notification *models.Notification) error {
+	query := `
+		INSERT INTO notifications (user_id, type, title, message, data, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at
+	`
+
+	err := r.db.QueryRowContext(ctx, query,
+		notification.UserID,
+		notification.Type,
+		notification.Title,
+		notification.Message,
+		nullableJSON(notification.Data),
+		time.Now(),
+	).Scan(&notification.ID, &notification.CreatedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to create notification: %w", err)
+	}
+
+	return nil
+}
+
+// ListByUser 查詢使用者的通知（新到舊），回傳通知與總筆數
+func (r *NotificationRepository) ListByUser(ctx context.Context, userID int64, unreadOnly bool, limit, offset int) ([]*models.Notification, int, error) {
+	query := `
+		SELECT id, user_id, type, title, message, data, read_at, created_at, COUNT(*) OVER()
+		FROM notifications
+		WHERE user_id = $1 AND (NOT $2 OR read_at IS NULL)
+		ORDER BY created_at DESC, id DESC
+		LIMIT $3 OFFSET $4
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID, unreadOnly, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list notifications: %w", err)
+	}
+	defer rows.Close()
+
+	notifications := []*models.Notification{}
+	total := 0
+	for rows.Next() {
+		notification := &models.Notification{}
+		var data []byte
+		err := rows.Scan(
+			&notification.ID,
+			&notification.UserID,
+			&notification.Type,
+			&notification.Title,
+			&notification.Message,
+			&data,
+			&notification.ReadAt,
+			&notification.CreatedAt,
+			&total,
+		)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan notification: %w", err)
+		}
+		notification.Data = data
+		notifications = append(notifications, notification)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("rows error: %w", err)
+	}
+
+	return notifications, total, nil
+}
+
+// CountUnread 計算使用者未讀的通知數量
+func (r *NotificationRepository) CountUnread(ctx context.Context, userID int64) (int, error) {
+	query := `SELECT COUNT(*) FROM notifications WHERE user_id = $1 AND read_at IS NULL`
+
+	var count int
+	if err := r.db.QueryRowContext(ctx, query, userID).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count unread notifications: %w", err)
+	}
+
+	return count, nil
+}
+
+// ExistsSince 檢查使用者自指定時間起是否已有同類型的通知（避免重複警示）
+func (r *NotificationRepository) ExistsSince(ctx context.Context, userID int64, notificationType string, since time.Time) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1 FROM notifications
+			WHERE user_id = $1 AND type = $2 AND created_at >= $3
+		)
+	`
+
+	var exists bool
+	if err := r.db.QueryRowContext(ctx, query, userID, notificationType, since).Scan(&exists); err != nil {
+		return false, fmt.Errorf("failed to check notifications: %w", err)
+	}
+
+	return exists, nil
+}
+
+// MarkRead 將使用者的一則通知標記為已讀，回傳通知是否存在
+func (r *NotificationRepository) MarkRead(ctx context.Context, userID, id int64) (bool, error) {
+	query := `
+		UPDATE notifications
+		SET read_at = COALESCE(read_at, $1)
+		WHERE id = $2 AND user_id = $3
+	`
+
+	result, err := r.db.ExecContext(ctx, query, time.Now(), id, userID)
+	if err != nil {
+		return false, fmt.Errorf("failed to mark notification read: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	return rows > 0, nil
+}
+
+// MarkAllRead 將使用者所有未讀通知標記為已讀，回傳更新筆數
+func (r *NotificationRepository) MarkAllRead(ctx context.Context, userID int64) (int64, error) {
+	query := `UPDATE notifications SET read_at = $1 WHERE user_id = $2 AND read_at IS NULL`
+
+	result, err := r.db.ExecContext(ctx, query, time.Now(), userID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to mark notifications read: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	return rows, nil
+}
@@ -0,0 +1,305 @@
+package repository
+
+import (
+	"bluelink-backend/internal/models"
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// OrganizationRepository 處理發行機構與成員的資料庫操作
+type OrganizationRepository struct {
+	db *sql.DB
+}
+
+// NewOrganizationRepository 建立新的 OrganizationRepository
+func NewOrganizationRepository(db *sql.DB) *OrganizationRepository {
+	return &OrganizationRepository{db: db}
+}
+
+const organizationColumns = `
+	o.id, o.name, o.registration_number, o.created_by,
+	(SELECT COUNT(*) FROM organization_members m WHERE m.organization_id = o.id),
+	o.created_at, o.updated_at
+`
+
+const organizationMemberColumns = `
+	m.id, m.organization_id, m.user_id, u.wallet_address, u.name, u.role,
+	m.org_role, m.added_by, m.created_at, m.updated_at
+`
+
+// Create 建立機構並將建立者設為 owner（事務處理）
+func (r *OrganizationRepository) Create(ctx context.Context, org *models.Organization, ownerID int64) error {
+	dbTx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer dbTx.Rollback()
+
+	now := time.Now()
+	err = dbTx.QueryRowContext(ctx, `
+		INSERT INTO organizations (name, registration_number, created_by, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $4)
+		RETURNING id, created_at, updated_at
+	`, org.Name, org.RegistrationNumber, ownerID, now).Scan(&org.ID, &org.CreatedAt, &org.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create organization: %w", err)
+	}
+
+	if _, err := dbTx.ExecContext(ctx, `
+		INSERT INTO organization_members (organization_id, user_id, org_role, added_by, created_at, updated_at)
+		VALUES ($1, $2, $3, $2, $4, $4)
+	`, org.ID, ownerID, models.OrgRoleOwner, now); err != nil {
+		return fmt.Errorf("failed to add organization owner: %w", err)
+	}
+
+	if err := dbTx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	org.CreatedBy = &ownerID
+	org.MemberCount = 1
+	return nil
+}
+
+// GetByID 根據 ID 查詢機構
+func (r *OrganizationRepository) GetByID(ctx context.Context, id int64) (*models.Organization, error) {
+	query := `SELECT ` + organizationColumns + `
+		FROM organizations o
+		WHERE o.id = $1
+	`
+
+	org, err := scanOrganization(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get organization by ID: %w", err)
+	}
+
+	return org, nil
+}
+
+// List 查詢所有機構（管理員用）
+func (r *OrganizationRepository) List(ctx context.Context, limit, offset int) ([]*models.Organization, error) {
+	query := `SELECT ` + organizationColumns + `
+		FROM organizations o
+		ORDER BY o.created_at DESC
+		LIMIT $1 OFFSET $2
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list organizations: %w", err)
+	}
+	defer rows.Close()
+
+	orgs := []*models.Organization{}
+	for rows.Next() {
+		org, err := scanOrganization(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan organization: %w", err)
+		}
+		orgs = append(orgs, org)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return orgs, nil
+}
+
+// Update 更新機構資料
+func (r *OrganizationRepository) Update(ctx context.Context, org *models.Organization) error {
+	query := `
+		UPDATE organizations
+		SET name = $1, registration_number = $2, updated_at = $3
+		WHERE id = $4
+	`
+
+	result, err := r.db.ExecContext(ctx, query, org.Name, org.RegistrationNumber, time.Now(), org.ID)
+	if err != nil {
+		return fmt.Errorf("failed to update organization: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("organization not found")
+	}
+
+	return nil
+}
+
+// GetMembership 查詢使用者所屬機構的成員資料（未加入機構時回傳 nil）
+func (r *OrganizationRepository) GetMembership(ctx context.Context, userID int64) (*models.OrganizationMember, error) {
+	query := `SELECT ` + organizationMemberColumns + `
+		FROM organization_members m
+		JOIN users u ON u.id = m.user_id
+		WHERE m.user_id = $1
+	`
+
+	member, err := scanOrganizationMember(r.db.QueryRowContext(ctx, query, userID))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get organization membership: %w", err)
+	}
+
+	return member, nil
+}
+
+// ListMembers 查詢機構的所有成員（不含已刪除的使用者）
+func (r *OrganizationRepository) ListMembers(ctx context.Context, organizationID int64) ([]*models.OrganizationMember, error) {
+	query := `SELECT ` + organizationMemberColumns + `
+		FROM organization_members m
+		JOIN users u ON u.id = m.user_id
+		WHERE m.organization_id = $1 AND u.deleted_at IS NULL
+		ORDER BY m.created_at ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, organizationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list organization members: %w", err)
+	}
+	defer rows.Close()
+
+	members := []*models.OrganizationMember{}
+	for rows.Next() {
+		member, err := scanOrganizationMember(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan organization member: %w", err)
+		}
+		members = append(members, member)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return members, nil
+}
+
+// AddMember 新增機構成員
+func (r *OrganizationRepository) AddMember(ctx context.Context, organizationID, userID int64, orgRole string, addedBy int64) error {
+	query := `
+		INSERT INTO organization_members (organization_id, user_id, org_role, added_by, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $5)
+	`
+
+	_, err := r.db.ExecContext(ctx, query, organizationID, userID, orgRole, addedBy, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to add organization member: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateMemberRole 變更成員在機構內的角色
+func (r *OrganizationRepository) UpdateMemberRole(ctx context.Context, organizationID, userID int64, orgRole string) error {
+	query := `
+		UPDATE organization_members
+		SET org_role = $1, updated_at = $2
+		WHERE organization_id = $3 AND user_id = $4
+	`
+
+	result, err := r.db.ExecContext(ctx, query, orgRole, time.Now(), organizationID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to update organization member role: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("organization member not found")
+	}
+
+	return nil
+}
+
+// RemoveMember 移除機構成員
+func (r *OrganizationRepository) RemoveMember(ctx context.Context, organizationID, userID int64) error {
+	query := `DELETE FROM organization_members WHERE organization_id = $1 AND user_id = $2`
+
+	result, err := r.db.ExecContext(ctx, query, organizationID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to remove organization member: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("organization member not found")
+	}
+
+	return nil
+}
+
+// CountOwners 計算機構的 owner 數量
+func (r *OrganizationRepository) CountOwners(ctx context.Context, organizationID int64) (int, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM organization_members
+		WHERE organization_id = $1 AND org_role = 'owner'
+	`
+
+	var count int
+	if err := r.db.QueryRowContext(ctx, query, organizationID).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count organization owners: %w", err)
+	}
+
+	return count, nil
+}
+
+func scanOrganization(row rowScanner) (*models.Organization, error) {
+	org := &models.Organization{}
+
+	err := row.Scan(
+		&org.ID,
+		&org.Name,
+		&org.RegistrationNumber,
+		&org.CreatedBy,
+		&org.MemberCount,
+		&org.CreatedAt,
+		&org.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return org, nil
+}
+
+func scanOrganizationMember(row rowScanner) (*models.OrganizationMember, error) {
+	member := &models.OrganizationMember{}
+
+	err := row.Scan(
+		&member.ID,
+		&member.OrganizationID,
+		&member.UserID,
+		&member.WalletAddress,
+		&member.Name,
+		&member.Role,
+		&member.OrgRole,
+		&member.AddedBy,
+		&member.CreatedAt,
+		&member.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return member, nil
+}
@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"bluelink-backend/internal/models"
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+type PriceRepository struct {
+	db *sql.DB
+}
+
+func NewPriceRepository(db *sql.DB) *PriceRepository {
+	return &PriceRepository{db: db}
+}
+
+// CreateSnapshot 記錄一筆匯率快照（同來源同時間的重複記錄會被忽略）
+func (r *PriceRepository) CreateSnapshot(ctx context.Context, quote *models.PriceQuote) error {
+	query := `
+		INSERT INTO price_snapshots (base, quote, rate, source, observed_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (base, quote, source, observed_at) DO NOTHING
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		quote.Base,
+		quote.Quote,
+		quote.Rate,
+		quote.Source,
+		quote.Timestamp,
+		time.Now(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create price snapshot: %w", err)
+	}
+
+	return nil
+}
+
+// GetLatest 查詢幣種對法幣的最新匯率快照
+func (r *PriceRepository) GetLatest(ctx context.Context, base, quote string) (*models.PriceQuote, error) {
+	query := `
+		SELECT id, base, quote, rate, source, observed_at
+		FROM price_snapshots
+		WHERE base = $1 AND quote = $2
+		ORDER BY observed_at DESC
+		LIMIT 1
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, base, quote)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest price: %w", err)
+	}
+	defer rows.Close()
+
+	snapshots, err := scanPriceSnapshots(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(snapshots) == 0 {
+		return nil, nil
+	}
+
+	return snapshots[0], nil
+}
+
+// ListHistory 查詢區間內的匯率快照，依時間由新到舊
+func (r *PriceRepository) ListHistory(ctx context.Context, base, quote string, from, to time.Time, limit int) ([]*models.PriceQuote, error) {
+	query := `
+		SELECT id, base, quote, rate, source, observed_at
+		FROM price_snapshots
+		WHERE base = $1 AND quote = $2 AND observed_at BETWEEN $3 AND $4
+		ORDER BY observed_at DESC
+		LIMIT $5
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, base, quote, from, to, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list price history: %w", err)
+	}
+	defer rows.Close()
+
+	return scanPriceSnapshots(rows)
+}
+
+func scanPriceSnapshots(rows *sql.Rows) ([]*models.PriceQuote, error) {
+	snapshots := []*models.PriceQuote{}
+	for rows.Next() {
+		p := &models.PriceQuote{}
+		if err := rows.Scan(&p.ID, &p.Base, &p.Quote, &p.Rate, &p.Source, &p.Timestamp); err != nil {
+			return nil, fmt.Errorf("failed to scan price snapshot: %w", err)
+		}
+		snapshots = append(snapshots, p)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return snapshots, nil
+}
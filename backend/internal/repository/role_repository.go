@@ -0,0 +1,233 @@
+package repository
+
+import (
+	"bluelink-backend/internal/models"
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// RoleRepository 處理角色定義與使用者角色的資料庫操作
+type RoleRepository struct {
+	db *sql.DB
+}
+
+// NewRoleRepository 建立新的 RoleRepository
+func NewRoleRepository(db *sql.DB) *RoleRepository {
+	return &RoleRepository{db: db}
+}
+
+const roleColumns = `name, description, permissions, is_system, created_at, updated_at`
+
+// List 查詢所有角色定義
+func (r *RoleRepository) List(ctx context.Context) ([]*models.Role, error) {
+	query := `SELECT ` + roleColumns + ` FROM roles ORDER BY is_system DESC, name ASC`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list roles: %w", err)
+	}
+	defer rows.Close()
+
+	roles := []*models.Role{}
+	for rows.Next() {
+		role, err := scanRole(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan role: %w", err)
+		}
+		roles = append(roles, role)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return roles, nil
+}
+
+// GetByName 根據名稱查詢角色（不存在時回傳 nil）
+func (r *RoleRepository) GetByName(ctx context.Context, name string) (*models.Role, error) {
+	query := `SELECT ` + roleColumns + ` FROM roles WHERE name = $1`
+
+	role, err := scanRole(r.db.QueryRowContext(ctx, query, name))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get role: %w", err)
+	}
+
+	return role, nil
+}
+
+// Create 建立角色定義
+func (r *RoleRepository) Create(ctx context.Context, role *models.Role) error {
+	query := `
+		INSERT INTO roles (name, description, permissions, is_system, created_at, updated_at)
+		VALUES ($1, $2, $3, FALSE, $4, $4)
+		RETURNING created_at, updated_at
+	`
+
+	err := r.db.QueryRowContext(ctx, query,
+		role.Name,
+		role.Description,
+		pq.Array(role.Permissions),
+		time.Now(),
+	).Scan(&role.CreatedAt, &role.UpdatedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to create role: %w", err)
+	}
+
+	return nil
+}
+
+// Update 更新角色的說明與權限
+func (r *RoleRepository) Update(ctx context.Context, role *models.Role) error {
+	query := `
+		UPDATE roles
+		SET description = $1, permissions = $2, updated_at = $3
+		WHERE name = $4
+		RETURNING updated_at
+	`
+
+	err := r.db.QueryRowContext(ctx, query,
+		role.Description,
+		pq.Array(role.Permissions),
+		time.Now(),
+		role.Name,
+	).Scan(&role.UpdatedAt)
+
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("role not found")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update role: %w", err)
+	}
+
+	return nil
+}
+
+// Delete 刪除非系統角色（使用者的額外角色一併移除）
+func (r *RoleRepository) Delete(ctx context.Context, name string) error {
+	query := `DELETE FROM roles WHERE name = $1 AND is_system = FALSE`
+
+	result, err := r.db.ExecContext(ctx, query, name)
+	if err != nil {
+		return fmt.Errorf("failed to delete role: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("role not found or is a system role")
+	}
+
+	return nil
+}
+
+// ListUserIDsByRole 查詢被授予該額外角色的使用者 ID
+func (r *RoleRepository) ListUserIDsByRole(ctx context.Context, name string) ([]int64, error) {
+	query := `SELECT user_id FROM user_roles WHERE role = $1`
+
+	rows, err := r.db.QueryContext(ctx, query, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list role users: %w", err)
+	}
+	defer rows.Close()
+
+	userIDs := []int64{}
+	for rows.Next() {
+		var userID int64
+		if err := rows.Scan(&userID); err != nil {
+			return nil, fmt.Errorf("failed to scan user ID: %w", err)
+		}
+		userIDs = append(userIDs, userID)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return userIDs, nil
+}
+
+// GetUserRoles 查詢使用者額外授予的角色（不含主要角色 users.role）
+func (r *RoleRepository) GetUserRoles(ctx context.Context, userID int64) ([]string, error) {
+	query := `SELECT role FROM user_roles WHERE user_id = $1 ORDER BY role ASC`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user roles: %w", err)
+	}
+	defer rows.Close()
+
+	roles := []string{}
+	for rows.Next() {
+		var role string
+		if err := rows.Scan(&role); err != nil {
+			return nil, fmt.Errorf("failed to scan user role: %w", err)
+		}
+		roles = append(roles, role)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return roles, nil
+}
+
+// SetUserRoles 以交易替換使用者的額外角色
+func (r *RoleRepository) SetUserRoles(ctx context.Context, userID int64, roles []string, grantedBy int64) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM user_roles WHERE user_id = $1 AND role <> ALL($2::text[])`, userID, pq.Array(roles)); err != nil {
+		return fmt.Errorf("failed to remove user roles: %w", err)
+	}
+
+	query := `
+		INSERT INTO user_roles (user_id, role, granted_by, granted_at)
+		SELECT $1, unnest($2::text[]), $3, $4
+		ON CONFLICT (user_id, role) DO NOTHING
+	`
+	if _, err := tx.ExecContext(ctx, query, userID, pq.Array(roles), grantedBy, time.Now()); err != nil {
+		return fmt.Errorf("failed to grant user roles: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func scanRole(row rowScanner) (*models.Role, error) {
+	role := &models.Role{}
+	var permissions pq.StringArray
+
+	err := row.Scan(
+		&role.Name,
+		&role.Description,
+		&permissions,
+		&role.IsSystem,
+		&role.CreatedAt,
+		&role.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	role.Permissions = []string(permissions)
+	return role, nil
+}
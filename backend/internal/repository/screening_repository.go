@@ -0,0 +1,232 @@
+package repository
+
+import (
+	"bluelink-backend/internal/models"
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// ScreeningRepository 處理名單比對紀錄與合規標記的資料庫操作
+type ScreeningRepository struct {
+	db *sql.DB
+}
+
+// NewScreeningRepository 建立新的 ScreeningRepository
+func NewScreeningRepository(db *sql.DB) *ScreeningRepository {
+	return &ScreeningRepository{db: db}
+}
+
+// CreateResult 寫入一次比對紀錄
+func (r *ScreeningRepository) CreateResult(ctx context.Context, result *models.ScreeningResult) error {
+	query := `
+		INSERT INTO screening_results (
+			wallet_address, user_id, trigger, result, list_name, reason,
+			list_version, tx_hash, screened_by, created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING id, created_at
+	`
+
+	err := r.db.QueryRowContext(ctx, query,
+		result.WalletAddress,
+		result.UserID,
+		result.Trigger,
+		result.Result,
+		result.ListName,
+		result.Reason,
+		result.ListVersion,
+		result.TxHash,
+		result.ScreenedBy,
+		time.Now(),
+	).Scan(&result.ID, &result.CreatedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to create screening result: %w", err)
+	}
+
+	return nil
+}
+
+// ListResults 查詢比對紀錄；wallet 為空時回傳全部，matchOnly 只回傳命中紀錄
+func (r *ScreeningRepository) ListResults(ctx context.Context, wallet string, matchOnly bool, limit, offset int) ([]*models.ScreeningResult, error) {
+	query := `
+		SELECT id, wallet_address, user_id, trigger, result, list_name, reason,
+		       list_version, tx_hash, screened_by, created_at
+		FROM screening_results
+		WHERE ($1 = '' OR wallet_address = $1)
+		  AND (NOT $2 OR result = 'match')
+		ORDER BY created_at DESC
+		LIMIT $3 OFFSET $4
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, wallet, matchOnly, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list screening results: %w", err)
+	}
+	defer rows.Close()
+
+	results := []*models.ScreeningResult{}
+	for rows.Next() {
+		result := &models.ScreeningResult{}
+		err := rows.Scan(
+			&result.ID,
+			&result.WalletAddress,
+			&result.UserID,
+			&result.Trigger,
+			&result.Result,
+			&result.ListName,
+			&result.Reason,
+			&result.ListVersion,
+			&result.TxHash,
+			&result.ScreenedBy,
+			&result.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan screening result: %w", err)
+		}
+		results = append(results, result)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return results, nil
+}
+
+// CreateFlag 建立合規標記；同一地址的同一筆交易只標記一次
+// 回傳 false 表示已存在
+func (r *ScreeningRepository) CreateFlag(ctx context.Context, flag *models.ComplianceFlag) (bool, error) {
+	query := `
+		INSERT INTO compliance_flags (
+			wallet_address, user_id, tx_hash, event_type, bond_id,
+			list_name, reason, status, created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (wallet_address, tx_hash) DO NOTHING
+		RETURNING id, created_at
+	`
+
+	err := r.db.QueryRowContext(ctx, query,
+		flag.WalletAddress,
+		flag.UserID,
+		flag.TxHash,
+		flag.EventType,
+		flag.BondID,
+		flag.ListName,
+		flag.Reason,
+		flag.Status,
+		time.Now(),
+	).Scan(&flag.ID, &flag.CreatedAt)
+
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to create compliance flag: %w", err)
+	}
+
+	return true, nil
+}
+
+// GetFlagByID 根據 ID 查詢合規標記
+func (r *ScreeningRepository) GetFlagByID(ctx context.Context, id int64) (*models.ComplianceFlag, error) {
+	query := `SELECT ` + complianceFlagColumns + `
+		FROM compliance_flags
+		WHERE id = $1
+	`
+
+	flag, err := scanComplianceFlag(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get compliance flag: %w", err)
+	}
+
+	return flag, nil
+}
+
+// ListFlags 根據狀態查詢合規標記；status 為空時回傳全部
+func (r *ScreeningRepository) ListFlags(ctx context.Context, status string, limit, offset int) ([]*models.ComplianceFlag, error) {
+	query := `SELECT ` + complianceFlagColumns + `
+		FROM compliance_flags
+		WHERE ($1 = '' OR status = $1)
+		ORDER BY created_at ASC
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, status, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list compliance flags: %w", err)
+	}
+	defer rows.Close()
+
+	flags := []*models.ComplianceFlag{}
+	for rows.Next() {
+		flag, err := scanComplianceFlag(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan compliance flag: %w", err)
+		}
+		flags = append(flags, flag)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return flags, nil
+}
+
+// ResolveFlag 結案合規標記（open → resolved）
+func (r *ScreeningRepository) ResolveFlag(ctx context.Context, id, resolvedBy int64, note string) error {
+	query := `
+		UPDATE compliance_flags
+		SET status = 'resolved', resolution_note = $1, resolved_by = $2, resolved_at = $3
+		WHERE id = $4 AND status = 'open'
+	`
+
+	result, err := r.db.ExecContext(ctx, query, note, resolvedBy, time.Now(), id)
+	if err != nil {
+		return fmt.Errorf("failed to resolve compliance flag: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("compliance flag not found or already resolved")
+	}
+
+	return nil
+}
+
+const complianceFlagColumns = `
+	id, wallet_address, user_id, tx_hash, event_type, bond_id,
+	list_name, reason, status, resolution_note, resolved_by, resolved_at, created_at
+`
+
+func scanComplianceFlag(row rowScanner) (*models.ComplianceFlag, error) {
+	flag := &models.ComplianceFlag{}
+	err := row.Scan(
+		&flag.ID,
+		&flag.WalletAddress,
+		&flag.UserID,
+		&flag.TxHash,
+		&flag.EventType,
+		&flag.BondID,
+		&flag.ListName,
+		&flag.Reason,
+		&flag.Status,
+		&flag.ResolutionNote,
+		&flag.ResolvedBy,
+		&flag.ResolvedAt,
+		&flag.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return flag, nil
+}
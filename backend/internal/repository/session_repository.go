@@ -6,6 +6,8 @@ import (
 	"database/sql"
 	"fmt"
 	"time"
+
+	"github.com/lib/pq"
 )
 
 // SessionRepository 處理 Session 的資料庫操作
@@ -22,10 +24,10 @@ func NewSessionRepository(db *sql.DB) *SessionRepository {
 func (r *SessionRepository) Create(ctx context.Context, session *models.DBSession) error {
 	query := `
 		INSERT INTO sessions (
-			id, user_id, wallet_address, role, 
+			id, user_id, wallet_address, role, roles, kyc_status, kyc_level,
 			ip_address, user_agent, created_at, 
 			last_active_at, expires_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
 	`
 
 	_, err := r.db.ExecContext(
@@ -34,6 +36,9 @@ func (r *SessionRepository) Create(ctx context.Context, session *models.DBSessio
 		session.UserID,
 		session.WalletAddress,
 		session.Role,
+		pq.Array(session.Roles),
+		session.KYCStatus,
+		session.KYCLevel,
 		session.IPAddress,
 		session.UserAgent,
 		session.CreatedAt,
@@ -52,7 +57,7 @@ func (r *SessionRepository) Create(ctx context.Context, session *models.DBSessio
 func (r *SessionRepository) GetByID(ctx context.Context, sessionID string) (*models.DBSession, error) {
 	query := `
 		SELECT 
-			id, user_id, wallet_address, role,
+			id, user_id, wallet_address, role, roles, kyc_status, kyc_level,
 			ip_address, user_agent, created_at,
 			last_active_at, expires_at
 		FROM sessions
@@ -60,11 +65,15 @@ func (r *SessionRepository) GetByID(ctx context.Context, sessionID string) (*mod
 	`
 
 	var session models.DBSession
+	var roles pq.StringArray
 	err := r.db.QueryRowContext(ctx, query, sessionID).Scan(
 		&session.ID,
 		&session.UserID,
 		&session.WalletAddress,
 		&session.Role,
+		&roles,
+		&session.KYCStatus,
+		&session.KYCLevel,
 		&session.IPAddress,
 		&session.UserAgent,
 		&session.CreatedAt,
@@ -79,18 +88,24 @@ func (r *SessionRepository) GetByID(ctx context.Context, sessionID string) (*mod
 		return nil, fmt.Errorf("failed to get session: %w", err)
 	}
 
+	session.Roles = []string(roles)
 	return &session, nil
 }
 
 // Update 更新 Session 的最後活躍時間
-func (r *SessionRepository) Update(ctx context.Context, sessionID string) error {
+// expiresAt 不為 nil 時一併延長到期時間（滑動到期），但不超過建立後 maxLifetime
+func (r *SessionRepository) Update(ctx context.Context, sessionID string, expiresAt *time.Time, maxLifetime time.Duration) error {
 	query := `
 		UPDATE sessions
-		SET last_active_at = $1
-		WHERE id = $2
+		SET last_active_at = $1,
+			expires_at = CASE
+				WHEN $2::timestamp IS NULL THEN expires_at
+				ELSE LEAST($2::timestamp, created_at + make_interval(secs => $3))
+			END
+		WHERE id = $4
 	`
 
-	result, err := r.db.ExecContext(ctx, query, time.Now(), sessionID)
+	result, err := r.db.ExecContext(ctx, query, time.Now(), expiresAt, maxLifetime.Seconds(), sessionID)
 	if err != nil {
 		return fmt.Errorf("failed to update session: %w", err)
 	}
@@ -107,6 +122,134 @@ func (r *SessionRepository) Update(ctx context.Context, sessionID string) error
 	return nil
 }
 
+// SetRefreshToken 設定 Session 的 refresh token 雜湊與到期時間（token 模式）
+func (r *SessionRepository) SetRefreshToken(ctx context.Context, sessionID, tokenHash string, expiresAt time.Time) error {
+	query := `
+		UPDATE sessions
+		SET refresh_token_hash = $1, expires_at = $2
+		WHERE id = $3
+	`
+
+	result, err := r.db.ExecContext(ctx, query, tokenHash, expiresAt, sessionID)
+	if err != nil {
+		return fmt.Errorf("failed to set refresh token: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("session not found")
+	}
+
+	return nil
+}
+
+// RotateRefreshToken 僅在目前的雜湊相符時替換 refresh token 並更新活躍時間，並記錄被替換的雜湊（同一交易）
+// 回傳是否成功輪替與 Session 到期時間；不相符時不做任何變更
+// extendTo 不為 nil 時一併延長到期時間（滑動到期）
+func (r *SessionRepository) RotateRefreshToken(ctx context.Context, sessionID, currentHash, newHash string, extendTo *time.Time) (bool, time.Time, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, time.Time{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE sessions
+		SET refresh_token_hash = $1, last_active_at = $2, expires_at = COALESCE($3, expires_at)
+		WHERE id = $4 AND refresh_token_hash = $5 AND expires_at > NOW()
+		RETURNING expires_at
+	`
+
+	var expiresAt time.Time
+	err = tx.QueryRowContext(ctx, query, newHash, time.Now(), extendTo, sessionID, currentHash).Scan(&expiresAt)
+	if err == sql.ErrNoRows {
+		return false, time.Time{}, nil
+	}
+	if err != nil {
+		return false, time.Time{}, fmt.Errorf("failed to rotate refresh token: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO rotated_refresh_tokens (token_hash, session_id, rotated_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (token_hash) DO NOTHING
+	`, currentHash, sessionID, time.Now())
+	if err != nil {
+		return false, time.Time{}, fmt.Errorf("failed to record rotated refresh token: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, time.Time{}, fmt.Errorf("failed to commit refresh token rotation: %w", err)
+	}
+
+	return true, expiresAt, nil
+}
+
+// IsRotatedRefreshToken 檢查雜湊是否為該 Session 已輪替過的 refresh token
+func (r *SessionRepository) IsRotatedRefreshToken(ctx context.Context, sessionID, tokenHash string) (bool, error) {
+	var exists bool
+	err := r.db.QueryRowContext(ctx, `
+		SELECT EXISTS (SELECT 1 FROM rotated_refresh_tokens WHERE token_hash = $1 AND session_id = $2)
+	`, tokenHash, sessionID).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to check rotated refresh token: %w", err)
+	}
+
+	return exists, nil
+}
+
+// UpdateKYCByUserID 更新使用者所有 Session 的 KYC 狀態
+func (r *SessionRepository) UpdateKYCByUserID(ctx context.Context, userID int64, kycStatus string, kycLevel int) error {
+	query := `
+		UPDATE sessions
+		SET kyc_status = $1, kyc_level = $2
+		WHERE user_id = $3
+	`
+
+	_, err := r.db.ExecContext(ctx, query, kycStatus, kycLevel, userID)
+	if err != nil {
+		return fmt.Errorf("failed to update session KYC: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateRoleByUserID 更新使用者所有 Session 的角色
+func (r *SessionRepository) UpdateRoleByUserID(ctx context.Context, userID int64, role string) error {
+	query := `
+		UPDATE sessions
+		SET role = $1
+		WHERE user_id = $2
+	`
+
+	_, err := r.db.ExecContext(ctx, query, role, userID)
+	if err != nil {
+		return fmt.Errorf("failed to update session role: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateRolesByUserID 更新使用者所有 Session 的額外角色
+func (r *SessionRepository) UpdateRolesByUserID(ctx context.Context, userID int64, roles []string) error {
+	query := `
+		UPDATE sessions
+		SET roles = $1
+		WHERE user_id = $2
+	`
+
+	_, err := r.db.ExecContext(ctx, query, pq.Array(roles), userID)
+	if err != nil {
+		return fmt.Errorf("failed to update session roles: %w", err)
+	}
+
+	return nil
+}
+
 // Delete 刪除特定的 Session
 func (r *SessionRepository) Delete(ctx context.Context, sessionID string) error {
 	query := `DELETE FROM sessions WHERE id = $1`
@@ -131,11 +274,23 @@ func (r *SessionRepository) DeleteByWalletAddress(ctx context.Context, walletAdd
 	return nil
 }
 
+// DeleteByUserID 刪除特定使用者所有錢包的 Session
+func (r *SessionRepository) DeleteByUserID(ctx context.Context, userID int64) error {
+	query := `DELETE FROM sessions WHERE user_id = $1`
+
+	_, err := r.db.ExecContext(ctx, query, userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete user sessions: %w", err)
+	}
+
+	return nil
+}
+
 // GetByWalletAddress 取得特定錢包地址的所有 Session
 func (r *SessionRepository) GetByWalletAddress(ctx context.Context, walletAddress string) ([]*models.DBSession, error) {
 	query := `
 		SELECT 
-			id, user_id, wallet_address, role,
+			id, user_id, wallet_address, role, roles, kyc_status, kyc_level,
 			ip_address, user_agent, created_at,
 			last_active_at, expires_at
 		FROM sessions
@@ -149,27 +304,28 @@ func (r *SessionRepository) GetByWalletAddress(ctx context.Context, walletAddres
 	}
 	defer rows.Close()
 
-	var sessions []*models.DBSession
-	for rows.Next() {
-		var session models.DBSession
-		err := rows.Scan(
-			&session.ID,
-			&session.UserID,
-			&session.WalletAddress,
-			&session.Role,
-			&session.IPAddress,
-			&session.UserAgent,
-			&session.CreatedAt,
-			&session.LastActiveAt,
-			&session.ExpiresAt,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("failed to scan session: %w", err)
-		}
-		sessions = append(sessions, &session)
+	return scanSessions(rows)
+}
+
+// GetByUserID 取得特定使用者所有錢包的 Session
+func (r *SessionRepository) GetByUserID(ctx context.Context, userID int64) ([]*models.DBSession, error) {
+	query := `
+		SELECT 
+			id, user_id, wallet_address, role, roles, kyc_status, kyc_level,
+			ip_address, user_agent, created_at,
+			last_active_at, expires_at
+		FROM sessions
+		WHERE user_id = $1 AND expires_at > NOW()
+		ORDER BY last_active_at DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user sessions: %w", err)
 	}
+	defer rows.Close()
 
-	return sessions, nil
+	return scanSessions(rows)
 }
 
 // CountByWalletAddress 計算特定錢包地址的 Session 數量
@@ -189,6 +345,23 @@ func (r *SessionRepository) CountByWalletAddress(ctx context.Context, walletAddr
 	return count, nil
 }
 
+// CountByUserID 計算特定使用者所有錢包的 Session 數量
+func (r *SessionRepository) CountByUserID(ctx context.Context, userID int64) (int, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM sessions
+		WHERE user_id = $1 AND expires_at > NOW()
+	`
+
+	var count int
+	err := r.db.QueryRowContext(ctx, query, userID).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count sessions: %w", err)
+	}
+
+	return count, nil
+}
+
 // DeleteExpired 清理過期的 Session
 func (r *SessionRepository) DeleteExpired(ctx context.Context) (int64, error) {
 	query := `DELETE FROM sessions WHERE expires_at <= NOW()`
@@ -213,7 +386,7 @@ func (r *SessionRepository) DeleteOldestSession(ctx context.Context, walletAddre
 		WHERE id = (
 			SELECT id 
 			FROM sessions 
-			WHERE wallet_address = $1 
+			WHERE wallet_address = $1 AND expires_at > NOW()
 			ORDER BY last_active_at ASC 
 			LIMIT 1
 		)
@@ -226,3 +399,53 @@ func (r *SessionRepository) DeleteOldestSession(ctx context.Context, walletAddre
 
 	return nil
 }
+
+// DeleteOldestByUserID 刪除特定使用者所有錢包中最久未活動的 Session
+func (r *SessionRepository) DeleteOldestByUserID(ctx context.Context, userID int64) error {
+	query := `
+		DELETE FROM sessions
+		WHERE id = (
+			SELECT id
+			FROM sessions
+			WHERE user_id = $1 AND expires_at > NOW()
+			ORDER BY last_active_at ASC
+			LIMIT 1
+		)
+	`
+
+	_, err := r.db.ExecContext(ctx, query, userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete oldest session: %w", err)
+	}
+
+	return nil
+}
+
+func scanSessions(rows *sql.Rows) ([]*models.DBSession, error) {
+	var sessions []*models.DBSession
+	for rows.Next() {
+		var session models.DBSession
+		var roles pq.StringArray
+		err := rows.Scan(
+			&session.ID,
+			&session.UserID,
+			&session.WalletAddress,
+			&session.Role,
+			&roles,
+			&session.KYCStatus,
+			&session.KYCLevel,
+			&session.IPAddress,
+			&session.UserAgent,
+			&session.CreatedAt,
+			&session.LastActiveAt,
+			&session.ExpiresAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan session: %w", err)
+		}
+		session.Roles = []string(roles)
+		sessions = append(sessions, &session)
+	}
+
+	return sessions, nil
+}
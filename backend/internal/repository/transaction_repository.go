@@ -7,6 +7,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"time"
+
+	"github.com/lib/pq"
 )
 
 type TransactionRepository struct {
@@ -108,6 +110,26 @@ func (r *TransactionRepository) ListByUser(ctx context.Context, userID int64, li
 	return r.scanTransactions(rows)
 }
 
+// ListByWallets 查詢多個錢包地址（同一使用者的綁定錢包）的交易記錄
+func (r *TransactionRepository) ListByWallets(ctx context.Context, walletAddresses []string, limit, offset int) ([]*models.Transaction, error) {
+	query := `
+		SELECT id, tx_hash, event_type, bond_id, user_id, wallet_address,
+		       amount, quantity, price, status, block_number, timestamp, metadata, created_at
+		FROM transactions
+		WHERE wallet_address = ANY($1)
+		ORDER BY timestamp DESC
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(walletAddresses), limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list wallet transactions: %w", err)
+	}
+	defer rows.Close()
+
+	return r.scanTransactions(rows)
+}
+
 // ListByBond 查詢債券的交易記錄
 func (r *TransactionRepository) ListByBond(ctx context.Context, bondID int64, limit, offset int) ([]*models.Transaction, error) {
 	query := `
@@ -196,7 +218,8 @@ func (r *TransactionRepository) GetUserBonds(ctx context.Context, userID int64)
 			ub.quantity, ub.average_purchase_price, ub.total_interest_earned,
 			ub.created_at, ub.updated_at,
 			b.bond_name, b.issuer_name, b.annual_interest_rate, 
-			b.maturity_date, b.active, b.redeemable
+			b.maturity_date, b.active, b.redeemable,
+			b.coin_type, b.coin_decimals
 		FROM user_bonds ub
 		JOIN bonds b ON ub.bond_id = b.id
 		WHERE ub.user_id = $1 AND ub.quantity > 0 AND b.deleted_at IS NULL
@@ -229,6 +252,8 @@ func (r *TransactionRepository) GetUserBonds(ctx context.Context, userID int64)
 			&ub.MaturityDate,
 			&ub.Active,
 			&ub.Redeemable,
+			&ub.CoinType,
+			&ub.CoinDecimals,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan user bond: %w", err)
@@ -393,3 +418,175 @@ func MetadataToJSON(metadata interface{}) (*string, error) {
 	str := string(bytes)
 	return &str, nil
 }
+
+// ListByBondAndEventType 查詢債券特定類型的交易記錄（如二級市場成交）
+func (r *TransactionRepository) ListByBondAndEventType(ctx context.Context, bondID int64, eventType string, limit, offset int) ([]*models.Transaction, error) {
+	query := `
+		SELECT id, tx_hash, event_type, bond_id, user_id, wallet_address,
+		       amount, quantity, price, status, block_number, timestamp, metadata, created_at
+		FROM transactions
+		WHERE bond_id = $1 AND event_type = $2
+		ORDER BY timestamp DESC
+		LIMIT $3 OFFSET $4
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, bondID, eventType, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list bond transactions by event type: %w", err)
+	}
+	defer rows.Close()
+
+	return r.scanTransactions(rows)
+}
+
+// GetByTxHashAndToken 查詢某筆鏈上交易中指定代幣的記錄
+func (r *TransactionRepository) GetByTxHashAndToken(ctx context.Context, txHash, tokenID string) (*models.Transaction, error) {
+	query := `
+		SELECT id, tx_hash, event_type, bond_id, user_id, wallet_address,
+		       amount, quantity, price, status, block_number, timestamp, metadata, created_at
+		FROM transactions
+		WHERE tx_hash = $1 AND metadata->>'token_id' = $2
+		LIMIT 1
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, txHash, tokenID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transaction by hash and token: %w", err)
+	}
+	defer rows.Close()
+
+	txs, err := r.scanTransactions(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(txs) == 0 {
+		return nil, nil
+	}
+
+	return txs[0], nil
+}
+
+// ListByToken 查詢代幣的完整交易歷史（購買、轉移、贖回），依時間由舊到新
+func (r *TransactionRepository) ListByToken(ctx context.Context, tokenID string) ([]*models.Transaction, error) {
+	query := `
+		SELECT id, tx_hash, event_type, bond_id, user_id, wallet_address,
+		       amount, quantity, price, status, block_number, timestamp, metadata, created_at
+		FROM transactions
+		WHERE metadata->>'token_id' = $1
+		ORDER BY timestamp ASC, id ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, tokenID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list token transactions: %w", err)
+	}
+	defer rows.Close()
+
+	return r.scanTransactions(rows)
+}
+
+// CreateTransferWithOwnership 記錄代幣轉移並更新持有者與雙方持倉（事務處理）
+// fromUserID 為 nil 時（原持有者不是平台使用者）僅增加接收方持倉
+func (r *TransactionRepository) CreateTransferWithOwnership(
+	ctx context.Context,
+	tx *models.Transaction,
+	tokenID string,
+	fromUserID *int64,
+	costBasis float64,
+) error {
+	if tx.UserID == nil || tx.BondID == nil {
+		return fmt.Errorf("user_id and bond_id are required for bond transfer")
+	}
+
+	dbTx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer dbTx.Rollback()
+
+	now := time.Now()
+
+	// 1. 創建交易記錄
+	err = dbTx.QueryRowContext(ctx, `
+		INSERT INTO transactions (
+			tx_hash, event_type, bond_id, user_id, wallet_address,
+			amount, quantity, price, status, block_number, timestamp, metadata, created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		RETURNING id, created_at
+	`,
+		tx.TxHash,
+		tx.EventType,
+		tx.BondID,
+		tx.UserID,
+		tx.WalletAddress,
+		tx.Amount,
+		tx.Quantity,
+		tx.Price,
+		tx.Status,
+		tx.BlockNumber,
+		tx.Timestamp,
+		tx.Metadata,
+		now,
+	).Scan(&tx.ID, &tx.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create transaction: %w", err)
+	}
+
+	// 2. 更新代幣持有者
+	if _, err := dbTx.ExecContext(ctx, `
+		UPDATE bond_tokens
+		SET owner = $1, updated_at = $2
+		WHERE on_chain_id = $3 AND deleted_at IS NULL
+	`, tx.WalletAddress, now, tokenID); err != nil {
+		return fmt.Errorf("failed to update bond token owner: %w", err)
+	}
+
+	// 3. 更新雙方持倉
+	if fromUserID != nil {
+		if err := adjustUserBond(ctx, dbTx, *fromUserID, *tx.BondID, -1, 0, now); err != nil {
+			return err
+		}
+	}
+	if err := adjustUserBond(ctx, dbTx, *tx.UserID, *tx.BondID, 1, costBasis, now); err != nil {
+		return err
+	}
+
+	if err := dbTx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// GetLastTrade 查詢債券在二級市場的最後一筆成交（含成交代幣面額）
+func (r *TransactionRepository) GetLastTrade(ctx context.Context, bondID int64) (*models.LastTrade, error) {
+	query := `
+		SELECT t.tx_hash, t.metadata->>'token_id', t.price, bt.amount, t.timestamp
+		FROM transactions t
+		JOIN bond_tokens bt ON bt.on_chain_id = t.metadata->>'token_id'
+		WHERE t.bond_id = $1
+		  AND t.event_type = $2
+		  AND t.price IS NOT NULL
+		  AND t.metadata->>'source' = 'secondary_market'
+		  AND bt.amount > 0
+		ORDER BY t.timestamp DESC, t.id DESC
+		LIMIT 1
+	`
+
+	trade := &models.LastTrade{}
+	err := r.db.QueryRowContext(ctx, query, bondID, models.EventBondTransferred).Scan(
+		&trade.TxHash,
+		&trade.TokenID,
+		&trade.Price,
+		&trade.FaceValue,
+		&trade.Timestamp,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get last trade: %w", err)
+	}
+
+	return trade, nil
+}
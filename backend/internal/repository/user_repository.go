@@ -5,6 +5,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strings"
 	"time"
 )
 
@@ -28,6 +29,7 @@ func (r *UserRepository) CreateWithRole(ctx context.Context, walletAddress, role
 		Role:          role,
 		Timezone:      "UTC",
 		Language:      "en",
+		KYCStatus:     models.KYCStatusNone,
 	}
 
 	query := `
@@ -59,6 +61,8 @@ func (r *UserRepository) GetByID(ctx context.Context, id int64) (*models.User, e
 
 	query := `
 		SELECT id, wallet_address, role, institution_name, name, timezone, language, 
+		       kyc_status, kyc_level, kyc_verified_at, COALESCE(country, ''), is_blacklisted,
+		       daily_limit, monthly_limit, max_bond_share,
 		       created_at, updated_at, deleted_at
 		FROM users
 		WHERE id = $1 AND deleted_at IS NULL
@@ -72,6 +76,14 @@ func (r *UserRepository) GetByID(ctx context.Context, id int64) (*models.User, e
 		&user.Name,
 		&user.Timezone,
 		&user.Language,
+		&user.KYCStatus,
+		&user.KYCLevel,
+		&user.KYCVerifiedAt,
+		&user.Country,
+		&user.IsBlacklisted,
+		&user.DailyLimit,
+		&user.MonthlyLimit,
+		&user.MaxBondShare,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 		&user.DeletedAt,
@@ -87,15 +99,18 @@ func (r *UserRepository) GetByID(ctx context.Context, id int64) (*models.User, e
 	return user, nil
 }
 
-// GetByWalletAddress 根據錢包地址查詢使用者
+// GetByWalletAddress 根據錢包地址查詢使用者（主錢包或已綁定的錢包）
 func (r *UserRepository) GetByWalletAddress(ctx context.Context, walletAddress string) (*models.User, error) {
 	user := &models.User{}
 
 	query := `
 		SELECT id, wallet_address, role, institution_name, name, timezone, language, 
+		       kyc_status, kyc_level, kyc_verified_at, COALESCE(country, ''), is_blacklisted,
+		       daily_limit, monthly_limit, max_bond_share,
 		       created_at, updated_at, deleted_at
 		FROM users
-		WHERE wallet_address = $1 AND deleted_at IS NULL
+		WHERE (wallet_address = $1 OR id = (SELECT user_id FROM user_wallets WHERE wallet_address = $1))
+		  AND deleted_at IS NULL
 	`
 
 	err := r.db.QueryRowContext(ctx, query, walletAddress).Scan(
@@ -106,6 +121,14 @@ func (r *UserRepository) GetByWalletAddress(ctx context.Context, walletAddress s
 		&user.Name,
 		&user.Timezone,
 		&user.Language,
+		&user.KYCStatus,
+		&user.KYCLevel,
+		&user.KYCVerifiedAt,
+		&user.Country,
+		&user.IsBlacklisted,
+		&user.DailyLimit,
+		&user.MonthlyLimit,
+		&user.MaxBondShare,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 		&user.DeletedAt,
@@ -183,6 +206,8 @@ func (r *UserRepository) Delete(ctx context.Context, id int64) error {
 func (r *UserRepository) List(ctx context.Context, limit, offset int) ([]*models.User, error) {
 	query := `
 		SELECT id, wallet_address, role, institution_name, name, timezone, language, 
+		       kyc_status, kyc_level, kyc_verified_at, COALESCE(country, ''), is_blacklisted,
+		       daily_limit, monthly_limit, max_bond_share,
 		       created_at, updated_at, deleted_at
 		FROM users
 		WHERE deleted_at IS NULL
@@ -207,6 +232,14 @@ func (r *UserRepository) List(ctx context.Context, limit, offset int) ([]*models
 			&user.Name,
 			&user.Timezone,
 			&user.Language,
+			&user.KYCStatus,
+			&user.KYCLevel,
+			&user.KYCVerifiedAt,
+			&user.Country,
+			&user.IsBlacklisted,
+			&user.DailyLimit,
+			&user.MonthlyLimit,
+			&user.MaxBondShare,
 			&user.CreatedAt,
 			&user.UpdatedAt,
 			&user.DeletedAt,
@@ -223,3 +256,254 @@ func (r *UserRepository) List(ctx context.Context, limit, offset int) ([]*models
 
 	return users, nil
 }
+
+// UpdateKYC 更新使用者的 KYC 狀態與等級
+func (r *UserRepository) UpdateKYC(ctx context.Context, userID int64, status string, level int, verifiedAt *time.Time) error {
+	query := `
+		UPDATE users
+		SET kyc_status = $1, kyc_level = $2, kyc_verified_at = $3, updated_at = $4
+		WHERE id = $5 AND deleted_at IS NULL
+	`
+
+	result, err := r.db.ExecContext(ctx, query, status, level, verifiedAt, time.Now(), userID)
+	if err != nil {
+		return fmt.Errorf("failed to update user KYC: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("user not found or already deleted")
+	}
+
+	return nil
+}
+
+// UpdateCountry 更新使用者的國家/地區
+func (r *UserRepository) UpdateCountry(ctx context.Context, userID int64, country string) error {
+	query := `
+		UPDATE users
+		SET country = $1, updated_at = $2
+		WHERE id = $3 AND deleted_at IS NULL
+	`
+
+	if _, err := r.db.ExecContext(ctx, query, country, time.Now(), userID); err != nil {
+		return fmt.Errorf("failed to update user country: %w", err)
+	}
+
+	return nil
+}
+
+// SetBlacklisted 設定使用者的黑名單狀態
+func (r *UserRepository) SetBlacklisted(ctx context.Context, userID int64, blacklisted bool) error {
+	query := `
+		UPDATE users
+		SET is_blacklisted = $1, updated_at = $2
+		WHERE id = $3 AND deleted_at IS NULL
+	`
+
+	if _, err := r.db.ExecContext(ctx, query, blacklisted, time.Now(), userID); err != nil {
+		return fmt.Errorf("failed to update user blacklist status: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateLimits 設定使用者的個別投資限額（nil 表示沿用 KYC 等級的預設值）
+func (r *UserRepository) UpdateLimits(ctx context.Context, userID int64, dailyLimit, monthlyLimit, maxBondShare *float64) error {
+	query := `
+		UPDATE users
+		SET daily_limit = $1, monthly_limit = $2, max_bond_share = $3, updated_at = $4
+		WHERE id = $5 AND deleted_at IS NULL
+	`
+
+	result, err := r.db.ExecContext(ctx, query, dailyLimit, monthlyLimit, maxBondShare, time.Now(), userID)
+	if err != nil {
+		return fmt.Errorf("failed to update user limits: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("user not found or already deleted")
+	}
+
+	return nil
+}
+
+// Search 依條件查詢使用者（管理員功能），同時回傳符合條件的總筆數
+func (r *UserRepository) Search(ctx context.Context, filter models.UserFilter, limit, offset int) ([]*models.User, int, error) {
+	query := `SELECT ` + userColumns + `, COUNT(*) OVER()
+		FROM users
+		WHERE ($1 = '' OR role = $1)
+		  AND ($2 = '' OR wallet_address ILIKE $2 || '%')
+		  AND ($3 = '' OR name ILIKE '%' || $3 || '%' OR institution_name ILIKE '%' || $3 || '%')
+		  AND CASE $4
+		        WHEN 'all' THEN TRUE
+		        WHEN 'deleted' THEN deleted_at IS NOT NULL
+		        ELSE deleted_at IS NULL
+		      END
+		ORDER BY created_at DESC
+		LIMIT $5 OFFSET $6
+	`
+
+	rows, err := r.db.QueryContext(ctx, query,
+		filter.Role,
+		escapeLike(filter.Wallet),
+		escapeLike(filter.Name),
+		filter.Status,
+		limit,
+		offset,
+	)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to search users: %w", err)
+	}
+	defer rows.Close()
+
+	users := []*models.User{}
+	total := 0
+	for rows.Next() {
+		user := &models.User{}
+		if err := rows.Scan(append(userScanTargets(user), &total)...); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan user: %w", err)
+		}
+		users = append(users, user)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("rows error: %w", err)
+	}
+
+	return users, total, nil
+}
+
+// GetByIDUnscoped 根據 ID 查詢使用者（包含已刪除，管理員功能）
+func (r *UserRepository) GetByIDUnscoped(ctx context.Context, id int64) (*models.User, error) {
+	query := `SELECT ` + userColumns + `
+		FROM users
+		WHERE id = $1
+	`
+
+	user := &models.User{}
+	err := r.db.QueryRowContext(ctx, query, id).Scan(userScanTargets(user)...)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user by ID: %w", err)
+	}
+
+	return user, nil
+}
+
+// GetByWalletAddressUnscoped 根據錢包地址查詢使用者（主錢包或已綁定的錢包，包含已刪除）
+func (r *UserRepository) GetByWalletAddressUnscoped(ctx context.Context, walletAddress string) (*models.User, error) {
+	query := `SELECT ` + userColumns + `
+		FROM users
+		WHERE wallet_address = $1 OR id = (SELECT user_id FROM user_wallets WHERE wallet_address = $1)
+	`
+
+	user := &models.User{}
+	err := r.db.QueryRowContext(ctx, query, walletAddress).Scan(userScanTargets(user)...)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user by wallet address: %w", err)
+	}
+
+	return user, nil
+}
+
+// UpdateRole 更新使用者角色
+func (r *UserRepository) UpdateRole(ctx context.Context, userID int64, role string) error {
+	query := `
+		UPDATE users
+		SET role = $1, updated_at = $2
+		WHERE id = $3 AND deleted_at IS NULL
+	`
+
+	result, err := r.db.ExecContext(ctx, query, role, time.Now(), userID)
+	if err != nil {
+		return fmt.Errorf("failed to update user role: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("user not found or already deleted")
+	}
+
+	return nil
+}
+
+// Restore 還原已軟刪除的使用者
+func (r *UserRepository) Restore(ctx context.Context, id int64) error {
+	query := `
+		UPDATE users
+		SET deleted_at = NULL, updated_at = $1
+		WHERE id = $2 AND deleted_at IS NOT NULL
+	`
+
+	result, err := r.db.ExecContext(ctx, query, time.Now(), id)
+	if err != nil {
+		return fmt.Errorf("failed to restore user: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("user not found or not deleted")
+	}
+
+	return nil
+}
+
+const userColumns = `
+	id, wallet_address, role, institution_name, name, timezone, language,
+	kyc_status, kyc_level, kyc_verified_at, COALESCE(country, ''), is_blacklisted,
+	daily_limit, monthly_limit, max_bond_share,
+	created_at, updated_at, deleted_at
+`
+
+// userScanTargets 對應 userColumns 的掃描目標
+func userScanTargets(user *models.User) []any {
+	return []any{
+		&user.ID,
+		&user.WalletAddress,
+		&user.Role,
+		&user.InstitutionName,
+		&user.Name,
+		&user.Timezone,
+		&user.Language,
+		&user.KYCStatus,
+		&user.KYCLevel,
+		&user.KYCVerifiedAt,
+		&user.Country,
+		&user.IsBlacklisted,
+		&user.DailyLimit,
+		&user.MonthlyLimit,
+		&user.MaxBondShare,
+		&user.CreatedAt,
+		&user.UpdatedAt,
+		&user.DeletedAt,
+	}
+}
+
+// escapeLike 跳脫 LIKE 樣式中的萬用字元
+func escapeLike(value string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+	return replacer.Replace(value)
+}
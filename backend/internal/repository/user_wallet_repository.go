@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"bluelink-backend/internal/models"
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// UserWalletRepository 處理使用者綁定錢包的資料庫操作
+type UserWalletRepository struct {
+	db *sql.DB
+}
+
+// NewUserWalletRepository 建立新的 UserWalletRepository
+func NewUserWalletRepository(db *sql.DB) *UserWalletRepository {
+	return &UserWalletRepository{db: db}
+}
+
+const linkedWalletColumns = `id, user_id, wallet_address, label, linked_at`
+
+// Create 綁定錢包到使用者帳戶
+func (r *UserWalletRepository) Create(ctx context.Context, wallet *models.LinkedWallet) error {
+	query := `
+		INSERT INTO user_wallets (user_id, wallet_address, label, linked_at)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, linked_at
+	`
+
+	err := r.db.QueryRowContext(ctx, query,
+		wallet.UserID,
+		wallet.WalletAddress,
+		wallet.Label,
+		time.Now(),
+	).Scan(&wallet.ID, &wallet.LinkedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to link wallet: %w", err)
+	}
+
+	return nil
+}
+
+// GetByAddress 根據地址查詢已綁定的錢包（未綁定時回傳 nil）
+func (r *UserWalletRepository) GetByAddress(ctx context.Context, walletAddress string) (*models.LinkedWallet, error) {
+	query := `SELECT ` + linkedWalletColumns + `
+		FROM user_wallets
+		WHERE wallet_address = $1
+	`
+
+	wallet, err := scanLinkedWallet(r.db.QueryRowContext(ctx, query, walletAddress))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get linked wallet: %w", err)
+	}
+
+	return wallet, nil
+}
+
+// ListByUser 查詢使用者已綁定的錢包（不含主錢包）
+func (r *UserWalletRepository) ListByUser(ctx context.Context, userID int64) ([]*models.LinkedWallet, error) {
+	query := `SELECT ` + linkedWalletColumns + `
+		FROM user_wallets
+		WHERE user_id = $1
+		ORDER BY linked_at ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list linked wallets: %w", err)
+	}
+	defer rows.Close()
+
+	wallets := []*models.LinkedWallet{}
+	for rows.Next() {
+		wallet, err := scanLinkedWallet(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan linked wallet: %w", err)
+		}
+		wallets = append(wallets, wallet)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return wallets, nil
+}
+
+// CountByUser 計算使用者已綁定的錢包數量
+func (r *UserWalletRepository) CountByUser(ctx context.Context, userID int64) (int, error) {
+	var count int
+	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM user_wallets WHERE user_id = $1`, userID).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count linked wallets: %w", err)
+	}
+
+	return count, nil
+}
+
+// Delete 解除綁定錢包
+func (r *UserWalletRepository) Delete(ctx context.Context, userID int64, walletAddress string) error {
+	query := `DELETE FROM user_wallets WHERE user_id = $1 AND wallet_address = $2`
+
+	result, err := r.db.ExecContext(ctx, query, userID, walletAddress)
+	if err != nil {
+		return fmt.Errorf("failed to unlink wallet: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("linked wallet not found")
+	}
+
+	return nil
+}
+
+func scanLinkedWallet(row rowScanner) (*models.LinkedWallet, error) {
+	wallet := &models.LinkedWallet{}
+
+	err := row.Scan(
+		&wallet.ID,
+		&wallet.UserID,
+		&wallet.WalletAddress,
+		&wallet.Label,
+		&wallet.LinkedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return wallet, nil
+}
@@ -2,15 +2,21 @@ package routes
 
 import (
 	"bluelink-backend/internal/config"
+	"bluelink-backend/internal/handlers/accounts"
 	"bluelink-backend/internal/handlers/auth"
 	"bluelink-backend/internal/handlers/bonds"
+	"bluelink-backend/internal/handlers/compliance"
+	"bluelink-backend/internal/handlers/kyc"
+	"bluelink-backend/internal/handlers/market"
 	"bluelink-backend/internal/handlers/users"
 	"bluelink-backend/internal/middleware"
 	"bluelink-backend/internal/models"
 	"bluelink-backend/internal/repository"
 	"bluelink-backend/internal/services"
 	"bluelink-backend/internal/session"
+	"bluelink-backend/internal/siws"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -22,17 +28,74 @@ func SetupRoutes(
 	bondService *services.BondService,
 	bondTokenService *services.BondTokenService,
 	syncService *services.SyncService,
+	proposalService *services.BondProposalService,
+	impactService *services.ImpactService,
+	marketService *services.MarketService,
+	valuationService *services.ValuationService,
+	coinService *services.CoinService,
+	priceService *services.PriceService,
+	kycService *services.KYCService,
+	screeningService *services.ScreeningService,
+	limitService *services.InvestmentLimitService,
+	adminUserService *services.AdminUserService,
+	issuerApplicationService *services.IssuerApplicationService,
+	organizationService *services.OrganizationService,
+	walletService *services.WalletService,
+	auditService *services.AuditService,
+	apiKeyService *services.APIKeyService,
+	roleService *services.RoleService,
+	loginHistoryService *services.LoginHistoryService,
+	notificationService *services.NotificationService,
+	authGuardService *services.AuthGuardService,
 	sessionManager session.SessionManager,
+	tokenManager *session.TokenManager,
 	nonceRepo *repository.NonceRepository,
 	cfg *config.Config,
 ) {
-	// 判斷是否為生產環境
-	isProduction := cfg.Environment == "production"
+	// Sign-In with Sui 登入訊息綁定的網域、網址與網路
+	siwsConfig := siws.Config{
+		Domain:  cfg.SIWSDomain,
+		URI:     cfg.SIWSURI,
+		ChainID: siws.ChainID(cfg.SuiNetwork),
+		TTL:     10 * time.Minute,
+	}
 
 	// 初始化 handlers
-	authHandler := auth.NewAuthHandler(userService, sessionManager, nonceRepo, isProduction)
+	challengeMetrics := auth.NewChallengeMetrics()
+	authHandler := auth.NewAuthHandler(userService, roleService, sessionManager, tokenManager, nonceRepo, screeningService, auditService, loginHistoryService, authGuardService, siwsConfig, challengeMetrics)
+	walletHandler := auth.NewWalletHandler(walletService, userService, nonceRepo, screeningService, authGuardService, siwsConfig, challengeMetrics)
 	profileHandler := users.NewProfileHandler(userService)
-	bondHandler := bonds.NewBondHandler(bondService, bondTokenService, syncService)
+	securityHandler := users.NewSecurityHandler(loginHistoryService, notificationService)
+	bondHandler := bonds.NewBondHandler(bondService, bondTokenService, syncService, valuationService, priceService, walletService, auditService)
+	proposalHandler := bonds.NewProposalHandler(proposalService)
+	impactHandler := bonds.NewImpactHandler(impactService, priceService)
+	marketHandler := market.NewMarketHandler(marketService)
+	coinHandler := bonds.NewCoinHandler(coinService)
+	priceHandler := bonds.NewPriceHandler(priceService)
+	kycHandler := kyc.NewKYCHandler(kycService)
+	screeningHandler := compliance.NewScreeningHandler(screeningService)
+	limitHandler := compliance.NewLimitHandler(limitService)
+	auditHandler := compliance.NewAuditHandler(auditService)
+	adminHandler := accounts.NewAdminHandler(adminUserService)
+	issuerApplicationHandler := accounts.NewIssuerApplicationHandler(issuerApplicationService)
+	organizationHandler := accounts.NewOrganizationHandler(organizationService)
+	apiKeyHandler := auth.NewAPIKeyHandler(apiKeyService)
+	roleHandler := accounts.NewRoleHandler(roleService)
+	lockoutHandler := auth.NewLockoutHandler(authGuardService)
+
+	// 驗證 Session / access token；指定 scope 時亦接受具有該 scope 的 API key
+	sessionAuth := func(scopes ...string) gin.HandlerFunc {
+		return middleware.SessionAuthMiddleware(sessionManager, tokenManager, apiKeyService, scopes...)
+	}
+
+	// 檢查使用者任一角色是否具有指定權限
+	requirePermission := func(permission string) gin.HandlerFunc {
+		return middleware.RequirePermissionMiddleware(roleService, permission)
+	}
+
+	// KYC 等級門檻
+	requireInvestorKYC := middleware.RequireKYCLevelMiddleware(cfg.KYCMinLevelInvestor)
+	requireIssuerKYC := middleware.RequireKYCLevelMiddleware(cfg.KYCMinLevelIssuer)
 
 	// API v1
 	v1 := r.Group("/api/v1")
@@ -46,6 +109,17 @@ func SetupRoutes(
 				"status": "ok",
 			})
 		})
+
+		// 全平台影響力彙總
+		public.GET("/impact/summary", impactHandler.GetPlatformImpact)
+
+		// 債券計價幣種
+		public.GET("/coins", coinHandler.ListCoins)
+		public.GET("/coins/metadata", coinHandler.GetCoinMetadata)
+
+		// 匯率（法幣換算）
+		public.GET("/prices", priceHandler.GetPrice)
+		public.GET("/prices/history", priceHandler.GetPriceHistory)
 	}
 
 	// ===== 認證路由（不需要 Session，但需要限流）=====
@@ -55,22 +129,31 @@ func SetupRoutes(
 		middleware.RateLimitMiddleware(20), // 每分鐘 20 次
 	)
 	{
+		// 查詢是否需要人機驗證（錢包或 IP 近期多次失敗時）
+		authGroup.POST("/human-check", authHandler.GetHumanCheck)
+
 		// 前端請求 nonce
 		authGroup.POST("/challenge", authHandler.GenerateChallenge)
 
 		// 前端提交簽名（這裡會建立 Session）
 		authGroup.POST("/verify", authHandler.VerifySignature)
 
+		// 換發 access token（token 模式）
+		authGroup.POST("/refresh", authHandler.RefreshTokens)
+
+		// 重新取得 CSRF token（cookie 模式，需要 Session）
+		authGroup.GET("/csrf", sessionAuth(), authHandler.GetCSRFToken)
+
 		// 登出（需要 Session）
 		authGroup.POST(
 			"/logout",
-			middleware.SessionAuthMiddleware(sessionManager),
+			sessionAuth(),
 			authHandler.Logout,
 		)
 
 		authGroup.POST(
 			"/logout-all",
-			middleware.SessionAuthMiddleware(sessionManager),
+			sessionAuth(),
 			authHandler.LogoutAll,
 		)
 	}
@@ -81,19 +164,42 @@ func SetupRoutes(
 		// 獲取所有債券 - 公開訪問
 		bondsPublic.GET("", bondHandler.GetAllBonds)
 		bondsPublic.GET("/:id", bondHandler.GetBondByID)
+		bondsPublic.GET("/:id/impact", impactHandler.GetBondImpact)
+		bondsPublic.GET("/:id/impact-reports", impactHandler.GetImpactReports)
+
+		// 影響力資料與報告 - 需要認證且為該債券發行者
+		bondsPublic.PUT("/:id/impact",
+			sessionAuth(),
+			requirePermission(models.PermissionImpactManage),
+			requireIssuerKYC,
+			impactHandler.UpsertBondImpact,
+		)
+		bondsPublic.POST("/:id/impact-reports",
+			sessionAuth(),
+			requirePermission(models.PermissionImpactManage),
+			requireIssuerKYC,
+			impactHandler.SubmitImpactReport,
+		)
 
 		// 同步鏈上交易 - 需要認證
 		bondsPublic.POST("/sync",
-			middleware.SessionAuthMiddleware(sessionManager),
+			sessionAuth(models.APIKeyScopeSyncTransactions),
 			bondHandler.SyncTransaction,
 		)
 	}
 
+	// ===== 二級市場公開路由（買賣盤與成交記錄）=====
+	marketPublic := v1.Group("/market")
+	{
+		marketPublic.GET("/bonds/:id/orderbook", marketHandler.GetOrderBook)
+		marketPublic.GET("/bonds/:id/trades", marketHandler.GetTrades) // Query: ?limit=10&offset=0
+	}
+
 	// ===== 3. 受保護路由（需要 Session）=====
 	protected := v1.Group("/")
 	protected.Use(
 		// SessionAuth - 驗證 Cookie 中的 session_id 並載入使用者資訊到 Context
-		middleware.SessionAuthMiddleware(sessionManager),
+		sessionAuth(),
 	)
 	{
 		// User 相關
@@ -109,22 +215,210 @@ func SetupRoutes(
 			sessionGroup.DELETE("/:session_id", authHandler.RevokeSession) // 撤銷特定 session
 		}
 
+		// 登入紀錄與安全通知
+		protected.GET("/login-history", securityHandler.GetLoginHistory)
+		notificationGroup := protected.Group("/notifications")
+		{
+			notificationGroup.GET("", securityHandler.ListNotifications) // Query: ?unread=true
+			notificationGroup.POST("/read-all", securityHandler.MarkAllNotificationsRead)
+			notificationGroup.POST("/:id/read", securityHandler.MarkNotificationRead)
+		}
+
+		// 多錢包綁定（綁定/解除綁定皆需錢包簽名）
+		walletGroup := protected.Group("/wallets")
+		{
+			walletGroup.GET("", walletHandler.ListWallets)
+			walletGroup.POST("/challenge", walletHandler.GenerateChallenge)
+			walletGroup.POST("/link", walletHandler.LinkWallet)
+			walletGroup.POST("/unlink", walletHandler.UnlinkWallet)
+		}
+
+		// API key 管理（僅限 Session，不接受 API key）
+		apiKeyGroup := protected.Group("/api-keys")
+		{
+			apiKeyGroup.GET("", apiKeyHandler.ListAPIKeys)
+			apiKeyGroup.POST("", apiKeyHandler.CreateAPIKey)
+			apiKeyGroup.POST("/:id/rotate", apiKeyHandler.RotateAPIKey)
+			apiKeyGroup.DELETE("/:id", apiKeyHandler.RevokeAPIKey)
+		}
+
+		// KYC 申請與文件上傳
+		kycGroup := protected.Group("/kyc")
+		{
+			kycGroup.GET("", kycHandler.GetMyKYC)
+			kycGroup.POST("/applications", kycHandler.CreateApplication)
+			kycGroup.GET("/applications/:id", kycHandler.GetApplication)
+			kycGroup.PUT("/applications/:id", kycHandler.UpdateApplication)
+			kycGroup.POST("/applications/:id/documents", kycHandler.UploadDocument)
+			kycGroup.GET("/applications/:id/documents/:document_id", kycHandler.DownloadDocument)
+			kycGroup.DELETE("/applications/:id/documents/:document_id", kycHandler.DeleteDocument)
+			kycGroup.POST("/applications/:id/submit", kycHandler.SubmitApplication)
+		}
+
+		// 買方升級為發行者申請
+		issuerApplicationGroup := protected.Group("/issuer-applications")
+		{
+			issuerApplicationGroup.GET("", issuerApplicationHandler.ListMyApplications)
+			issuerApplicationGroup.POST("", issuerApplicationHandler.CreateApplication)
+			issuerApplicationGroup.GET("/:id", issuerApplicationHandler.GetApplication)
+			issuerApplicationGroup.PUT("/:id", issuerApplicationHandler.UpdateApplication)
+			issuerApplicationGroup.POST("/:id/documents", issuerApplicationHandler.UploadDocument)
+			issuerApplicationGroup.GET("/:id/documents/:document_id", issuerApplicationHandler.DownloadDocument)
+			issuerApplicationGroup.DELETE("/:id/documents/:document_id", issuerApplicationHandler.DeleteDocument)
+			issuerApplicationGroup.POST("/:id/submit", issuerApplicationHandler.SubmitApplication)
+		}
+
+		// 機構帳戶（成員錢包與機構儀表板）
+		organizationGroup := protected.Group("/organizations")
+		{
+			organizationGroup.POST("", organizationHandler.CreateOrganization)
+			organizationGroup.GET("/me", organizationHandler.GetMyOrganization)
+			organizationGroup.PUT("/me", organizationHandler.UpdateMyOrganization)
+			organizationGroup.GET("/me/dashboard", organizationHandler.GetMyDashboard)
+			organizationGroup.POST("/me/members", organizationHandler.AddMember)
+			organizationGroup.PUT("/me/members/:user_id", organizationHandler.UpdateMemberRole)
+			organizationGroup.DELETE("/me/members/:user_id", organizationHandler.RemoveMember)
+		}
+
+		// 投資限額與交易前檢查
+		protected.GET("/limits", limitHandler.GetMyLimits)
+		protected.POST("/limits/check", limitHandler.PreTradeCheck)
+
+		// 債券發行申請（需要 bonds:propose 權限，且需達發行者 KYC 等級）
+		proposalGroup := protected.Group("/bond-proposals")
+		proposalGroup.Use(requirePermission(models.PermissionBondsPropose), requireIssuerKYC)
+		{
+			proposalGroup.POST("", proposalHandler.CreateProposal)
+			proposalGroup.GET("", proposalHandler.GetMyProposals)
+			proposalGroup.GET("/:id", proposalHandler.GetProposal)
+			proposalGroup.PUT("/:id", proposalHandler.UpdateProposal)
+			proposalGroup.DELETE("/:id", proposalHandler.DeleteProposal)
+			proposalGroup.POST("/:id/submit", proposalHandler.SubmitProposal)
+		}
+
+		// 二級市場掛單（掛單與成交需達投資人 KYC 等級）
+		marketGroup := protected.Group("/market/orders")
+		{
+			marketGroup.POST("/message", marketHandler.GetOrderMessage)
+			marketGroup.POST("", requireInvestorKYC, marketHandler.PlaceOrder)
+			marketGroup.GET("", marketHandler.GetMyOrders) // Query: ?status=open&limit=10&offset=0
+			marketGroup.DELETE("/:id", marketHandler.CancelOrder)
+			marketGroup.POST("/:id/fill", requireInvestorKYC, marketHandler.FillOrder)
+		}
+	}
+
+	// ===== 可使用 API key 的路由（Session，或具有對應 scope 的 API key）=====
+	integration := v1.Group("/")
+	{
 		// 🆕 BondToken 相關
-		protected.GET("/bond-tokens/:id", bondHandler.GetBondTokenByID)
-		protected.GET("/bond-tokens/on-chain/:on_chain_id", bondHandler.GetBondTokenByOnChainID)
-		protected.GET("/bond-tokens/owner", bondHandler.GetBondTokensByOwner)     // Query: ?owner=0x...&limit=10&offset=0
-		protected.GET("/bond-tokens/project", bondHandler.GetBondTokensByProject) // Query: ?project_id=0x...&limit=10&offset=0
+		integration.GET("/bond-tokens/:id", sessionAuth(models.APIKeyScopeReadBonds), bondHandler.GetBondTokenByID)
+		integration.GET("/bond-tokens/on-chain/:on_chain_id", sessionAuth(models.APIKeyScopeReadBonds), bondHandler.GetBondTokenByOnChainID)
+		integration.GET("/bond-tokens/on-chain/:on_chain_id/history", sessionAuth(models.APIKeyScopeReadBonds), bondHandler.GetBondTokenHistory)
+		integration.GET("/bond-tokens/project", sessionAuth(models.APIKeyScopeReadBonds), bondHandler.GetBondTokensByProject) // Query: ?project_id=0x...&limit=10&offset=0
+		integration.GET("/bond-tokens/owner", sessionAuth(models.APIKeyScopeReadPortfolio), bondHandler.GetBondTokensByOwner) // Query: ?owner=0x...&limit=10&offset=0（省略 owner 則為所有綁定錢包）
+		integration.GET("/portfolio", sessionAuth(models.APIKeyScopeReadPortfolio), bondHandler.GetMyPortfolio)
+		integration.GET("/transactions", sessionAuth(models.APIKeyScopeReadPortfolio), bondHandler.GetMyTransactions) // 所有綁定錢包的交易記錄
 	}
 
-	// ===== 4. 管理員路由（需要 Session + 管理員權限）=====
+	// ===== 4. 管理員路由（需要 Session 或 admin:* API key，並依功能檢查權限）=====
 	admin := v1.Group("/admin")
-	admin.Use(
-		middleware.SessionAuthMiddleware(sessionManager),
-		middleware.RequireRoleMiddleware("admin"),
-	)
+	admin.Use(sessionAuth(models.APIKeyScopeAdmin))
 	{
-		// TODO: 管理員功能路由
-		// admin.GET("/users", adminHandler.GetAllUsers)
-		// admin.PUT("/users/:id/role", adminHandler.UpdateUserRole)
+		// 使用者管理
+		userAdmin := admin.Group("/users", requirePermission(models.PermissionUsersManage))
+		{
+			userAdmin.GET("", adminHandler.ListUsers) // Query: ?role=issuer&wallet=0x12&name=acme&status=active&limit=50&offset=0
+			userAdmin.GET("/:id", adminHandler.GetUser)
+			userAdmin.DELETE("/:id", adminHandler.DeleteUser)
+			userAdmin.POST("/:id/restore", adminHandler.RestoreUser)
+			userAdmin.POST("/:id/logout", adminHandler.ForceLogout)
+		}
+
+		// 登入失敗鎖定
+		lockoutAdmin := admin.Group("/auth/lockouts", requirePermission(models.PermissionUsersManage))
+		{
+			lockoutAdmin.GET("", lockoutHandler.ListLockouts)
+			lockoutAdmin.DELETE("/wallets/:address", lockoutHandler.UnlockWallet)
+			lockoutAdmin.DELETE("/ips/:ip", lockoutHandler.UnlockIP)
+		}
+		admin.PUT("/users/:id/limits", requirePermission(models.PermissionLimitsManage), limitHandler.SetUserLimits)
+
+		// 角色定義與使用者角色
+		roleAdmin := admin.Group("/", requirePermission(models.PermissionRolesManage))
+		{
+			roleAdmin.GET("/roles", roleHandler.ListRoles)
+			roleAdmin.POST("/roles", roleHandler.CreateRole)
+			roleAdmin.PUT("/roles/:name", roleHandler.UpdateRole)
+			roleAdmin.DELETE("/roles/:name", roleHandler.DeleteRole)
+			roleAdmin.PUT("/users/:id/role", adminHandler.UpdateUserRole) // 主要角色（該使用者會被登出）
+			roleAdmin.GET("/users/:id/roles", roleHandler.GetUserRoles)
+			roleAdmin.PUT("/users/:id/roles", roleHandler.SetUserRoles) // 額外角色（Session 即時更新）
+		}
+
+		// 債券發行申請審核與鏈上創建但未經核准的債券
+		moderation := admin.Group("/", requirePermission(models.PermissionBondsModerate))
+		{
+			moderation.GET("/bond-proposals", proposalHandler.ListProposals) // Query: ?status=submitted&limit=10&offset=0
+			moderation.GET("/bond-proposals/:id", proposalHandler.GetProposalForReview)
+			moderation.POST("/bond-proposals/:id/approve", proposalHandler.ApproveProposal)
+			moderation.POST("/bond-proposals/:id/reject", proposalHandler.RejectProposal)
+			moderation.GET("/bonds/needs-review", proposalHandler.GetBondsNeedingReview)
+			moderation.POST("/bonds/:id/clear-review", proposalHandler.ClearBondReviewFlag)
+		}
+
+		// KYC 審核
+		kycAdmin := admin.Group("/kyc", requirePermission(models.PermissionKYCReview))
+		{
+			kycAdmin.GET("/applications", kycHandler.ListApplications) // Query: ?status=pending&limit=10&offset=0
+			kycAdmin.GET("/applications/:id", kycHandler.GetApplicationForReview)
+			kycAdmin.GET("/applications/:id/documents/:document_id", kycHandler.DownloadDocumentForReview)
+			kycAdmin.POST("/applications/:id/approve", kycHandler.ApproveApplication)
+			kycAdmin.POST("/applications/:id/reject", kycHandler.RejectApplication)
+		}
+
+		// 發行者升級申請審核
+		issuerAdmin := admin.Group("/issuer-applications", requirePermission(models.PermissionIssuerApplicationsReview))
+		{
+			issuerAdmin.GET("", issuerApplicationHandler.ListApplications) // Query: ?status=pending&limit=10&offset=0
+			issuerAdmin.GET("/:id", issuerApplicationHandler.GetApplicationForReview)
+			issuerAdmin.GET("/:id/documents/:document_id", issuerApplicationHandler.DownloadDocumentForReview)
+			issuerAdmin.POST("/:id/approve", issuerApplicationHandler.ApproveApplication)
+			issuerAdmin.POST("/:id/deny", issuerApplicationHandler.DenyApplication)
+		}
+
+		// 機構帳戶
+		organizationAdmin := admin.Group("/organizations", requirePermission(models.PermissionOrganizationsRead))
+		{
+			organizationAdmin.GET("", organizationHandler.ListOrganizations) // Query: ?limit=10&offset=0
+			organizationAdmin.GET("/:id", organizationHandler.GetOrganizationDashboard)
+		}
+
+		compliance := admin.Group("/", requirePermission(models.PermissionComplianceManage))
+		{
+			// 制裁/黑名單比對
+			compliance.GET("/screening/status", screeningHandler.GetListStatus)
+			compliance.POST("/screening/reload", screeningHandler.ReloadLists)
+			compliance.POST("/screening/check", screeningHandler.CheckAddress)
+			compliance.POST("/screening/rescan", screeningHandler.RescanHolders)
+			compliance.GET("/screening/history", screeningHandler.ListHistory) // Query: ?wallet=0x...&match_only=true
+
+			// 合規標記審查
+			compliance.GET("/compliance/flags", screeningHandler.ListFlags) // Query: ?status=open&limit=10&offset=0
+			compliance.POST("/compliance/flags/:id/resolve", screeningHandler.ResolveFlag)
+
+			// 限額違規警示
+			compliance.GET("/compliance/alerts", limitHandler.ListAlerts) // Query: ?status=open&limit=10&offset=0
+			compliance.POST("/compliance/alerts/:id/resolve", limitHandler.ResolveAlert)
+		}
+
+		auditAdmin := admin.Group("/", requirePermission(models.PermissionAuditRead))
+		{
+			// 稽核紀錄
+			auditAdmin.GET("/audit-events", auditHandler.ListEvents)          // Query: ?actor_id=1&action=admin.&target_type=user&target_id=42&from=&to=
+			auditAdmin.GET("/audit-events/export", auditHandler.ExportEvents) // Query: 同上，?format=csv|json
+
+			// 登入/錢包綁定挑戰統計
+			auditAdmin.GET("/auth/challenge-metrics", authHandler.GetChallengeMetrics)
+		}
 	}
 }
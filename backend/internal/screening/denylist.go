@@ -0,0 +1,285 @@
+package screening
+
+import (
+	"bluelink-backend/internal/logger"
+	"bluelink-backend/internal/models"
+	"bytes"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DenyList 從本機 CSV/JSON 檔案載入的地址名單，檔案修改後自動重新載入
+//
+// CSV 格式（標題列可省略，# 開頭為註解）：address,list,reason
+// JSON 格式：{"name": "OFAC", "entries": [{"address": "0x..", "reason": ".."}]}
+// 或陣列：[{"address": "0x..", "list": "OFAC", "reason": ".."}] / ["0x..", ...]
+// 未指定 list 時以檔名作為名單名稱
+type DenyList struct {
+	paths []string
+
+	mu       sync.RWMutex
+	modTimes map[string]time.Time
+	entries  map[string]*models.DenyListEntry
+	version  string
+	loadedAt time.Time
+}
+
+// NewDenyList 建立名單，paths 為空時不會命中任何地址
+func NewDenyList(paths []string) *DenyList {
+	return &DenyList{
+		paths:    paths,
+		modTimes: make(map[string]time.Time),
+	}
+}
+
+// Lookup 比對地址，回傳命中的名單項目（未命中為 nil）與名單版本
+func (d *DenyList) Lookup(address string) (*models.DenyListEntry, string, error) {
+	if err := d.refresh(false); err != nil {
+		return nil, "", err
+	}
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.entries[NormalizeAddress(address)], d.version, nil
+}
+
+// Reload 強制重新讀取所有名單檔案
+func (d *DenyList) Reload() error {
+	return d.refresh(true)
+}
+
+// Status 目前載入的名單概況
+func (d *DenyList) Status() models.DenyListStatus {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	lists := make(map[string]int)
+	for _, entry := range d.entries {
+		lists[entry.ListName]++
+	}
+	return models.DenyListStatus{
+		Version:  d.version,
+		Entries:  len(d.entries),
+		Lists:    lists,
+		LoadedAt: d.loadedAt,
+	}
+}
+
+// refresh 任一檔案修改時間改變時重新讀取；讀取失敗時沿用上一份成功載入的名單
+func (d *DenyList) refresh(force bool) error {
+	modTimes := make(map[string]time.Time, len(d.paths))
+	for _, path := range d.paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return d.keepPrevious(fmt.Errorf("failed to stat deny list %s: %w", path, err))
+		}
+		modTimes[path] = info.ModTime()
+	}
+
+	d.mu.RLock()
+	unchanged := d.entries != nil && !force && sameModTimes(d.modTimes, modTimes)
+	d.mu.RUnlock()
+	if unchanged {
+		return nil
+	}
+
+	entries := make(map[string]*models.DenyListEntry)
+	for _, path := range d.paths {
+		loaded, err := loadFile(path)
+		if err != nil {
+			return d.keepPrevious(err)
+		}
+		for _, entry := range loaded {
+			// 同一地址出現在多份名單時保留第一筆
+			if _, exists := entries[entry.Address]; !exists {
+				entries[entry.Address] = entry
+			}
+		}
+	}
+
+	d.mu.Lock()
+	d.entries = entries
+	d.modTimes = modTimes
+	d.version = listVersion(entries)
+	d.loadedAt = time.Now()
+	d.mu.Unlock()
+
+	logger.Info("Deny lists loaded: %d addresses from %d files (version %s)", len(entries), len(d.paths), d.version[:12])
+	return nil
+}
+
+// keepPrevious 已有成功載入的名單時記錄錯誤並繼續使用；從未載入成功時回傳錯誤
+func (d *DenyList) keepPrevious(err error) error {
+	d.mu.RLock()
+	loaded := d.entries != nil
+	d.mu.RUnlock()
+
+	if loaded {
+		logger.Error("Failed to reload deny lists, keeping previous version: %v", err)
+		return nil
+	}
+	return err
+}
+
+func sameModTimes(a, b map[string]time.Time) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for path, modTime := range b {
+		if !a[path].Equal(modTime) {
+			return false
+		}
+	}
+	return true
+}
+
+// listVersion 名單內容的雜湊，記錄於每筆比對結果以便稽核
+func listVersion(entries map[string]*models.DenyListEntry) string {
+	addresses := make([]string, 0, len(entries))
+	for address := range entries {
+		addresses = append(addresses, address)
+	}
+	sort.Strings(addresses)
+
+	hasher := sha256.New()
+	for _, address := range addresses {
+		fmt.Fprintf(hasher, "%s|%s\n", address, entries[address].ListName)
+	}
+	return hex.EncodeToString(hasher.Sum(nil))
+}
+
+func loadFile(path string) ([]*models.DenyListEntry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read deny list %s: %w", path, err)
+	}
+
+	defaultList := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+
+	var entries []*models.DenyListEntry
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		entries, err = parseJSON(data, defaultList)
+	case ".csv", ".txt":
+		entries, err = parseCSV(data, defaultList)
+	default:
+		return nil, fmt.Errorf("unsupported deny list format: %s", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse deny list %s: %w", path, err)
+	}
+	return entries, nil
+}
+
+func parseCSV(data []byte, defaultList string) ([]*models.DenyListEntry, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.Comment = '#'
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]*models.DenyListEntry, 0, len(records))
+	for i, record := range records {
+		address := strings.TrimSpace(record[0])
+		if i == 0 && strings.EqualFold(address, "address") {
+			continue // 標題列
+		}
+		entry, ok := newEntry(address, field(record, 1), field(record, 2), defaultList)
+		if ok {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, nil
+}
+
+type jsonEntry struct {
+	Address string `json:"address"`
+	List    string `json:"list"`
+	Reason  string `json:"reason"`
+}
+
+func parseJSON(data []byte, defaultList string) ([]*models.DenyListEntry, error) {
+	data = bytes.TrimSpace(data)
+
+	var raw []json.RawMessage
+	if len(data) > 0 && data[0] == '{' {
+		var file struct {
+			Name    string            `json:"name"`
+			Entries []json.RawMessage `json:"entries"`
+		}
+		if err := json.Unmarshal(data, &file); err != nil {
+			return nil, err
+		}
+		if file.Name != "" {
+			defaultList = file.Name
+		}
+		raw = file.Entries
+	} else if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+
+	entries := make([]*models.DenyListEntry, 0, len(raw))
+	for _, item := range raw {
+		var e jsonEntry
+		if len(item) > 0 && item[0] == '"' {
+			if err := json.Unmarshal(item, &e.Address); err != nil {
+				return nil, err
+			}
+		} else if err := json.Unmarshal(item, &e); err != nil {
+			return nil, err
+		}
+
+		entry, ok := newEntry(e.Address, e.List, e.Reason, defaultList)
+		if ok {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, nil
+}
+
+func newEntry(address, list, reason, defaultList string) (*models.DenyListEntry, bool) {
+	normalized := NormalizeAddress(address)
+	if normalized == "" {
+		if address != "" {
+			logger.Warn("Skipping invalid deny list address %q", address)
+		}
+		return nil, false
+	}
+	if list == "" {
+		list = defaultList
+	}
+	return &models.DenyListEntry{Address: normalized, ListName: list, Reason: reason}, true
+}
+
+func field(record []string, i int) string {
+	if i < len(record) {
+		return strings.TrimSpace(record[i])
+	}
+	return ""
+}
+
+// NormalizeAddress 將 Sui 地址轉為小寫並補齊為 32 bytes（0x + 64 個十六進位字元）
+// 非十六進位或過長的地址回傳空字串
+func NormalizeAddress(address string) string {
+	hexPart := strings.TrimPrefix(strings.ToLower(strings.TrimSpace(address)), "0x")
+	if hexPart == "" || len(hexPart) > 64 {
+		return ""
+	}
+	if _, err := hex.DecodeString(strings.Repeat("0", len(hexPart)%2) + hexPart); err != nil {
+		return ""
+	}
+	return "0x" + strings.Repeat("0", 64-len(hexPart)) + hexPart
+}
@@ -0,0 +1,131 @@
+package screening
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+const (
+	listedAddress = "0x00000000000000000000000000000000000000000000000000000000000000ab"
+	otherAddress  = "0x00000000000000000000000000000000000000000000000000000000000000cd"
+)
+
+func writeList(t *testing.T, path, content string, modTime time.Time) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestNormalizeAddress(t *testing.T) {
+	tests := []struct {
+		address string
+		want    string
+	}{
+		{"0xAB", listedAddress},
+		{"  ab ", listedAddress},
+		{"0xabc", "0x0000000000000000000000000000000000000000000000000000000000000abc"},
+		{"0xzz", ""},
+		{"0x", ""},
+		{"0x" + listedAddress[2:] + "00", ""},
+	}
+
+	for _, tt := range tests {
+		if got := NormalizeAddress(tt.address); got != tt.want {
+			t.Fatalf("NormalizeAddress(%q) = %q, want %q", tt.address, got, tt.want)
+		}
+	}
+}
+
+func TestDenyListLoadsCSVAndJSON(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+
+	csvPath := filepath.Join(dir, "ofac.csv")
+	writeList(t, csvPath, "address,list,reason\n# 註解\n0xAB,,sanctioned\nnot-an-address\n", now)
+	jsonPath := filepath.Join(dir, "internal.json")
+	writeList(t, jsonPath, `{"name": "Internal", "entries": ["0xcd", {"address": "0xab", "reason": "duplicate"}]}`, now)
+
+	list := NewDenyList([]string{csvPath, jsonPath})
+
+	tests := []struct {
+		address  string
+		wantList string
+		reason   string
+	}{
+		{listedAddress, "ofac", "sanctioned"}, // 未指定名單時以檔名命名，重複地址保留第一份名單
+		{"0xcd", "Internal", ""},
+		{"0xef", "", ""},
+	}
+
+	for _, tt := range tests {
+		entry, version, err := list.Lookup(tt.address)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if version == "" {
+			t.Fatal("lookup should report the list version")
+		}
+		if tt.wantList == "" {
+			if entry != nil {
+				t.Fatalf("Lookup(%s) = %+v, want no match", tt.address, entry)
+			}
+			continue
+		}
+		if entry == nil || entry.ListName != tt.wantList || entry.Reason != tt.reason {
+			t.Fatalf("Lookup(%s) = %+v, want list %s reason %q", tt.address, entry, tt.wantList, tt.reason)
+		}
+	}
+
+	if status := list.Status(); status.Entries != 2 || status.Lists["ofac"] != 1 || status.Lists["Internal"] != 1 {
+		t.Fatalf("Status() = %+v", status)
+	}
+}
+
+func TestDenyListReloadsChangedFiles(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ofac.csv")
+	writeList(t, path, listedAddress+"\n", time.Now().Add(-time.Hour))
+
+	list := NewDenyList([]string{path})
+	_, before, err := list.Lookup(listedAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 檔案修改後下一次比對即使用新名單
+	writeList(t, path, otherAddress+"\n", time.Now())
+	entry, after, err := list.Lookup(otherAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entry == nil || after == before {
+		t.Fatalf("modified list not reloaded: entry %+v, version %s -> %s", entry, before, after)
+	}
+}
+
+func TestDenyListKeepsPreviousVersionOnError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ofac.json")
+
+	// 從未成功載入時回傳錯誤，由呼叫端拒絕登入
+	writeList(t, path, `{"entries": [`, time.Now().Add(-time.Hour))
+	list := NewDenyList([]string{path})
+	if _, _, err := list.Lookup(listedAddress); err == nil {
+		t.Fatal("lookup should fail when no list has been loaded")
+	}
+
+	writeList(t, path, `["`+listedAddress+`"]`, time.Now().Add(-time.Minute))
+	if entry, _, err := list.Lookup(listedAddress); err != nil || entry == nil {
+		t.Fatalf("Lookup() = %+v, %v, want match", entry, err)
+	}
+
+	// 之後的檔案損毀時沿用上一份名單
+	writeList(t, path, `{"entries": [`, time.Now())
+	if entry, _, err := list.Lookup(listedAddress); err != nil || entry == nil {
+		t.Fatalf("Lookup() after corrupt reload = %+v, %v, want previous match", entry, err)
+	}
+}
@@ -0,0 +1,137 @@
+package screening
+
+import (
+	"bluelink-backend/internal/logger"
+	"bluelink-backend/internal/models"
+	"bluelink-backend/internal/repository"
+	"bluelink-backend/internal/session"
+	"context"
+	"fmt"
+)
+
+// Screener 比對地址、記錄比對歷史，命中時封鎖使用者並標記鏈上活動
+// 由登入驗證、事件監聽器與定期掃描共用
+type Screener struct {
+	list     *DenyList
+	repo     *repository.ScreeningRepository
+	userRepo *repository.UserRepository
+	sessions session.SessionManager
+}
+
+// Subject 一次比對的對象與情境
+type Subject struct {
+	Address    string
+	UserID     *int64
+	Trigger    string // models.ScreeningTrigger*
+	TxHash     string // 鏈上活動觸發時的交易
+	ScreenedBy *int64 // 手動比對的管理員
+}
+
+// NewScreener 建立名單比對器
+func NewScreener(
+	list *DenyList,
+	repo *repository.ScreeningRepository,
+	userRepo *repository.UserRepository,
+	sessions session.SessionManager,
+) *Screener {
+	return &Screener{
+		list:     list,
+		repo:     repo,
+		userRepo: userRepo,
+		sessions: sessions,
+	}
+}
+
+// List 目前使用的名單
+func (s *Screener) List() *DenyList {
+	return s.list
+}
+
+// Screen 比對地址並寫入比對紀錄；命中且已有使用者時將其列入黑名單並撤銷所有 Session
+func (s *Screener) Screen(ctx context.Context, subject Subject) (*models.ScreeningResult, error) {
+	address := NormalizeAddress(subject.Address)
+	if address == "" {
+		return nil, fmt.Errorf("invalid wallet address: %q", subject.Address)
+	}
+
+	entry, version, err := s.list.Lookup(address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up deny lists: %w", err)
+	}
+
+	result := &models.ScreeningResult{
+		WalletAddress: address,
+		UserID:        subject.UserID,
+		Trigger:       subject.Trigger,
+		Result:        models.ScreeningResultClear,
+		ListVersion:   version,
+		ScreenedBy:    subject.ScreenedBy,
+	}
+	if subject.TxHash != "" {
+		txHash := subject.TxHash
+		result.TxHash = &txHash
+	}
+	if entry != nil {
+		result.Result = models.ScreeningResultMatch
+		result.ListName = &entry.ListName
+		if entry.Reason != "" {
+			result.Reason = &entry.Reason
+		}
+	}
+
+	if err := s.repo.CreateResult(ctx, result); err != nil {
+		return nil, err
+	}
+
+	if result.IsMatch() {
+		logger.Warn("🚫 Wallet %s matched deny list %s (trigger: %s)", address, entry.ListName, subject.Trigger)
+		s.blockUser(ctx, subject.UserID)
+	}
+
+	return result, nil
+}
+
+// ScreenActivity 比對鏈上活動的地址，命中時建立合規標記
+func (s *Screener) ScreenActivity(ctx context.Context, subject Subject, eventType string, bondID *int64) (*models.ScreeningResult, error) {
+	result, err := s.Screen(ctx, subject)
+	if err != nil || !result.IsMatch() {
+		return result, err
+	}
+
+	flag := &models.ComplianceFlag{
+		WalletAddress: result.WalletAddress,
+		UserID:        subject.UserID,
+		TxHash:        subject.TxHash,
+		EventType:     eventType,
+		BondID:        bondID,
+		ListName:      *result.ListName,
+		Reason:        result.Reason,
+		Status:        models.ComplianceFlagOpen,
+	}
+
+	created, err := s.repo.CreateFlag(ctx, flag)
+	if err != nil {
+		return result, err
+	}
+	if created {
+		logger.Warn("🚩 Compliance flag %d: %s by listed wallet %s (tx %s)", flag.ID, eventType, flag.WalletAddress, flag.TxHash)
+	}
+	return result, nil
+}
+
+// blockUser 將命中名單的使用者列入黑名單並強制登出所有錢包（含綁定錢包）
+func (s *Screener) blockUser(ctx context.Context, userID *int64) {
+	if userID == nil {
+		return
+	}
+
+	if err := s.userRepo.SetBlacklisted(ctx, *userID, true); err != nil {
+		logger.Error("Failed to blacklist user %d: %v", *userID, err)
+	}
+
+	if s.sessions != nil {
+		if err := s.sessions.DeleteAllByUserID(ctx, *userID); err != nil {
+			logger.Error("Failed to revoke sessions of user %d: %v", *userID, err)
+		}
+	}
+}
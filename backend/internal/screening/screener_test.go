@@ -0,0 +1,119 @@
+package screening
+
+import (
+	"bluelink-backend/internal/models"
+	"bluelink-backend/internal/repository"
+	"bluelink-backend/internal/session"
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func newTestScreener(t *testing.T) (*Screener, *session.MemorySessionManager, sqlmock.Sqlmock) {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "ofac.csv")
+	writeList(t, path, listedAddress+",OFAC,SDN\n", time.Now())
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	sessions := session.NewMemorySessionManager(session.Policy{AbsoluteTimeout: time.Hour, IdleTimeout: time.Hour, MaxDevices: 5})
+	screener := NewScreener(NewDenyList([]string{path}), repository.NewScreeningRepository(db), repository.NewUserRepository(db), sessions)
+	return screener, sessions, mock
+}
+
+func TestScreenMatchBlocksUserOnAllWallets(t *testing.T) {
+	screener, sessions, mock := newTestScreener(t)
+	ctx := context.Background()
+	userID := int64(7)
+
+	// 使用者以主錢包與綁定錢包登入，比對命中的是綁定錢包
+	for _, wallet := range []string{otherAddress, listedAddress} {
+		if _, err := sessions.Create(ctx, userID, wallet, "buyer", nil, "approved", 1, "203.0.113.1", "test"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	mock.ExpectQuery(`INSERT INTO screening_results`).
+		WithArgs(listedAddress, &userID, models.ScreeningTriggerLogin, models.ScreeningResultMatch, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), nil, nil, sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(1, time.Now()))
+	mock.ExpectExec(`UPDATE users\s+SET is_blacklisted = \$1`).
+		WithArgs(true, sqlmock.AnyArg(), userID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	result, err := screener.Screen(ctx, Subject{Address: "0xAB", UserID: &userID, Trigger: models.ScreeningTriggerLogin})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.IsMatch() || *result.ListName != "OFAC" || *result.Reason != "SDN" {
+		t.Fatalf("Screen() = %+v, want OFAC match", result)
+	}
+
+	remaining, _ := sessions.GetUserSessionsByUserID(ctx, userID)
+	if len(remaining) != 0 {
+		t.Fatalf("user still has %d sessions after a deny list match", len(remaining))
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestScreenClearRecordsResultOnly(t *testing.T) {
+	screener, sessions, mock := newTestScreener(t)
+	ctx := context.Background()
+	userID := int64(7)
+
+	if _, err := sessions.Create(ctx, userID, otherAddress, "buyer", nil, "approved", 1, "203.0.113.1", "test"); err != nil {
+		t.Fatal(err)
+	}
+
+	mock.ExpectQuery(`INSERT INTO screening_results`).
+		WithArgs(otherAddress, &userID, models.ScreeningTriggerPeriodic, models.ScreeningResultClear, nil, nil, sqlmock.AnyArg(), nil, nil, sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(1, time.Now()))
+
+	result, err := screener.Screen(ctx, Subject{Address: otherAddress, UserID: &userID, Trigger: models.ScreeningTriggerPeriodic})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.IsMatch() {
+		t.Fatalf("Screen() = %+v, want clear", result)
+	}
+
+	remaining, _ := sessions.GetUserSessionsByUserID(ctx, userID)
+	if len(remaining) != 1 {
+		t.Fatalf("clear result should not revoke sessions, %d left", len(remaining))
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestScreenActivityFlagsListedWallet(t *testing.T) {
+	screener, _, mock := newTestScreener(t)
+	bondID := int64(3)
+
+	mock.ExpectQuery(`INSERT INTO screening_results`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(1, time.Now()))
+	mock.ExpectQuery(`INSERT INTO compliance_flags`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(2, time.Now()))
+
+	result, err := screener.ScreenActivity(context.Background(),
+		Subject{Address: listedAddress, Trigger: models.ScreeningTriggerPurchase, TxHash: "0xtx"},
+		models.EventBondPurchased, &bondID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.IsMatch() || *result.TxHash != "0xtx" {
+		t.Fatalf("ScreenActivity() = %+v, want match for tx 0xtx", result)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
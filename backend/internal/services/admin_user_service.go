@@ -0,0 +1,214 @@
+package services
+
+import (
+	"bluelink-backend/internal/logger"
+	"bluelink-backend/internal/models"
+	"bluelink-backend/internal/repository"
+	"bluelink-backend/internal/session"
+	"context"
+	"errors"
+)
+
+var (
+	ErrUserAlreadyDeleted = errors.New("user is already deleted")
+	ErrUserNotDeleted     = errors.New("user is not deleted")
+	ErrCannotModifySelf   = errors.New("admins cannot change the role of or delete their own account")
+)
+
+// AdminUserService 管理員使用者管理服務層
+type AdminUserService struct {
+	userService    *UserService
+	userRepo       *repository.UserRepository
+	txRepo         *repository.TransactionRepository
+	sessionManager session.SessionManager
+	audit          *AuditService
+}
+
+// NewAdminUserService 建立新的 AdminUserService 實例
+func NewAdminUserService(
+	userService *UserService,
+	userRepo *repository.UserRepository,
+	txRepo *repository.TransactionRepository,
+	sessionManager session.SessionManager,
+	audit *AuditService,
+) *AdminUserService {
+	return &AdminUserService{
+		userService:    userService,
+		userRepo:       userRepo,
+		txRepo:         txRepo,
+		sessionManager: sessionManager,
+		audit:          audit,
+	}
+}
+
+// ListUsers 依條件查詢使用者，回傳使用者與總筆數
+func (s *AdminUserService) ListUsers(ctx context.Context, filter models.UserFilter, limit, offset int) ([]*models.User, int, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+
+	users, total, err := s.userRepo.Search(ctx, filter, limit, offset)
+	if err != nil {
+		logger.Error("Failed to search users: %v", err)
+		return nil, 0, err
+	}
+	return users, total, nil
+}
+
+// GetUserDetail 取得使用者資料、登入裝置與持倉（包含已刪除的使用者）
+func (s *AdminUserService) GetUserDetail(ctx context.Context, userID int64) (*models.AdminUserDetail, error) {
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	detail := &models.AdminUserDetail{
+		User:     user,
+		Sessions: []*models.UserSessionInfo{},
+		Holdings: []*models.UserBondWithDetails{},
+	}
+
+	sessions, err := s.sessionManager.GetUserSessionsByUserID(ctx, userID)
+	if err != nil {
+		logger.Error("Failed to get sessions for user %d: %v", userID, err)
+		return nil, err
+	}
+	for _, sess := range sessions {
+		detail.Sessions = append(detail.Sessions, &models.UserSessionInfo{
+			CreatedAt:    sess.CreatedAt,
+			LastActiveAt: sess.LastActiveAt,
+			IPAddress:    sess.IPAddress,
+			UserAgent:    sess.UserAgent,
+		})
+	}
+
+	holdings, err := s.txRepo.GetUserBonds(ctx, userID)
+	if err != nil {
+		logger.Error("Failed to get holdings for user %d: %v", userID, err)
+		return nil, err
+	}
+	if holdings != nil {
+		detail.Holdings = holdings
+	}
+
+	return detail, nil
+}
+
+// UpdateRole 變更使用者角色，並登出該使用者所有裝置使新角色生效
+func (s *AdminUserService) UpdateRole(ctx context.Context, adminID, userID int64, role string) error {
+	if adminID == userID {
+		return ErrCannotModifySelf
+	}
+
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.userService.UpdateRole(ctx, userID, role); err != nil {
+		return err
+	}
+
+	s.audit.Record(ctx, models.AuditActionRoleChange, models.AuditTargetUser, auditID(userID),
+		map[string]any{"role": user.Role}, map[string]any{"role": role})
+	s.revokeSessions(ctx, userID)
+	return nil
+}
+
+// DeleteUser 軟刪除使用者並登出所有裝置
+func (s *AdminUserService) DeleteUser(ctx context.Context, adminID, userID int64) error {
+	if adminID == userID {
+		return ErrCannotModifySelf
+	}
+
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user.DeletedAt != nil {
+		return ErrUserAlreadyDeleted
+	}
+
+	if err := s.userRepo.Delete(ctx, userID); err != nil {
+		logger.Error("Failed to delete user %d: %v", userID, err)
+		return err
+	}
+
+	if err := s.deleteSessions(ctx, user); err != nil {
+		logger.Error("Failed to revoke sessions of deleted user %d: %v", userID, err)
+	}
+
+	logger.Info("User deleted by admin %d: ID=%d", adminID, userID)
+
+	s.audit.Record(ctx, models.AuditActionUserDelete, models.AuditTargetUser, auditID(userID),
+		map[string]any{"deleted": false}, map[string]any{"deleted": true})
+	return nil
+}
+
+// RestoreUser 還原已軟刪除的使用者
+func (s *AdminUserService) RestoreUser(ctx context.Context, adminID, userID int64) error {
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user.DeletedAt == nil {
+		return ErrUserNotDeleted
+	}
+
+	if err := s.userRepo.Restore(ctx, userID); err != nil {
+		logger.Error("Failed to restore user %d: %v", userID, err)
+		return err
+	}
+
+	logger.Info("User restored by admin %d: ID=%d", adminID, userID)
+
+	s.audit.Record(ctx, models.AuditActionUserRestore, models.AuditTargetUser, auditID(userID),
+		map[string]any{"deleted": true}, map[string]any{"deleted": false})
+	return nil
+}
+
+// ForceLogout 強制登出使用者的所有裝置
+func (s *AdminUserService) ForceLogout(ctx context.Context, userID int64) error {
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.deleteSessions(ctx, user); err != nil {
+		logger.Error("Failed to revoke sessions of user %d: %v", userID, err)
+		return err
+	}
+
+	logger.Info("User %d logged out from all devices by admin", userID)
+
+	s.audit.Record(ctx, models.AuditActionUserForceLogout, models.AuditTargetUser, auditID(userID), nil, nil)
+	return nil
+}
+
+// revokeSessions 登出使用者所有裝置（失敗時僅記錄）
+func (s *AdminUserService) revokeSessions(ctx context.Context, userID int64) {
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return
+	}
+	if err := s.deleteSessions(ctx, user); err != nil {
+		logger.Error("Failed to revoke sessions of user %d: %v", userID, err)
+	}
+}
+
+// deleteSessions 刪除使用者主錢包與所有綁定錢包的 session
+func (s *AdminUserService) deleteSessions(ctx context.Context, user *models.User) error {
+	return s.sessionManager.DeleteAllByUserID(ctx, user.ID)
+}
+
+func (s *AdminUserService) getUser(ctx context.Context, userID int64) (*models.User, error) {
+	user, err := s.userRepo.GetByIDUnscoped(ctx, userID)
+	if err != nil {
+		logger.Error("Failed to get user ID %d: %v", userID, err)
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+	return user, nil
+}
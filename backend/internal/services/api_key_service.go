@@ -0,0 +1,319 @@
+package services
+
+import (
+	"bluelink-backend/internal/logger"
+	"bluelink-backend/internal/models"
+	"bluelink-backend/internal/repository"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"net"
+	"slices"
+	"strings"
+	"time"
+)
+
+/*
+   API key 格式：blk_<prefix>_<secret>
+   - prefix：12 字元的隨機十六進位字串，唯一且明文保存，用於查找與讓使用者辨識
+   - secret：32 bytes 隨機值（Base64 URL），不保存
+   資料庫僅保存整個 key 的 SHA-256 雜湊；明文只在建立與輪替時回傳一次
+*/
+
+var (
+	ErrAPIKeyNotFound        = errors.New("api key not found")
+	ErrInvalidAPIKey         = errors.New("invalid, expired or revoked api key")
+	ErrAPIKeyIPNotAllowed    = errors.New("request IP is not in the api key allowlist")
+	ErrInvalidAPIKeyScope    = errors.New("unknown api key scope")
+	ErrAPIKeyScopeForbidden  = errors.New("the admin:* scope requires a role with all permissions")
+	ErrInvalidIPAllowlist    = errors.New("ip allowlist entries must be IP addresses or CIDR ranges")
+	ErrInvalidAPIKeyExpiry   = errors.New("api key expiry must be in the future")
+	ErrAPIKeyLimit           = errors.New("maximum number of active api keys reached")
+	ErrAPIKeyAlreadyRevoked  = errors.New("api key is already revoked")
+	ErrAPIKeyOwnerNotAllowed = errors.New("api key owner is not allowed to sign in")
+)
+
+const (
+	apiKeyPrefix = "blk"
+	// maxActiveAPIKeys 每個使用者同時有效的 API key 上限
+	maxActiveAPIKeys = 20
+)
+
+// APIKeyService API key 管理與驗證服務層
+type APIKeyService struct {
+	repo     *repository.APIKeyRepository
+	userRepo *repository.UserRepository
+	roles    *RoleService
+	audit    *AuditService
+}
+
+// NewAPIKeyService 建立新的 APIKeyService 實例
+func NewAPIKeyService(repo *repository.APIKeyRepository, userRepo *repository.UserRepository, roles *RoleService, audit *AuditService) *APIKeyService {
+	return &APIKeyService{
+		repo:     repo,
+		userRepo: userRepo,
+		roles:    roles,
+		audit:    audit,
+	}
+}
+
+// ListAPIKeys 取得使用者的所有 API key（不含明文與雜湊）
+func (s *APIKeyService) ListAPIKeys(ctx context.Context, userID int64) ([]*models.APIKey, error) {
+	keys, err := s.repo.ListByUser(ctx, userID)
+	if err != nil {
+		logger.Error("Failed to list api keys of user %d: %v", userID, err)
+		return nil, err
+	}
+	return keys, nil
+}
+
+// CreateAPIKey 驗證權限範圍、IP 白名單與到期時間後建立 API key
+func (s *APIKeyService) CreateAPIKey(ctx context.Context, key *models.APIKey) (*models.IssuedAPIKey, error) {
+	user, err := s.userRepo.GetByID(ctx, key.UserID)
+	if err != nil {
+		logger.Error("Failed to get user ID %d: %v", key.UserID, err)
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+
+	// admin:* 可存取所有管理員 API，需由角色解析出所有權限（"*"）
+	canGrantAdmin := false
+	if slices.Contains(key.Scopes, models.APIKeyScopeAdmin) {
+		canGrantAdmin, err = s.roles.UserHasPermission(ctx, user, models.PermissionAll)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := validateAPIKeySettings(key, canGrantAdmin); err != nil {
+		return nil, err
+	}
+
+	count, err := s.repo.CountActiveByUser(ctx, key.UserID)
+	if err != nil {
+		logger.Error("Failed to count api keys of user %d: %v", key.UserID, err)
+		return nil, err
+	}
+	if count >= maxActiveAPIKeys {
+		return nil, ErrAPIKeyLimit
+	}
+
+	plaintext, prefix, keyHash, err := generateAPIKey()
+	if err != nil {
+		return nil, err
+	}
+	key.Prefix = prefix
+	key.KeyHash = keyHash
+
+	if err := s.repo.Create(ctx, key); err != nil {
+		logger.Error("Failed to create api key for user %d: %v", key.UserID, err)
+		return nil, err
+	}
+
+	logger.Info("API key created: user=%d, prefix=%s, scopes=%v", key.UserID, key.Prefix, key.Scopes)
+
+	s.audit.Record(ctx, models.AuditActionAPIKeyCreate, models.AuditTargetAPIKey, auditID(key.ID), nil, key)
+	return &models.IssuedAPIKey{APIKey: key, Key: plaintext}, nil
+}
+
+// RotateAPIKey 產生新的 key 取代舊 key（舊 key 立即失效），權限範圍等設定保持不變
+func (s *APIKeyService) RotateAPIKey(ctx context.Context, userID, id int64) (*models.IssuedAPIKey, error) {
+	key, err := s.getOwnedKey(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if key.RevokedAt != nil {
+		return nil, ErrAPIKeyAlreadyRevoked
+	}
+
+	plaintext, prefix, keyHash, err := generateAPIKey()
+	if err != nil {
+		return nil, err
+	}
+
+	oldPrefix := key.Prefix
+	if err := s.repo.Rotate(ctx, id, prefix, keyHash); err != nil {
+		logger.Error("Failed to rotate api key %d: %v", id, err)
+		return nil, err
+	}
+
+	now := time.Now()
+	key.Prefix = prefix
+	key.KeyHash = keyHash
+	key.RotatedAt = &now
+
+	logger.Info("API key rotated: user=%d, prefix=%s -> %s", userID, oldPrefix, prefix)
+
+	s.audit.Record(ctx, models.AuditActionAPIKeyRotate, models.AuditTargetAPIKey, auditID(id),
+		map[string]any{"prefix": oldPrefix}, map[string]any{"prefix": prefix})
+	return &models.IssuedAPIKey{APIKey: key, Key: plaintext}, nil
+}
+
+// RevokeAPIKey 撤銷 API key
+func (s *APIKeyService) RevokeAPIKey(ctx context.Context, userID, id int64) error {
+	key, err := s.getOwnedKey(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+	if key.RevokedAt != nil {
+		return ErrAPIKeyAlreadyRevoked
+	}
+
+	if err := s.repo.Revoke(ctx, id); err != nil {
+		logger.Error("Failed to revoke api key %d: %v", id, err)
+		return err
+	}
+
+	logger.Info("API key revoked: user=%d, prefix=%s", userID, key.Prefix)
+
+	s.audit.Record(ctx, models.AuditActionAPIKeyRevoke, models.AuditTargetAPIKey, auditID(id), key, nil)
+	return nil
+}
+
+// Authenticate 驗證明文 API key 與來源 IP，回傳 key 與其擁有者（含額外授予的角色）
+// 擁有者已刪除或列入黑名單時 key 一併失效
+func (s *APIKeyService) Authenticate(ctx context.Context, plaintext, ipAddress string) (*models.APIKey, *models.User, error) {
+	prefix, ok := parseAPIKeyPrefix(plaintext)
+	if !ok {
+		return nil, nil, ErrInvalidAPIKey
+	}
+
+	key, err := s.repo.GetByPrefix(ctx, prefix)
+	if err != nil {
+		logger.Error("Failed to look up api key %s: %v", prefix, err)
+		return nil, nil, err
+	}
+	if key == nil || subtle.ConstantTimeCompare([]byte(key.KeyHash), []byte(hashAPIKey(plaintext))) != 1 {
+		return nil, nil, ErrInvalidAPIKey
+	}
+	if !key.IsActive(time.Now()) {
+		return nil, nil, ErrInvalidAPIKey
+	}
+	if !ipAllowed(key.IPAllowlist, ipAddress) {
+		return nil, nil, ErrAPIKeyIPNotAllowed
+	}
+
+	user, err := s.userRepo.GetByID(ctx, key.UserID)
+	if err != nil {
+		logger.Error("Failed to get owner %d of api key %s: %v", key.UserID, prefix, err)
+		return nil, nil, err
+	}
+	if user == nil || user.IsBlacklisted {
+		return nil, nil, ErrAPIKeyOwnerNotAllowed
+	}
+
+	user.Roles, err = s.roles.GrantedRoles(ctx, user.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := s.repo.TouchLastUsed(ctx, key.ID, ipAddress); err != nil {
+		logger.Warn("Failed to record usage of api key %s: %v", prefix, err)
+	}
+
+	return key, user, nil
+}
+
+// getOwnedKey 取得屬於使用者的 API key
+func (s *APIKeyService) getOwnedKey(ctx context.Context, userID, id int64) (*models.APIKey, error) {
+	key, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		logger.Error("Failed to get api key %d: %v", id, err)
+		return nil, err
+	}
+	if key == nil || key.UserID != userID {
+		return nil, ErrAPIKeyNotFound
+	}
+	return key, nil
+}
+
+// validateAPIKeySettings 檢查權限範圍、IP 白名單與到期時間
+func validateAPIKeySettings(key *models.APIKey, canGrantAdmin bool) error {
+	for _, scope := range key.Scopes {
+		if !slices.Contains(models.APIKeyScopes, scope) {
+			return ErrInvalidAPIKeyScope
+		}
+		if scope == models.APIKeyScopeAdmin && !canGrantAdmin {
+			return ErrAPIKeyScopeForbidden
+		}
+	}
+	slices.Sort(key.Scopes)
+	key.Scopes = slices.Compact(key.Scopes)
+
+	for _, entry := range key.IPAllowlist {
+		if net.ParseIP(entry) == nil {
+			if _, _, err := net.ParseCIDR(entry); err != nil {
+				return ErrInvalidIPAllowlist
+			}
+		}
+	}
+
+	if key.ExpiresAt != nil && !key.ExpiresAt.After(time.Now()) {
+		return ErrInvalidAPIKeyExpiry
+	}
+	return nil
+}
+
+// ipAllowed 檢查 IP 是否在白名單內（白名單為空表示不限制）
+func ipAllowed(allowlist []string, ipAddress string) bool {
+	if len(allowlist) == 0 {
+		return true
+	}
+
+	ip := net.ParseIP(ipAddress)
+	if ip == nil {
+		return false
+	}
+	for _, entry := range allowlist {
+		if allowed := net.ParseIP(entry); allowed != nil {
+			if allowed.Equal(ip) {
+				return true
+			}
+			continue
+		}
+		if _, network, err := net.ParseCIDR(entry); err == nil && network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// generateAPIKey 產生明文 API key、識別前綴與雜湊
+func generateAPIKey() (string, string, string, error) {
+	prefixBytes := make([]byte, 6)
+	secret := make([]byte, 32)
+	if _, err := rand.Read(prefixBytes); err != nil {
+		return "", "", "", err
+	}
+	if _, err := rand.Read(secret); err != nil {
+		return "", "", "", err
+	}
+
+	prefix := hex.EncodeToString(prefixBytes)
+	plaintext := apiKeyPrefix + "_" + prefix + "_" + base64.RawURLEncoding.EncodeToString(secret)
+	return plaintext, prefix, hashAPIKey(plaintext), nil
+}
+
+// parseAPIKeyPrefix 由明文 API key 取出識別前綴
+func parseAPIKeyPrefix(plaintext string) (string, bool) {
+	rest, ok := strings.CutPrefix(plaintext, apiKeyPrefix+"_")
+	if !ok {
+		return "", false
+	}
+	prefix, secret, ok := strings.Cut(rest, "_")
+	if !ok || len(prefix) != 12 || secret == "" {
+		return "", false
+	}
+	return prefix, true
+}
+
+func hashAPIKey(plaintext string) string {
+	sum := sha256.Sum256([]byte(plaintext))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,81 @@
+package services
+
+import (
+	"bluelink-backend/internal/audit"
+	"bluelink-backend/internal/logger"
+	"bluelink-backend/internal/models"
+	"bluelink-backend/internal/repository"
+	"context"
+	"strconv"
+)
+
+// auditExportLimit 單次匯出的稽核事件上限
+const auditExportLimit = 10000
+
+// AuditService 稽核事件服務層
+type AuditService struct {
+	repo *repository.AuditRepository
+}
+
+// NewAuditService 建立新的 AuditService 實例
+func NewAuditService(repo *repository.AuditRepository) *AuditService {
+	return &AuditService{repo: repo}
+}
+
+// Record 記錄一筆稽核事件；操作主體與請求來源取自 context，before / after 只保留變動的欄位
+// 寫入失敗時僅記錄錯誤，不影響原本的操作
+func (s *AuditService) Record(ctx context.Context, action, targetType, targetID string, before, after any) {
+	actor := audit.ActorFromContext(ctx)
+
+	event := &models.AuditEvent{
+		ActorID:     actor.UserID,
+		ActorWallet: optionalString(actor.WalletAddress),
+		ActorRole:   optionalString(actor.Role),
+		Action:      action,
+		TargetType:  targetType,
+		TargetID:    optionalString(targetID),
+		IPAddress:   optionalString(actor.IPAddress),
+		UserAgent:   optionalString(actor.UserAgent),
+		RequestID:   optionalString(actor.RequestID),
+	}
+
+	var err error
+	event.Before, event.After, err = audit.Diff(before, after)
+	if err != nil {
+		logger.Error("Failed to diff audit event %s on %s %s: %v", action, targetType, targetID, err)
+	}
+
+	// 請求結束後 context 可能已取消，仍需寫入稽核事件
+	if err := s.repo.Create(context.WithoutCancel(ctx), event); err != nil {
+		logger.Error("Failed to record audit event %s on %s %s: %v", action, targetType, targetID, err)
+	}
+}
+
+// auditID 將數字 ID 轉為稽核事件的 target_id
+func auditID(id int64) string {
+	return strconv.FormatInt(id, 10)
+}
+
+// Search 依條件查詢稽核事件，回傳事件與總筆數
+func (s *AuditService) Search(ctx context.Context, filter models.AuditFilter, limit, offset int) ([]*models.AuditEvent, int, error) {
+	if limit <= 0 {
+		limit = 100
+	}
+
+	events, total, err := s.repo.Search(ctx, filter, limit, offset)
+	if err != nil {
+		logger.Error("Failed to search audit events: %v", err)
+		return nil, 0, err
+	}
+	return events, total, nil
+}
+
+// Export 匯出符合條件的稽核事件（最多 auditExportLimit 筆）
+func (s *AuditService) Export(ctx context.Context, filter models.AuditFilter) ([]*models.AuditEvent, error) {
+	events, _, err := s.repo.Search(ctx, filter, auditExportLimit, 0)
+	if err != nil {
+		logger.Error("Failed to export audit events: %v", err)
+		return nil, err
+	}
+	return events, nil
+}
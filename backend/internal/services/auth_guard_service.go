@@ -0,0 +1,304 @@
+package services
+
+import (
+	"bluelink-backend/internal/config"
+	"bluelink-backend/internal/humancheck"
+	"bluelink-backend/internal/logger"
+	"bluelink-backend/internal/models"
+	"bluelink-backend/internal/repository"
+	"context"
+	"errors"
+	"time"
+)
+
+/*
+   登入暴力破解防護：
+   - 登入訊息無效或 nonce 不存在時只累計於 IP；
+     已發出的 nonce 被使用後簽名無效或地址不符時，才同時累計於錢包與 IP；
+     錢包的計數以「錢包地址|IP」為鍵：任何人都能請求他人錢包的挑戰並送出錯誤簽名，
+     只鎖定該錢包在攻擊者 IP 上的嘗試，不影響錢包擁有者從其他 IP 登入
+   - 時間窗內達 AUTH_WALLET_MAX_FAILURES / AUTH_IP_MAX_FAILURES 次即鎖定，
+     鎖定時長由 AUTH_LOCKOUT_BASE 起每次加倍（上限 AUTH_LOCKOUT_MAX），一天內無失敗則重新計算
+   - 錢包登入成功時清除該錢包在此 IP 的紀錄；IP 的紀錄不因成功登入清除（避免以自己的錢包重置計數）
+   - 錢包或 IP 失敗達 HUMAN_CHECK_AFTER 次後，請求挑戰需先通過人機驗證（proof-of-work 或 CAPTCHA）
+   - 限制單一 IP 同時有效的挑戰數量；全平台有效挑戰達 AUTH_MAX_OUTSTANDING_NONCES 後，
+     啟用人機驗證時請求挑戰一律需先通過人機驗證（避免攻擊者塞滿 nonces 表即阻擋所有人登入），
+     未啟用時拒絕發出新的挑戰（輪換 IP 仍受全平台上限限制）
+   - 計數存於資料庫，多個實例共用
+*/
+
+var (
+	ErrAuthLocked            = errors.New("too many failed sign-in attempts, try again later")
+	ErrHumanCheckRequired    = errors.New("human verification required")
+	ErrHumanCheckFailed      = errors.New("human verification failed")
+	ErrTooManyChallenges     = errors.New("too many outstanding sign-in challenges from this address")
+	ErrChallengeCapacityFull = errors.New("sign-in challenge capacity reached, try again later")
+	ErrAuthLockoutNotFound   = errors.New("no failed sign-in record found")
+)
+
+// lockoutDecay 最後一次失敗超過此時間後，連續鎖定次數歸零並可清除紀錄
+const lockoutDecay = 24 * time.Hour
+
+// AuthAttemptStatus 錢包與 IP 目前的登入限制狀態
+type AuthAttemptStatus struct {
+	LockedUntil        *time.Time `json:"locked_until,omitempty"`
+	HumanCheckRequired bool       `json:"human_check_required"`
+}
+
+// AuthGuardService 登入失敗計數、鎖定與人機驗證服務層
+type AuthGuardService struct {
+	repo      *repository.AuthLockoutRepository
+	nonceRepo *repository.NonceRepository
+	verifier  humancheck.Verifier // nil 表示不需要人機驗證
+	audit     *AuditService
+
+	walletMaxFailures    int
+	ipMaxFailures        int
+	failureWindow        time.Duration
+	lockoutBase          time.Duration
+	lockoutMax           time.Duration
+	maxOutstandingNonces int64
+	maxNoncesPerIP       int64
+	humanCheckAfter      int
+}
+
+// NewAuthGuardService 建立新的 AuthGuardService 實例
+func NewAuthGuardService(
+	repo *repository.AuthLockoutRepository,
+	nonceRepo *repository.NonceRepository,
+	verifier humancheck.Verifier,
+	audit *AuditService,
+	cfg *config.Config,
+) *AuthGuardService {
+	return &AuthGuardService{
+		repo:                 repo,
+		nonceRepo:            nonceRepo,
+		verifier:             verifier,
+		audit:                audit,
+		walletMaxFailures:    cfg.AuthWalletMaxFailures,
+		ipMaxFailures:        cfg.AuthIPMaxFailures,
+		failureWindow:        time.Duration(cfg.AuthFailureWindow) * time.Second,
+		lockoutBase:          time.Duration(cfg.AuthLockoutBase) * time.Second,
+		lockoutMax:           time.Duration(cfg.AuthLockoutMax) * time.Second,
+		maxOutstandingNonces: int64(cfg.AuthMaxOutstandingNonces),
+		maxNoncesPerIP:       int64(cfg.AuthMaxNoncesPerIP),
+		humanCheckAfter:      cfg.HumanCheckAfter,
+	}
+}
+
+// Start 啟動定期清理過期紀錄的背景工作
+func (s *AuthGuardService) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(time.Hour)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				deleted, err := s.repo.DeleteStale(ctx, time.Now().Add(-lockoutDecay))
+				if err != nil {
+					logger.Error("Failed to clean up auth lockouts: %v", err)
+					continue
+				}
+				if deleted > 0 {
+					logger.Info("Cleaned up %d stale auth lockouts", deleted)
+				}
+			}
+		}
+	}()
+}
+
+// CheckAttempt 取得錢包與 IP 的鎖定狀態，以及是否需要人機驗證
+func (s *AuthGuardService) CheckAttempt(ctx context.Context, walletAddress, ipAddress string) (*AuthAttemptStatus, error) {
+	lockouts, err := s.repo.GetForAttempt(ctx, models.AuthLockoutWalletSubject(walletAddress, ipAddress), ipAddress)
+	if err != nil {
+		logger.Error("Failed to get auth lockouts of wallet %s / ip %s: %v", walletAddress, ipAddress, err)
+		return nil, err
+	}
+
+	now := time.Now()
+	windowStart := now.Add(-s.failureWindow)
+	status := &AuthAttemptStatus{HumanCheckRequired: s.verifier != nil && s.humanCheckAfter == 0}
+
+	for _, lockout := range lockouts {
+		if lockout.IsLocked(now) && (status.LockedUntil == nil || lockout.LockedUntil.After(*status.LockedUntil)) {
+			status.LockedUntil = lockout.LockedUntil
+		}
+		// 曾被鎖定或時間窗內失敗達門檻時，需要人機驗證
+		recentFailures := lockout.WindowStartedAt.After(windowStart) && lockout.Failures >= s.humanCheckAfter
+		if s.verifier != nil && (recentFailures || lockout.Lockouts > 0) {
+			status.HumanCheckRequired = true
+		}
+	}
+
+	return status, nil
+}
+
+// CheckChallengeAttempt 發出挑戰前的限制狀態：除 CheckAttempt 外，全平台有效挑戰達上限時需人機驗證
+// 未啟用人機驗證時改為回傳 ErrChallengeCapacityFull
+func (s *AuthGuardService) CheckChallengeAttempt(ctx context.Context, walletAddress, ipAddress string) (*AuthAttemptStatus, error) {
+	status, err := s.CheckAttempt(ctx, walletAddress, ipAddress)
+	if err != nil || status.LockedUntil != nil || status.HumanCheckRequired {
+		return status, err
+	}
+
+	total, err := s.nonceRepo.CountActive(ctx)
+	if err != nil {
+		logger.Error("Failed to count outstanding challenges: %v", err)
+		return nil, err
+	}
+	if total >= s.maxOutstandingNonces {
+		if s.verifier == nil {
+			logger.Warn("Challenge capacity reached (%d outstanding)", total)
+			return nil, ErrChallengeCapacityFull
+		}
+		logger.Warn("Challenge capacity reached (%d outstanding), requiring human check", total)
+		status.HumanCheckRequired = true
+	}
+
+	return status, nil
+}
+
+// HumanCheckChallenge 產生人機驗證的題目（未啟用人機驗證時回傳 nil）
+func (s *AuthGuardService) HumanCheckChallenge(ctx context.Context) (*humancheck.Challenge, error) {
+	if s.verifier == nil {
+		return nil, nil
+	}
+
+	challenge, err := s.verifier.Challenge(ctx)
+	if err != nil {
+		logger.Error("Failed to create human check challenge: %v", err)
+		return nil, err
+	}
+	return challenge, nil
+}
+
+// VerifyHumanCheck 驗證前端提交的人機驗證解答
+func (s *AuthGuardService) VerifyHumanCheck(ctx context.Context, solution, ipAddress string) error {
+	if s.verifier == nil {
+		return nil
+	}
+	if solution == "" {
+		return ErrHumanCheckRequired
+	}
+
+	err := s.verifier.Verify(ctx, solution, ipAddress)
+	if errors.Is(err, humancheck.ErrVerificationFailed) {
+		logger.Warn("Human check failed from %s: %v", ipAddress, err)
+		return ErrHumanCheckFailed
+	}
+	if err != nil {
+		logger.Error("Failed to verify human check: %v", err)
+		return err
+	}
+	return nil
+}
+
+// CheckChallengeCapacity 檢查該 IP 同時有效的挑戰數量是否已達上限
+func (s *AuthGuardService) CheckChallengeCapacity(ctx context.Context, ipAddress string) error {
+	perIP, err := s.nonceRepo.CountActiveByIP(ctx, ipAddress)
+	if err != nil {
+		logger.Error("Failed to count outstanding challenges of ip %s: %v", ipAddress, err)
+		return err
+	}
+	if perIP >= s.maxNoncesPerIP {
+		logger.Warn("Challenge limit reached for ip %s (%d outstanding)", ipAddress, perIP)
+		return ErrTooManyChallenges
+	}
+
+	return nil
+}
+
+// RecordFailure 記錄一次已使用 nonce 後的登入驗證失敗，錢包或 IP 達門檻時鎖定
+// 寫入失敗時僅記錄錯誤，不影響原本的回應
+func (s *AuthGuardService) RecordFailure(ctx context.Context, walletAddress, ipAddress string) {
+	ctx = context.WithoutCancel(ctx)
+
+	s.recordFailure(ctx, models.AuthLockoutScopeWallet, models.AuthLockoutWalletSubject(walletAddress, ipAddress), s.walletMaxFailures)
+	s.RecordIPFailure(ctx, ipAddress)
+}
+
+// RecordIPFailure 記錄一次只歸屬於 IP 的登入驗證失敗（訊息無效或 nonce 不存在），IP 達門檻時鎖定
+func (s *AuthGuardService) RecordIPFailure(ctx context.Context, ipAddress string) {
+	if ipAddress == "" {
+		return
+	}
+	s.recordFailure(context.WithoutCancel(ctx), models.AuthLockoutScopeIP, ipAddress, s.ipMaxFailures)
+}
+
+// RecordSuccess 登入成功時清除該錢包在此 IP 的失敗計數
+func (s *AuthGuardService) RecordSuccess(ctx context.Context, walletAddress, ipAddress string) {
+	subject := models.AuthLockoutWalletSubject(walletAddress, ipAddress)
+	if _, err := s.repo.Delete(context.WithoutCancel(ctx), models.AuthLockoutScopeWallet, subject); err != nil {
+		logger.Error("Failed to reset auth failures of wallet %s: %v", walletAddress, err)
+	}
+}
+
+// ListLocked 取得目前鎖定中的錢包與 IP
+func (s *AuthGuardService) ListLocked(ctx context.Context) ([]*models.AuthLockout, error) {
+	lockouts, err := s.repo.ListLocked(ctx)
+	if err != nil {
+		logger.Error("Failed to list auth lockouts: %v", err)
+		return nil, err
+	}
+	return lockouts, nil
+}
+
+// Unlock 管理員解除錢包（所有 IP）或 IP 的鎖定並清除失敗計數
+func (s *AuthGuardService) Unlock(ctx context.Context, scope, subject string) error {
+	var found bool
+	var err error
+	if scope == models.AuthLockoutScopeWallet {
+		found, err = s.repo.DeleteByWallet(ctx, subject)
+	} else {
+		found, err = s.repo.Delete(ctx, scope, subject)
+	}
+	if err != nil {
+		logger.Error("Failed to unlock %s %s: %v", scope, subject, err)
+		return err
+	}
+	if !found {
+		return ErrAuthLockoutNotFound
+	}
+
+	logger.Info("Auth lockout cleared: %s %s", scope, subject)
+
+	target := models.AuditTargetWallet
+	if scope == models.AuthLockoutScopeIP {
+		target = models.AuditTargetIP
+	}
+	s.audit.Record(ctx, models.AuditActionAuthUnlock, target, subject, nil, nil)
+	return nil
+}
+
+func (s *AuthGuardService) recordFailure(ctx context.Context, scope, subject string, maxFailures int) {
+	now := time.Now()
+	lockout, err := s.repo.RecordFailure(ctx, scope, subject, now.Add(-s.failureWindow), now.Add(-lockoutDecay))
+	if err != nil {
+		logger.Error("Failed to record auth failure of %s %s: %v", scope, subject, err)
+		return
+	}
+	if lockout.Failures < maxFailures {
+		return
+	}
+
+	until := now.Add(s.lockoutDuration(lockout.Lockouts))
+	if err := s.repo.Lock(ctx, scope, subject, until); err != nil {
+		logger.Error("Failed to lock %s %s: %v", scope, subject, err)
+		return
+	}
+
+	logger.Warn("Sign-in locked: %s %s after %d failures, until %s (lockout #%d)",
+		scope, subject, lockout.Failures, until.Format(time.RFC3339), lockout.Lockouts+1)
+}
+
+// lockoutDuration 第 n+1 次鎖定的時長：AUTH_LOCKOUT_BASE × 2^n，上限 AUTH_LOCKOUT_MAX
+func (s *AuthGuardService) lockoutDuration(previousLockouts int) time.Duration {
+	duration := s.lockoutBase
+	for i := 0; i < previousLockouts && duration < s.lockoutMax; i++ {
+		duration *= 2
+	}
+	return min(duration, s.lockoutMax)
+}
@@ -0,0 +1,184 @@
+package services
+
+import (
+	"bluelink-backend/internal/config"
+	"bluelink-backend/internal/humancheck"
+	"bluelink-backend/internal/models"
+	"bluelink-backend/internal/repository"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+var authLockoutTestColumns = []string{"scope", "subject", "failures", "lockouts", "window_started_at", "last_failure_at", "locked_until"}
+
+// stubVerifier 一律通過的人機驗證
+type stubVerifier struct{}
+
+func (stubVerifier) Challenge(ctx context.Context) (*humancheck.Challenge, error) {
+	return &humancheck.Challenge{Type: "pow"}, nil
+}
+
+func (stubVerifier) Verify(ctx context.Context, solution, remoteIP string) error {
+	return nil
+}
+
+func newTestAuthGuard(t *testing.T, verifier humancheck.Verifier) (*AuthGuardService, sqlmock.Sqlmock) {
+	t.Helper()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	cfg := &config.Config{
+		AuthWalletMaxFailures:    5,
+		AuthIPMaxFailures:        20,
+		AuthFailureWindow:        900,
+		AuthLockoutBase:          60,
+		AuthLockoutMax:           3600,
+		AuthMaxOutstandingNonces: 100,
+		AuthMaxNoncesPerIP:       10,
+		HumanCheckAfter:          3,
+	}
+	guard := NewAuthGuardService(repository.NewAuthLockoutRepository(db), repository.NewNonceRepository(db), verifier, nil, cfg)
+	return guard, mock
+}
+
+func TestRecordIPFailureCountsIPOnly(t *testing.T) {
+	guard, mock := newTestAuthGuard(t, nil)
+
+	now := time.Now()
+	mock.ExpectQuery(`INSERT INTO auth_lockouts`).
+		WithArgs(models.AuthLockoutScopeIP, "203.0.113.9", sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows(authLockoutTestColumns).AddRow(models.AuthLockoutScopeIP, "203.0.113.9", 1, 0, now, now, nil))
+
+	guard.RecordIPFailure(context.Background(), "203.0.113.9")
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWalletFailuresFromOtherIPDoNotLockOwner(t *testing.T) {
+	guard, mock := newTestAuthGuard(t, nil)
+
+	const (
+		wallet     = "0x00000000000000000000000000000000000000000000000000000000000000ab"
+		attackerIP = "198.51.100.7"
+		ownerIP    = "203.0.113.9"
+	)
+	attackerSubject := models.AuthLockoutWalletSubject(wallet, attackerIP)
+	now := time.Now()
+
+	// 攻擊者請求受害錢包的挑戰並送出第 5 次錯誤簽名：鎖定的是「錢包|攻擊者 IP」
+	mock.ExpectQuery(`INSERT INTO auth_lockouts`).
+		WithArgs(models.AuthLockoutScopeWallet, attackerSubject, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows(authLockoutTestColumns).AddRow(models.AuthLockoutScopeWallet, attackerSubject, 5, 0, now, now, nil))
+	mock.ExpectExec(`UPDATE auth_lockouts`).
+		WithArgs(models.AuthLockoutScopeWallet, attackerSubject, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(`INSERT INTO auth_lockouts`).
+		WithArgs(models.AuthLockoutScopeIP, attackerIP, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows(authLockoutTestColumns).AddRow(models.AuthLockoutScopeIP, attackerIP, 5, 0, now, now, nil))
+
+	guard.RecordFailure(context.Background(), wallet, attackerIP)
+
+	// 錢包擁有者從自己的 IP 登入時查詢的是「錢包|擁有者 IP」，不受攻擊者的鎖定影響
+	lockedUntil := now.Add(time.Minute)
+	mock.ExpectQuery(`FROM auth_lockouts`).
+		WithArgs(models.AuthLockoutScopeWallet, models.AuthLockoutWalletSubject(wallet, ownerIP), models.AuthLockoutScopeIP, ownerIP).
+		WillReturnRows(sqlmock.NewRows(authLockoutTestColumns))
+	mock.ExpectQuery(`FROM auth_lockouts`).
+		WithArgs(models.AuthLockoutScopeWallet, attackerSubject, models.AuthLockoutScopeIP, attackerIP).
+		WillReturnRows(sqlmock.NewRows(authLockoutTestColumns).
+			AddRow(models.AuthLockoutScopeWallet, attackerSubject, 0, 1, now, now, lockedUntil))
+
+	owner, err := guard.CheckAttempt(context.Background(), wallet, ownerIP)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if owner.LockedUntil != nil {
+		t.Fatalf("owner locked until %v by failures from another IP", owner.LockedUntil)
+	}
+
+	attacker, err := guard.CheckAttempt(context.Background(), wallet, attackerIP)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if attacker.LockedUntil == nil {
+		t.Fatal("attacker IP should be locked for the wallet")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestChallengeCapacityRequiresHumanCheck(t *testing.T) {
+	tests := []struct {
+		name        string
+		outstanding int64
+		want        bool
+	}{
+		{"below capacity", 99, false},
+		{"capacity reached", 100, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			guard, mock := newTestAuthGuard(t, stubVerifier{})
+
+			mock.ExpectQuery(`FROM auth_lockouts`).
+				WillReturnRows(sqlmock.NewRows(authLockoutTestColumns))
+			mock.ExpectQuery(`SELECT COUNT\(\*\) FROM nonces WHERE expires_at >= \$1`).
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(tt.outstanding))
+
+			status, err := guard.CheckChallengeAttempt(context.Background(), "0xabc", "203.0.113.9")
+			if err != nil {
+				t.Fatalf("CheckChallengeAttempt() error = %v", err)
+			}
+			if status.LockedUntil != nil || status.HumanCheckRequired != tt.want {
+				t.Fatalf("CheckChallengeAttempt() = %+v, want HumanCheckRequired=%v", status, tt.want)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestCheckChallengeCapacityLimitsPerIP(t *testing.T) {
+	guard, mock := newTestAuthGuard(t, stubVerifier{})
+
+	mock.ExpectQuery(`FROM nonces WHERE ip_address = \$1`).
+		WithArgs("203.0.113.9", sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(10))
+
+	if err := guard.CheckChallengeCapacity(context.Background(), "203.0.113.9"); !errors.Is(err, ErrTooManyChallenges) {
+		t.Fatalf("CheckChallengeCapacity() error = %v, want ErrTooManyChallenges", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestChallengeCapacityRejectsWithoutHumanCheck(t *testing.T) {
+	guard, mock := newTestAuthGuard(t, nil)
+
+	mock.ExpectQuery(`FROM auth_lockouts`).
+		WillReturnRows(sqlmock.NewRows(authLockoutTestColumns))
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM nonces WHERE expires_at >= \$1`).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(100))
+
+	if _, err := guard.CheckChallengeAttempt(context.Background(), "0xabc", "203.0.113.9"); !errors.Is(err, ErrChallengeCapacityFull) {
+		t.Fatalf("CheckChallengeAttempt() error = %v, want ErrChallengeCapacityFull", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
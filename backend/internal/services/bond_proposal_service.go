@@ -0,0 +1,224 @@
+package services
+
+import (
+	"bluelink-backend/internal/logger"
+	"bluelink-backend/internal/models"
+	"bluelink-backend/internal/repository"
+	"context"
+	"errors"
+)
+
+var (
+	ErrProposalNotFound     = errors.New("bond proposal not found")
+	ErrProposalForbidden    = errors.New("bond proposal belongs to another issuer")
+	ErrProposalNotEditable  = errors.New("bond proposal can only be changed while in draft or rejected state")
+	ErrProposalNotInReview  = errors.New("bond proposal is not awaiting review")
+	ErrReviewCommentMissing = errors.New("a comment is required when rejecting a proposal")
+)
+
+// BondProposalService 債券發行申請服務層
+type BondProposalService struct {
+	repo     *repository.BondProposalRepository
+	bondRepo *repository.BondRepository
+	audit    *AuditService
+}
+
+// NewBondProposalService 建立新的 BondProposalService 實例
+func NewBondProposalService(repo *repository.BondProposalRepository, bondRepo *repository.BondRepository, audit *AuditService) *BondProposalService {
+	return &BondProposalService{
+		repo:     repo,
+		bondRepo: bondRepo,
+		audit:    audit,
+	}
+}
+
+// CreateProposal 建立新的申請草稿
+func (s *BondProposalService) CreateProposal(ctx context.Context, proposal *models.BondProposal) error {
+	proposal.Status = models.ProposalStatusDraft
+	if proposal.Documents == nil {
+		proposal.Documents = []models.ProposalDocument{}
+	}
+
+	if err := s.repo.Create(ctx, proposal); err != nil {
+		logger.Error("Failed to create bond proposal %s: %v", proposal.BondName, err)
+		return err
+	}
+	logger.Info("Bond proposal created: ID=%d, bond=%s, issuer=%s", proposal.ID, proposal.BondName, proposal.IssuerAddress)
+	return nil
+}
+
+// GetProposal 取得申請（issuerUserID 非 0 時檢查擁有者）
+func (s *BondProposalService) GetProposal(ctx context.Context, id, issuerUserID int64) (*models.BondProposal, error) {
+	proposal, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		logger.Error("Failed to get bond proposal ID %d: %v", id, err)
+		return nil, err
+	}
+	if proposal == nil {
+		return nil, ErrProposalNotFound
+	}
+	if issuerUserID != 0 && proposal.IssuerUserID != issuerUserID {
+		return nil, ErrProposalForbidden
+	}
+	return proposal, nil
+}
+
+// ListMyProposals 取得發行者自己的申請列表
+func (s *BondProposalService) ListMyProposals(ctx context.Context, issuerUserID int64, limit, offset int) ([]*models.BondProposal, error) {
+	if limit <= 0 {
+		limit = 100
+	}
+
+	proposals, err := s.repo.ListByIssuer(ctx, issuerUserID, limit, offset)
+	if err != nil {
+		logger.Error("Failed to list bond proposals for user %d: %v", issuerUserID, err)
+		return nil, err
+	}
+	return proposals, nil
+}
+
+// UpdateProposal 更新申請內容（僅擁有者、僅草稿或被退回狀態）
+func (s *BondProposalService) UpdateProposal(ctx context.Context, issuerUserID int64, proposal *models.BondProposal) error {
+	existing, err := s.GetProposal(ctx, proposal.ID, issuerUserID)
+	if err != nil {
+		return err
+	}
+	if !isEditableProposal(existing.Status) {
+		return ErrProposalNotEditable
+	}
+
+	if proposal.Documents == nil {
+		proposal.Documents = []models.ProposalDocument{}
+	}
+
+	if err := s.repo.Update(ctx, proposal); err != nil {
+		logger.Error("Failed to update bond proposal ID %d: %v", proposal.ID, err)
+		return err
+	}
+	logger.Info("Bond proposal updated: ID=%d", proposal.ID)
+	return nil
+}
+
+// SubmitProposal 送出申請給管理員審核
+func (s *BondProposalService) SubmitProposal(ctx context.Context, id, issuerUserID int64) error {
+	existing, err := s.GetProposal(ctx, id, issuerUserID)
+	if err != nil {
+		return err
+	}
+	if !isEditableProposal(existing.Status) {
+		return ErrProposalNotEditable
+	}
+
+	if err := s.repo.Submit(ctx, id); err != nil {
+		logger.Error("Failed to submit bond proposal ID %d: %v", id, err)
+		return err
+	}
+	logger.Info("Bond proposal submitted for review: ID=%d", id)
+	return nil
+}
+
+// DeleteProposal 刪除尚未送審的申請
+func (s *BondProposalService) DeleteProposal(ctx context.Context, id, issuerUserID int64) error {
+	existing, err := s.GetProposal(ctx, id, issuerUserID)
+	if err != nil {
+		return err
+	}
+	if !isEditableProposal(existing.Status) {
+		return ErrProposalNotEditable
+	}
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		logger.Error("Failed to delete bond proposal ID %d: %v", id, err)
+		return err
+	}
+	logger.Info("Bond proposal deleted: ID=%d", id)
+	return nil
+}
+
+// ListProposals 依狀態列出申請（管理員功能）
+func (s *BondProposalService) ListProposals(ctx context.Context, status string, limit, offset int) ([]*models.BondProposal, error) {
+	if limit <= 0 {
+		limit = 100
+	}
+
+	proposals, err := s.repo.ListByStatus(ctx, status, limit, offset)
+	if err != nil {
+		logger.Error("Failed to list bond proposals with status %q: %v", status, err)
+		return nil, err
+	}
+	return proposals, nil
+}
+
+// ApproveProposal 核准申請（管理員功能）
+func (s *BondProposalService) ApproveProposal(ctx context.Context, id, reviewerID int64, comment string) error {
+	return s.review(ctx, id, reviewerID, models.ProposalStatusApproved, comment)
+}
+
+// RejectProposal 退回申請（管理員功能，必須附上意見）
+func (s *BondProposalService) RejectProposal(ctx context.Context, id, reviewerID int64, comment string) error {
+	if comment == "" {
+		return ErrReviewCommentMissing
+	}
+	return s.review(ctx, id, reviewerID, models.ProposalStatusRejected, comment)
+}
+
+func (s *BondProposalService) review(ctx context.Context, id, reviewerID int64, status, comment string) error {
+	existing, err := s.GetProposal(ctx, id, 0)
+	if err != nil {
+		return err
+	}
+	if existing.Status != models.ProposalStatusSubmitted {
+		return ErrProposalNotInReview
+	}
+
+	var commentPtr *string
+	if comment != "" {
+		commentPtr = &comment
+	}
+
+	if err := s.repo.Review(ctx, id, status, reviewerID, commentPtr); err != nil {
+		logger.Error("Failed to review bond proposal ID %d: %v", id, err)
+		return err
+	}
+	logger.Info("Bond proposal reviewed: ID=%d, status=%s, reviewer=%d", id, status, reviewerID)
+
+	action := models.AuditActionProposalApprove
+	if status == models.ProposalStatusRejected {
+		action = models.AuditActionProposalReject
+	}
+	s.audit.Record(ctx, action, models.AuditTargetBondProposal, auditID(id),
+		map[string]any{"status": existing.Status},
+		map[string]any{"status": status, "review_comment": commentPtr})
+	return nil
+}
+
+// ListBondsNeedingReview 列出鏈上創建但沒有對應已核准申請的債券（管理員功能）
+func (s *BondProposalService) ListBondsNeedingReview(ctx context.Context, limit, offset int) ([]*models.Bond, error) {
+	if limit <= 0 {
+		limit = 100
+	}
+
+	bonds, err := s.bondRepo.ListNeedsReview(ctx, limit, offset)
+	if err != nil {
+		logger.Error("Failed to list bonds needing review: %v", err)
+		return nil, err
+	}
+	return bonds, nil
+}
+
+// ClearBondReviewFlag 清除債券的審核標記（管理員確認後）
+func (s *BondProposalService) ClearBondReviewFlag(ctx context.Context, bondID int64) error {
+	if err := s.bondRepo.SetNeedsReview(ctx, bondID, false); err != nil {
+		logger.Error("Failed to clear review flag for bond ID %d: %v", bondID, err)
+		return err
+	}
+	logger.Info("Bond review flag cleared: ID=%d", bondID)
+
+	s.audit.Record(ctx, models.AuditActionBondClearReview, models.AuditTargetBond, auditID(bondID),
+		map[string]any{"needs_review": true}, map[string]any{"needs_review": false})
+	return nil
+}
+
+func isEditableProposal(status string) bool {
+	return status == models.ProposalStatusDraft || status == models.ProposalStatusRejected
+}
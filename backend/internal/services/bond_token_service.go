@@ -9,12 +9,13 @@ import (
 
 // BondTokenService 債券代幣服務層
 type BondTokenService struct {
-	repo *repository.BondTokenRepository
+	repo   *repository.BondTokenRepository
+	txRepo *repository.TransactionRepository
 }
 
 // NewBondTokenService 建立新的 BondTokenService 實例
-func NewBondTokenService(repo *repository.BondTokenRepository) *BondTokenService {
-	return &BondTokenService{repo: repo}
+func NewBondTokenService(repo *repository.BondTokenRepository, txRepo *repository.TransactionRepository) *BondTokenService {
+	return &BondTokenService{repo: repo, txRepo: txRepo}
 }
 
 // GetBondTokenByID 根據 ID 獲取債券代幣
@@ -51,6 +52,34 @@ func (s *BondTokenService) GetBondTokensByOwner(ctx context.Context, owner strin
 	return tokens, nil
 }
 
+// GetBondTokensByOwners 獲取多個錢包地址持有的債券代幣列表
+func (s *BondTokenService) GetBondTokensByOwners(ctx context.Context, owners []string, limit, offset int) ([]*models.BondToken, error) {
+	if limit <= 0 {
+		limit = 100
+	}
+
+	tokens, err := s.repo.GetByOwners(ctx, owners, limit, offset)
+	if err != nil {
+		logger.Error("Failed to get bond tokens by owners %v: %v", owners, err)
+		return nil, err
+	}
+	return tokens, nil
+}
+
+// GetTransactionsByWallets 獲取多個錢包地址的交易記錄
+func (s *BondTokenService) GetTransactionsByWallets(ctx context.Context, walletAddresses []string, limit, offset int) ([]*models.Transaction, error) {
+	if limit <= 0 {
+		limit = 100
+	}
+
+	txs, err := s.txRepo.ListByWallets(ctx, walletAddresses, limit, offset)
+	if err != nil {
+		logger.Error("Failed to get transactions by wallets %v: %v", walletAddresses, err)
+		return nil, err
+	}
+	return txs, nil
+}
+
 // GetBondTokensByProjectID 根據專案 ID 獲取債券代幣列表
 func (s *BondTokenService) GetBondTokensByProjectID(ctx context.Context, projectID string, limit, offset int) ([]*models.BondToken, error) {
 	if limit <= 0 {
@@ -85,3 +114,23 @@ func (s *BondTokenService) UpdateBondTokenRedeemed(ctx context.Context, id int64
 	logger.Info("Bond token redeemed status updated: ID=%d, isRedeemed=%v", id, isRedeemed)
 	return nil
 }
+
+// GetBondTokenProvenance 取得代幣的持有歷史
+func (s *BondTokenService) GetBondTokenProvenance(ctx context.Context, onChainID string) (*models.TokenProvenance, error) {
+	token, err := s.repo.GetByOnChainID(ctx, onChainID)
+	if err != nil {
+		logger.Error("Failed to get bond token by on-chain ID %s: %v", onChainID, err)
+		return nil, err
+	}
+	if token == nil {
+		return nil, nil
+	}
+
+	history, err := s.txRepo.ListByToken(ctx, onChainID)
+	if err != nil {
+		logger.Error("Failed to get history for bond token %s: %v", onChainID, err)
+		return nil, err
+	}
+
+	return &models.TokenProvenance{Token: token, History: history}, nil
+}
@@ -0,0 +1,40 @@
+package services
+
+import (
+	"bluelink-backend/internal/blockchain"
+	"bluelink-backend/internal/logger"
+	"bluelink-backend/internal/models"
+	"bluelink-backend/internal/repository"
+	"context"
+)
+
+// CoinService 債券計價幣種服務層
+type CoinService struct {
+	registry *blockchain.CoinRegistry
+	repo     *repository.CoinMetadataRepository
+}
+
+// NewCoinService 建立新的 CoinService 實例
+func NewCoinService(registry *blockchain.CoinRegistry, repo *repository.CoinMetadataRepository) *CoinService {
+	return &CoinService{registry: registry, repo: repo}
+}
+
+// ListCoins 列出已登錄的幣種元數據
+func (s *CoinService) ListCoins(ctx context.Context) ([]*models.CoinMetadata, error) {
+	coins, err := s.repo.List(ctx)
+	if err != nil {
+		logger.Error("Failed to list coin metadata: %v", err)
+		return nil, err
+	}
+	return coins, nil
+}
+
+// GetCoin 取得幣種元數據（尚未登錄時從鏈上讀取並快取）
+func (s *CoinService) GetCoin(ctx context.Context, coinType string) (*models.CoinMetadata, error) {
+	meta, err := s.registry.Get(ctx, coinType)
+	if err != nil {
+		logger.Error("Failed to get coin metadata for %s: %v", coinType, err)
+		return nil, err
+	}
+	return meta, nil
+}
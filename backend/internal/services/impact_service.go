@@ -0,0 +1,232 @@
+package services
+
+import (
+	"bluelink-backend/internal/logger"
+	"bluelink-backend/internal/models"
+	"bluelink-backend/internal/repository"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	ErrBondNotFound        = errors.New("bond not found")
+	ErrNotBondIssuer       = errors.New("only the bond issuer can manage its impact data")
+	ErrImpactNotConfigured = errors.New("bond has no impact profile")
+	ErrInvalidImpactData   = errors.New("invalid impact data")
+)
+
+// 支援的專案類別
+var validImpactCategories = map[string]bool{
+	models.ImpactCategoryFisheries:            true,
+	models.ImpactCategoryCoral:                true,
+	models.ImpactCategoryPlastics:             true,
+	models.ImpactCategoryMangroves:            true,
+	models.ImpactCategoryWastewater:           true,
+	models.ImpactCategoryCoastalResilience:    true,
+	models.ImpactCategoryMarineProtectedAreas: true,
+	models.ImpactCategoryShipping:             true,
+	models.ImpactCategoryOther:                true,
+}
+
+// 支援的 GeoJSON 型別
+var validGeoJSONTypes = map[string]bool{
+	"Point":              true,
+	"MultiPoint":         true,
+	"LineString":         true,
+	"MultiLineString":    true,
+	"Polygon":            true,
+	"MultiPolygon":       true,
+	"GeometryCollection": true,
+	"Feature":            true,
+	"FeatureCollection":  true,
+}
+
+// ImpactService 債券影響力服務層
+type ImpactService struct {
+	impactRepo *repository.ImpactRepository
+	bondRepo   *repository.BondRepository
+}
+
+// NewImpactService 建立新的 ImpactService 實例
+func NewImpactService(impactRepo *repository.ImpactRepository, bondRepo *repository.BondRepository) *ImpactService {
+	return &ImpactService{
+		impactRepo: impactRepo,
+		bondRepo:   bondRepo,
+	}
+}
+
+// GetBondImpact 取得債券影響力資料
+func (s *ImpactService) GetBondImpact(ctx context.Context, bondID int64) (*models.BondImpact, error) {
+	impact, err := s.impactRepo.GetByBondID(ctx, bondID)
+	if err != nil {
+		logger.Error("Failed to get impact for bond %d: %v", bondID, err)
+		return nil, err
+	}
+	if impact == nil {
+		return nil, ErrImpactNotConfigured
+	}
+	return impact, nil
+}
+
+// UpsertBondImpact 設定債券影響力資料（僅發行者）
+func (s *ImpactService) UpsertBondImpact(ctx context.Context, walletAddress string, impact *models.BondImpact) error {
+	if err := s.checkIssuer(ctx, impact.BondID, walletAddress); err != nil {
+		return err
+	}
+	if err := validateImpact(impact); err != nil {
+		return err
+	}
+
+	if err := s.impactRepo.UpsertImpact(ctx, impact); err != nil {
+		logger.Error("Failed to upsert impact for bond %d: %v", impact.BondID, err)
+		return err
+	}
+
+	logger.Info("Impact profile saved: bond=%d, category=%s, kpis=%d", impact.BondID, impact.Category, len(impact.KPIs))
+	return nil
+}
+
+// SubmitReport 提交影響力報告（僅發行者）
+func (s *ImpactService) SubmitReport(ctx context.Context, walletAddress string, report *models.ImpactReport) error {
+	if err := s.checkIssuer(ctx, report.BondID, walletAddress); err != nil {
+		return err
+	}
+
+	kpis, err := s.impactRepo.ListKPIs(ctx, report.BondID)
+	if err != nil {
+		logger.Error("Failed to list KPIs for bond %d: %v", report.BondID, err)
+		return err
+	}
+	if len(kpis) == 0 {
+		return ErrImpactNotConfigured
+	}
+
+	if err := validateReport(report, kpis); err != nil {
+		return err
+	}
+
+	if err := s.impactRepo.CreateReport(ctx, report); err != nil {
+		logger.Error("Failed to create impact report for bond %d: %v", report.BondID, err)
+		return err
+	}
+
+	logger.Info("Impact report submitted: ID=%d, bond=%d, period=%s~%s", report.ID, report.BondID, report.PeriodStart, report.PeriodEnd)
+	return nil
+}
+
+// ListReports 取得債券的影響力報告
+func (s *ImpactService) ListReports(ctx context.Context, bondID int64, limit, offset int) ([]*models.ImpactReport, error) {
+	if limit <= 0 {
+		limit = 100
+	}
+
+	reports, err := s.impactRepo.ListReports(ctx, bondID, limit, offset)
+	if err != nil {
+		logger.Error("Failed to list impact reports for bond %d: %v", bondID, err)
+		return nil, err
+	}
+	return reports, nil
+}
+
+// GetPlatformImpact 取得全平台影響力彙總
+func (s *ImpactService) GetPlatformImpact(ctx context.Context) (*models.PlatformImpact, error) {
+	summary, err := s.impactRepo.GetPlatformImpact(ctx)
+	if err != nil {
+		logger.Error("Failed to aggregate platform impact: %v", err)
+		return nil, err
+	}
+	return summary, nil
+}
+
+// checkIssuer 確認債券存在且由該錢包發行
+func (s *ImpactService) checkIssuer(ctx context.Context, bondID int64, walletAddress string) error {
+	bond, err := s.bondRepo.GetByID(ctx, bondID)
+	if err != nil {
+		logger.Error("Failed to get bond %d: %v", bondID, err)
+		return err
+	}
+	if bond == nil {
+		return ErrBondNotFound
+	}
+	if !strings.EqualFold(bond.IssuerAddress, walletAddress) {
+		return ErrNotBondIssuer
+	}
+	return nil
+}
+
+func validateImpact(impact *models.BondImpact) error {
+	if !validImpactCategories[impact.Category] {
+		return fmt.Errorf("%w: unknown category %q", ErrInvalidImpactData, impact.Category)
+	}
+
+	seen := make(map[int64]bool)
+	for _, sdg := range impact.SDGTargets {
+		if sdg < 1 || sdg > 17 {
+			return fmt.Errorf("%w: SDG target %d out of range 1-17", ErrInvalidImpactData, sdg)
+		}
+		if seen[sdg] {
+			return fmt.Errorf("%w: duplicate SDG target %d", ErrInvalidImpactData, sdg)
+		}
+		seen[sdg] = true
+	}
+
+	if len(impact.Location) > 0 {
+		var geo struct {
+			Type string `json:"type"`
+		}
+		if err := json.Unmarshal(impact.Location, &geo); err != nil {
+			return fmt.Errorf("%w: location must be a GeoJSON object", ErrInvalidImpactData)
+		}
+		if !validGeoJSONTypes[geo.Type] {
+			return fmt.Errorf("%w: unsupported GeoJSON type %q", ErrInvalidImpactData, geo.Type)
+		}
+	}
+
+	for _, kpi := range impact.KPIs {
+		if strings.TrimSpace(kpi.Name) == "" || strings.TrimSpace(kpi.Unit) == "" {
+			return fmt.Errorf("%w: KPI name and unit are required", ErrInvalidImpactData)
+		}
+	}
+
+	return nil
+}
+
+func validateReport(report *models.ImpactReport, kpis []*models.ImpactKPI) error {
+	start, err := time.Parse("2006-01-02", report.PeriodStart)
+	if err != nil {
+		return fmt.Errorf("%w: invalid period_start", ErrInvalidImpactData)
+	}
+	end, err := time.Parse("2006-01-02", report.PeriodEnd)
+	if err != nil {
+		return fmt.Errorf("%w: invalid period_end", ErrInvalidImpactData)
+	}
+	if end.Before(start) {
+		return fmt.Errorf("%w: period_end is before period_start", ErrInvalidImpactData)
+	}
+
+	if len(report.Values) == 0 {
+		return fmt.Errorf("%w: report must include at least one KPI value", ErrInvalidImpactData)
+	}
+
+	known := make(map[int64]bool, len(kpis))
+	for _, kpi := range kpis {
+		known[kpi.ID] = true
+	}
+
+	seen := make(map[int64]bool)
+	for _, v := range report.Values {
+		if !known[v.KPIID] {
+			return fmt.Errorf("%w: KPI %d does not belong to this bond", ErrInvalidImpactData, v.KPIID)
+		}
+		if seen[v.KPIID] {
+			return fmt.Errorf("%w: duplicate value for KPI %d", ErrInvalidImpactData, v.KPIID)
+		}
+		seen[v.KPIID] = true
+	}
+
+	return nil
+}
@@ -0,0 +1,197 @@
+package services
+
+import (
+	"bluelink-backend/internal/config"
+	"bluelink-backend/internal/limits"
+	"bluelink-backend/internal/logger"
+	"bluelink-backend/internal/models"
+	"bluelink-backend/internal/repository"
+	"context"
+	"errors"
+)
+
+var (
+	ErrInvalidTradeAmount      = errors.New("trade amount must be positive")
+	ErrInvalidLimitValue       = errors.New("limits must be non-negative and max_bond_share must not exceed 1")
+	ErrComplianceAlertNotFound = errors.New("compliance alert not found")
+	ErrComplianceAlertResolved = errors.New("compliance alert is already resolved")
+)
+
+// InvestmentLimitService 投資限額服務層（額度查詢、交易前檢查、限額違規審查）
+type InvestmentLimitService struct {
+	checker        *limits.Checker
+	repo           *repository.InvestmentLimitRepository
+	userRepo       *repository.UserRepository
+	bondRepo       *repository.BondRepository
+	audit          *AuditService
+	minKYCInvestor int
+}
+
+// NewInvestmentLimitService 建立新的 InvestmentLimitService 實例
+func NewInvestmentLimitService(
+	checker *limits.Checker,
+	repo *repository.InvestmentLimitRepository,
+	userRepo *repository.UserRepository,
+	bondRepo *repository.BondRepository,
+	audit *AuditService,
+	cfg *config.Config,
+) *InvestmentLimitService {
+	return &InvestmentLimitService{
+		checker:        checker,
+		repo:           repo,
+		userRepo:       userRepo,
+		bondRepo:       bondRepo,
+		audit:          audit,
+		minKYCInvestor: cfg.KYCMinLevelInvestor,
+	}
+}
+
+// GetMyLimits 取得使用者適用的限額與目前用量
+func (s *InvestmentLimitService) GetMyLimits(ctx context.Context, userID int64) (*models.InvestmentLimits, *models.InvestmentUsage, error) {
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	usage, err := s.checker.Usage(ctx, user.ID)
+	if err != nil {
+		logger.Error("Failed to compute investment usage for user %d: %v", user.ID, err)
+		return nil, nil, err
+	}
+	return s.checker.Limits(user), usage, nil
+}
+
+// PreTradeCheck 交易前檢查：在簽署交易前判斷是否超出限額
+// amount 為投資金額、faceValue 為取得的代幣面額（皆為債券幣種最小單位，faceValue 為 0 時等於 amount）
+func (s *InvestmentLimitService) PreTradeCheck(ctx context.Context, userID, bondID, amount, faceValue int64) (*models.PreTradeCheck, error) {
+	if amount <= 0 || faceValue < 0 {
+		return nil, ErrInvalidTradeAmount
+	}
+	if faceValue == 0 {
+		faceValue = amount
+	}
+
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	bond, err := s.bondRepo.GetByID(ctx, bondID)
+	if err != nil {
+		logger.Error("Failed to get bond ID %d for pre-trade check: %v", bondID, err)
+		return nil, err
+	}
+	if bond == nil {
+		return nil, ErrBondNotFound
+	}
+
+	check, err := s.checker.Evaluate(ctx, limits.Trade{
+		User:      user,
+		Wallet:    user.WalletAddress,
+		Bond:      bond,
+		Amount:    amount,
+		FaceValue: faceValue,
+	}, true)
+	if err != nil {
+		logger.Error("Failed to evaluate limits for user %d on bond %d: %v", user.ID, bondID, err)
+		return nil, err
+	}
+
+	if user.KYCLevel < s.minKYCInvestor {
+		check.Violations = append(check.Violations, models.LimitViolation{
+			Type:   models.LimitKYCLevel,
+			Limit:  float64(s.minKYCInvestor),
+			Actual: float64(user.KYCLevel),
+		})
+	}
+	if user.IsBlacklisted {
+		check.Violations = append(check.Violations, models.LimitViolation{Type: models.LimitBlacklisted})
+	}
+	check.Allowed = len(check.Violations) == 0
+
+	return check, nil
+}
+
+// SetUserLimits 設定使用者的個別限額（nil 表示沿用 KYC 等級預設）
+func (s *InvestmentLimitService) SetUserLimits(ctx context.Context, userID int64, daily, monthly, maxBondShare *float64) error {
+	for _, value := range []*float64{daily, monthly, maxBondShare} {
+		if value != nil && *value < 0 {
+			return ErrInvalidLimitValue
+		}
+	}
+	if maxBondShare != nil && *maxBondShare > 1 {
+		return ErrInvalidLimitValue
+	}
+
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.userRepo.UpdateLimits(ctx, userID, daily, monthly, maxBondShare); err != nil {
+		logger.Error("Failed to update limits for user %d: %v", userID, err)
+		return err
+	}
+	logger.Info("Investment limits updated for user %d", userID)
+
+	s.audit.Record(ctx, models.AuditActionUserLimitsUpdate, models.AuditTargetUser, auditID(userID),
+		map[string]any{"daily_limit": user.DailyLimit, "monthly_limit": user.MonthlyLimit, "max_bond_share": user.MaxBondShare},
+		map[string]any{"daily_limit": daily, "monthly_limit": monthly, "max_bond_share": maxBondShare})
+	return nil
+}
+
+// ListAlerts 依狀態列出限額違規警示
+func (s *InvestmentLimitService) ListAlerts(ctx context.Context, status string, limit, offset int) ([]*models.ComplianceAlert, error) {
+	if limit <= 0 {
+		limit = 100
+	}
+
+	alerts, err := s.repo.ListAlerts(ctx, status, limit, offset)
+	if err != nil {
+		logger.Error("Failed to list compliance alerts with status %q: %v", status, err)
+		return nil, err
+	}
+	return alerts, nil
+}
+
+// ResolveAlert 結案限額違規警示（必須附上處理說明）
+func (s *InvestmentLimitService) ResolveAlert(ctx context.Context, id, reviewerID int64, note string) error {
+	if note == "" {
+		return ErrResolutionNoteMissing
+	}
+
+	alert, err := s.repo.GetAlertByID(ctx, id)
+	if err != nil {
+		logger.Error("Failed to get compliance alert ID %d: %v", id, err)
+		return err
+	}
+	if alert == nil {
+		return ErrComplianceAlertNotFound
+	}
+	if alert.Status != models.ComplianceAlertOpen {
+		return ErrComplianceAlertResolved
+	}
+
+	if err := s.repo.ResolveAlert(ctx, id, reviewerID, note); err != nil {
+		logger.Error("Failed to resolve compliance alert ID %d: %v", id, err)
+		return err
+	}
+	logger.Info("Compliance alert resolved: ID=%d, reviewer=%d", id, reviewerID)
+
+	s.audit.Record(ctx, models.AuditActionAlertResolve, models.AuditTargetComplianceAlert, auditID(id),
+		map[string]any{"status": alert.Status},
+		map[string]any{"status": models.ComplianceAlertResolved, "resolution_note": note})
+	return nil
+}
+
+func (s *InvestmentLimitService) getUser(ctx context.Context, userID int64) (*models.User, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		logger.Error("Failed to get user ID %d: %v", userID, err)
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+	return user, nil
+}
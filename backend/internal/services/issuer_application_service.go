@@ -0,0 +1,369 @@
+package services
+
+import (
+	"bluelink-backend/internal/config"
+	"bluelink-backend/internal/logger"
+	"bluelink-backend/internal/models"
+	"bluelink-backend/internal/repository"
+	"bluelink-backend/internal/storage"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrIssuerApplicationNotFound     = errors.New("issuer application not found")
+	ErrIssuerApplicationForbidden    = errors.New("issuer application belongs to another user")
+	ErrIssuerApplicationExists       = errors.New("an open issuer application already exists")
+	ErrIssuerApplicationNotEditable  = errors.New("issuer application can only be changed while in draft state")
+	ErrIssuerApplicationNotInReview  = errors.New("issuer application is not awaiting review")
+	ErrIssuerApplicationNotEligible  = errors.New("user can already propose bonds and cannot apply to become an issuer")
+	ErrIssuerDocumentNotFound        = errors.New("issuer application document not found")
+	ErrIssuerDocumentsMissing        = errors.New("at least one registration document is required before submitting")
+	ErrIssuerDocumentTooLarge        = errors.New("issuer application document exceeds the maximum size")
+	ErrIssuerDocumentTypeUnsupported = errors.New("unsupported issuer application document content type")
+)
+
+// issuerRole 申請核准後授予的主要角色
+const issuerRole = "issuer"
+
+// IssuerApplicationService 買方升級為發行者的申請與審核服務層
+type IssuerApplicationService struct {
+	repo            *repository.IssuerApplicationRepository
+	userRepo        *repository.UserRepository
+	blobs           storage.BlobStore
+	roles           *RoleService
+	audit           *AuditService
+	maxDocumentSize int64
+}
+
+// NewIssuerApplicationService 建立新的 IssuerApplicationService 實例
+func NewIssuerApplicationService(
+	repo *repository.IssuerApplicationRepository,
+	userRepo *repository.UserRepository,
+	blobs storage.BlobStore,
+	roles *RoleService,
+	audit *AuditService,
+	cfg *config.Config,
+) *IssuerApplicationService {
+	return &IssuerApplicationService{
+		repo:            repo,
+		userRepo:        userRepo,
+		blobs:           blobs,
+		roles:           roles,
+		audit:           audit,
+		maxDocumentSize: cfg.KYCMaxDocumentSize,
+	}
+}
+
+// ListMyApplications 取得使用者的所有申請紀錄
+func (s *IssuerApplicationService) ListMyApplications(ctx context.Context, userID int64) ([]*models.IssuerApplication, error) {
+	apps, err := s.repo.ListByUser(ctx, userID)
+	if err != nil {
+		logger.Error("Failed to list issuer applications for user %d: %v", userID, err)
+		return nil, err
+	}
+	return apps, nil
+}
+
+// CreateApplication 建立新的發行者申請草稿（僅限買方）
+func (s *IssuerApplicationService) CreateApplication(ctx context.Context, app *models.IssuerApplication) error {
+	user, err := s.userRepo.GetByID(ctx, app.UserID)
+	if err != nil {
+		logger.Error("Failed to get user ID %d for issuer application: %v", app.UserID, err)
+		return err
+	}
+	if user == nil {
+		return ErrUserNotFound
+	}
+	if err := s.checkEligible(ctx, user); err != nil {
+		return err
+	}
+
+	open, err := s.repo.GetOpen(ctx, app.UserID)
+	if err != nil {
+		logger.Error("Failed to check open issuer application for user %d: %v", app.UserID, err)
+		return err
+	}
+	if open != nil {
+		return ErrIssuerApplicationExists
+	}
+
+	app.Status = models.IssuerApplicationDraft
+	if err := s.repo.Create(ctx, app); err != nil {
+		logger.Error("Failed to create issuer application for user %d: %v", app.UserID, err)
+		return err
+	}
+	app.Documents = []*models.IssuerApplicationDocument{}
+
+	logger.Info("Issuer application created: ID=%d, user=%d, institution=%s", app.ID, app.UserID, app.InstitutionName)
+	return nil
+}
+
+// GetApplication 取得申請與附件（userID 非 0 時檢查擁有者）
+func (s *IssuerApplicationService) GetApplication(ctx context.Context, id, userID int64) (*models.IssuerApplication, error) {
+	app, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		logger.Error("Failed to get issuer application ID %d: %v", id, err)
+		return nil, err
+	}
+	if app == nil {
+		return nil, ErrIssuerApplicationNotFound
+	}
+	if userID != 0 && app.UserID != userID {
+		return nil, ErrIssuerApplicationForbidden
+	}
+
+	docs, err := s.repo.ListDocuments(ctx, id)
+	if err != nil {
+		logger.Error("Failed to list documents of issuer application ID %d: %v", id, err)
+		return nil, err
+	}
+	app.Documents = docs
+
+	return app, nil
+}
+
+// UpdateApplication 更新機構與聯絡人資料（僅擁有者、僅草稿狀態）
+func (s *IssuerApplicationService) UpdateApplication(ctx context.Context, userID int64, app *models.IssuerApplication) error {
+	existing, err := s.GetApplication(ctx, app.ID, userID)
+	if err != nil {
+		return err
+	}
+	if existing.Status != models.IssuerApplicationDraft {
+		return ErrIssuerApplicationNotEditable
+	}
+
+	if err := s.repo.Update(ctx, app); err != nil {
+		logger.Error("Failed to update issuer application ID %d: %v", app.ID, err)
+		return err
+	}
+	logger.Info("Issuer application updated: ID=%d", app.ID)
+	return nil
+}
+
+// UploadDocument 上傳登記文件到 Blob Store 並記錄雜湊
+func (s *IssuerApplicationService) UploadDocument(ctx context.Context, userID, applicationID int64, doc *models.IssuerApplicationDocument, content io.Reader) error {
+	existing, err := s.GetApplication(ctx, applicationID, userID)
+	if err != nil {
+		return err
+	}
+	if existing.Status != models.IssuerApplicationDraft {
+		return ErrIssuerApplicationNotEditable
+	}
+	if !kycAllowedContentTypes[doc.ContentType] {
+		return ErrIssuerDocumentTypeUnsupported
+	}
+	if doc.SizeBytes > s.maxDocumentSize {
+		return ErrIssuerDocumentTooLarge
+	}
+
+	doc.ApplicationID = applicationID
+	doc.StorageKey = fmt.Sprintf("issuer/%d/%d/%s", existing.UserID, applicationID, uuid.New().String())
+
+	// 邊寫入邊計算雜湊與實際大小（不信任客戶端宣告的大小）
+	hasher := sha256.New()
+	counter := &countingReader{r: io.LimitReader(content, s.maxDocumentSize+1)}
+	if err := s.blobs.Put(ctx, doc.StorageKey, io.TeeReader(counter, hasher)); err != nil {
+		logger.Error("Failed to store issuer document for application %d: %v", applicationID, err)
+		return err
+	}
+	if counter.n > s.maxDocumentSize {
+		s.deleteBlob(ctx, doc.StorageKey)
+		return ErrIssuerDocumentTooLarge
+	}
+
+	doc.SizeBytes = counter.n
+	doc.SHA256 = hex.EncodeToString(hasher.Sum(nil))
+
+	if err := s.repo.CreateDocument(ctx, doc); err != nil {
+		logger.Error("Failed to record issuer document for application %d: %v", applicationID, err)
+		s.deleteBlob(ctx, doc.StorageKey)
+		return err
+	}
+
+	logger.Info("Issuer document uploaded: ID=%d, application=%d, type=%s, size=%d", doc.ID, applicationID, doc.DocType, doc.SizeBytes)
+	return nil
+}
+
+// DeleteDocument 刪除草稿申請的附件
+func (s *IssuerApplicationService) DeleteDocument(ctx context.Context, userID, applicationID, documentID int64) error {
+	existing, err := s.GetApplication(ctx, applicationID, userID)
+	if err != nil {
+		return err
+	}
+	if existing.Status != models.IssuerApplicationDraft {
+		return ErrIssuerApplicationNotEditable
+	}
+
+	doc, err := s.repo.GetDocument(ctx, applicationID, documentID)
+	if err != nil {
+		logger.Error("Failed to get issuer document ID %d: %v", documentID, err)
+		return err
+	}
+	if doc == nil {
+		return ErrIssuerDocumentNotFound
+	}
+
+	if err := s.repo.DeleteDocument(ctx, applicationID, documentID); err != nil {
+		logger.Error("Failed to delete issuer document ID %d: %v", documentID, err)
+		return err
+	}
+	s.deleteBlob(ctx, doc.StorageKey)
+
+	logger.Info("Issuer document deleted: ID=%d, application=%d", documentID, applicationID)
+	return nil
+}
+
+// OpenDocument 開啟附件內容（userID 非 0 時檢查擁有者），呼叫端負責關閉
+func (s *IssuerApplicationService) OpenDocument(ctx context.Context, userID, applicationID, documentID int64) (*models.IssuerApplicationDocument, io.ReadCloser, error) {
+	if _, err := s.GetApplication(ctx, applicationID, userID); err != nil {
+		return nil, nil, err
+	}
+
+	doc, err := s.repo.GetDocument(ctx, applicationID, documentID)
+	if err != nil {
+		logger.Error("Failed to get issuer document ID %d: %v", documentID, err)
+		return nil, nil, err
+	}
+	if doc == nil {
+		return nil, nil, ErrIssuerDocumentNotFound
+	}
+
+	content, err := s.blobs.Get(ctx, doc.StorageKey)
+	if errors.Is(err, storage.ErrBlobNotFound) {
+		logger.Warn("Issuer document ID %d is missing from blob store: %s", documentID, doc.StorageKey)
+		return nil, nil, ErrIssuerDocumentNotFound
+	}
+	if err != nil {
+		logger.Error("Failed to read issuer document ID %d: %v", documentID, err)
+		return nil, nil, err
+	}
+
+	return doc, content, nil
+}
+
+// SubmitApplication 送出申請給管理員審核
+func (s *IssuerApplicationService) SubmitApplication(ctx context.Context, userID, applicationID int64) error {
+	existing, err := s.GetApplication(ctx, applicationID, userID)
+	if err != nil {
+		return err
+	}
+	if existing.Status != models.IssuerApplicationDraft {
+		return ErrIssuerApplicationNotEditable
+	}
+	if len(existing.Documents) == 0 {
+		return ErrIssuerDocumentsMissing
+	}
+
+	if err := s.repo.Submit(ctx, applicationID); err != nil {
+		logger.Error("Failed to submit issuer application ID %d: %v", applicationID, err)
+		return err
+	}
+
+	logger.Info("Issuer application submitted for review: ID=%d, user=%d", applicationID, userID)
+	return nil
+}
+
+// ===== 管理員功能 =====
+
+// ListApplications 依狀態列出申請
+func (s *IssuerApplicationService) ListApplications(ctx context.Context, status string, limit, offset int) ([]*models.IssuerApplication, error) {
+	if limit <= 0 {
+		limit = 100
+	}
+
+	apps, err := s.repo.ListByStatus(ctx, status, limit, offset)
+	if err != nil {
+		logger.Error("Failed to list issuer applications with status %q: %v", status, err)
+		return nil, err
+	}
+	return apps, nil
+}
+
+// ApproveApplication 核准申請，將使用者升級為發行者並同步到現有 Session
+func (s *IssuerApplicationService) ApproveApplication(ctx context.Context, id, reviewerID int64, comment string) error {
+	existing, err := s.GetApplication(ctx, id, 0)
+	if err != nil {
+		return err
+	}
+	if existing.Status != models.IssuerApplicationPending {
+		return ErrIssuerApplicationNotInReview
+	}
+
+	user, err := s.userRepo.GetByID(ctx, existing.UserID)
+	if err != nil {
+		logger.Error("Failed to get user ID %d for issuer application: %v", existing.UserID, err)
+		return err
+	}
+	if user == nil {
+		return ErrUserNotFound
+	}
+	if err := s.checkEligible(ctx, user); err != nil {
+		return err
+	}
+
+	// 僅在使用者的主要角色未於審核期間變更時升級
+	if err := s.repo.Approve(ctx, existing, reviewerID, optionalString(comment), user.Role, issuerRole); err != nil {
+		logger.Error("Failed to approve issuer application ID %d: %v", id, err)
+		return err
+	}
+	s.roles.SyncPrimaryRole(ctx, existing.UserID, issuerRole)
+
+	logger.Info("Issuer application approved: ID=%d, user=%d, reviewer=%d", id, existing.UserID, reviewerID)
+
+	s.audit.Record(ctx, models.AuditActionIssuerApprove, models.AuditTargetIssuerApplication, auditID(id),
+		map[string]any{"status": existing.Status, "user_id": existing.UserID, "user_role": user.Role},
+		map[string]any{"status": models.IssuerApplicationApproved, "user_id": existing.UserID, "user_role": issuerRole, "review_comment": optionalString(comment)})
+	return nil
+}
+
+// checkEligible 依角色權限判斷使用者是否可申請成為發行者（已可提交債券發行申請者不需申請）
+func (s *IssuerApplicationService) checkEligible(ctx context.Context, user *models.User) error {
+	canPropose, err := s.roles.UserHasPermission(ctx, user, models.PermissionBondsPropose)
+	if err != nil {
+		return err
+	}
+	if canPropose {
+		return ErrIssuerApplicationNotEligible
+	}
+	return nil
+}
+
+// DenyApplication 駁回申請（必須附上意見）
+func (s *IssuerApplicationService) DenyApplication(ctx context.Context, id, reviewerID int64, comment string) error {
+	if comment == "" {
+		return ErrReviewCommentMissing
+	}
+
+	existing, err := s.GetApplication(ctx, id, 0)
+	if err != nil {
+		return err
+	}
+	if existing.Status != models.IssuerApplicationPending {
+		return ErrIssuerApplicationNotInReview
+	}
+
+	if err := s.repo.Deny(ctx, id, reviewerID, comment); err != nil {
+		logger.Error("Failed to deny issuer application ID %d: %v", id, err)
+		return err
+	}
+
+	logger.Info("Issuer application denied: ID=%d, user=%d, reviewer=%d", id, existing.UserID, reviewerID)
+
+	s.audit.Record(ctx, models.AuditActionIssuerDeny, models.AuditTargetIssuerApplication, auditID(id),
+		map[string]any{"status": existing.Status},
+		map[string]any{"status": models.IssuerApplicationDenied, "user_id": existing.UserID, "review_comment": comment})
+	return nil
+}
+
+func (s *IssuerApplicationService) deleteBlob(ctx context.Context, key string) {
+	if err := s.blobs.Delete(ctx, key); err != nil {
+		logger.Warn("Failed to delete issuer application blob %s: %v", key, err)
+	}
+}
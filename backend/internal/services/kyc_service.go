@@ -0,0 +1,443 @@
+package services
+
+import (
+	"bluelink-backend/internal/config"
+	"bluelink-backend/internal/logger"
+	"bluelink-backend/internal/models"
+	"bluelink-backend/internal/repository"
+	"bluelink-backend/internal/session"
+	"bluelink-backend/internal/storage"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrKYCApplicationNotFound     = errors.New("KYC application not found")
+	ErrKYCApplicationForbidden    = errors.New("KYC application belongs to another user")
+	ErrKYCApplicationExists       = errors.New("an open KYC application already exists")
+	ErrKYCApplicationNotEditable  = errors.New("KYC application can only be changed while in draft state")
+	ErrKYCApplicationNotInReview  = errors.New("KYC application is not awaiting review")
+	ErrKYCLevelAlreadyGranted     = errors.New("requested KYC level is already granted")
+	ErrKYCInvalidLevel            = errors.New("invalid KYC level")
+	ErrKYCDocumentNotFound        = errors.New("KYC document not found")
+	ErrKYCDocumentsMissing        = errors.New("at least one document is required before submitting")
+	ErrKYCDocumentTooLarge        = errors.New("KYC document exceeds the maximum size")
+	ErrKYCDocumentTypeUnsupported = errors.New("unsupported KYC document content type")
+)
+
+// kycAllowedContentTypes 可上傳的文件格式
+var kycAllowedContentTypes = map[string]bool{
+	"image/jpeg":      true,
+	"image/png":       true,
+	"application/pdf": true,
+}
+
+// KYCService KYC 申請、文件與審核服務層
+type KYCService struct {
+	repo            *repository.KYCRepository
+	userRepo        *repository.UserRepository
+	blobs           storage.BlobStore
+	sessionManager  session.SessionManager
+	audit           *AuditService
+	maxDocumentSize int64
+}
+
+// NewKYCService 建立新的 KYCService 實例
+func NewKYCService(
+	repo *repository.KYCRepository,
+	userRepo *repository.UserRepository,
+	blobs storage.BlobStore,
+	sessionManager session.SessionManager,
+	audit *AuditService,
+	cfg *config.Config,
+) *KYCService {
+	return &KYCService{
+		repo:            repo,
+		userRepo:        userRepo,
+		blobs:           blobs,
+		sessionManager:  sessionManager,
+		audit:           audit,
+		maxDocumentSize: cfg.KYCMaxDocumentSize,
+	}
+}
+
+// GetMyKYC 取得使用者目前的 KYC 狀態與申請紀錄
+func (s *KYCService) GetMyKYC(ctx context.Context, userID int64) (*models.User, []*models.KYCApplication, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		logger.Error("Failed to get user ID %d for KYC: %v", userID, err)
+		return nil, nil, err
+	}
+	if user == nil {
+		return nil, nil, errors.New("user not found")
+	}
+
+	apps, err := s.repo.ListByUser(ctx, userID)
+	if err != nil {
+		logger.Error("Failed to list KYC applications for user %d: %v", userID, err)
+		return nil, nil, err
+	}
+	return user, apps, nil
+}
+
+// CreateApplication 建立新的 KYC 申請草稿
+func (s *KYCService) CreateApplication(ctx context.Context, app *models.KYCApplication) error {
+	if !isValidKYCLevel(app.RequestedLevel) {
+		return ErrKYCInvalidLevel
+	}
+
+	user, err := s.userRepo.GetByID(ctx, app.UserID)
+	if err != nil {
+		logger.Error("Failed to get user ID %d for KYC: %v", app.UserID, err)
+		return err
+	}
+	if user == nil {
+		return errors.New("user not found")
+	}
+	if user.KYCStatus == models.KYCStatusVerified && user.KYCLevel >= app.RequestedLevel {
+		return ErrKYCLevelAlreadyGranted
+	}
+
+	open, err := s.repo.GetOpenApplication(ctx, app.UserID)
+	if err != nil {
+		logger.Error("Failed to check open KYC application for user %d: %v", app.UserID, err)
+		return err
+	}
+	if open != nil {
+		return ErrKYCApplicationExists
+	}
+
+	app.Status = models.KYCApplicationDraft
+	if err := s.repo.CreateApplication(ctx, app); err != nil {
+		logger.Error("Failed to create KYC application for user %d: %v", app.UserID, err)
+		return err
+	}
+	app.Documents = []*models.KYCDocument{}
+
+	logger.Info("KYC application created: ID=%d, user=%d, level=%d", app.ID, app.UserID, app.RequestedLevel)
+	return nil
+}
+
+// GetApplication 取得申請與附件（userID 非 0 時檢查擁有者）
+func (s *KYCService) GetApplication(ctx context.Context, id, userID int64) (*models.KYCApplication, error) {
+	app, err := s.repo.GetApplicationByID(ctx, id)
+	if err != nil {
+		logger.Error("Failed to get KYC application ID %d: %v", id, err)
+		return nil, err
+	}
+	if app == nil {
+		return nil, ErrKYCApplicationNotFound
+	}
+	if userID != 0 && app.UserID != userID {
+		return nil, ErrKYCApplicationForbidden
+	}
+
+	docs, err := s.repo.ListDocuments(ctx, id)
+	if err != nil {
+		logger.Error("Failed to list documents of KYC application ID %d: %v", id, err)
+		return nil, err
+	}
+	app.Documents = docs
+
+	return app, nil
+}
+
+// UpdateApplication 更新申請人資料（僅擁有者、僅草稿狀態）
+func (s *KYCService) UpdateApplication(ctx context.Context, userID int64, app *models.KYCApplication) error {
+	if !isValidKYCLevel(app.RequestedLevel) {
+		return ErrKYCInvalidLevel
+	}
+
+	existing, err := s.GetApplication(ctx, app.ID, userID)
+	if err != nil {
+		return err
+	}
+	if existing.Status != models.KYCApplicationDraft {
+		return ErrKYCApplicationNotEditable
+	}
+
+	if err := s.repo.UpdateApplication(ctx, app); err != nil {
+		logger.Error("Failed to update KYC application ID %d: %v", app.ID, err)
+		return err
+	}
+	logger.Info("KYC application updated: ID=%d", app.ID)
+	return nil
+}
+
+// UploadDocument 上傳申請附件到 Blob Store 並記錄雜湊
+func (s *KYCService) UploadDocument(ctx context.Context, userID, applicationID int64, doc *models.KYCDocument, content io.Reader) error {
+	existing, err := s.GetApplication(ctx, applicationID, userID)
+	if err != nil {
+		return err
+	}
+	if existing.Status != models.KYCApplicationDraft {
+		return ErrKYCApplicationNotEditable
+	}
+	if !kycAllowedContentTypes[doc.ContentType] {
+		return ErrKYCDocumentTypeUnsupported
+	}
+	if doc.SizeBytes > s.maxDocumentSize {
+		return ErrKYCDocumentTooLarge
+	}
+
+	doc.ApplicationID = applicationID
+	doc.StorageKey = fmt.Sprintf("kyc/%d/%d/%s", existing.UserID, applicationID, uuid.New().String())
+
+	// 邊寫入邊計算雜湊與實際大小（不信任客戶端宣告的大小）
+	hasher := sha256.New()
+	counter := &countingReader{r: io.LimitReader(content, s.maxDocumentSize+1)}
+	if err := s.blobs.Put(ctx, doc.StorageKey, io.TeeReader(counter, hasher)); err != nil {
+		logger.Error("Failed to store KYC document for application %d: %v", applicationID, err)
+		return err
+	}
+	if counter.n > s.maxDocumentSize {
+		s.deleteBlob(ctx, doc.StorageKey)
+		return ErrKYCDocumentTooLarge
+	}
+
+	doc.SizeBytes = counter.n
+	doc.SHA256 = hex.EncodeToString(hasher.Sum(nil))
+
+	if err := s.repo.CreateDocument(ctx, doc); err != nil {
+		logger.Error("Failed to record KYC document for application %d: %v", applicationID, err)
+		s.deleteBlob(ctx, doc.StorageKey)
+		return err
+	}
+
+	logger.Info("KYC document uploaded: ID=%d, application=%d, type=%s, size=%d", doc.ID, applicationID, doc.DocType, doc.SizeBytes)
+	return nil
+}
+
+// DeleteDocument 刪除草稿申請的附件
+func (s *KYCService) DeleteDocument(ctx context.Context, userID, applicationID, documentID int64) error {
+	existing, err := s.GetApplication(ctx, applicationID, userID)
+	if err != nil {
+		return err
+	}
+	if existing.Status != models.KYCApplicationDraft {
+		return ErrKYCApplicationNotEditable
+	}
+
+	doc, err := s.repo.GetDocument(ctx, applicationID, documentID)
+	if err != nil {
+		logger.Error("Failed to get KYC document ID %d: %v", documentID, err)
+		return err
+	}
+	if doc == nil {
+		return ErrKYCDocumentNotFound
+	}
+
+	if err := s.repo.DeleteDocument(ctx, applicationID, documentID); err != nil {
+		logger.Error("Failed to delete KYC document ID %d: %v", documentID, err)
+		return err
+	}
+	s.deleteBlob(ctx, doc.StorageKey)
+
+	logger.Info("KYC document deleted: ID=%d, application=%d", documentID, applicationID)
+	return nil
+}
+
+// OpenDocument 開啟附件內容（userID 非 0 時檢查擁有者），呼叫端負責關閉
+func (s *KYCService) OpenDocument(ctx context.Context, userID, applicationID, documentID int64) (*models.KYCDocument, io.ReadCloser, error) {
+	if _, err := s.GetApplication(ctx, applicationID, userID); err != nil {
+		return nil, nil, err
+	}
+
+	doc, err := s.repo.GetDocument(ctx, applicationID, documentID)
+	if err != nil {
+		logger.Error("Failed to get KYC document ID %d: %v", documentID, err)
+		return nil, nil, err
+	}
+	if doc == nil {
+		return nil, nil, ErrKYCDocumentNotFound
+	}
+
+	content, err := s.blobs.Get(ctx, doc.StorageKey)
+	if errors.Is(err, storage.ErrBlobNotFound) {
+		logger.Warn("KYC document ID %d is missing from blob store: %s", documentID, doc.StorageKey)
+		return nil, nil, ErrKYCDocumentNotFound
+	}
+	if err != nil {
+		logger.Error("Failed to read KYC document ID %d: %v", documentID, err)
+		return nil, nil, err
+	}
+
+	return doc, content, nil
+}
+
+// SubmitApplication 送出申請給管理員審核
+func (s *KYCService) SubmitApplication(ctx context.Context, userID, applicationID int64) error {
+	existing, err := s.GetApplication(ctx, applicationID, userID)
+	if err != nil {
+		return err
+	}
+	if existing.Status != models.KYCApplicationDraft {
+		return ErrKYCApplicationNotEditable
+	}
+	if len(existing.Documents) == 0 {
+		return ErrKYCDocumentsMissing
+	}
+
+	if err := s.repo.Submit(ctx, applicationID); err != nil {
+		logger.Error("Failed to submit KYC application ID %d: %v", applicationID, err)
+		return err
+	}
+
+	// 已認證的使用者申請升級時保留原有狀態，避免審核期間失去既有權限
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		logger.Error("Failed to get user ID %d for KYC: %v", userID, err)
+		return err
+	}
+	if user != nil && user.KYCStatus != models.KYCStatusVerified {
+		if err := s.setUserKYC(ctx, userID, models.KYCStatusPending, user.KYCLevel, user.KYCVerifiedAt); err != nil {
+			return err
+		}
+	}
+
+	logger.Info("KYC application submitted for review: ID=%d, user=%d", applicationID, userID)
+	return nil
+}
+
+// ===== 管理員功能 =====
+
+// ListApplications 依狀態列出申請
+func (s *KYCService) ListApplications(ctx context.Context, status string, limit, offset int) ([]*models.KYCApplication, error) {
+	if limit <= 0 {
+		limit = 100
+	}
+
+	apps, err := s.repo.ListByStatus(ctx, status, limit, offset)
+	if err != nil {
+		logger.Error("Failed to list KYC applications with status %q: %v", status, err)
+		return nil, err
+	}
+	return apps, nil
+}
+
+// ApproveApplication 核准申請並授予 KYC 等級（level 為 0 時採用申請的等級）
+func (s *KYCService) ApproveApplication(ctx context.Context, id, reviewerID int64, level int, comment string) error {
+	existing, err := s.GetApplication(ctx, id, 0)
+	if err != nil {
+		return err
+	}
+	if existing.Status != models.KYCApplicationPending {
+		return ErrKYCApplicationNotInReview
+	}
+
+	if level == 0 {
+		level = existing.RequestedLevel
+	}
+	if !isValidKYCLevel(level) {
+		return ErrKYCInvalidLevel
+	}
+
+	if err := s.repo.Review(ctx, id, models.KYCApplicationApproved, &level, reviewerID, optionalString(comment)); err != nil {
+		logger.Error("Failed to approve KYC application ID %d: %v", id, err)
+		return err
+	}
+
+	if err := s.userRepo.UpdateCountry(ctx, existing.UserID, existing.Country); err != nil {
+		logger.Warn("Failed to update country of user %d: %v", existing.UserID, err)
+	}
+
+	now := time.Now()
+	if err := s.setUserKYC(ctx, existing.UserID, models.KYCStatusVerified, level, &now); err != nil {
+		return err
+	}
+
+	logger.Info("KYC application approved: ID=%d, user=%d, level=%d, reviewer=%d", id, existing.UserID, level, reviewerID)
+
+	s.audit.Record(ctx, models.AuditActionKYCApprove, models.AuditTargetKYCApplication, auditID(id),
+		map[string]any{"status": existing.Status},
+		map[string]any{"status": models.KYCApplicationApproved, "user_id": existing.UserID, "kyc_level": level, "review_comment": optionalString(comment)})
+	return nil
+}
+
+// RejectApplication 退回申請（必須附上意見）
+func (s *KYCService) RejectApplication(ctx context.Context, id, reviewerID int64, comment string) error {
+	if comment == "" {
+		return ErrReviewCommentMissing
+	}
+
+	existing, err := s.GetApplication(ctx, id, 0)
+	if err != nil {
+		return err
+	}
+	if existing.Status != models.KYCApplicationPending {
+		return ErrKYCApplicationNotInReview
+	}
+
+	if err := s.repo.Review(ctx, id, models.KYCApplicationRejected, nil, reviewerID, &comment); err != nil {
+		logger.Error("Failed to reject KYC application ID %d: %v", id, err)
+		return err
+	}
+
+	// 升級申請被退回時保留既有的認證等級
+	user, err := s.userRepo.GetByID(ctx, existing.UserID)
+	if err != nil {
+		logger.Error("Failed to get user ID %d for KYC: %v", existing.UserID, err)
+		return err
+	}
+	if user != nil && user.KYCLevel == models.KYCLevelNone {
+		if err := s.setUserKYC(ctx, user.ID, models.KYCStatusRejected, models.KYCLevelNone, nil); err != nil {
+			return err
+		}
+	}
+
+	logger.Info("KYC application rejected: ID=%d, user=%d, reviewer=%d", id, existing.UserID, reviewerID)
+
+	s.audit.Record(ctx, models.AuditActionKYCReject, models.AuditTargetKYCApplication, auditID(id),
+		map[string]any{"status": existing.Status},
+		map[string]any{"status": models.KYCApplicationRejected, "user_id": existing.UserID, "review_comment": comment})
+	return nil
+}
+
+// setUserKYC 更新使用者 KYC 狀態並同步到現有 Session
+func (s *KYCService) setUserKYC(ctx context.Context, userID int64, status string, level int, verifiedAt *time.Time) error {
+	if err := s.userRepo.UpdateKYC(ctx, userID, status, level, verifiedAt); err != nil {
+		logger.Error("Failed to update KYC of user %d: %v", userID, err)
+		return err
+	}
+
+	if err := s.sessionManager.UpdateUserKYC(ctx, userID, status, level); err != nil {
+		logger.Warn("Failed to refresh sessions of user %d after KYC change: %v", userID, err)
+	}
+	return nil
+}
+
+func (s *KYCService) deleteBlob(ctx context.Context, key string) {
+	if err := s.blobs.Delete(ctx, key); err != nil {
+		logger.Warn("Failed to delete KYC blob %s: %v", key, err)
+	}
+}
+
+func isValidKYCLevel(level int) bool {
+	return level == models.KYCLevelBasic || level == models.KYCLevelAdvanced
+}
+
+func optionalString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+// countingReader 計算實際讀取的位元組數
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
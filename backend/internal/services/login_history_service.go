@@ -0,0 +1,307 @@
+package services
+
+import (
+	"bluelink-backend/internal/config"
+	"bluelink-backend/internal/logger"
+	"bluelink-backend/internal/models"
+	"bluelink-backend/internal/repository"
+	"bluelink-backend/internal/session"
+	"bluelink-backend/internal/utils"
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+/*
+   登入紀錄與異常偵測：
+   - 每次登入（成功或失敗）寫入 login_events，保留 IP、IP 網段、國家、User-Agent 與裝置標籤
+   - 國家由反向代理提供的 Header 取得（GEO_COUNTRY_HEADER，例如 Cloudflare 的 CF-IPCountry），未設定時不判斷國家
+   - 異常規則（命中時通知使用者）：
+     1. 新位置：成功登入的國家或 IP 網段（IPv4 /24、IPv6 /48）從未出現過（首次登入除外）
+     2. 連續失敗：同一錢包在 LOGIN_FAILURE_WINDOW 內失敗達 LOGIN_FAILURE_ALERT_THRESHOLD 次（每個時間窗最多通知一次）
+     3. 遠距離同時登入：同一錢包的其他有效 Session 來自不同國家；
+        國家未知時以較大的網段（IPv4 /16、IPv6 /32）判斷
+*/
+
+// LoginAttempt 一次登入嘗試的來源資訊
+type LoginAttempt struct {
+	WalletAddress string
+	IPAddress     string
+	Country       string // ISO 3166-1 alpha-2，未知時為空字串
+	UserAgent     string
+}
+
+// LoginHistoryService 登入紀錄與異常偵測服務層
+type LoginHistoryService struct {
+	repo             *repository.LoginEventRepository
+	userRepo         *repository.UserRepository
+	sessionManager   session.SessionManager
+	notifications    *NotificationService
+	countryHeader    string
+	failureThreshold int
+	failureWindow    time.Duration
+}
+
+// NewLoginHistoryService 建立新的 LoginHistoryService 實例
+func NewLoginHistoryService(
+	repo *repository.LoginEventRepository,
+	userRepo *repository.UserRepository,
+	sessionManager session.SessionManager,
+	notifications *NotificationService,
+	cfg *config.Config,
+) *LoginHistoryService {
+	return &LoginHistoryService{
+		repo:             repo,
+		userRepo:         userRepo,
+		sessionManager:   sessionManager,
+		notifications:    notifications,
+		countryHeader:    cfg.GeoCountryHeader,
+		failureThreshold: cfg.LoginFailureAlertThreshold,
+		failureWindow:    time.Duration(cfg.LoginFailureWindow) * time.Second,
+	}
+}
+
+// ClientCountry 由反向代理的 Header 取得請求來源國家（未設定 Header 或值無效時回傳空字串）
+func (s *LoginHistoryService) ClientCountry(r *http.Request) string {
+	if s.countryHeader == "" {
+		return ""
+	}
+
+	country := strings.ToUpper(strings.TrimSpace(r.Header.Get(s.countryHeader)))
+	if len(country) != 2 || country == "XX" || country[0] < 'A' || country[0] > 'Z' || country[1] < 'A' || country[1] > 'Z' {
+		return ""
+	}
+	return country
+}
+
+// RecordFailure 記錄登入失敗；錢包屬於已註冊使用者且短時間內失敗過多時通知該使用者
+// 寫入失敗時僅記錄錯誤，不影響原本的回應
+func (s *LoginHistoryService) RecordFailure(ctx context.Context, attempt LoginAttempt, reason string) {
+	ctx = context.WithoutCancel(ctx)
+
+	user, err := s.userRepo.GetByWalletAddress(ctx, attempt.WalletAddress)
+	if err != nil {
+		logger.Error("Failed to look up user of wallet %s for login history: %v", attempt.WalletAddress, err)
+	}
+
+	event := newLoginEvent(attempt)
+	event.Success = false
+	event.FailureReason = &reason
+	if user != nil {
+		event.UserID = &user.ID
+	}
+
+	if err := s.repo.Create(ctx, event); err != nil {
+		logger.Error("Failed to record login failure of wallet %s: %v", attempt.WalletAddress, err)
+		return
+	}
+
+	if user != nil {
+		s.checkFailureBurst(ctx, user.ID, attempt)
+	}
+}
+
+// RecordSuccess 記錄成功登入，並檢查新位置與遠距離同時登入
+// 寫入失敗時僅記錄錯誤，不影響登入
+func (s *LoginHistoryService) RecordSuccess(ctx context.Context, attempt LoginAttempt, userID int64, sessionID string) {
+	ctx = context.WithoutCancel(ctx)
+
+	event := newLoginEvent(attempt)
+	event.Success = true
+	event.UserID = &userID
+	sessionRef := session.Ref(sessionID)
+	event.SessionRef = &sessionRef
+
+	// 先統計過去的登入位置，再寫入本次紀錄
+	total, sameCountry, sameNetwork, err := s.repo.CountSuccessesByLocation(ctx, userID, attempt.Country, stringValue(event.IPNetwork))
+	if err != nil {
+		logger.Error("Failed to check login locations of user %d: %v", userID, err)
+	}
+
+	if err := s.repo.Create(ctx, event); err != nil {
+		logger.Error("Failed to record login of user %d: %v", userID, err)
+		return
+	}
+
+	if err == nil && total > 0 {
+		newCountry := attempt.Country != "" && sameCountry == 0
+		newNetwork := event.IPNetwork != nil && sameNetwork == 0
+		if newCountry || newNetwork {
+			s.notifications.Notify(ctx, userID, models.NotificationLoginNewLocation,
+				"New sign-in location",
+				fmt.Sprintf("Your account was signed in from %s using %s. If this wasn't you, sign out all devices.",
+					describeLocation(attempt), stringValue(event.Device)),
+				loginNotificationData(event))
+		}
+	}
+
+	s.checkConcurrentSessions(ctx, userID, attempt, sessionID)
+}
+
+// ListHistory 取得使用者的登入紀錄，回傳紀錄與總筆數
+func (s *LoginHistoryService) ListHistory(ctx context.Context, userID int64, limit, offset int) ([]*models.LoginEvent, int, error) {
+	if limit <= 0 || limit > 200 {
+		limit = 50
+	}
+
+	events, total, err := s.repo.ListByUser(ctx, userID, limit, offset)
+	if err != nil {
+		logger.Error("Failed to list login history of user %d: %v", userID, err)
+		return nil, 0, err
+	}
+	return events, total, nil
+}
+
+// checkFailureBurst 時間窗內失敗次數達門檻時通知使用者（同一時間窗只通知一次）
+func (s *LoginHistoryService) checkFailureBurst(ctx context.Context, userID int64, attempt LoginAttempt) {
+	since := time.Now().Add(-s.failureWindow)
+
+	failures, err := s.repo.CountFailuresSince(ctx, attempt.WalletAddress, since)
+	if err != nil {
+		logger.Error("Failed to count login failures of wallet %s: %v", attempt.WalletAddress, err)
+		return
+	}
+	if failures < s.failureThreshold {
+		return
+	}
+
+	notified, err := s.notifications.repo.ExistsSince(ctx, userID, models.NotificationLoginFailureBurst, since)
+	if err != nil {
+		logger.Error("Failed to check login failure notifications of user %d: %v", userID, err)
+		return
+	}
+	if notified {
+		return
+	}
+
+	logger.Warn("Login failure burst: wallet=%s, failures=%d, last_ip=%s", attempt.WalletAddress, failures, attempt.IPAddress)
+
+	s.notifications.Notify(ctx, userID, models.NotificationLoginFailureBurst,
+		"Multiple failed sign-in attempts",
+		fmt.Sprintf("%d failed sign-in attempts for wallet %s in the last %s. The latest came from %s.",
+			failures, attempt.WalletAddress, s.failureWindow, describeLocation(attempt)),
+		map[string]any{
+			"wallet_address": attempt.WalletAddress,
+			"failures":       failures,
+			"window_seconds": int(s.failureWindow.Seconds()),
+			"ip_address":     attempt.IPAddress,
+			"country":        attempt.Country,
+			"device":         utils.DeviceLabel(attempt.UserAgent),
+		})
+}
+
+// checkConcurrentSessions 檢查同一使用者（含綁定錢包）的其他有效 Session 是否來自遠距離的位置
+func (s *LoginHistoryService) checkConcurrentSessions(ctx context.Context, userID int64, attempt LoginAttempt, sessionID string) {
+	sessions, err := s.sessionManager.GetUserSessionsByUserID(ctx, userID)
+	if err != nil {
+		logger.Error("Failed to get sessions of user %d: %v", userID, err)
+		return
+	}
+
+	refs := []string{}
+	for _, sess := range sessions {
+		if sess.ID != sessionID {
+			refs = append(refs, session.Ref(sess.ID))
+		}
+	}
+	if len(refs) == 0 {
+		return
+	}
+
+	others, err := s.repo.GetBySessionRefs(ctx, refs)
+	if err != nil {
+		logger.Error("Failed to get login events of active sessions of user %d: %v", userID, err)
+		return
+	}
+
+	for _, other := range others {
+		if !distantLogins(attempt, other) {
+			continue
+		}
+
+		s.notifications.Notify(ctx, userID, models.NotificationConcurrentSessionsFar,
+			"Signed in from distant locations",
+			fmt.Sprintf("Your account is signed in with wallet %s from %s and with wallet %s from %s at the same time. If this wasn't you, sign out all devices.",
+				attempt.WalletAddress, describeLocation(attempt), other.WalletAddress, describeEventLocation(other)),
+			map[string]any{
+				"wallet_address": attempt.WalletAddress,
+				"ip_address":     attempt.IPAddress,
+				"country":        attempt.Country,
+				"other_wallet":   other.WalletAddress,
+				"other_ip":       stringValue(other.IPAddress),
+				"other_country":  stringValue(other.Country),
+				"other_device":   stringValue(other.Device),
+			})
+		return
+	}
+}
+
+// distantLogins 兩次登入是否來自遠距離的位置：國家皆已知時比較國家，否則比較較大的網段
+func distantLogins(attempt LoginAttempt, other *models.LoginEvent) bool {
+	otherCountry := stringValue(other.Country)
+	if attempt.Country != "" && otherCountry != "" {
+		return attempt.Country != otherCountry
+	}
+
+	current := ipNetwork(attempt.IPAddress, 16, 32)
+	previous := ipNetwork(stringValue(other.IPAddress), 16, 32)
+	return current != "" && previous != "" && current != previous
+}
+
+// newLoginEvent 由登入嘗試建立登入紀錄（IP 網段與裝置標籤）
+func newLoginEvent(attempt LoginAttempt) *models.LoginEvent {
+	device := utils.DeviceLabel(attempt.UserAgent)
+	return &models.LoginEvent{
+		WalletAddress: attempt.WalletAddress,
+		IPAddress:     optionalString(attempt.IPAddress),
+		IPNetwork:     optionalString(ipNetwork(attempt.IPAddress, 24, 48)),
+		Country:       optionalString(attempt.Country),
+		UserAgent:     optionalString(attempt.UserAgent),
+		Device:        &device,
+	}
+}
+
+// ipNetwork 取得 IP 所屬網段（例如 203.0.113.0/24），無法解析時回傳空字串
+func ipNetwork(ipAddress string, v4Bits, v6Bits int) string {
+	ip := net.ParseIP(ipAddress)
+	if ip == nil {
+		return ""
+	}
+	if v4 := ip.To4(); v4 != nil {
+		network := &net.IPNet{IP: v4.Mask(net.CIDRMask(v4Bits, 32)), Mask: net.CIDRMask(v4Bits, 32)}
+		return network.String()
+	}
+	network := &net.IPNet{IP: ip.Mask(net.CIDRMask(v6Bits, 128)), Mask: net.CIDRMask(v6Bits, 128)}
+	return network.String()
+}
+
+func loginNotificationData(event *models.LoginEvent) map[string]any {
+	return map[string]any{
+		"wallet_address": event.WalletAddress,
+		"ip_address":     stringValue(event.IPAddress),
+		"ip_network":     stringValue(event.IPNetwork),
+		"country":        stringValue(event.Country),
+		"device":         stringValue(event.Device),
+	}
+}
+
+func describeLocation(attempt LoginAttempt) string {
+	if attempt.Country != "" {
+		return fmt.Sprintf("%s (%s)", attempt.IPAddress, attempt.Country)
+	}
+	return attempt.IPAddress
+}
+
+func describeEventLocation(event *models.LoginEvent) string {
+	return describeLocation(LoginAttempt{IPAddress: stringValue(event.IPAddress), Country: stringValue(event.Country)})
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
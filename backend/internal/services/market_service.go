@@ -0,0 +1,414 @@
+package services
+
+import (
+	"bluelink-backend/internal/blockchain"
+	"bluelink-backend/internal/limits"
+	"bluelink-backend/internal/logger"
+	"bluelink-backend/internal/models"
+	"bluelink-backend/internal/repository"
+	"bluelink-backend/internal/suisig"
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/block-vision/sui-go-sdk/sui"
+)
+
+var (
+	ErrOrderNotFound         = errors.New("market order not found")
+	ErrOrderNotOpen          = errors.New("market order is no longer open")
+	ErrOrderExpired          = errors.New("market order has expired")
+	ErrInvalidOrder          = errors.New("invalid market order")
+	ErrInvalidOrderSignature = errors.New("order signature does not match the maker wallet")
+	ErrTokenNotOwned         = errors.New("bond token is not owned by this wallet")
+	ErrTokenAlreadyListed    = errors.New("bond token already has an open listing")
+	ErrSelfTrade             = errors.New("cannot fill your own order")
+	ErrSettlementMismatch    = errors.New("settlement transaction does not match the order")
+)
+
+// PlaceOrderInput 掛單參數
+type PlaceOrderInput struct {
+	BondID       int64
+	Side         string
+	TokenID      string // 賣單必填
+	Price        int64  // 計價幣種最小單位
+	Nonce        string
+	ExpiresAt    *time.Time
+	Signature    string
+	MakerUserID  int64
+	MakerAddress string
+}
+
+// MarketService 二級市場服務層
+type MarketService struct {
+	chainReader *blockchain.ChainReader
+	marketRepo  *repository.MarketRepository
+	bondRepo    *repository.BondRepository
+	tokenRepo   *repository.BondTokenRepository
+	txRepo      *repository.TransactionRepository
+	userRepo    *repository.UserRepository
+	limits      *limits.Checker
+}
+
+// NewMarketService 建立新的 MarketService 實例
+func NewMarketService(
+	suiClient sui.ISuiAPI,
+	packageID string,
+	marketRepo *repository.MarketRepository,
+	bondRepo *repository.BondRepository,
+	tokenRepo *repository.BondTokenRepository,
+	txRepo *repository.TransactionRepository,
+	userRepo *repository.UserRepository,
+	coins *blockchain.CoinRegistry,
+	limitChecker *limits.Checker,
+) *MarketService {
+	return &MarketService{
+		chainReader: blockchain.NewChainReader(suiClient, packageID, coins),
+		marketRepo:  marketRepo,
+		bondRepo:    bondRepo,
+		tokenRepo:   tokenRepo,
+		txRepo:      txRepo,
+		userRepo:    userRepo,
+		limits:      limitChecker,
+	}
+}
+
+// BuildOrderMessage 產生掛單者需以錢包簽署的訊息
+// 前後端必須使用完全相同的格式
+func BuildOrderMessage(side, bondOnChainID, tokenID string, price int64, nonce string, expiresAt *time.Time) string {
+	if tokenID == "" {
+		tokenID = "any"
+	}
+	expires := "never"
+	if expiresAt != nil {
+		expires = expiresAt.UTC().Format(time.RFC3339)
+	}
+
+	return fmt.Sprintf(
+		"BlueLink market order\nSide: %s\nBond: %s\nToken: %s\nPrice: %d\nNonce: %s\nExpires: %s",
+		side, bondOnChainID, tokenID, price, nonce, expires,
+	)
+}
+
+// GetOrderMessage 取得待簽署的掛單訊息
+func (s *MarketService) GetOrderMessage(ctx context.Context, input *PlaceOrderInput) (string, error) {
+	bond, err := s.getBond(ctx, input.BondID)
+	if err != nil {
+		return "", err
+	}
+	return BuildOrderMessage(input.Side, bond.OnChainID, input.TokenID, input.Price, input.Nonce, input.ExpiresAt), nil
+}
+
+// PlaceOrder 建立經簽名的掛單
+func (s *MarketService) PlaceOrder(ctx context.Context, input *PlaceOrderInput) (*models.MarketOrder, error) {
+	bond, err := s.getBond(ctx, input.BondID)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Price <= 0 || input.Nonce == "" {
+		return nil, fmt.Errorf("%w: price and nonce are required", ErrInvalidOrder)
+	}
+	if input.ExpiresAt != nil && !input.ExpiresAt.After(time.Now()) {
+		return nil, ErrOrderExpired
+	}
+
+	switch input.Side {
+	case models.OrderSideAsk:
+		if err := s.checkTokenOwner(ctx, bond, input.TokenID, input.MakerAddress); err != nil {
+			return nil, err
+		}
+
+		existing, err := s.marketRepo.GetOpenAskByToken(ctx, input.TokenID)
+		if err != nil {
+			logger.Error("Failed to check open listing for token %s: %v", input.TokenID, err)
+			return nil, err
+		}
+		if existing != nil {
+			return nil, ErrTokenAlreadyListed
+		}
+	case models.OrderSideBid:
+		if input.TokenID != "" {
+			return nil, fmt.Errorf("%w: bids apply to any token of the bond", ErrInvalidOrder)
+		}
+	default:
+		return nil, fmt.Errorf("%w: unknown side %q", ErrInvalidOrder, input.Side)
+	}
+
+	// 驗證簽名：訊息由後端依參數重建，避免前端竄改
+	// 與登入相同支援 Ed25519 / Secp256k1 / Secp256r1 與 MultiSig 錢包
+	message := BuildOrderMessage(input.Side, bond.OnChainID, input.TokenID, input.Price, input.Nonce, input.ExpiresAt)
+	signer, err := suisig.VerifyPersonalMessage([]byte(message), input.Signature)
+	if err != nil || !strings.EqualFold(signer, input.MakerAddress) {
+		logger.Warn("Invalid order signature from %s: %v", input.MakerAddress, err)
+		return nil, ErrInvalidOrderSignature
+	}
+
+	order := &models.MarketOrder{
+		BondID:       bond.ID,
+		Side:         input.Side,
+		MakerUserID:  input.MakerUserID,
+		MakerAddress: input.MakerAddress,
+		Price:        input.Price,
+		Nonce:        input.Nonce,
+		ExpiresAt:    input.ExpiresAt,
+		Message:      message,
+		Signature:    input.Signature,
+		Status:       models.OrderStatusOpen,
+	}
+	if input.TokenID != "" {
+		tokenID := input.TokenID
+		order.TokenID = &tokenID
+	}
+
+	if err := s.marketRepo.Create(ctx, order); err != nil {
+		logger.Error("Failed to create market order for %s: %v", input.MakerAddress, err)
+		return nil, err
+	}
+
+	logger.Info("Market order placed: ID=%d, %s %s @ %s by %s", order.ID, order.Side, bond.BondName, bond.FormatAmount(order.Price), order.MakerAddress)
+	return order, nil
+}
+
+// CancelOrder 掛單者取消自己的掛單
+func (s *MarketService) CancelOrder(ctx context.Context, id int64, makerAddress string) error {
+	order, err := s.getOrder(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !strings.EqualFold(order.MakerAddress, makerAddress) {
+		return ErrOrderNotFound
+	}
+	if order.Status != models.OrderStatusOpen {
+		return ErrOrderNotOpen
+	}
+
+	if err := s.marketRepo.Cancel(ctx, id, order.MakerAddress); err != nil {
+		logger.Error("Failed to cancel market order %d: %v", id, err)
+		return err
+	}
+
+	logger.Info("Market order cancelled: ID=%d by %s", id, makerAddress)
+	return nil
+}
+
+// GetOrderBook 取得債券的買賣盤
+func (s *MarketService) GetOrderBook(ctx context.Context, bondID int64) (*models.OrderBook, error) {
+	if _, err := s.getBond(ctx, bondID); err != nil {
+		return nil, err
+	}
+
+	asks, err := s.marketRepo.ListOpenByBond(ctx, bondID, models.OrderSideAsk)
+	if err != nil {
+		logger.Error("Failed to list asks for bond %d: %v", bondID, err)
+		return nil, err
+	}
+
+	bids, err := s.marketRepo.ListOpenByBond(ctx, bondID, models.OrderSideBid)
+	if err != nil {
+		logger.Error("Failed to list bids for bond %d: %v", bondID, err)
+		return nil, err
+	}
+
+	return &models.OrderBook{
+		BondID: bondID,
+		Asks:   asks,
+		Bids:   bids,
+	}, nil
+}
+
+// ListMyOrders 取得使用者自己的掛單
+func (s *MarketService) ListMyOrders(ctx context.Context, makerAddress, status string, limit, offset int) ([]*models.MarketOrder, error) {
+	if limit <= 0 {
+		limit = 100
+	}
+
+	orders, err := s.marketRepo.ListByMaker(ctx, makerAddress, status, limit, offset)
+	if err != nil {
+		logger.Error("Failed to list market orders for %s: %v", makerAddress, err)
+		return nil, err
+	}
+	return orders, nil
+}
+
+// FillOrder 以鏈上結算交易完成掛單
+// 賣單：買方吃單，tokenID 取自掛單；買單：賣方吃單，需指定要賣出的 tokenID
+func (s *MarketService) FillOrder(ctx context.Context, orderID, takerUserID int64, takerAddress, txDigest, tokenID string) (*models.MarketOrder, error) {
+	order, err := s.getOrder(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if order.Status != models.OrderStatusOpen {
+		return nil, ErrOrderNotOpen
+	}
+	if order.ExpiresAt != nil && order.ExpiresAt.Before(time.Now()) {
+		return nil, ErrOrderExpired
+	}
+	if strings.EqualFold(order.MakerAddress, takerAddress) {
+		return nil, ErrSelfTrade
+	}
+
+	bond, err := s.getBond(ctx, order.BondID)
+	if err != nil {
+		return nil, err
+	}
+
+	fill := &models.TradeFill{
+		OrderID:   order.ID,
+		BondID:    order.BondID,
+		Price:     order.Price,
+		TakerAddr: takerAddress,
+		TxHash:    txDigest,
+	}
+
+	if order.Side == models.OrderSideAsk {
+		fill.TokenID = *order.TokenID
+		fill.SellerUserID, fill.SellerAddr = order.MakerUserID, order.MakerAddress
+		fill.BuyerUserID, fill.BuyerAddr = takerUserID, takerAddress
+	} else {
+		if tokenID == "" {
+			return nil, fmt.Errorf("%w: token_id is required to fill a bid", ErrInvalidOrder)
+		}
+		// 轉移索引可能已將持有者更新為買方
+		if err := s.checkTokenOwner(ctx, bond, tokenID, takerAddress, order.MakerAddress); err != nil {
+			return nil, err
+		}
+		fill.TokenID = tokenID
+		fill.SellerUserID, fill.SellerAddr = takerUserID, takerAddress
+		fill.BuyerUserID, fill.BuyerAddr = order.MakerUserID, order.MakerAddress
+	}
+
+	// 轉移索引可能已先記錄此交易（尚無成交價），此時僅補上成交資訊
+	existing, err := s.txRepo.GetByTxHashAndToken(ctx, txDigest, fill.TokenID)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		if existing.EventType != models.EventBondTransferred || existing.Price != nil {
+			return nil, fmt.Errorf("%w: transaction already recorded", ErrSettlementMismatch)
+		}
+		fill.AlreadyIndexed = true
+	}
+
+	// 確認結算交易確實由賣方將代幣轉給買方
+	transfer, err := s.chainReader.GetTokenTransferFromTransaction(ctx, txDigest, fill.TokenID)
+	if err != nil {
+		logger.Warn("Settlement %s for order %d could not be verified: %v", txDigest, order.ID, err)
+		return nil, fmt.Errorf("%w: %v", ErrSettlementMismatch, err)
+	}
+	if !strings.EqualFold(transfer.NewOwner, fill.BuyerAddr) || !strings.EqualFold(transfer.Sender, fill.SellerAddr) {
+		return nil, fmt.Errorf("%w: token was not transferred from seller to buyer", ErrSettlementMismatch)
+	}
+
+	fill.Timestamp = time.Now()
+	if ms, err := strconv.ParseInt(transfer.TimestampMs, 10, 64); err == nil {
+		fill.Timestamp = time.UnixMilli(ms)
+	}
+
+	if err := s.marketRepo.FillOrder(ctx, fill); err != nil {
+		logger.Error("Failed to fill market order %d: %v", order.ID, err)
+		return nil, err
+	}
+
+	logger.Info("✅ Market order filled: ID=%d, token %s %s → %s @ %s (tx %s)",
+		order.ID, fill.TokenID, fill.SellerAddr, fill.BuyerAddr, bond.FormatAmount(fill.Price), txDigest)
+
+	s.reviewLimits(ctx, bond, fill)
+
+	return s.getOrder(ctx, order.ID)
+}
+
+// reviewLimits 成交後檢查買方限額，違規時建立合規警示（不影響已完成的成交）
+func (s *MarketService) reviewLimits(ctx context.Context, bond *models.Bond, fill *models.TradeFill) {
+	if s.limits == nil {
+		return
+	}
+
+	buyer, err := s.userRepo.GetByID(ctx, fill.BuyerUserID)
+	if err != nil || buyer == nil {
+		logger.Error("Failed to load buyer %d for limit review: %v", fill.BuyerUserID, err)
+		return
+	}
+
+	faceValue := fill.Price
+	if token, err := s.tokenRepo.GetByOnChainID(ctx, fill.TokenID); err == nil && token != nil {
+		faceValue = token.Amount
+	}
+
+	trade := limits.Trade{
+		User:      buyer,
+		Wallet:    fill.BuyerAddr,
+		Bond:      bond,
+		Amount:    fill.Price,
+		FaceValue: faceValue,
+	}
+	if err := s.limits.Review(ctx, trade, fill.TxHash); err != nil {
+		logger.Error("Failed to review limits for tx %s: %v", fill.TxHash, err)
+	}
+}
+
+// GetTradeHistory 取得債券的二級市場成交記錄（價格歷史）
+func (s *MarketService) GetTradeHistory(ctx context.Context, bondID int64, limit, offset int) ([]*models.Transaction, error) {
+	if limit <= 0 {
+		limit = 100
+	}
+
+	trades, err := s.txRepo.ListByBondAndEventType(ctx, bondID, models.EventBondTransferred, limit, offset)
+	if err != nil {
+		logger.Error("Failed to list trades for bond %d: %v", bondID, err)
+		return nil, err
+	}
+	return trades, nil
+}
+
+func (s *MarketService) getBond(ctx context.Context, bondID int64) (*models.Bond, error) {
+	bond, err := s.bondRepo.GetByID(ctx, bondID)
+	if err != nil {
+		logger.Error("Failed to get bond %d: %v", bondID, err)
+		return nil, err
+	}
+	if bond == nil {
+		return nil, ErrBondNotFound
+	}
+	return bond, nil
+}
+
+func (s *MarketService) getOrder(ctx context.Context, id int64) (*models.MarketOrder, error) {
+	order, err := s.marketRepo.GetByID(ctx, id)
+	if err != nil {
+		logger.Error("Failed to get market order %d: %v", id, err)
+		return nil, err
+	}
+	if order == nil {
+		return nil, ErrOrderNotFound
+	}
+	return order, nil
+}
+
+// checkTokenOwner 依 bond_tokens 確認代幣屬於該債券、未贖回且由指定錢包之一持有
+func (s *MarketService) checkTokenOwner(ctx context.Context, bond *models.Bond, tokenID string, owners ...string) error {
+	if tokenID == "" {
+		return fmt.Errorf("%w: token_id is required", ErrInvalidOrder)
+	}
+
+	token, err := s.tokenRepo.GetByOnChainID(ctx, tokenID)
+	if err != nil {
+		logger.Error("Failed to get bond token %s: %v", tokenID, err)
+		return err
+	}
+	if token == nil || token.ProjectID != bond.OnChainID {
+		return fmt.Errorf("%w: token %s does not belong to this bond", ErrInvalidOrder, tokenID)
+	}
+	if token.IsRedeemed {
+		return fmt.Errorf("%w: token %s has been redeemed", ErrInvalidOrder, tokenID)
+	}
+	for _, owner := range owners {
+		if strings.EqualFold(token.Owner, owner) {
+			return nil
+		}
+	}
+	return ErrTokenNotOwned
+}
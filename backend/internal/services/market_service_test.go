@@ -0,0 +1,120 @@
+package services
+
+import (
+	"bluelink-backend/internal/models"
+	"bluelink-backend/internal/repository"
+	"bluelink-backend/internal/suisig"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+)
+
+var bondTestColumns = []string{
+	"id", "on_chain_id", "issuer_address", "issuer_name", "bond_name",
+	"bond_image_url", "token_image_url", "metadata_url",
+	"total_amount", "amount_raised", "amount_redeemed",
+	"tokens_issued", "tokens_redeemed",
+	"annual_interest_rate", "maturity_date", "issue_date",
+	"active", "redeemable",
+	"raised_funds_balance", "redemption_pool_balance",
+	"coin_type", "coin_decimals",
+	"needs_review",
+	"created_at", "updated_at", "deleted_at",
+}
+
+// signSecp256k1Message 以 Secp256k1 錢包簽署 personal message，回傳序列化簽名與地址
+func signSecp256k1Message(t *testing.T, message string) (string, string) {
+	t.Helper()
+	priv, err := secp256k1.GeneratePrivateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hash := sha256.Sum256(suisig.PersonalMessageDigest([]byte(message)))
+	signature := ecdsa.Sign(priv, hash[:])
+	r, s := signature.R(), signature.S()
+	rBytes, sBytes := r.Bytes(), s.Bytes()
+
+	publicKey := suisig.PublicKey{Flag: suisig.FlagSecp256k1, Bytes: priv.PubKey().SerializeCompressed()}
+	serialized := append([]byte{publicKey.Flag}, rBytes[:]...)
+	serialized = append(serialized, sBytes[:]...)
+	serialized = append(serialized, publicKey.Bytes...)
+	return base64.StdEncoding.EncodeToString(serialized), publicKey.Address()
+}
+
+func newMarketTestService(t *testing.T) (*MarketService, sqlmock.Sqlmock) {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	now := time.Now()
+	mock.ExpectQuery(`FROM bonds\s+WHERE id = \$1`).
+		WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows(bondTestColumns).AddRow(
+			1, "0xb0nd", "0x1551", "Issuer", "Green Bond",
+			"", "", "",
+			1000, 0, 0,
+			0, 0,
+			500, "2030-01-01", "2025-01-01",
+			true, false,
+			0, 0,
+			"0x2::sui::SUI", 9,
+			false,
+			now, now, nil,
+		))
+
+	return &MarketService{
+		marketRepo: repository.NewMarketRepository(db),
+		bondRepo:   repository.NewBondRepository(db),
+	}, mock
+}
+
+func TestPlaceOrderAcceptsSecp256k1Signature(t *testing.T) {
+	service, mock := newMarketTestService(t)
+
+	input := &PlaceOrderInput{BondID: 1, Side: models.OrderSideBid, Price: 100, Nonce: "n-1", MakerUserID: 7}
+	message := BuildOrderMessage(input.Side, "0xb0nd", "", input.Price, input.Nonce, nil)
+	input.Signature, input.MakerAddress = signSecp256k1Message(t, message)
+
+	now := time.Now()
+	mock.ExpectQuery(`INSERT INTO market_orders`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at"}).AddRow(42, now, now))
+
+	order, err := service.PlaceOrder(context.Background(), input)
+	if err != nil {
+		t.Fatalf("PlaceOrder() error = %v", err)
+	}
+	if order.ID != 42 || order.Message != message {
+		t.Fatalf("unexpected order: %+v", order)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPlaceOrderRejectsSignatureFromOtherWallet(t *testing.T) {
+	service, mock := newMarketTestService(t)
+
+	input := &PlaceOrderInput{BondID: 1, Side: models.OrderSideBid, Price: 100, Nonce: "n-1", MakerUserID: 7}
+	message := BuildOrderMessage(input.Side, "0xb0nd", "", input.Price, input.Nonce, nil)
+	input.Signature, _ = signSecp256k1Message(t, message)
+	_, input.MakerAddress = signSecp256k1Message(t, message)
+
+	if _, err := service.PlaceOrder(context.Background(), input); !errors.Is(err, ErrInvalidOrderSignature) {
+		t.Fatalf("PlaceOrder() error = %v, want ErrInvalidOrderSignature", err)
+	}
+	// 簽名無效時不寫入掛單
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,92 @@
+package services
+
+import (
+	"bluelink-backend/internal/logger"
+	"bluelink-backend/internal/models"
+	"bluelink-backend/internal/repository"
+	"context"
+	"encoding/json"
+	"errors"
+)
+
+var ErrNotificationNotFound = errors.New("notification not found")
+
+// NotificationService 使用者通知服務層
+type NotificationService struct {
+	repo *repository.NotificationRepository
+}
+
+// NewNotificationService 建立新的 NotificationService 實例
+func NewNotificationService(repo *repository.NotificationRepository) *NotificationService {
+	return &NotificationService{repo: repo}
+}
+
+// Notify 建立使用者通知；寫入失敗時僅記錄錯誤，不影響原本的操作
+func (s *NotificationService) Notify(ctx context.Context, userID int64, notificationType, title, message string, data any) {
+	notification := &models.Notification{
+		UserID:  userID,
+		Type:    notificationType,
+		Title:   title,
+		Message: message,
+	}
+
+	if data != nil {
+		encoded, err := json.Marshal(data)
+		if err != nil {
+			logger.Error("Failed to encode %s notification data for user %d: %v", notificationType, userID, err)
+		}
+		notification.Data = encoded
+	}
+
+	// 請求結束後 context 可能已取消，仍需寫入通知
+	if err := s.repo.Create(context.WithoutCancel(ctx), notification); err != nil {
+		logger.Error("Failed to create %s notification for user %d: %v", notificationType, userID, err)
+		return
+	}
+
+	logger.Info("Notification %s sent to user %d", notificationType, userID)
+}
+
+// ListNotifications 取得使用者的通知，回傳通知、總筆數與未讀數量
+func (s *NotificationService) ListNotifications(ctx context.Context, userID int64, unreadOnly bool, limit, offset int) ([]*models.Notification, int, int, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+
+	notifications, total, err := s.repo.ListByUser(ctx, userID, unreadOnly, limit, offset)
+	if err != nil {
+		logger.Error("Failed to list notifications of user %d: %v", userID, err)
+		return nil, 0, 0, err
+	}
+
+	unread, err := s.repo.CountUnread(ctx, userID)
+	if err != nil {
+		logger.Error("Failed to count unread notifications of user %d: %v", userID, err)
+		return nil, 0, 0, err
+	}
+
+	return notifications, total, unread, nil
+}
+
+// MarkRead 將一則通知標記為已讀
+func (s *NotificationService) MarkRead(ctx context.Context, userID, id int64) error {
+	found, err := s.repo.MarkRead(ctx, userID, id)
+	if err != nil {
+		logger.Error("Failed to mark notification %d read: %v", id, err)
+		return err
+	}
+	if !found {
+		return ErrNotificationNotFound
+	}
+	return nil
+}
+
+// MarkAllRead 將使用者所有未讀通知標記為已讀，回傳更新筆數
+func (s *NotificationService) MarkAllRead(ctx context.Context, userID int64) (int64, error) {
+	updated, err := s.repo.MarkAllRead(ctx, userID)
+	if err != nil {
+		logger.Error("Failed to mark notifications of user %d read: %v", userID, err)
+		return 0, err
+	}
+	return updated, nil
+}
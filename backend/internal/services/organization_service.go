@@ -0,0 +1,373 @@
+package services
+
+import (
+	"bluelink-backend/internal/logger"
+	"bluelink-backend/internal/models"
+	"bluelink-backend/internal/repository"
+	"context"
+	"errors"
+	"strings"
+)
+
+var (
+	ErrOrganizationNotFound      = errors.New("organization not found")
+	ErrNotOrganizationMember     = errors.New("user is not a member of an organization")
+	ErrAlreadyOrganizationMember = errors.New("user already belongs to an organization")
+	ErrOrganizationMemberMissing = errors.New("organization member not found")
+	ErrOrganizationForbidden     = errors.New("insufficient organization role for this action")
+	ErrOrganizationIssuerOnly    = errors.New("only users who can propose bonds can create an organization")
+	ErrLastOrganizationOwner     = errors.New("an organization must keep at least one owner")
+	ErrInvalidOrgRole            = errors.New("invalid organization role")
+)
+
+// orgRoleRank 機構內角色的權限高低
+var orgRoleRank = map[string]int{
+	models.OrgRoleViewer:  1,
+	models.OrgRoleManager: 2,
+	models.OrgRoleOwner:   3,
+}
+
+// OrganizationService 發行機構與成員管理服務層
+type OrganizationService struct {
+	repo         *repository.OrganizationRepository
+	userRepo     *repository.UserRepository
+	bondRepo     *repository.BondRepository
+	proposalRepo *repository.BondProposalRepository
+	roles        *RoleService
+}
+
+// NewOrganizationService 建立新的 OrganizationService 實例
+func NewOrganizationService(
+	repo *repository.OrganizationRepository,
+	userRepo *repository.UserRepository,
+	bondRepo *repository.BondRepository,
+	proposalRepo *repository.BondProposalRepository,
+	roles *RoleService,
+) *OrganizationService {
+	return &OrganizationService{
+		repo:         repo,
+		userRepo:     userRepo,
+		bondRepo:     bondRepo,
+		proposalRepo: proposalRepo,
+		roles:        roles,
+	}
+}
+
+// CreateOrganization 建立機構，建立者成為 owner（需具有 bonds:propose 權限，且尚未加入其他機構）
+func (s *OrganizationService) CreateOrganization(ctx context.Context, userID int64, org *models.Organization) error {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		logger.Error("Failed to get user ID %d for organization: %v", userID, err)
+		return err
+	}
+	if user == nil {
+		return ErrUserNotFound
+	}
+	canPropose, err := s.roles.UserHasPermission(ctx, user, models.PermissionBondsPropose)
+	if err != nil {
+		return err
+	}
+	if !canPropose {
+		return ErrOrganizationIssuerOnly
+	}
+
+	membership, err := s.getMembership(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if membership != nil {
+		return ErrAlreadyOrganizationMember
+	}
+
+	if err := s.repo.Create(ctx, org, userID); err != nil {
+		logger.Error("Failed to create organization for user %d: %v", userID, err)
+		return err
+	}
+
+	logger.Info("Organization created: ID=%d, name=%s, owner=%d", org.ID, org.Name, userID)
+	return nil
+}
+
+// GetMyOrganization 取得使用者所屬機構與成員列表
+func (s *OrganizationService) GetMyOrganization(ctx context.Context, userID int64) (*models.OrganizationDetail, error) {
+	membership, err := s.requireRole(ctx, userID, models.OrgRoleViewer)
+	if err != nil {
+		return nil, err
+	}
+	return s.GetOrganization(ctx, membership.OrganizationID)
+}
+
+// GetOrganization 取得機構與成員列表
+func (s *OrganizationService) GetOrganization(ctx context.Context, organizationID int64) (*models.OrganizationDetail, error) {
+	org, err := s.repo.GetByID(ctx, organizationID)
+	if err != nil {
+		logger.Error("Failed to get organization ID %d: %v", organizationID, err)
+		return nil, err
+	}
+	if org == nil {
+		return nil, ErrOrganizationNotFound
+	}
+
+	members, err := s.repo.ListMembers(ctx, organizationID)
+	if err != nil {
+		logger.Error("Failed to list members of organization %d: %v", organizationID, err)
+		return nil, err
+	}
+
+	return &models.OrganizationDetail{Organization: org, Members: members}, nil
+}
+
+// UpdateOrganization 更新機構資料（owner 或 manager）
+func (s *OrganizationService) UpdateOrganization(ctx context.Context, userID int64, org *models.Organization) error {
+	membership, err := s.requireRole(ctx, userID, models.OrgRoleManager)
+	if err != nil {
+		return err
+	}
+
+	org.ID = membership.OrganizationID
+	if err := s.repo.Update(ctx, org); err != nil {
+		logger.Error("Failed to update organization ID %d: %v", org.ID, err)
+		return err
+	}
+
+	logger.Info("Organization updated: ID=%d, by user %d", org.ID, userID)
+	return nil
+}
+
+// AddMember 依錢包地址加入成員（owner 或 manager；只有 owner 可加入 owner）
+func (s *OrganizationService) AddMember(ctx context.Context, userID int64, walletAddress, orgRole string) (*models.OrganizationMember, error) {
+	if _, ok := orgRoleRank[orgRole]; !ok {
+		return nil, ErrInvalidOrgRole
+	}
+
+	membership, err := s.requireRole(ctx, userID, models.OrgRoleManager)
+	if err != nil {
+		return nil, err
+	}
+	if orgRoleRank[orgRole] > orgRoleRank[membership.OrgRole] {
+		return nil, ErrOrganizationForbidden
+	}
+
+	target, err := s.userRepo.GetByWalletAddress(ctx, strings.TrimSpace(walletAddress))
+	if err != nil {
+		logger.Error("Failed to get user by wallet %s for organization: %v", walletAddress, err)
+		return nil, err
+	}
+	if target == nil {
+		return nil, ErrUserNotFound
+	}
+
+	existing, err := s.getMembership(ctx, target.ID)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, ErrAlreadyOrganizationMember
+	}
+
+	if err := s.repo.AddMember(ctx, membership.OrganizationID, target.ID, orgRole, userID); err != nil {
+		logger.Error("Failed to add user %d to organization %d: %v", target.ID, membership.OrganizationID, err)
+		return nil, err
+	}
+
+	logger.Info("Organization member added: organization=%d, user=%d, org_role=%s, by=%d",
+		membership.OrganizationID, target.ID, orgRole, userID)
+	return s.getMembership(ctx, target.ID)
+}
+
+// UpdateMemberRole 變更成員在機構內的角色（僅 owner）
+func (s *OrganizationService) UpdateMemberRole(ctx context.Context, userID, memberUserID int64, orgRole string) error {
+	if _, ok := orgRoleRank[orgRole]; !ok {
+		return ErrInvalidOrgRole
+	}
+
+	membership, err := s.requireRole(ctx, userID, models.OrgRoleOwner)
+	if err != nil {
+		return err
+	}
+
+	target, err := s.getMemberOf(ctx, membership.OrganizationID, memberUserID)
+	if err != nil {
+		return err
+	}
+	if target.OrgRole == models.OrgRoleOwner && orgRole != models.OrgRoleOwner {
+		if err := s.ensureAnotherOwner(ctx, membership.OrganizationID); err != nil {
+			return err
+		}
+	}
+
+	if err := s.repo.UpdateMemberRole(ctx, membership.OrganizationID, memberUserID, orgRole); err != nil {
+		logger.Error("Failed to update role of user %d in organization %d: %v", memberUserID, membership.OrganizationID, err)
+		return err
+	}
+
+	logger.Info("Organization member role changed: organization=%d, user=%d, org_role=%s, by=%d",
+		membership.OrganizationID, memberUserID, orgRole, userID)
+	return nil
+}
+
+// RemoveMember 移除成員：owner 可移除任何人、manager 僅可移除 viewer，成員皆可自行退出
+func (s *OrganizationService) RemoveMember(ctx context.Context, userID, memberUserID int64) error {
+	membership, err := s.requireRole(ctx, userID, models.OrgRoleViewer)
+	if err != nil {
+		return err
+	}
+
+	target, err := s.getMemberOf(ctx, membership.OrganizationID, memberUserID)
+	if err != nil {
+		return err
+	}
+
+	if userID != memberUserID {
+		switch membership.OrgRole {
+		case models.OrgRoleOwner:
+		case models.OrgRoleManager:
+			if target.OrgRole != models.OrgRoleViewer {
+				return ErrOrganizationForbidden
+			}
+		default:
+			return ErrOrganizationForbidden
+		}
+	}
+
+	if target.OrgRole == models.OrgRoleOwner {
+		if err := s.ensureAnotherOwner(ctx, membership.OrganizationID); err != nil {
+			return err
+		}
+	}
+
+	if err := s.repo.RemoveMember(ctx, membership.OrganizationID, memberUserID); err != nil {
+		logger.Error("Failed to remove user %d from organization %d: %v", memberUserID, membership.OrganizationID, err)
+		return err
+	}
+
+	logger.Info("Organization member removed: organization=%d, user=%d, by=%d", membership.OrganizationID, memberUserID, userID)
+	return nil
+}
+
+// GetMyDashboard 取得使用者所屬機構的發行者儀表板（所有成員皆可檢視）
+func (s *OrganizationService) GetMyDashboard(ctx context.Context, userID int64) (*models.OrganizationDashboard, error) {
+	membership, err := s.requireRole(ctx, userID, models.OrgRoleViewer)
+	if err != nil {
+		return nil, err
+	}
+	return s.GetDashboard(ctx, membership.OrganizationID)
+}
+
+// GetDashboard 以任一成員的發行地址關聯債券，彙總機構的發行概況
+func (s *OrganizationService) GetDashboard(ctx context.Context, organizationID int64) (*models.OrganizationDashboard, error) {
+	detail, err := s.GetOrganization(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	addresses := make([]string, 0, len(detail.Members))
+	userIDs := make([]int64, 0, len(detail.Members))
+	for _, member := range detail.Members {
+		addresses = append(addresses, member.WalletAddress)
+		userIDs = append(userIDs, member.UserID)
+	}
+
+	bonds, err := s.bondRepo.ListByIssuerAddresses(ctx, addresses)
+	if err != nil {
+		logger.Error("Failed to list bonds of organization %d: %v", organizationID, err)
+		return nil, err
+	}
+	if bonds == nil {
+		bonds = []*models.Bond{}
+	}
+
+	proposals, err := s.proposalRepo.ListByIssuers(ctx, userIDs, 100, 0)
+	if err != nil {
+		logger.Error("Failed to list proposals of organization %d: %v", organizationID, err)
+		return nil, err
+	}
+	if proposals == nil {
+		proposals = []*models.BondProposal{}
+	}
+
+	totals := &models.IssuanceTotals{
+		BondCount:      len(bonds),
+		TotalAmount:    map[string]int64{},
+		AmountRaised:   map[string]int64{},
+		AmountRedeemed: map[string]int64{},
+	}
+	for _, bond := range bonds {
+		if bond.Active {
+			totals.ActiveBonds++
+		}
+		totals.TotalAmount[bond.CoinType] += bond.TotalAmount
+		totals.AmountRaised[bond.CoinType] += bond.AmountRaised
+		totals.AmountRedeemed[bond.CoinType] += bond.AmountRedeemed
+	}
+
+	return &models.OrganizationDashboard{
+		Organization: detail.Organization,
+		Bonds:        bonds,
+		Proposals:    proposals,
+		Totals:       totals,
+	}, nil
+}
+
+// ListOrganizations 列出所有機構（管理員用）
+func (s *OrganizationService) ListOrganizations(ctx context.Context, limit, offset int) ([]*models.Organization, error) {
+	if limit <= 0 {
+		limit = 100
+	}
+
+	orgs, err := s.repo.List(ctx, limit, offset)
+	if err != nil {
+		logger.Error("Failed to list organizations: %v", err)
+		return nil, err
+	}
+	return orgs, nil
+}
+
+// requireRole 取得使用者的機構成員資料並確認角色至少為 minRole
+func (s *OrganizationService) requireRole(ctx context.Context, userID int64, minRole string) (*models.OrganizationMember, error) {
+	membership, err := s.getMembership(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if membership == nil {
+		return nil, ErrNotOrganizationMember
+	}
+	if orgRoleRank[membership.OrgRole] < orgRoleRank[minRole] {
+		return nil, ErrOrganizationForbidden
+	}
+	return membership, nil
+}
+
+func (s *OrganizationService) getMembership(ctx context.Context, userID int64) (*models.OrganizationMember, error) {
+	membership, err := s.repo.GetMembership(ctx, userID)
+	if err != nil {
+		logger.Error("Failed to get organization membership of user %d: %v", userID, err)
+		return nil, err
+	}
+	return membership, nil
+}
+
+// getMemberOf 取得指定機構中的成員
+func (s *OrganizationService) getMemberOf(ctx context.Context, organizationID, userID int64) (*models.OrganizationMember, error) {
+	member, err := s.getMembership(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if member == nil || member.OrganizationID != organizationID {
+		return nil, ErrOrganizationMemberMissing
+	}
+	return member, nil
+}
+
+// ensureAnotherOwner 確認移除或降級一位 owner 後仍有其他 owner
+func (s *OrganizationService) ensureAnotherOwner(ctx context.Context, organizationID int64) error {
+	owners, err := s.repo.CountOwners(ctx, organizationID)
+	if err != nil {
+		logger.Error("Failed to count owners of organization %d: %v", organizationID, err)
+		return err
+	}
+	if owners <= 1 {
+		return ErrLastOrganizationOwner
+	}
+	return nil
+}
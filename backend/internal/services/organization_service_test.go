@@ -0,0 +1,150 @@
+package services
+
+import (
+	"bluelink-backend/internal/models"
+	"bluelink-backend/internal/repository"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+var organizationMemberTestColumns = []string{
+	"id", "organization_id", "user_id", "wallet_address", "name", "role",
+	"org_role", "added_by", "created_at", "updated_at",
+}
+
+func newTestOrganizationService(t *testing.T) (*OrganizationService, sqlmock.Sqlmock) {
+	t.Helper()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	roles := NewRoleService(repository.NewRoleRepository(db), repository.NewUserRepository(db), nil, nil)
+	service := NewOrganizationService(repository.NewOrganizationRepository(db), repository.NewUserRepository(db),
+		repository.NewBondRepository(db), repository.NewBondProposalRepository(db), roles)
+	return service, mock
+}
+
+// expectMembership 使用者在機構 organizationID 中的角色（organizationID 為 0 表示未加入機構）
+func expectMembership(mock sqlmock.Sqlmock, userID, organizationID int64, orgRole string) {
+	rows := sqlmock.NewRows(organizationMemberTestColumns)
+	if organizationID != 0 {
+		now := time.Now()
+		rows.AddRow(userID, organizationID, userID, "0xabc", nil, "issuer", orgRole, nil, now, now)
+	}
+	mock.ExpectQuery(`FROM organization_members m\s+JOIN users u ON u.id = m.user_id\s+WHERE m.user_id = \$1`).
+		WithArgs(userID).
+		WillReturnRows(rows)
+}
+
+func TestRemoveMember(t *testing.T) {
+	tests := []struct {
+		name       string
+		actorRole  string
+		targetID   int64
+		targetOrg  int64
+		targetRole string
+		owners     int // 目標為 owner 時的 owner 數量
+		want       error
+	}{
+		{"owner removes manager", models.OrgRoleOwner, 2, 1, models.OrgRoleManager, 0, nil},
+		{"manager removes viewer", models.OrgRoleManager, 2, 1, models.OrgRoleViewer, 0, nil},
+		{"manager cannot remove manager", models.OrgRoleManager, 2, 1, models.OrgRoleManager, 0, ErrOrganizationForbidden},
+		{"viewer cannot remove viewer", models.OrgRoleViewer, 2, 1, models.OrgRoleViewer, 0, ErrOrganizationForbidden},
+		{"viewer leaves", models.OrgRoleViewer, 1, 1, models.OrgRoleViewer, 0, nil},
+		{"owner leaves with another owner", models.OrgRoleOwner, 1, 1, models.OrgRoleOwner, 2, nil},
+		{"last owner cannot leave", models.OrgRoleOwner, 1, 1, models.OrgRoleOwner, 1, ErrLastOrganizationOwner},
+		{"member of another organization", models.OrgRoleOwner, 2, 9, models.OrgRoleViewer, 0, ErrOrganizationMemberMissing},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mock := newTestOrganizationService(t)
+
+			expectMembership(mock, 1, 1, tt.actorRole)
+			expectMembership(mock, tt.targetID, tt.targetOrg, tt.targetRole)
+			if tt.owners > 0 {
+				mock.ExpectQuery(`SELECT COUNT\(\*\)\s+FROM organization_members`).
+					WithArgs(int64(1)).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(tt.owners))
+			}
+			if tt.want == nil {
+				mock.ExpectExec(`DELETE FROM organization_members`).
+					WithArgs(int64(1), tt.targetID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			}
+
+			if err := service.RemoveMember(context.Background(), 1, tt.targetID); !errors.Is(err, tt.want) {
+				t.Fatalf("RemoveMember() error = %v, want %v", err, tt.want)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestUpdateMemberRoleKeepsAnOwner(t *testing.T) {
+	service, mock := newTestOrganizationService(t)
+
+	expectMembership(mock, 1, 1, models.OrgRoleOwner)
+	expectMembership(mock, 1, 1, models.OrgRoleOwner)
+	mock.ExpectQuery(`SELECT COUNT\(\*\)\s+FROM organization_members`).
+		WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+	if err := service.UpdateMemberRole(context.Background(), 1, 1, models.OrgRoleManager); !errors.Is(err, ErrLastOrganizationOwner) {
+		t.Fatalf("UpdateMemberRole() error = %v, want ErrLastOrganizationOwner", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestAddMemberCannotGrantHigherRole(t *testing.T) {
+	service, mock := newTestOrganizationService(t)
+
+	expectMembership(mock, 1, 1, models.OrgRoleManager)
+
+	if _, err := service.AddMember(context.Background(), 1, "0xdef", models.OrgRoleOwner); !errors.Is(err, ErrOrganizationForbidden) {
+		t.Fatalf("AddMember() error = %v, want ErrOrganizationForbidden", err)
+	}
+	if _, err := service.AddMember(context.Background(), 1, "0xdef", "admin"); !errors.Is(err, ErrInvalidOrgRole) {
+		t.Fatalf("AddMember() error = %v, want ErrInvalidOrgRole", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCreateOrganizationRequiresProposePermission(t *testing.T) {
+	service, mock := newTestOrganizationService(t)
+	now := time.Now()
+
+	mock.ExpectQuery(`FROM users`).
+		WithArgs(int64(7)).
+		WillReturnRows(sqlmock.NewRows(userTestColumns).AddRow(
+			7, "0xabc", "buyer", nil, nil, "UTC", "en",
+			"approved", 1, nil, "", false,
+			nil, nil, nil,
+			now, now, nil,
+		))
+	mock.ExpectQuery(`SELECT role FROM user_roles WHERE user_id = \$1`).
+		WithArgs(int64(7)).
+		WillReturnRows(sqlmock.NewRows([]string{"role"}))
+	expectRoleDefinitions(mock)
+
+	err := service.CreateOrganization(context.Background(), 7, &models.Organization{Name: "Acme"})
+	if !errors.Is(err, ErrOrganizationIssuerOnly) {
+		t.Fatalf("CreateOrganization() error = %v, want ErrOrganizationIssuerOnly", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
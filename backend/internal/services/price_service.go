@@ -0,0 +1,208 @@
+package services
+
+import (
+	"bluelink-backend/internal/blockchain"
+	"bluelink-backend/internal/config"
+	"bluelink-backend/internal/logger"
+	"bluelink-backend/internal/models"
+	"bluelink-backend/internal/pricing"
+	"bluelink-backend/internal/repository"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	ErrUnsupportedQuote = errors.New("unsupported quote currency")
+	ErrPriceUnavailable = errors.New("price unavailable")
+)
+
+// cachedQuote 記憶體中的匯率快取
+type cachedQuote struct {
+	quote     *models.PriceQuote
+	fetchedAt time.Time
+}
+
+// PriceService 匯率與法幣換算服務
+// 匯率來源失效時退回 price_snapshots 中最新的快照，回應的 rate_timestamp 反映匯率實際時間
+type PriceService struct {
+	provider   pricing.PriceProvider
+	priceRepo  *repository.PriceRepository
+	coins      *blockchain.CoinRegistry
+	currencies map[string]bool
+	cacheTTL   time.Duration
+
+	mu    sync.Mutex
+	cache map[string]*cachedQuote
+}
+
+// NewPriceService 建立新的 PriceService 實例
+func NewPriceService(
+	provider pricing.PriceProvider,
+	priceRepo *repository.PriceRepository,
+	coins *blockchain.CoinRegistry,
+	cfg *config.Config,
+) *PriceService {
+	currencies := make(map[string]bool, len(cfg.PriceQuoteCurrencies))
+	for _, currency := range cfg.PriceQuoteCurrencies {
+		currencies[strings.ToUpper(currency)] = true
+	}
+
+	return &PriceService{
+		provider:   provider,
+		priceRepo:  priceRepo,
+		coins:      coins,
+		currencies: currencies,
+		cacheTTL:   time.Duration(cfg.PriceCacheTTL) * time.Second,
+		cache:      make(map[string]*cachedQuote),
+	}
+}
+
+// NormalizeQuote 驗證並正規化法幣代碼
+func (s *PriceService) NormalizeQuote(quote string) (string, error) {
+	quote = strings.ToUpper(strings.TrimSpace(quote))
+	if !s.currencies[quote] {
+		return "", fmt.Errorf("%w: %s", ErrUnsupportedQuote, quote)
+	}
+	return quote, nil
+}
+
+// GetPrice 取得幣種符號兌法幣的匯率
+func (s *PriceService) GetPrice(ctx context.Context, base, quote string) (*models.PriceQuote, error) {
+	quote, err := s.NormalizeQuote(quote)
+	if err != nil {
+		return nil, err
+	}
+	base = strings.ToUpper(strings.TrimSpace(base))
+	key := base + "/" + quote
+
+	s.mu.Lock()
+	cached, ok := s.cache[key]
+	s.mu.Unlock()
+	if ok && time.Since(cached.fetchedAt) < s.cacheTTL {
+		return cached.quote, nil
+	}
+
+	price, err := s.provider.GetPrice(ctx, base, quote)
+	if err != nil {
+		logger.Warn("Price provider %s failed for %s: %v", s.provider.Name(), key, err)
+		return s.latestSnapshot(ctx, base, quote)
+	}
+
+	if err := s.priceRepo.CreateSnapshot(ctx, price); err != nil {
+		logger.Error("Failed to record price snapshot for %s: %v", key, err)
+	}
+
+	s.mu.Lock()
+	s.cache[key] = &cachedQuote{quote: price, fetchedAt: time.Now()}
+	s.mu.Unlock()
+
+	return price, nil
+}
+
+// GetPriceHistory 取得歷史匯率
+func (s *PriceService) GetPriceHistory(ctx context.Context, base, quote string, from, to time.Time, limit int) ([]*models.PriceQuote, error) {
+	quote, err := s.NormalizeQuote(quote)
+	if err != nil {
+		return nil, err
+	}
+
+	history, err := s.priceRepo.ListHistory(ctx, strings.ToUpper(base), quote, from, to, limit)
+	if err != nil {
+		logger.Error("Failed to list price history for %s/%s: %v", base, quote, err)
+		return nil, err
+	}
+	return history, nil
+}
+
+// ConvertAmounts 將同一幣種的多個金額（最小單位）換算為法幣
+func (s *PriceService) ConvertAmounts(ctx context.Context, coinType, quote string, amounts map[string]int64) (*models.FiatConversion, error) {
+	price, decimals, err := s.coinPrice(ctx, coinType, quote)
+	if err != nil {
+		return nil, err
+	}
+
+	conversion := &models.FiatConversion{
+		Currency:      price.Quote,
+		Rate:          price.Rate,
+		RateTimestamp: price.Timestamp,
+		Source:        price.Source,
+		Values:        make(map[string]float64, len(amounts)),
+	}
+	for name, amount := range amounts {
+		conversion.Values[name] = toFiat(amount, decimals, price.Rate)
+	}
+
+	return conversion, nil
+}
+
+// ConvertMixed 將不同幣種的金額換算為法幣後加總
+// amounts 的鍵為欄位名稱，值為「幣種型別 → 最小單位金額」
+func (s *PriceService) ConvertMixed(ctx context.Context, quote string, amounts map[string]map[string]int64) (*models.FiatConversion, error) {
+	quote, err := s.NormalizeQuote(quote)
+	if err != nil {
+		return nil, err
+	}
+
+	conversion := &models.FiatConversion{
+		Currency: quote,
+		Values:   make(map[string]float64, len(amounts)),
+	}
+	for name, byCoin := range amounts {
+		total := 0.0
+		for coinType, amount := range byCoin {
+			price, decimals, err := s.coinPrice(ctx, coinType, quote)
+			if err != nil {
+				return nil, err
+			}
+
+			total += toFiat(amount, decimals, price.Rate)
+			if conversion.RateTimestamp.IsZero() || price.Timestamp.Before(conversion.RateTimestamp) {
+				conversion.RateTimestamp = price.Timestamp
+			}
+		}
+		conversion.Values[name] = roundFiat(total)
+	}
+
+	return conversion, nil
+}
+
+// coinPrice 取得幣種型別對應的匯率與小數位數
+func (s *PriceService) coinPrice(ctx context.Context, coinType, quote string) (*models.PriceQuote, int, error) {
+	meta, err := s.coins.Get(ctx, coinType)
+	if err != nil {
+		return nil, 0, fmt.Errorf("%w: %v", ErrPriceUnavailable, err)
+	}
+
+	price, err := s.GetPrice(ctx, meta.Symbol, quote)
+	if err != nil {
+		return nil, 0, err
+	}
+	return price, meta.Decimals, nil
+}
+
+// latestSnapshot 匯率來源失效時使用最新的歷史快照
+func (s *PriceService) latestSnapshot(ctx context.Context, base, quote string) (*models.PriceQuote, error) {
+	snapshot, err := s.priceRepo.GetLatest(ctx, base, quote)
+	if err != nil {
+		return nil, err
+	}
+	if snapshot == nil {
+		return nil, fmt.Errorf("%w: %s/%s", ErrPriceUnavailable, base, quote)
+	}
+	return snapshot, nil
+}
+
+// toFiat 最小單位金額換算為法幣金額
+func toFiat(amount int64, decimals int, rate float64) float64 {
+	return roundFiat(float64(amount) / math.Pow10(decimals) * rate)
+}
+
+// roundFiat 法幣金額取到小數第二位
+func roundFiat(value float64) float64 {
+	return math.Round(value*100) / 100
+}
@@ -0,0 +1,335 @@
+package services
+
+import (
+	"bluelink-backend/internal/logger"
+	"bluelink-backend/internal/models"
+	"bluelink-backend/internal/repository"
+	"bluelink-backend/internal/session"
+	"context"
+	"errors"
+	"regexp"
+	"slices"
+	"sync"
+	"time"
+)
+
+/*
+   權限模型：
+   - 角色（roles 表）對應一組權限，"*" 表示所有權限
+   - 使用者的主要角色為 users.role（buyer / issuer / admin），管理員可另外授予多個角色（user_roles 表）
+   - Session 與 access token 只快取角色名稱；權限於每次檢查時由角色定義解析，
+     因此修改角色權限立即生效，授予或移除角色時同步更新該使用者所有 Session
+*/
+
+var (
+	ErrRoleNotFound        = errors.New("role not found")
+	ErrRoleAlreadyExists   = errors.New("role already exists")
+	ErrInvalidRoleName     = errors.New("role name must be 2-20 lowercase letters, digits or underscores")
+	ErrInvalidPermission   = errors.New("unknown permission")
+	ErrSystemRoleProtected = errors.New("system roles cannot be deleted and the admin role's permissions cannot be changed")
+)
+
+// roleCacheTTL 角色定義快取的有效期（多個實例時，其他實例的修改最晚在此時間後生效）
+const roleCacheTTL = time.Minute
+
+var roleNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]{1,19}$`)
+
+// RoleService 角色定義與權限檢查服務層
+type RoleService struct {
+	repo           *repository.RoleRepository
+	userRepo       *repository.UserRepository
+	sessionManager session.SessionManager
+	audit          *AuditService
+
+	mu          sync.RWMutex
+	permissions map[string][]string // 角色名稱 -> 權限
+	loadedAt    time.Time
+}
+
+// NewRoleService 建立新的 RoleService 實例
+func NewRoleService(repo *repository.RoleRepository, userRepo *repository.UserRepository, sessionManager session.SessionManager, audit *AuditService) *RoleService {
+	return &RoleService{
+		repo:           repo,
+		userRepo:       userRepo,
+		sessionManager: sessionManager,
+		audit:          audit,
+	}
+}
+
+// HasPermission 檢查任一角色是否具有指定權限
+// 角色定義載入失敗時沿用上次的快取（尚未載入過則一律拒絕）
+func (s *RoleService) HasPermission(ctx context.Context, roles []string, permission string) bool {
+	permissions := s.rolePermissions(ctx)
+	for _, role := range roles {
+		granted := permissions[role]
+		if slices.Contains(granted, models.PermissionAll) || slices.Contains(granted, permission) {
+			return true
+		}
+	}
+	return false
+}
+
+// UserHasPermission 檢查使用者的主要角色與額外授予的角色是否具有指定權限
+func (s *RoleService) UserHasPermission(ctx context.Context, user *models.User, permission string) (bool, error) {
+	granted, err := s.GrantedRoles(ctx, user.ID)
+	if err != nil {
+		return false, err
+	}
+	return s.HasPermission(ctx, append([]string{user.Role}, granted...), permission), nil
+}
+
+// ListRoles 取得所有角色定義
+func (s *RoleService) ListRoles(ctx context.Context) ([]*models.Role, error) {
+	roles, err := s.repo.List(ctx)
+	if err != nil {
+		logger.Error("Failed to list roles: %v", err)
+		return nil, err
+	}
+	return roles, nil
+}
+
+// CreateRole 建立自訂角色
+func (s *RoleService) CreateRole(ctx context.Context, role *models.Role) error {
+	if !roleNamePattern.MatchString(role.Name) {
+		return ErrInvalidRoleName
+	}
+	permissions, err := normalizePermissions(role.Permissions)
+	if err != nil {
+		return err
+	}
+	role.Permissions = permissions
+
+	existing, err := s.repo.GetByName(ctx, role.Name)
+	if err != nil {
+		logger.Error("Failed to get role %s: %v", role.Name, err)
+		return err
+	}
+	if existing != nil {
+		return ErrRoleAlreadyExists
+	}
+
+	if err := s.repo.Create(ctx, role); err != nil {
+		logger.Error("Failed to create role %s: %v", role.Name, err)
+		return err
+	}
+	s.reload(ctx)
+
+	logger.Info("Role created: %s, permissions=%v", role.Name, role.Permissions)
+
+	s.audit.Record(ctx, models.AuditActionRoleCreate, models.AuditTargetRole, role.Name, nil, role)
+	return nil
+}
+
+// UpdateRole 更新角色的說明與權限（admin 角色的權限不可修改，避免管理員被鎖在外）
+func (s *RoleService) UpdateRole(ctx context.Context, name string, description *string, permissions []string) (*models.Role, error) {
+	role, err := s.getRole(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	normalized, err := normalizePermissions(permissions)
+	if err != nil {
+		return nil, err
+	}
+	if name == "admin" && !slices.Equal(normalized, role.Permissions) {
+		return nil, ErrSystemRoleProtected
+	}
+
+	before := *role
+	role.Description = description
+	role.Permissions = normalized
+
+	if err := s.repo.Update(ctx, role); err != nil {
+		logger.Error("Failed to update role %s: %v", name, err)
+		return nil, err
+	}
+	s.reload(ctx)
+
+	logger.Info("Role updated: %s, permissions=%v", name, role.Permissions)
+
+	s.audit.Record(ctx, models.AuditActionRoleUpdate, models.AuditTargetRole, name, &before, role)
+	return role, nil
+}
+
+// DeleteRole 刪除自訂角色，並同步更新原本持有該角色的使用者的 Session
+func (s *RoleService) DeleteRole(ctx context.Context, name string) error {
+	role, err := s.getRole(ctx, name)
+	if err != nil {
+		return err
+	}
+	if role.IsSystem {
+		return ErrSystemRoleProtected
+	}
+
+	userIDs, err := s.repo.ListUserIDsByRole(ctx, name)
+	if err != nil {
+		logger.Error("Failed to list users of role %s: %v", name, err)
+		return err
+	}
+
+	if err := s.repo.Delete(ctx, name); err != nil {
+		logger.Error("Failed to delete role %s: %v", name, err)
+		return err
+	}
+	s.reload(ctx)
+
+	for _, userID := range userIDs {
+		s.syncSessionRoles(ctx, userID)
+	}
+
+	logger.Info("Role deleted: %s (held by %d users)", name, len(userIDs))
+
+	s.audit.Record(ctx, models.AuditActionRoleDelete, models.AuditTargetRole, name, role, nil)
+	return nil
+}
+
+// GetUserRoles 取得使用者的主要角色與額外授予的角色
+func (s *RoleService) GetUserRoles(ctx context.Context, userID int64) (*models.UserRoles, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		logger.Error("Failed to get user ID %d: %v", userID, err)
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+
+	roles, err := s.GrantedRoles(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.UserRoles{UserID: userID, Primary: user.Role, Roles: roles}, nil
+}
+
+// GrantedRoles 取得使用者額外授予的角色（登入時寫入 Session）
+func (s *RoleService) GrantedRoles(ctx context.Context, userID int64) ([]string, error) {
+	roles, err := s.repo.GetUserRoles(ctx, userID)
+	if err != nil {
+		logger.Error("Failed to get roles of user %d: %v", userID, err)
+		return nil, err
+	}
+	return roles, nil
+}
+
+// SetUserRoles 替換使用者額外授予的角色，並立即更新該使用者所有 Session
+func (s *RoleService) SetUserRoles(ctx context.Context, adminID, userID int64, roles []string) (*models.UserRoles, error) {
+	if adminID == userID {
+		return nil, ErrCannotModifySelf
+	}
+
+	current, err := s.GetUserRoles(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 驗證角色存在；主要角色不需重複授予
+	permissions := s.rolePermissions(ctx)
+	granted := []string{}
+	for _, role := range roles {
+		if _, ok := permissions[role]; !ok {
+			return nil, ErrRoleNotFound
+		}
+		if role != current.Primary {
+			granted = append(granted, role)
+		}
+	}
+	slices.Sort(granted)
+	granted = slices.Compact(granted)
+
+	if err := s.repo.SetUserRoles(ctx, userID, granted, adminID); err != nil {
+		logger.Error("Failed to set roles of user %d: %v", userID, err)
+		return nil, err
+	}
+
+	if err := s.sessionManager.UpdateUserRoles(ctx, userID, granted); err != nil {
+		logger.Error("Failed to update session roles for user %d: %v", userID, err)
+	}
+
+	logger.Info("User %d roles set by admin %d: %v", userID, adminID, granted)
+
+	s.audit.Record(ctx, models.AuditActionUserRolesChange, models.AuditTargetUser, auditID(userID),
+		map[string]any{"roles": current.Roles}, map[string]any{"roles": granted})
+	return &models.UserRoles{UserID: userID, Primary: current.Primary, Roles: granted}, nil
+}
+
+// SyncPrimaryRole 使用者的主要角色變更後，同步更新其所有 Session 的主要角色與額外角色（失敗時僅記錄）
+func (s *RoleService) SyncPrimaryRole(ctx context.Context, userID int64, role string) {
+	if err := s.sessionManager.UpdateUserRole(ctx, userID, role); err != nil {
+		logger.Error("Failed to update session role for user %d: %v", userID, err)
+	}
+	s.syncSessionRoles(ctx, userID)
+}
+
+// syncSessionRoles 以資料庫中的角色更新使用者所有 Session（失敗時僅記錄）
+func (s *RoleService) syncSessionRoles(ctx context.Context, userID int64) {
+	roles, err := s.repo.GetUserRoles(ctx, userID)
+	if err != nil {
+		logger.Error("Failed to get roles of user %d: %v", userID, err)
+		return
+	}
+	if err := s.sessionManager.UpdateUserRoles(ctx, userID, roles); err != nil {
+		logger.Error("Failed to update session roles for user %d: %v", userID, err)
+	}
+}
+
+func (s *RoleService) getRole(ctx context.Context, name string) (*models.Role, error) {
+	role, err := s.repo.GetByName(ctx, name)
+	if err != nil {
+		logger.Error("Failed to get role %s: %v", name, err)
+		return nil, err
+	}
+	if role == nil {
+		return nil, ErrRoleNotFound
+	}
+	return role, nil
+}
+
+// rolePermissions 取得角色定義快取，過期時重新載入
+func (s *RoleService) rolePermissions(ctx context.Context) map[string][]string {
+	s.mu.RLock()
+	permissions, loadedAt := s.permissions, s.loadedAt
+	s.mu.RUnlock()
+
+	if permissions != nil && time.Since(loadedAt) < roleCacheTTL {
+		return permissions
+	}
+	if reloaded := s.reload(ctx); reloaded != nil {
+		return reloaded
+	}
+	return permissions
+}
+
+// reload 重新載入角色定義快取（失敗時保留原本的快取並回傳 nil）
+func (s *RoleService) reload(ctx context.Context) map[string][]string {
+	roles, err := s.repo.List(ctx)
+	if err != nil {
+		logger.Error("Failed to load role definitions: %v", err)
+		return nil
+	}
+
+	permissions := make(map[string][]string, len(roles))
+	for _, role := range roles {
+		permissions[role.Name] = role.Permissions
+	}
+
+	s.mu.Lock()
+	s.permissions = permissions
+	s.loadedAt = time.Now()
+	s.mu.Unlock()
+
+	return permissions
+}
+
+// normalizePermissions 驗證權限並排序去重
+func normalizePermissions(permissions []string) ([]string, error) {
+	normalized := []string{}
+	for _, permission := range permissions {
+		if !slices.Contains(models.Permissions, permission) {
+			return nil, ErrInvalidPermission
+		}
+		normalized = append(normalized, permission)
+	}
+	slices.Sort(normalized)
+	return slices.Compact(normalized), nil
+}
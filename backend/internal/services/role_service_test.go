@@ -0,0 +1,130 @@
+package services
+
+import (
+	"bluelink-backend/internal/models"
+	"bluelink-backend/internal/repository"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+var roleTestColumns = []string{"name", "description", "permissions", "is_system", "created_at", "updated_at"}
+
+func newTestRoleService(t *testing.T) (*RoleService, sqlmock.Sqlmock) {
+	t.Helper()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	return NewRoleService(repository.NewRoleRepository(db), repository.NewUserRepository(db), nil, nil), mock
+}
+
+// expectRoleDefinitions 載入系統角色與一個自訂的合規角色
+func expectRoleDefinitions(mock sqlmock.Sqlmock) {
+	now := time.Now()
+	mock.ExpectQuery(`FROM roles ORDER BY`).
+		WillReturnRows(sqlmock.NewRows(roleTestColumns).
+			AddRow("admin", nil, "{*}", true, now, now).
+			AddRow("buyer", nil, "{}", true, now, now).
+			AddRow("issuer", nil, "{bonds:propose,impact:manage}", true, now, now).
+			AddRow("compliance", nil, "{compliance:manage,limits:manage}", false, now, now))
+}
+
+func TestHasPermission(t *testing.T) {
+	service, mock := newTestRoleService(t)
+	expectRoleDefinitions(mock)
+
+	tests := []struct {
+		name       string
+		roles      []string
+		permission string
+		want       bool
+	}{
+		{"wildcard grants everything", []string{"admin"}, models.PermissionAuditRead, true},
+		{"role with permission", []string{"issuer"}, models.PermissionBondsPropose, true},
+		{"role without permission", []string{"issuer"}, models.PermissionKYCReview, false},
+		{"role without permissions", []string{"buyer"}, models.PermissionBondsPropose, false},
+		{"any of several roles", []string{"buyer", "compliance"}, models.PermissionLimitsManage, true},
+		{"undefined role", []string{"auditor"}, models.PermissionAuditRead, false},
+		{"no roles", nil, models.PermissionAuditRead, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := service.HasPermission(context.Background(), tt.roles, tt.permission); got != tt.want {
+				t.Fatalf("HasPermission(%v, %s) = %v, want %v", tt.roles, tt.permission, got, tt.want)
+			}
+		})
+	}
+
+	// 角色定義只載入一次，之後由快取解析
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestUserHasPermissionIncludesGrantedRoles(t *testing.T) {
+	service, mock := newTestRoleService(t)
+	user := &models.User{ID: 7, Role: "buyer"}
+
+	mock.ExpectQuery(`SELECT role FROM user_roles WHERE user_id = \$1`).
+		WithArgs(int64(7)).
+		WillReturnRows(sqlmock.NewRows([]string{"role"}).AddRow("compliance"))
+	expectRoleDefinitions(mock)
+
+	ok, err := service.UserHasPermission(context.Background(), user, models.PermissionComplianceManage)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ok {
+		t.Fatal("granted role should give the user its permissions")
+	}
+
+	mock.ExpectQuery(`SELECT role FROM user_roles WHERE user_id = \$1`).
+		WithArgs(int64(7)).
+		WillReturnRows(sqlmock.NewRows([]string{"role"}).AddRow("compliance"))
+
+	ok, err = service.UserHasPermission(context.Background(), user, models.PermissionBondsPropose)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok {
+		t.Fatal("buyer with the compliance role should not be able to propose bonds")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestHasPermissionKeepsCacheWhenReloadFails(t *testing.T) {
+	service, mock := newTestRoleService(t)
+
+	// 尚未載入過且載入失敗時一律拒絕
+	mock.ExpectQuery(`FROM roles ORDER BY`).WillReturnError(errors.New("connection refused"))
+	if service.HasPermission(context.Background(), []string{"admin"}, models.PermissionAuditRead) {
+		t.Fatal("permissions should be denied before role definitions are loaded")
+	}
+
+	expectRoleDefinitions(mock)
+	if !service.HasPermission(context.Background(), []string{"admin"}, models.PermissionAuditRead) {
+		t.Fatal("admin should have every permission")
+	}
+
+	// 快取過期後重新載入失敗時沿用上次的角色定義
+	service.loadedAt = time.Now().Add(-2 * roleCacheTTL)
+	mock.ExpectQuery(`FROM roles ORDER BY`).WillReturnError(errors.New("connection refused"))
+	if !service.HasPermission(context.Background(), []string{"issuer"}, models.PermissionBondsPropose) {
+		t.Fatal("stale role definitions should be used when reloading fails")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
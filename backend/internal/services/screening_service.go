@@ -0,0 +1,262 @@
+package services
+
+import (
+	"bluelink-backend/internal/config"
+	"bluelink-backend/internal/logger"
+	"bluelink-backend/internal/models"
+	"bluelink-backend/internal/repository"
+	"bluelink-backend/internal/screening"
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	ErrWalletBlocked             = errors.New("wallet address is blocked by compliance screening")
+	ErrInvalidWalletAddress      = errors.New("invalid wallet address")
+	ErrComplianceFlagNotFound    = errors.New("compliance flag not found")
+	ErrComplianceFlagResolved    = errors.New("compliance flag is already resolved")
+	ErrResolutionNoteMissing     = errors.New("a note is required when resolving a compliance flag")
+	ErrScreeningListsUnavailable = errors.New("deny lists could not be loaded")
+)
+
+// holderBatchSize 定期比對時每批讀取的持有者數量
+const holderBatchSize = 500
+
+// ScreeningService 制裁/黑名單比對服務層（登入檢查、定期掃描、合規審查）
+type ScreeningService struct {
+	screener       *screening.Screener
+	repo           *repository.ScreeningRepository
+	userRepo       *repository.UserRepository
+	tokenRepo      *repository.BondTokenRepository
+	audit          *AuditService
+	rescanInterval time.Duration
+}
+
+// NewScreeningService 建立新的 ScreeningService 實例
+func NewScreeningService(
+	screener *screening.Screener,
+	repo *repository.ScreeningRepository,
+	userRepo *repository.UserRepository,
+	tokenRepo *repository.BondTokenRepository,
+	audit *AuditService,
+	cfg *config.Config,
+) *ScreeningService {
+	return &ScreeningService{
+		screener:       screener,
+		repo:           repo,
+		userRepo:       userRepo,
+		tokenRepo:      tokenRepo,
+		audit:          audit,
+		rescanInterval: time.Duration(cfg.ScreeningRescanInterval) * time.Second,
+	}
+}
+
+// ScreenLogin 登入時比對錢包；命中名單或已被列入黑名單時拒絕登入
+// 比對失敗（名單無法載入、紀錄寫入失敗）時回傳錯誤，由呼叫端拒絕登入
+func (s *ScreeningService) ScreenLogin(ctx context.Context, walletAddress string, user *models.User) error {
+	subject := screening.Subject{
+		Address: walletAddress,
+		Trigger: models.ScreeningTriggerLogin,
+	}
+	if user != nil {
+		subject.UserID = &user.ID
+	}
+
+	result, err := s.screener.Screen(ctx, subject)
+	if err != nil {
+		logger.Error("Failed to screen wallet %s at login: %v", walletAddress, err)
+		return err
+	}
+
+	if result.IsMatch() || (user != nil && user.IsBlacklisted) {
+		logger.Warn("Blocked login from wallet %s", walletAddress)
+		return ErrWalletBlocked
+	}
+	return nil
+}
+
+// ScreenAddress 管理員手動比對地址
+func (s *ScreeningService) ScreenAddress(ctx context.Context, walletAddress string, adminID int64) (*models.ScreeningResult, error) {
+	if screening.NormalizeAddress(walletAddress) == "" {
+		return nil, ErrInvalidWalletAddress
+	}
+
+	subject := screening.Subject{
+		Address:    walletAddress,
+		Trigger:    models.ScreeningTriggerManual,
+		ScreenedBy: &adminID,
+	}
+
+	user, err := s.userRepo.GetByWalletAddress(ctx, walletAddress)
+	if err != nil {
+		logger.Error("Failed to get user by wallet %s for screening: %v", walletAddress, err)
+		return nil, err
+	}
+	if user != nil {
+		subject.UserID = &user.ID
+	}
+
+	result, err := s.screener.Screen(ctx, subject)
+	if err != nil {
+		logger.Error("Failed to screen wallet %s: %v", walletAddress, err)
+		return nil, err
+	}
+	return result, nil
+}
+
+// RescanHolders 重新比對所有持有未贖回代幣的地址，回傳比對數與命中數
+// 定期比對與管理員手動觸發皆會記錄稽核事件（定期比對的操作主體為空）
+func (s *ScreeningService) RescanHolders(ctx context.Context) (int, int, error) {
+	screened, matched := 0, 0
+
+	for offset := 0; ; offset += holderBatchSize {
+		holders, err := s.tokenRepo.ListHolders(ctx, holderBatchSize, offset)
+		if err != nil {
+			logger.Error("Failed to list bond token holders: %v", err)
+			return screened, matched, err
+		}
+
+		for _, holder := range holders {
+			subject := screening.Subject{
+				Address: holder,
+				Trigger: models.ScreeningTriggerPeriodic,
+			}
+
+			user, err := s.userRepo.GetByWalletAddress(ctx, holder)
+			if err != nil {
+				logger.Error("Failed to get user by wallet %s for screening: %v", holder, err)
+			} else if user != nil {
+				subject.UserID = &user.ID
+			}
+
+			result, err := s.screener.Screen(ctx, subject)
+			if err != nil {
+				logger.Error("Failed to screen holder %s: %v", holder, err)
+				continue
+			}
+
+			screened++
+			if result.IsMatch() {
+				matched++
+			}
+		}
+
+		if len(holders) < holderBatchSize {
+			break
+		}
+	}
+
+	logger.Info("Periodic screening finished: %d holders screened, %d matched", screened, matched)
+
+	s.audit.Record(ctx, models.AuditActionScreeningRescan, models.AuditTargetScreeningList, "",
+		nil, map[string]any{"screened": screened, "matched": matched})
+	return screened, matched, nil
+}
+
+// Start 啟動定期比對（間隔為 0 時不啟動）
+func (s *ScreeningService) Start(ctx context.Context) {
+	if s.rescanInterval <= 0 {
+		logger.Info("Periodic holder screening disabled")
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(s.rescanInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if _, _, err := s.RescanHolders(ctx); err != nil {
+					logger.Error("Periodic holder screening failed: %v", err)
+				}
+			}
+		}
+	}()
+}
+
+// ListStatus 目前載入的名單概況
+func (s *ScreeningService) ListStatus() models.DenyListStatus {
+	return s.screener.List().Status()
+}
+
+// ReloadLists 強制重新載入名單
+func (s *ScreeningService) ReloadLists(ctx context.Context) (models.DenyListStatus, error) {
+	before := s.screener.List().Status()
+	if err := s.screener.List().Reload(); err != nil {
+		logger.Error("Failed to reload deny lists: %v", err)
+		return models.DenyListStatus{}, errors.Join(ErrScreeningListsUnavailable, err)
+	}
+
+	after := s.screener.List().Status()
+	s.audit.Record(ctx, models.AuditActionScreeningReload, models.AuditTargetScreeningList, "", before, after)
+	return after, nil
+}
+
+// ListHistory 查詢比對紀錄
+func (s *ScreeningService) ListHistory(ctx context.Context, walletAddress string, matchOnly bool, limit, offset int) ([]*models.ScreeningResult, error) {
+	if limit <= 0 {
+		limit = 100
+	}
+
+	if walletAddress != "" {
+		walletAddress = screening.NormalizeAddress(walletAddress)
+		if walletAddress == "" {
+			return nil, ErrInvalidWalletAddress
+		}
+	}
+
+	results, err := s.repo.ListResults(ctx, walletAddress, matchOnly, limit, offset)
+	if err != nil {
+		logger.Error("Failed to list screening results: %v", err)
+		return nil, err
+	}
+	return results, nil
+}
+
+// ListFlags 依狀態列出合規標記
+func (s *ScreeningService) ListFlags(ctx context.Context, status string, limit, offset int) ([]*models.ComplianceFlag, error) {
+	if limit <= 0 {
+		limit = 100
+	}
+
+	flags, err := s.repo.ListFlags(ctx, status, limit, offset)
+	if err != nil {
+		logger.Error("Failed to list compliance flags with status %q: %v", status, err)
+		return nil, err
+	}
+	return flags, nil
+}
+
+// ResolveFlag 結案合規標記（必須附上處理說明）
+func (s *ScreeningService) ResolveFlag(ctx context.Context, id, reviewerID int64, note string) error {
+	if note == "" {
+		return ErrResolutionNoteMissing
+	}
+
+	flag, err := s.repo.GetFlagByID(ctx, id)
+	if err != nil {
+		logger.Error("Failed to get compliance flag ID %d: %v", id, err)
+		return err
+	}
+	if flag == nil {
+		return ErrComplianceFlagNotFound
+	}
+	if flag.Status != models.ComplianceFlagOpen {
+		return ErrComplianceFlagResolved
+	}
+
+	if err := s.repo.ResolveFlag(ctx, id, reviewerID, note); err != nil {
+		logger.Error("Failed to resolve compliance flag ID %d: %v", id, err)
+		return err
+	}
+	logger.Info("Compliance flag resolved: ID=%d, reviewer=%d", id, reviewerID)
+
+	s.audit.Record(ctx, models.AuditActionFlagResolve, models.AuditTargetComplianceFlag, auditID(id),
+		map[string]any{"status": flag.Status},
+		map[string]any{"status": models.ComplianceFlagResolved, "resolution_note": note})
+	return nil
+}
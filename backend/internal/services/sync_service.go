@@ -12,10 +12,11 @@ import (
 
 // SyncService 同步服務
 type SyncService struct {
-	chainReader *blockchain.ChainReader
-	bondRepo    *repository.BondRepository
-	userRepo    *repository.UserRepository
-	txRepo      *repository.TransactionRepository
+	chainReader  *blockchain.ChainReader
+	bondRepo     *repository.BondRepository
+	userRepo     *repository.UserRepository
+	txRepo       *repository.TransactionRepository
+	proposalRepo *repository.BondProposalRepository
 }
 
 // NewSyncService 創建同步服務
@@ -25,12 +26,15 @@ func NewSyncService(
 	bondRepo *repository.BondRepository,
 	userRepo *repository.UserRepository,
 	txRepo *repository.TransactionRepository,
+	proposalRepo *repository.BondProposalRepository,
+	coins *blockchain.CoinRegistry,
 ) *SyncService {
 	return &SyncService{
-		chainReader: blockchain.NewChainReader(suiClient, packageID),
-		bondRepo:    bondRepo,
-		userRepo:    userRepo,
-		txRepo:      txRepo,
+		chainReader:  blockchain.NewChainReader(suiClient, packageID, coins),
+		bondRepo:     bondRepo,
+		userRepo:     userRepo,
+		txRepo:       txRepo,
+		proposalRepo: proposalRepo,
 	}
 }
 
@@ -54,8 +58,8 @@ func (s *SyncService) SyncBondCreated(ctx context.Context, txDigest string) erro
 		return fmt.Errorf("failed to get bond project from chain: %w", err)
 	}
 
-	// 查詢或創建發行者
-	user, err := s.userRepo.GetByWalletAddress(ctx, bondData.Issuer)
+	// 查詢或創建發行者（已刪除的帳戶仍歸屬原使用者）
+	user, err := s.userRepo.GetByWalletAddressUnscoped(ctx, bondData.Issuer)
 	if err != nil {
 		return fmt.Errorf("failed to get user: %w", err)
 	}
@@ -83,9 +87,12 @@ func (s *SyncService) SyncBondCreated(ctx context.Context, txDigest string) erro
 		logger.Info("✅ Bond synced successfully:")
 		logger.Info("   📋 Name: %s", bond.BondName)
 		logger.Info("   🆔 ID: %s", bond.OnChainID)
-		logger.Info("   💰 Total Amount: %d MIST (%.2f SUI)",
-			bond.TotalAmount,
-			float64(bond.TotalAmount)/1e9)
+		logger.Info("   💰 Total Amount: %d (%s)", bond.TotalAmount, bond.FormatAmount(bond.TotalAmount))
+
+		// 關聯已核准的申請，沒有的話標記需審核
+		if err := blockchain.LinkBondToProposal(ctx, s.proposalRepo, s.bondRepo, bond); err != nil {
+			logger.Error("Failed to link bond %s to proposal: %v", bond.OnChainID, err)
+		}
 	}
 
 	return nil
@@ -8,14 +8,22 @@ import (
 	"errors"
 )
 
+var (
+	ErrUserNotFound = errors.New("user not found")
+	ErrUserDeleted  = errors.New("account deleted")
+	ErrInvalidRole  = errors.New("invalid role")
+)
+
 // UserService 使用者服務層，處理使用者相關的業務邏輯
 type UserService struct {
-	repo *repository.UserRepository
+	repo     *repository.UserRepository
+	roleRepo *repository.RoleRepository
+	audit    *AuditService
 }
 
 // NewUserService 建立新的 UserService 實例
-func NewUserService(repo *repository.UserRepository) *UserService {
-	return &UserService{repo: repo}
+func NewUserService(repo *repository.UserRepository, roleRepo *repository.RoleRepository, audit *AuditService) *UserService {
+	return &UserService{repo: repo, roleRepo: roleRepo, audit: audit}
 }
 
 // GetByID 根據 ID 取得完整使用者資料
@@ -27,7 +35,7 @@ func (s *UserService) GetByID(ctx context.Context, userID int64) (*models.User,
 	}
 	if user == nil {
 		logger.Warn("User not found: ID=%d", userID)
-		return nil, errors.New("user not found")
+		return nil, ErrUserNotFound
 	}
 	return user, nil
 }
@@ -41,7 +49,7 @@ func (s *UserService) GetByWalletAddress(ctx context.Context, walletAddress stri
 	}
 	if user == nil {
 		logger.Warn("User not found: wallet=%s", walletAddress)
-		return nil, errors.New("user not found")
+		return nil, ErrUserNotFound
 	}
 	return user, nil
 }
@@ -52,17 +60,28 @@ func (s *UserService) Create(ctx context.Context, walletAddress string) (*models
 }
 
 // CreateWithRole 建立新使用者並指定角色
+// 角色不存在或具有所有權限（例如 admin）時改為 buyer，避免自行註冊取得管理權限
 func (s *UserService) CreateWithRole(ctx context.Context, walletAddress, role string) (*models.User, error) {
-	// 驗證角色
-	validRoles := map[string]bool{
-		"buyer":  true,
-		"issuer": true,
-		"admin":  false, // admin 不允許自行註冊
+	// 已刪除的帳戶不可重新註冊（錢包地址唯一，需由管理員還原）
+	existing, err := s.repo.GetByWalletAddressUnscoped(ctx, walletAddress)
+	if err != nil {
+		logger.Error("Failed to check existing user for wallet %s: %v", walletAddress, err)
+		return nil, err
+	}
+	if existing != nil && existing.DeletedAt != nil {
+		logger.Warn("Deleted user attempted to sign in: ID=%d, wallet=%s", existing.ID, walletAddress)
+		return nil, ErrUserDeleted
 	}
 
-	if !validRoles[role] || role == "admin" {
-		logger.Warn("Invalid role attempted during registration: %s for wallet %s", role, walletAddress)
-		role = "buyer" // 無效角色時預設為 buyer
+	if role != "buyer" {
+		definition, err := s.getRole(ctx, role)
+		if err != nil && !errors.Is(err, ErrInvalidRole) {
+			return nil, err
+		}
+		if definition == nil || definition.HasPermission(models.PermissionAll) {
+			logger.Warn("Invalid role attempted during registration: %s for wallet %s", role, walletAddress)
+			role = "buyer" // 無效角色時預設為 buyer
+		}
 	}
 
 	user, err := s.repo.CreateWithRole(ctx, walletAddress, role)
@@ -84,17 +103,32 @@ func (s *UserService) Update(ctx context.Context, user *models.User) error {
 	return nil
 }
 
-// UpdateRole 更新使用者角色（管理員功能）
-func (s *UserService) UpdateRole(ctx context.Context, userID int64, newRole string) error {
-	validRoles := map[string]bool{
-		"buyer":  true,
-		"issuer": true,
-		"admin":  true,
+// UpdateProfile 更新使用者的個人資料並記錄稽核事件
+func (s *UserService) UpdateProfile(ctx context.Context, userID int64, name string) (*models.User, error) {
+	user, err := s.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	before := map[string]any{"name": user.Name}
+	user.Name = &name
+
+	if err := s.Update(ctx, user); err != nil {
+		return nil, err
 	}
 
-	if !validRoles[newRole] {
-		logger.Warn("Invalid role attempted: %s for user ID %d", newRole, userID)
-		return errors.New("invalid role")
+	s.audit.Record(ctx, models.AuditActionProfileUpdate, models.AuditTargetUser, auditID(userID),
+		before, map[string]any{"name": user.Name})
+	return user, nil
+}
+
+// UpdateRole 更新使用者角色（管理員功能）
+func (s *UserService) UpdateRole(ctx context.Context, userID int64, newRole string) error {
+	if _, err := s.getRole(ctx, newRole); err != nil {
+		if errors.Is(err, ErrInvalidRole) {
+			logger.Warn("Invalid role attempted: %s for user ID %d", newRole, userID)
+		}
+		return err
 	}
 
 	user, err := s.repo.GetByID(ctx, userID)
@@ -104,11 +138,10 @@ func (s *UserService) UpdateRole(ctx context.Context, userID int64, newRole stri
 	}
 	if user == nil {
 		logger.Warn("User not found for role update: ID=%d", userID)
-		return errors.New("user not found")
+		return ErrUserNotFound
 	}
 
-	user.Role = newRole
-	if err := s.repo.Update(ctx, user); err != nil {
+	if err := s.repo.UpdateRole(ctx, userID, newRole); err != nil {
 		logger.Error("Failed to update user role, ID %d: %v", userID, err)
 		return err
 	}
@@ -117,6 +150,19 @@ func (s *UserService) UpdateRole(ctx context.Context, userID int64, newRole stri
 	return nil
 }
 
+// getRole 由 roles 表取得角色定義，角色不存在時回傳 ErrInvalidRole
+func (s *UserService) getRole(ctx context.Context, name string) (*models.Role, error) {
+	role, err := s.roleRepo.GetByName(ctx, name)
+	if err != nil {
+		logger.Error("Failed to get role %s: %v", name, err)
+		return nil, err
+	}
+	if role == nil {
+		return nil, ErrInvalidRole
+	}
+	return role, nil
+}
+
 // Exists 檢查使用者是否存在
 func (s *UserService) Exists(ctx context.Context, walletAddress string) (bool, error) {
 	user, err := s.repo.GetByWalletAddress(ctx, walletAddress)
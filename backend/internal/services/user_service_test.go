@@ -0,0 +1,70 @@
+package services
+
+import (
+	"bluelink-backend/internal/repository"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+var userTestColumns = []string{
+	"id", "wallet_address", "role", "institution_name", "name", "timezone", "language",
+	"kyc_status", "kyc_level", "kyc_verified_at", "country", "is_blacklisted",
+	"daily_limit", "monthly_limit", "max_bond_share",
+	"created_at", "updated_at", "deleted_at",
+}
+
+func TestCreateRejectsDeletedUser(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	wallet := "0x00000000000000000000000000000000000000000000000000000000000000ab"
+	now := time.Now()
+	mock.ExpectQuery(`FROM users\s+WHERE wallet_address = \$1 OR id = \(SELECT user_id FROM user_wallets`).
+		WithArgs(wallet).
+		WillReturnRows(sqlmock.NewRows(userTestColumns).AddRow(
+			7, wallet, "buyer", nil, nil, "UTC", "en",
+			"pending", 0, nil, "", false,
+			nil, nil, nil,
+			now, now, now,
+		))
+
+	service := NewUserService(repository.NewUserRepository(db), repository.NewRoleRepository(db), nil)
+	user, err := service.Create(context.Background(), wallet)
+	if !errors.Is(err, ErrUserDeleted) {
+		t.Fatalf("Create() error = %v, want ErrUserDeleted", err)
+	}
+	if user != nil {
+		t.Fatalf("Create() user = %+v, want nil", user)
+	}
+	// 未預期的 INSERT 會讓 sqlmock 回傳錯誤，這裡確認查詢已執行且沒有其他語句
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestUpdateRoleRejectsUndefinedRole(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(`FROM roles WHERE name = \$1`).
+		WithArgs("auditor").
+		WillReturnRows(sqlmock.NewRows([]string{"name", "description", "permissions", "is_system", "created_at", "updated_at"}))
+
+	service := NewUserService(repository.NewUserRepository(db), repository.NewRoleRepository(db), nil)
+	if err := service.UpdateRole(context.Background(), 7, "auditor"); !errors.Is(err, ErrInvalidRole) {
+		t.Fatalf("UpdateRole() error = %v, want ErrInvalidRole", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,192 @@
+package services
+
+import (
+	"bluelink-backend/internal/config"
+	"bluelink-backend/internal/logger"
+	"bluelink-backend/internal/models"
+	"bluelink-backend/internal/repository"
+	"context"
+	"math"
+	"strings"
+	"time"
+)
+
+// msPerYear 一年的毫秒數（以 365 日計）
+const msPerYear = 365 * 24 * 60 * 60 * 1000
+
+// ValuationService 債券代幣估值服務（mark-to-model）
+// 有二級市場成交時以最後成交價推算，否則以殖利率曲線加發行者信用利差折現到期現金流
+type ValuationService struct {
+	bondRepo      *repository.BondRepository
+	txRepo        *repository.TransactionRepository
+	yieldCurve    []config.YieldCurvePoint
+	defaultSpread float64
+	issuerSpreads map[string]float64
+}
+
+// NewValuationService 建立新的 ValuationService 實例
+func NewValuationService(
+	bondRepo *repository.BondRepository,
+	txRepo *repository.TransactionRepository,
+	cfg *config.Config,
+) *ValuationService {
+	return &ValuationService{
+		bondRepo:      bondRepo,
+		txRepo:        txRepo,
+		yieldCurve:    cfg.YieldCurve,
+		defaultSpread: cfg.DefaultCreditSpread,
+		issuerSpreads: cfg.IssuerCreditSpreads,
+	}
+}
+
+// ValueTokens 為代幣計算估值並填入 Valuation 欄位
+// 同一債券的發行者與最後成交只查詢一次
+func (s *ValuationService) ValueTokens(ctx context.Context, tokens []*models.BondToken) error {
+	now := time.Now()
+	bonds := make(map[string]*models.Bond)
+	trades := make(map[string]*models.LastTrade)
+
+	for _, token := range tokens {
+		if token == nil {
+			continue
+		}
+
+		bond, loaded := bonds[token.ProjectID]
+		if !loaded {
+			var err error
+			bond, err = s.bondRepo.GetByOnChainID(ctx, token.ProjectID)
+			if err != nil {
+				logger.Error("Failed to get bond %s for valuation: %v", token.ProjectID, err)
+				return err
+			}
+			bonds[token.ProjectID] = bond
+
+			if bond != nil {
+				trade, err := s.txRepo.GetLastTrade(ctx, bond.ID)
+				if err != nil {
+					logger.Error("Failed to get last trade for bond %d: %v", bond.ID, err)
+					return err
+				}
+				trades[token.ProjectID] = trade
+			}
+		}
+
+		issuer := ""
+		if bond != nil {
+			issuer = bond.IssuerAddress
+		}
+		token.Valuation = s.valueToken(token, issuer, trades[token.ProjectID], now)
+	}
+
+	return nil
+}
+
+// ValuePortfolio 取得錢包持有的未贖回代幣並彙總估值
+func (s *ValuationService) ValuePortfolio(ctx context.Context, owner string, tokens []*models.BondToken) (*models.Portfolio, error) {
+	held := make([]*models.BondToken, 0, len(tokens))
+	for _, token := range tokens {
+		if token != nil && !token.IsRedeemed {
+			held = append(held, token)
+		}
+	}
+
+	if err := s.ValueTokens(ctx, held); err != nil {
+		return nil, err
+	}
+
+	portfolio := &models.Portfolio{
+		Owner:  owner,
+		Tokens: held,
+		Count:  len(held),
+		Totals: []*models.PortfolioTotal{},
+		AsOf:   time.Now(),
+	}
+
+	// 不同幣種的金額不能直接相加
+	totals := make(map[string]*models.PortfolioTotal)
+	for _, token := range held {
+		total, ok := totals[token.CoinType]
+		if !ok {
+			total = &models.PortfolioTotal{CoinType: token.CoinType, CoinDecimals: token.CoinDecimals}
+			totals[token.CoinType] = total
+			portfolio.Totals = append(portfolio.Totals, total)
+		}
+		total.FaceValue += token.Amount
+		total.Value += token.Valuation.Value
+	}
+
+	return portfolio, nil
+}
+
+// valueToken 計算單一代幣的估值
+func (s *ValuationService) valueToken(token *models.BondToken, issuer string, trade *models.LastTrade, now time.Time) *models.TokenValuation {
+	maturityPayment := maturityPayment(token)
+	nowMs := now.UnixMilli()
+	years := math.Max(float64(token.MaturityDate-nowMs)/msPerYear, 0)
+
+	valuation := &models.TokenValuation{
+		TokenID:         token.OnChainID,
+		FaceValue:       token.Amount,
+		MaturityPayment: maturityPayment,
+		YearsToMaturity: years,
+		AsOf:            now,
+	}
+
+	// 已到期：持有人可依到期應付金額贖回
+	if token.MaturityDate <= nowMs {
+		valuation.Value = maturityPayment
+		valuation.Method = models.ValuationMethodMatured
+		return valuation
+	}
+
+	// 有成交時以最後成交價佔面額的比例推算
+	if trade != nil && trade.FaceValue > 0 {
+		valuation.Value = int64(math.Round(trade.Price / float64(trade.FaceValue) * float64(token.Amount)))
+		valuation.Method = models.ValuationMethodLastTrade
+		valuation.AsOf = trade.Timestamp
+		valuation.LastTradeTxHash = trade.TxHash
+		return valuation
+	}
+
+	rate := s.curveRate(years) + s.creditSpread(issuer)
+	valuation.DiscountRate = &rate
+	valuation.Value = int64(math.Round(float64(maturityPayment) / math.Pow(1+rate, years)))
+	valuation.Method = models.ValuationMethodDiscountedCashFlow
+	return valuation
+}
+
+// curveRate 以線性內插取得指定期限的殖利率，超出曲線範圍時取端點值
+func (s *ValuationService) curveRate(years float64) float64 {
+	curve := s.yieldCurve
+	if len(curve) == 0 {
+		return 0
+	}
+	if years <= curve[0].TenorYears {
+		return curve[0].Rate
+	}
+
+	for i := 1; i < len(curve); i++ {
+		if years <= curve[i].TenorYears {
+			prev := curve[i-1]
+			weight := (years - prev.TenorYears) / (curve[i].TenorYears - prev.TenorYears)
+			return prev.Rate + weight*(curve[i].Rate-prev.Rate)
+		}
+	}
+
+	return curve[len(curve)-1].Rate
+}
+
+// creditSpread 取得發行者的信用利差，未設定時使用預設值
+func (s *ValuationService) creditSpread(issuer string) float64 {
+	if spread, ok := s.issuerSpreads[strings.ToLower(issuer)]; ok {
+		return spread
+	}
+	return s.defaultSpread
+}
+
+// maturityPayment 計算到期應付金額：本金加上購買日至到期日的單利利息
+func maturityPayment(token *models.BondToken) int64 {
+	termYears := math.Max(float64(token.MaturityDate-token.PurchaseDate)/msPerYear, 0)
+	interest := float64(token.Amount) * float64(token.AnnualInterestRate) / 10000 * termYears
+	return token.Amount + int64(math.Round(interest))
+}
@@ -0,0 +1,119 @@
+package services
+
+import (
+	"bluelink-backend/internal/config"
+	"bluelink-backend/internal/models"
+	"testing"
+	"time"
+)
+
+func newTestValuationService() *ValuationService {
+	return &ValuationService{
+		yieldCurve: []config.YieldCurvePoint{
+			{TenorYears: 1, Rate: 0.04},
+			{TenorYears: 3, Rate: 0.05},
+		},
+		defaultSpread: 0.02,
+		issuerSpreads: map[string]float64{"0xissuer": 0.01},
+	}
+}
+
+func TestCurveRate(t *testing.T) {
+	s := newTestValuationService()
+
+	tests := []struct {
+		name  string
+		years float64
+		want  float64
+	}{
+		{"before first tenor", 0.5, 0.04},
+		{"on first tenor", 1, 0.04},
+		{"interpolated", 2, 0.045},
+		{"on last tenor", 3, 0.05},
+		{"beyond last tenor", 10, 0.05},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := s.curveRate(tt.years); got < tt.want-1e-12 || got > tt.want+1e-12 {
+				t.Fatalf("curveRate(%g) = %g, want %g", tt.years, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValueToken(t *testing.T) {
+	s := newTestValuationService()
+	now := time.UnixMilli(1_700_000_000_000)
+	nowMs := now.UnixMilli()
+	tradedAt := now.Add(-time.Hour)
+
+	// 面額 1,000,000、年利率 5%、購買日為一年前
+	token := func(maturity int64) *models.BondToken {
+		return &models.BondToken{
+			OnChainID:          "0xtoken",
+			Amount:             1_000_000,
+			AnnualInterestRate: 500,
+			PurchaseDate:       nowMs - msPerYear,
+			MaturityDate:       maturity,
+		}
+	}
+
+	tests := []struct {
+		name   string
+		token  *models.BondToken
+		issuer string
+		trade  *models.LastTrade
+		want   int64
+		method string
+	}{
+		{
+			name:   "matured pays principal and interest",
+			token:  token(nowMs - 1),
+			method: models.ValuationMethodMatured,
+			want:   1_050_000,
+		},
+		{
+			name:   "last trade scales price to face value",
+			token:  token(nowMs + msPerYear),
+			trade:  &models.LastTrade{TxHash: "0xtx", Price: 495_000, FaceValue: 500_000, Timestamp: tradedAt},
+			method: models.ValuationMethodLastTrade,
+			want:   990_000,
+		},
+		{
+			name:   "trade without face value is discounted",
+			token:  token(nowMs + msPerYear),
+			trade:  &models.LastTrade{TxHash: "0xtx", Price: 495_000, Timestamp: tradedAt},
+			method: models.ValuationMethodDiscountedCashFlow,
+			want:   1_037_736, // 1,100,000 / 1.06
+		},
+		{
+			name:   "discounted with default spread",
+			token:  token(nowMs + msPerYear),
+			method: models.ValuationMethodDiscountedCashFlow,
+			want:   1_037_736,
+		},
+		{
+			name:   "discounted with issuer spread",
+			token:  token(nowMs + msPerYear),
+			issuer: "0xISSUER",
+			method: models.ValuationMethodDiscountedCashFlow,
+			want:   1_047_619, // 1,100,000 / 1.05
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := s.valueToken(tt.token, tt.issuer, tt.trade, now)
+			if got.Value != tt.want || got.Method != tt.method {
+				t.Fatalf("valueToken() = %d (%s), want %d (%s)", got.Value, got.Method, tt.want, tt.method)
+			}
+			if tt.method == models.ValuationMethodLastTrade && (!got.AsOf.Equal(tradedAt) || got.LastTradeTxHash != "0xtx") {
+				t.Fatalf("last trade valuation as of %v with tx %q", got.AsOf, got.LastTradeTxHash)
+			}
+			if tt.method == models.ValuationMethodDiscountedCashFlow && got.DiscountRate == nil {
+				t.Fatal("discounted valuation should report its discount rate")
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package services
+
+import (
+	"bluelink-backend/internal/logger"
+	"bluelink-backend/internal/models"
+	"bluelink-backend/internal/repository"
+	"bluelink-backend/internal/session"
+	"context"
+	"errors"
+)
+
+var (
+	ErrWalletAlreadyLinked = errors.New("wallet is already registered or linked to an account")
+	ErrWalletNotLinked     = errors.New("wallet is not linked to this account")
+	ErrPrimaryWalletUnlink = errors.New("the primary wallet of an account cannot be unlinked")
+	ErrLinkedWalletLimit   = errors.New("maximum number of linked wallets reached")
+)
+
+// maxLinkedWallets 每個帳戶最多可綁定的額外錢包數量
+const maxLinkedWallets = 10
+
+// WalletService 使用者多錢包綁定服務層
+type WalletService struct {
+	repo           *repository.UserWalletRepository
+	userRepo       *repository.UserRepository
+	sessionManager session.SessionManager
+	audit          *AuditService
+}
+
+// NewWalletService 建立新的 WalletService 實例
+func NewWalletService(repo *repository.UserWalletRepository, userRepo *repository.UserRepository, sessionManager session.SessionManager, audit *AuditService) *WalletService {
+	return &WalletService{
+		repo:           repo,
+		userRepo:       userRepo,
+		sessionManager: sessionManager,
+		audit:          audit,
+	}
+}
+
+// ListWallets 取得使用者的主錢包與已綁定錢包
+func (s *WalletService) ListWallets(ctx context.Context, userID int64) (*models.UserWallets, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		logger.Error("Failed to get user ID %d: %v", userID, err)
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+
+	linked, err := s.repo.ListByUser(ctx, userID)
+	if err != nil {
+		logger.Error("Failed to list linked wallets of user %d: %v", userID, err)
+		return nil, err
+	}
+
+	return &models.UserWallets{Primary: user.WalletAddress, Linked: linked}, nil
+}
+
+// WalletAddresses 取得使用者所有錢包地址（主錢包在前）
+func (s *WalletService) WalletAddresses(ctx context.Context, userID int64) ([]string, error) {
+	wallets, err := s.ListWallets(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return wallets.Addresses(), nil
+}
+
+// LinkWallet 將已驗證簽名的錢包綁定到使用者帳戶
+// 錢包不可已是其他使用者（包含已刪除的帳戶）的主錢包或已綁定到任何帳戶
+func (s *WalletService) LinkWallet(ctx context.Context, userID int64, walletAddress string, label *string) (*models.LinkedWallet, error) {
+	owner, err := s.userRepo.GetByWalletAddressUnscoped(ctx, walletAddress)
+	if err != nil {
+		logger.Error("Failed to look up wallet %s: %v", walletAddress, err)
+		return nil, err
+	}
+	if owner != nil {
+		return nil, ErrWalletAlreadyLinked
+	}
+
+	count, err := s.repo.CountByUser(ctx, userID)
+	if err != nil {
+		logger.Error("Failed to count linked wallets of user %d: %v", userID, err)
+		return nil, err
+	}
+	if count >= maxLinkedWallets {
+		return nil, ErrLinkedWalletLimit
+	}
+
+	wallet := &models.LinkedWallet{
+		UserID:        userID,
+		WalletAddress: walletAddress,
+		Label:         label,
+	}
+	if err := s.repo.Create(ctx, wallet); err != nil {
+		logger.Error("Failed to link wallet %s to user %d: %v", walletAddress, userID, err)
+		return nil, err
+	}
+
+	logger.Info("Wallet linked: user=%d, wallet=%s", userID, walletAddress)
+
+	s.audit.Record(ctx, models.AuditActionWalletLink, models.AuditTargetWallet, walletAddress, nil, wallet)
+	return wallet, nil
+}
+
+// UnlinkWallet 解除綁定錢包，並登出該錢包的所有裝置
+func (s *WalletService) UnlinkWallet(ctx context.Context, userID int64, walletAddress string) error {
+	wallet, err := s.repo.GetByAddress(ctx, walletAddress)
+	if err != nil {
+		logger.Error("Failed to get linked wallet %s: %v", walletAddress, err)
+		return err
+	}
+	if wallet == nil || wallet.UserID != userID {
+		user, err := s.userRepo.GetByID(ctx, userID)
+		if err != nil {
+			logger.Error("Failed to get user ID %d: %v", userID, err)
+			return err
+		}
+		if user != nil && user.WalletAddress == walletAddress {
+			return ErrPrimaryWalletUnlink
+		}
+		return ErrWalletNotLinked
+	}
+
+	if err := s.repo.Delete(ctx, userID, walletAddress); err != nil {
+		logger.Error("Failed to unlink wallet %s from user %d: %v", walletAddress, userID, err)
+		return err
+	}
+
+	if err := s.sessionManager.DeleteAllUserSessions(ctx, walletAddress); err != nil {
+		logger.Error("Failed to revoke sessions of unlinked wallet %s: %v", walletAddress, err)
+	}
+
+	logger.Info("Wallet unlinked: user=%d, wallet=%s", userID, walletAddress)
+
+	s.audit.Record(ctx, models.AuditActionWalletUnlink, models.AuditTargetWallet, walletAddress, wallet, nil)
+	return nil
+}
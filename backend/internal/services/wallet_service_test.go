@@ -0,0 +1,42 @@
+package services
+
+import (
+	"bluelink-backend/internal/repository"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestLinkWalletRejectsDeletedAccountWallet(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	wallet := "0x00000000000000000000000000000000000000000000000000000000000000cd"
+	now := time.Now()
+	mock.ExpectQuery(`FROM users\s+WHERE wallet_address = \$1 OR id = \(SELECT user_id FROM user_wallets`).
+		WithArgs(wallet).
+		WillReturnRows(sqlmock.NewRows(userTestColumns).AddRow(
+			9, wallet, "buyer", nil, nil, "UTC", "en",
+			"none", 0, nil, "", false,
+			nil, nil, nil,
+			now, now, now,
+		))
+
+	service := NewWalletService(repository.NewUserWalletRepository(db), repository.NewUserRepository(db), nil, nil)
+	linked, err := service.LinkWallet(context.Background(), 7, wallet, nil)
+	if !errors.Is(err, ErrWalletAlreadyLinked) {
+		t.Fatalf("LinkWallet() error = %v, want ErrWalletAlreadyLinked", err)
+	}
+	if linked != nil {
+		t.Fatalf("LinkWallet() = %+v, want nil", linked)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,112 @@
+package session
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+)
+
+/*
+   CSRF 防護（cookie 模式）：
+   生產環境的 session Cookie 為 SameSite=None，任何網站都能讓瀏覽器帶著 Cookie 發出請求，
+   因此以 Cookie 驗證的狀態變更請求必須同時通過：
+   1. Origin（缺少時為 Referer）屬於 CSRF_TRUSTED_ORIGINS；未設定時須與請求的 Host 同源
+      （不沿用 CORS_ALLOWED_ORIGINS，避免 "*" 讓任何來源通過）
+   2. X-CSRF-Token Header 與 csrf_token Cookie 相同（double-submit），
+      且等於以 session ID 衍生的 HMAC，無法由其他網站注入的 Cookie 偽造
+   Bearer access token 與 API key 不會被瀏覽器自動夾帶，不需檢查
+*/
+
+const (
+	CSRFCookieName = "csrf_token"
+	CSRFHeaderName = "X-CSRF-Token"
+)
+
+var (
+	ErrCSRFOriginMismatch = errors.New("request origin is not allowed")
+	ErrCSRFTokenMissing   = errors.New("missing CSRF token")
+	ErrCSRFTokenInvalid   = errors.New("invalid CSRF token")
+)
+
+// CSRFToken 以 session ID 衍生 CSRF token（不需另外保存，session 失效即一併失效）
+func (p Policy) CSRFToken(sessionID string) string {
+	mac := hmac.New(sha256.New, p.CSRFSecret)
+	mac.Write([]byte("csrf:" + sessionID))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// CSRFCookie 建立 CSRF Cookie（非 HttpOnly，屬性與 session Cookie 一致）
+func (p Policy) CSRFCookie(token string, expiresAt time.Time) *http.Cookie {
+	return &http.Cookie{
+		Name:     CSRFCookieName,
+		Value:    token,
+		Path:     "/",
+		MaxAge:   int(time.Until(expiresAt).Seconds()),
+		Secure:   p.CookieSecure,
+		SameSite: p.CookieSameSite,
+	}
+}
+
+// ExpiredCSRFCookie 清除 CSRF Cookie
+func (p Policy) ExpiredCSRFCookie() *http.Cookie {
+	return &http.Cookie{
+		Name:     CSRFCookieName,
+		Value:    "",
+		Path:     "/",
+		MaxAge:   -1,
+		Secure:   p.CookieSecure,
+		SameSite: p.CookieSameSite,
+	}
+}
+
+// VerifyCSRF 檢查以 Cookie 驗證的狀態變更請求的來源與 CSRF token
+func (p Policy) VerifyCSRF(r *http.Request, sessionID string) error {
+	if !p.originAllowed(r) {
+		return ErrCSRFOriginMismatch
+	}
+
+	header := r.Header.Get(CSRFHeaderName)
+	cookie, err := r.Cookie(CSRFCookieName)
+	if header == "" || err != nil || cookie.Value == "" {
+		return ErrCSRFTokenMissing
+	}
+
+	expected := []byte(p.CSRFToken(sessionID))
+	if !hmac.Equal([]byte(header), []byte(cookie.Value)) || !hmac.Equal([]byte(header), expected) {
+		return ErrCSRFTokenInvalid
+	}
+	return nil
+}
+
+// originAllowed 以 Origin（缺少時為 Referer）比對信任的來源；未設定信任來源時比對請求本身的 Host
+func (p Policy) originAllowed(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		referer, err := url.Parse(r.Header.Get("Referer"))
+		if err != nil || referer.Scheme == "" || referer.Host == "" {
+			return false
+		}
+		origin = referer.Scheme + "://" + referer.Host
+	}
+
+	if len(p.TrustedOrigins) == 0 {
+		parsed, err := url.Parse(origin)
+		return err == nil && parsed.Host != "" && strings.EqualFold(parsed.Host, r.Host)
+	}
+	return slices.Contains(p.TrustedOrigins, origin)
+}
+
+// IsSafeMethod 不變更狀態的 HTTP 方法不需 CSRF 檢查
+func IsSafeMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+	return false
+}
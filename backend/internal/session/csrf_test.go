@@ -0,0 +1,42 @@
+package session
+
+import (
+	"net/http/httptest"
+	"testing"
+)
+
+func TestOriginAllowed(t *testing.T) {
+	tests := []struct {
+		name    string
+		trusted []string
+		host    string
+		origin  string
+		referer string
+		want    bool
+	}{
+		{"trusted origin", []string{"https://app.bluelink.io"}, "api.bluelink.io", "https://app.bluelink.io", "", true},
+		{"untrusted origin", []string{"https://app.bluelink.io"}, "api.bluelink.io", "https://evil.example", "", false},
+		{"trusted referer", []string{"https://app.bluelink.io"}, "api.bluelink.io", "", "https://app.bluelink.io/bonds", true},
+		{"wildcard is not a match", []string{"*"}, "api.bluelink.io", "https://evil.example", "", false},
+		{"same origin without trusted list", nil, "localhost:8080", "http://localhost:8080", "", true},
+		{"cross origin without trusted list", nil, "localhost:8080", "https://evil.example", "", false},
+		{"missing origin and referer", nil, "localhost:8080", "", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("POST", "/api/v1/orders", nil)
+			r.Host = tt.host
+			if tt.origin != "" {
+				r.Header.Set("Origin", tt.origin)
+			}
+			if tt.referer != "" {
+				r.Header.Set("Referer", tt.referer)
+			}
+
+			if got := (Policy{TrustedOrigins: tt.trusted}).originAllowed(r); got != tt.want {
+				t.Fatalf("originAllowed() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
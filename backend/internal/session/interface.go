@@ -1,22 +1,43 @@
 package session
 
+import "context"
+
 // SessionManager 定義 Session 管理介面
 type SessionManager interface {
-	// Create 建立新的 Session
-	Create(userID int64, walletAddress, role string, ipAddress, userAgent string) (*Session, error)
+	// Create 建立新的 Session（裝置數達上限時依政策登出最舊的裝置或回傳 ErrMaxDevicesReached）
+	// roles 為主要角色以外額外授予的角色
+	Create(ctx context.Context, userID int64, walletAddress, role string, roles []string, kycStatus string, kycLevel int, ipAddress, userAgent string) (*Session, error)
 
 	// Get 取得 Session
-	Get(sessionID string) (*Session, error)
+	Get(ctx context.Context, sessionID string) (*Session, error)
 
-	// Touch 更新 Session 的最後活躍時間
-	Touch(sessionID string) error
+	// Touch 更新 Session 的最後活躍時間（SlidingExpiry 時一併延長到期時間）
+	Touch(ctx context.Context, sessionID string) error
 
 	// Delete 刪除特定的 Session
-	Delete(sessionID string) error
+	Delete(ctx context.Context, sessionID string) error
 
 	// DeleteAllUserSessions 刪除特定錢包地址的所有 Session
-	DeleteAllUserSessions(walletAddress string) error
+	DeleteAllUserSessions(ctx context.Context, walletAddress string) error
 
 	// GetUserSessions 取得特定錢包地址的所有 Session
-	GetUserSessions(walletAddress string) ([]*Session, error)
+	GetUserSessions(ctx context.Context, walletAddress string) ([]*Session, error)
+
+	// DeleteAllByUserID 刪除特定使用者所有錢包（主錢包與綁定錢包）的 Session
+	DeleteAllByUserID(ctx context.Context, userID int64) error
+
+	// GetUserSessionsByUserID 取得特定使用者所有錢包（主錢包與綁定錢包）的 Session
+	GetUserSessionsByUserID(ctx context.Context, userID int64) ([]*Session, error)
+
+	// UpdateUserKYC 更新特定使用者所有 Session 的 KYC 狀態（審核結果即時生效）
+	UpdateUserKYC(ctx context.Context, userID int64, kycStatus string, kycLevel int) error
+
+	// UpdateUserRole 更新特定使用者所有 Session 的角色（角色升級即時生效）
+	UpdateUserRole(ctx context.Context, userID int64, role string) error
+
+	// UpdateUserRoles 更新特定使用者所有 Session 的額外角色（授予或移除即時生效）
+	UpdateUserRoles(ctx context.Context, userID int64, roles []string) error
+
+	// Policy 取得 Session 政策（Cookie 屬性亦由此決定）
+	Policy() Policy
 }
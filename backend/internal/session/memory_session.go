@@ -1,6 +1,9 @@
 package session
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"sync"
 	"time"
@@ -9,12 +12,10 @@ import (
 )
 
 type MemorySessionManager struct {
-	sessions      map[string]*Session
-	userSessions  map[string]map[string]bool // wallet -> sessionIDs
-	mu            sync.RWMutex
-	timeout       time.Duration
-	idleTimeout   time.Duration
-	maxConcurrent int
+	sessions     map[string]*Session
+	userSessions map[string]map[string]bool // wallet -> sessionIDs
+	mu           sync.RWMutex
+	policy       Policy
 }
 
 type Session struct {
@@ -22,19 +23,21 @@ type Session struct {
 	UserID        int64     `json:"user_id"`
 	WalletAddress string    `json:"wallet_address"`
 	Role          string    `json:"role"`
+	Roles         []string  `json:"roles"` // 額外授予的角色
+	KYCStatus     string    `json:"kyc_status"`
+	KYCLevel      int       `json:"kyc_level"`
 	CreatedAt     time.Time `json:"created_at"`
 	LastActiveAt  time.Time `json:"last_active_at"`
+	ExpiresAt     time.Time `json:"expires_at"`
 	IPAddress     string    `json:"ip_address"`
 	UserAgent     string    `json:"user_agent"`
 }
 
-func NewMemorySessionManager() *MemorySessionManager {
+func NewMemorySessionManager(policy Policy) *MemorySessionManager {
 	manager := &MemorySessionManager{
-		sessions:      make(map[string]*Session),
-		userSessions:  make(map[string]map[string]bool),
-		timeout:       24 * time.Hour,
-		idleTimeout:   30 * time.Minute,
-		maxConcurrent: 3,
+		sessions:     make(map[string]*Session),
+		userSessions: make(map[string]map[string]bool),
+		policy:       policy,
 	}
 
 	// 啟動清理協程
@@ -44,15 +47,16 @@ func NewMemorySessionManager() *MemorySessionManager {
 }
 
 // Create new session
-func (m *MemorySessionManager) Create(userID int64, walletAddress, role string, ipAddress, userAgent string) (*Session, error) {
+func (m *MemorySessionManager) Create(ctx context.Context, userID int64, walletAddress, role string, roles []string, kycStatus string, kycLevel int, ipAddress, userAgent string) (*Session, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	// 檢查並發數量
-	if userSessions, exists := m.userSessions[walletAddress]; exists {
-		if len(userSessions) >= m.maxConcurrent {
-			return nil, fmt.Errorf("maximum concurrent sessions reached")
+	// 檢查裝置數量（以使用者計算），達到上限時依政策拒絕或移除最久未活動的 Session
+	for m.countUserSessions(userID) >= m.policy.MaxDevices {
+		if m.policy.Eviction == EvictReject {
+			return nil, ErrMaxDevicesReached
 		}
+		m.evictOldest(userID)
 	}
 
 	// 生成 Session ID
@@ -67,8 +71,12 @@ func (m *MemorySessionManager) Create(userID int64, walletAddress, role string,
 		UserID:        userID,
 		WalletAddress: walletAddress,
 		Role:          role,
+		Roles:         roles,
+		KYCStatus:     kycStatus,
+		KYCLevel:      kycLevel,
 		CreatedAt:     now,
 		LastActiveAt:  now,
+		ExpiresAt:     m.policy.ExpiresAt(now, now),
 		IPAddress:     ipAddress,
 		UserAgent:     userAgent,
 	}
@@ -86,7 +94,7 @@ func (m *MemorySessionManager) Create(userID int64, walletAddress, role string,
 }
 
 // Get session
-func (m *MemorySessionManager) Get(sessionID string) (*Session, error) {
+func (m *MemorySessionManager) Get(ctx context.Context, sessionID string) (*Session, error) {
 	m.mu.RLock()
 	session, exists := m.sessions[sessionID]
 	m.mu.RUnlock()
@@ -95,23 +103,23 @@ func (m *MemorySessionManager) Get(sessionID string) (*Session, error) {
 		return nil, fmt.Errorf("session not found")
 	}
 
-	// 檢查是否過期
-	if time.Since(session.CreatedAt) > m.timeout {
-		m.Delete(sessionID)
+	// 檢查是否過期（滑動到期亦不超過建立後的絕對期限）
+	if now := time.Now(); now.After(session.ExpiresAt) || now.After(m.policy.Deadline(session.CreatedAt)) {
+		m.Delete(ctx, sessionID)
 		return nil, fmt.Errorf("session expired")
 	}
 
 	// 檢查閒置超時
-	if time.Since(session.LastActiveAt) > m.idleTimeout {
-		m.Delete(sessionID)
+	if time.Since(session.LastActiveAt) > m.policy.IdleTimeout {
+		m.Delete(ctx, sessionID)
 		return nil, fmt.Errorf("session expired due to inactivity")
 	}
 
 	return session, nil
 }
 
-// Touch 更新活躍時間
-func (m *MemorySessionManager) Touch(sessionID string) error {
+// Touch 更新活躍時間（滑動到期時一併延長到期時間，不超過絕對期限）
+func (m *MemorySessionManager) Touch(ctx context.Context, sessionID string) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
@@ -121,11 +129,14 @@ func (m *MemorySessionManager) Touch(sessionID string) error {
 	}
 
 	session.LastActiveAt = time.Now()
+	if m.policy.SlidingExpiry {
+		session.ExpiresAt = m.policy.ExpiresAt(session.CreatedAt, session.LastActiveAt)
+	}
 	return nil
 }
 
 // Delete session
-func (m *MemorySessionManager) Delete(sessionID string) error {
+func (m *MemorySessionManager) Delete(ctx context.Context, sessionID string) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
@@ -141,7 +152,7 @@ func (m *MemorySessionManager) Delete(sessionID string) error {
 }
 
 // DeleteAllUserSessions session
-func (m *MemorySessionManager) DeleteAllUserSessions(walletAddress string) error {
+func (m *MemorySessionManager) DeleteAllUserSessions(ctx context.Context, walletAddress string) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
@@ -155,7 +166,7 @@ func (m *MemorySessionManager) DeleteAllUserSessions(walletAddress string) error
 }
 
 // GetUserSessions session
-func (m *MemorySessionManager) GetUserSessions(walletAddress string) ([]*Session, error) {
+func (m *MemorySessionManager) GetUserSessions(ctx context.Context, walletAddress string) ([]*Session, error) {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
@@ -171,6 +182,108 @@ func (m *MemorySessionManager) GetUserSessions(walletAddress string) ([]*Session
 	return sessions, nil
 }
 
+// DeleteAllByUserID 刪除使用者所有錢包的 session
+func (m *MemorySessionManager) DeleteAllByUserID(ctx context.Context, userID int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for sessionID, session := range m.sessions {
+		if session.UserID == userID {
+			delete(m.sessions, sessionID)
+			delete(m.userSessions[session.WalletAddress], sessionID)
+		}
+	}
+
+	return nil
+}
+
+// GetUserSessionsByUserID 取得使用者所有錢包的 session
+func (m *MemorySessionManager) GetUserSessionsByUserID(ctx context.Context, userID int64) ([]*Session, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	sessions := []*Session{}
+	for _, session := range m.sessions {
+		if session.UserID == userID {
+			sessions = append(sessions, session)
+		}
+	}
+
+	return sessions, nil
+}
+
+// UpdateUserKYC 更新使用者所有 session 的 KYC 狀態
+func (m *MemorySessionManager) UpdateUserKYC(ctx context.Context, userID int64, kycStatus string, kycLevel int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, session := range m.sessions {
+		if session.UserID == userID {
+			session.KYCStatus = kycStatus
+			session.KYCLevel = kycLevel
+		}
+	}
+
+	return nil
+}
+
+// UpdateUserRole 更新使用者所有 session 的角色
+func (m *MemorySessionManager) UpdateUserRole(ctx context.Context, userID int64, role string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, session := range m.sessions {
+		if session.UserID == userID {
+			session.Role = role
+		}
+	}
+
+	return nil
+}
+
+// UpdateUserRoles 更新使用者所有 session 的額外角色
+func (m *MemorySessionManager) UpdateUserRoles(ctx context.Context, userID int64, roles []string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, session := range m.sessions {
+		if session.UserID == userID {
+			session.Roles = roles
+		}
+	}
+
+	return nil
+}
+
+// Policy 取得 Session 政策
+func (m *MemorySessionManager) Policy() Policy {
+	return m.policy
+}
+
+// countUserSessions 計算使用者所有錢包的 session 數量（呼叫端需持有鎖）
+func (m *MemorySessionManager) countUserSessions(userID int64) int {
+	count := 0
+	for _, session := range m.sessions {
+		if session.UserID == userID {
+			count++
+		}
+	}
+	return count
+}
+
+// evictOldest 移除使用者所有錢包中最久未活動的 session（呼叫端需持有鎖）
+func (m *MemorySessionManager) evictOldest(userID int64) {
+	var oldest *Session
+	for _, session := range m.sessions {
+		if session.UserID == userID && (oldest == nil || session.LastActiveAt.Before(oldest.LastActiveAt)) {
+			oldest = session
+		}
+	}
+
+	delete(m.sessions, oldest.ID)
+	delete(m.userSessions[oldest.WalletAddress], oldest.ID)
+}
+
 // cleanup session
 func (m *MemorySessionManager) cleanup() {
 	ticker := time.NewTicker(5 * time.Minute)
@@ -182,8 +295,8 @@ func (m *MemorySessionManager) cleanup() {
 
 		for sessionID, session := range m.sessions {
 			// 清理過期或閒置的 session
-			if now.Sub(session.CreatedAt) > m.timeout ||
-				now.Sub(session.LastActiveAt) > m.idleTimeout {
+			if now.After(session.ExpiresAt) ||
+				now.Sub(session.LastActiveAt) > m.policy.IdleTimeout {
 				delete(m.sessions, sessionID)
 				delete(m.userSessions[session.WalletAddress], sessionID)
 			}
@@ -193,6 +306,12 @@ func (m *MemorySessionManager) cleanup() {
 	}
 }
 
+// Ref 稽核與登入紀錄中代表 session 的識別碼（不記錄可直接使用的 session ID）
+func Ref(sessionID string) string {
+	sum := sha256.Sum256([]byte(sessionID))
+	return hex.EncodeToString(sum[:8])
+}
+
 func generateSecureID() (string, error) {
 	// 使用 UUID v4 生成 session ID (36 個字符,符合數據庫 VARCHAR(36) 定義)
 	return uuid.New().String(), nil
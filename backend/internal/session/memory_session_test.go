@@ -0,0 +1,102 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSlidingExpiryCappedByAbsoluteTimeout(t *testing.T) {
+	ctx := context.Background()
+	manager := NewMemorySessionManager(Policy{
+		AbsoluteTimeout: time.Hour,
+		IdleTimeout:     30 * time.Minute,
+		MaxDevices:      1,
+		SlidingExpiry:   true,
+	})
+
+	sess, err := manager.Create(ctx, 7, "0xabc", "buyer", nil, "approved", 1, "203.0.113.1", "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 建立 50 分鐘後活動：滑動後為 80 分鐘，但不超過建立後 1 小時
+	sess.CreatedAt = time.Now().Add(-50 * time.Minute)
+	if err := manager.Touch(ctx, sess.ID); err != nil {
+		t.Fatal(err)
+	}
+	if deadline := sess.CreatedAt.Add(time.Hour); !sess.ExpiresAt.Equal(deadline) {
+		t.Fatalf("ExpiresAt = %v, want capped at %v", sess.ExpiresAt, deadline)
+	}
+
+	// 超過絕對期限後即使仍在活動也失效
+	sess.CreatedAt = time.Now().Add(-2 * time.Hour)
+	sess.ExpiresAt = time.Now().Add(time.Hour)
+	if _, err := manager.Get(ctx, sess.ID); err == nil {
+		t.Fatal("session past its absolute timeout should be expired")
+	}
+}
+
+func TestSlidingExpiryExtendsWithinAbsoluteTimeout(t *testing.T) {
+	policy := Policy{AbsoluteTimeout: time.Hour, IdleTimeout: 30 * time.Minute, SlidingExpiry: true}
+	createdAt := time.Now()
+	lastActiveAt := createdAt.Add(10 * time.Minute)
+
+	if got, want := policy.ExpiresAt(createdAt, lastActiveAt), lastActiveAt.Add(30*time.Minute); !got.Equal(want) {
+		t.Fatalf("ExpiresAt = %v, want %v", got, want)
+	}
+
+	policy.SlidingExpiry = false
+	if got, want := policy.ExpiresAt(createdAt, lastActiveAt), createdAt.Add(time.Hour); !got.Equal(want) {
+		t.Fatalf("ExpiresAt without sliding = %v, want %v", got, want)
+	}
+}
+
+func TestMaxDevicesCountsAllWalletsOfUser(t *testing.T) {
+	ctx := context.Background()
+	policy := Policy{AbsoluteTimeout: time.Hour, IdleTimeout: time.Hour, MaxDevices: 2, Eviction: EvictReject}
+
+	manager := NewMemorySessionManager(policy)
+	if _, err := manager.Create(ctx, 7, "0xabc", "buyer", nil, "approved", 1, "203.0.113.1", "test"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := manager.Create(ctx, 7, "0xdef", "buyer", nil, "approved", 1, "203.0.113.1", "test"); err != nil {
+		t.Fatal(err)
+	}
+
+	// 以綁定錢包登入不會繞過使用者的裝置上限
+	if _, err := manager.Create(ctx, 7, "0x123", "buyer", nil, "approved", 1, "203.0.113.1", "test"); !errors.Is(err, ErrMaxDevicesReached) {
+		t.Fatalf("Create() error = %v, want ErrMaxDevicesReached", err)
+	}
+
+	// 其他使用者不受影響
+	if _, err := manager.Create(ctx, 8, "0x456", "buyer", nil, "approved", 1, "203.0.113.1", "test"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMaxDevicesEvictsOldestSessionOfUser(t *testing.T) {
+	ctx := context.Background()
+	manager := NewMemorySessionManager(Policy{AbsoluteTimeout: time.Hour, IdleTimeout: time.Hour, MaxDevices: 2, Eviction: EvictOldest})
+
+	oldest, err := manager.Create(ctx, 7, "0xabc", "buyer", nil, "approved", 1, "203.0.113.1", "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	oldest.LastActiveAt = time.Now().Add(-time.Minute)
+	if _, err := manager.Create(ctx, 7, "0xdef", "buyer", nil, "approved", 1, "203.0.113.1", "test"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := manager.Create(ctx, 7, "0xdef", "buyer", nil, "approved", 1, "203.0.113.1", "test"); err != nil {
+		t.Fatal(err)
+	}
+
+	sessions, _ := manager.GetUserSessionsByUserID(ctx, 7)
+	if len(sessions) != 2 {
+		t.Fatalf("user has %d sessions, want 2", len(sessions))
+	}
+	if _, err := manager.Get(ctx, oldest.ID); err == nil {
+		t.Fatal("oldest session on the other wallet should be evicted")
+	}
+}
@@ -0,0 +1,101 @@
+package session
+
+import (
+	"bluelink-backend/internal/config"
+	"errors"
+	"net/http"
+	"time"
+)
+
+// CookieName session ID 的 Cookie 名稱
+const CookieName = "session_id"
+
+// EvictionStrategy 裝置數達到上限時的處理方式
+type EvictionStrategy string
+
+const (
+	EvictOldest EvictionStrategy = "oldest" // 登出最久未活動的裝置
+	EvictReject EvictionStrategy = "reject" // 拒絕新的登入
+)
+
+var ErrMaxDevicesReached = errors.New("maximum number of signed-in devices reached")
+
+// Policy Session 的有效期限、裝置數、Cookie 屬性與 CSRF 設定
+type Policy struct {
+	AbsoluteTimeout time.Duration    // 建立後的有效期限（滑動到期亦不超過）
+	IdleTimeout     time.Duration    // 閒置超過即失效
+	MaxDevices      int              // 同一使用者（含綁定錢包）同時登入的裝置上限
+	Eviction        EvictionStrategy // 達到上限時的處理方式
+	SlidingExpiry   bool             // 到期時間隨活動延後（最後活動後 IdleTimeout，上限為 AbsoluteTimeout）
+	CookieSecure    bool             // 僅限 HTTPS
+	CookieSameSite  http.SameSite
+	CSRFSecret      []byte   // 衍生 CSRF token 的金鑰
+	TrustedOrigins  []string // 允許以 Cookie 發出狀態變更請求的來源（CSRF_TRUSTED_ORIGINS，空清單表示僅允許同源）
+}
+
+// PolicyFromConfig 由設定載入 Session 政策
+// 生產環境的 Cookie 需跨站（SameSite=None + Secure），開發環境使用 Lax
+func PolicyFromConfig(cfg *config.Config) Policy {
+	isProduction := cfg.Environment == "production"
+
+	sameSite := http.SameSiteLaxMode
+	if isProduction {
+		sameSite = http.SameSiteNoneMode
+	}
+
+	return Policy{
+		AbsoluteTimeout: time.Duration(cfg.SessionTimeout) * time.Second,
+		IdleTimeout:     time.Duration(cfg.SessionIdleTimeout) * time.Second,
+		MaxDevices:      cfg.SessionMaxDevices,
+		Eviction:        EvictionStrategy(cfg.SessionEviction),
+		SlidingExpiry:   cfg.SessionSlidingExpiry,
+		CookieSecure:    isProduction,
+		CookieSameSite:  sameSite,
+		CSRFSecret:      []byte(cfg.JWTSecret),
+		TrustedOrigins:  cfg.CSRFTrustedOrigins,
+	}
+}
+
+// ExpiresAt 依政策計算到期時間
+// SlidingExpiry 時為最後活動後 IdleTimeout，但不超過 Deadline；否則即為 Deadline
+func (p Policy) ExpiresAt(createdAt, lastActiveAt time.Time) time.Time {
+	deadline := p.Deadline(createdAt)
+	if !p.SlidingExpiry {
+		return deadline
+	}
+	if slid := lastActiveAt.Add(p.IdleTimeout); slid.Before(deadline) {
+		return slid
+	}
+	return deadline
+}
+
+// Deadline 建立後的絕對到期時間，無論是否滑動到期都不會延長
+func (p Policy) Deadline(createdAt time.Time) time.Time {
+	return createdAt.Add(p.AbsoluteTimeout)
+}
+
+// Cookie 以政策的有效期限與屬性建立 session Cookie
+func (p Policy) Cookie(sessionID string, expiresAt time.Time) *http.Cookie {
+	return &http.Cookie{
+		Name:     CookieName,
+		Value:    sessionID,
+		Path:     "/",
+		MaxAge:   int(time.Until(expiresAt).Seconds()),
+		Secure:   p.CookieSecure,
+		HttpOnly: true,
+		SameSite: p.CookieSameSite,
+	}
+}
+
+// ExpiredCookie 清除 session Cookie（屬性需與設定時一致瀏覽器才會覆蓋）
+func (p Policy) ExpiredCookie() *http.Cookie {
+	return &http.Cookie{
+		Name:     CookieName,
+		Value:    "",
+		Path:     "/",
+		MaxAge:   -1,
+		Secure:   p.CookieSecure,
+		HttpOnly: true,
+		SameSite: p.CookieSameSite,
+	}
+}
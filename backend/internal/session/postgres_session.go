@@ -11,19 +11,15 @@ import (
 
 // PostgresSessionManager 使用 PostgreSQL 管理 Session
 type PostgresSessionManager struct {
-	repo          *repository.SessionRepository
-	timeout       time.Duration
-	idleTimeout   time.Duration
-	maxConcurrent int
+	repo   *repository.SessionRepository
+	policy Policy
 }
 
 // NewPostgresSessionManager 建立新的 PostgreSQL Session Manager
-func NewPostgresSessionManager(repo *repository.SessionRepository) *PostgresSessionManager {
+func NewPostgresSessionManager(repo *repository.SessionRepository, policy Policy) *PostgresSessionManager {
 	manager := &PostgresSessionManager{
-		repo:          repo,
-		timeout:       24 * time.Hour,   // Session 總過期時間
-		idleTimeout:   30 * time.Minute, // 閒置過期時間
-		maxConcurrent: 3,                // 最大並發 Session 數
+		repo:   repo,
+		policy: policy,
 	}
 
 	// 啟動清理協程
@@ -33,19 +29,22 @@ func NewPostgresSessionManager(repo *repository.SessionRepository) *PostgresSess
 }
 
 // Create 建立新的 Session
-func (m *PostgresSessionManager) Create(userID int64, walletAddress, role string, ipAddress, userAgent string) (*Session, error) {
-	ctx := context.Background()
-
-	// 檢查並發數量
-	count, err := m.repo.CountByWalletAddress(ctx, walletAddress)
+func (m *PostgresSessionManager) Create(ctx context.Context, userID int64, walletAddress, role string, roles []string, kycStatus string, kycLevel int, ipAddress, userAgent string) (*Session, error) {
+	// 檢查裝置數量（以使用者計算，主錢包與綁定錢包共用上限）
+	count, err := m.repo.CountByUserID(ctx, userID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to count sessions: %w", err)
 	}
 
-	// 如果達到最大並發數，刪除最舊的 Session
-	if count >= m.maxConcurrent {
-		if err := m.repo.DeleteOldestSession(ctx, walletAddress); err != nil {
-			return nil, fmt.Errorf("failed to delete oldest session: %w", err)
+	// 達到上限時依政策拒絕，或刪除最久未活動的 Session 直到有空位
+	if count >= m.policy.MaxDevices {
+		if m.policy.Eviction == EvictReject {
+			return nil, ErrMaxDevicesReached
+		}
+		for ; count >= m.policy.MaxDevices; count-- {
+			if err := m.repo.DeleteOldestByUserID(ctx, userID); err != nil {
+				return nil, fmt.Errorf("failed to delete oldest session: %w", err)
+			}
 		}
 	}
 
@@ -56,13 +55,16 @@ func (m *PostgresSessionManager) Create(userID int64, walletAddress, role string
 	}
 
 	now := time.Now()
-	expiresAt := now.Add(m.timeout)
+	expiresAt := m.policy.ExpiresAt(now, now)
 
 	dbSession := &models.DBSession{
 		ID:            sessionID,
 		UserID:        userID,
 		WalletAddress: walletAddress,
 		Role:          role,
+		Roles:         roles,
+		KYCStatus:     kycStatus,
+		KYCLevel:      kycLevel,
 		IPAddress:     ipAddress,
 		UserAgent:     userAgent,
 		CreatedAt:     now,
@@ -75,92 +77,92 @@ func (m *PostgresSessionManager) Create(userID int64, walletAddress, role string
 		return nil, fmt.Errorf("failed to create session: %w", err)
 	}
 
-	return &Session{
-		ID:            dbSession.ID,
-		UserID:        dbSession.UserID,
-		WalletAddress: dbSession.WalletAddress,
-		Role:          dbSession.Role,
-		CreatedAt:     dbSession.CreatedAt,
-		LastActiveAt:  dbSession.LastActiveAt,
-		IPAddress:     dbSession.IPAddress,
-		UserAgent:     dbSession.UserAgent,
-	}, nil
+	return toSession(dbSession), nil
 }
 
 // Get 取得 Session
-func (m *PostgresSessionManager) Get(sessionID string) (*Session, error) {
-	ctx := context.Background()
-
+func (m *PostgresSessionManager) Get(ctx context.Context, sessionID string) (*Session, error) {
 	dbSession, err := m.repo.GetByID(ctx, sessionID)
 	if err != nil {
 		return nil, err
 	}
 
+	// 檢查絕對期限（滑動到期亦不超過）
+	if time.Now().After(m.policy.Deadline(dbSession.CreatedAt)) {
+		m.Delete(ctx, sessionID)
+		return nil, fmt.Errorf("session expired")
+	}
+
 	// 檢查閒置超時
-	if time.Since(dbSession.LastActiveAt) > m.idleTimeout {
-		m.Delete(sessionID)
+	if time.Since(dbSession.LastActiveAt) > m.policy.IdleTimeout {
+		m.Delete(ctx, sessionID)
 		return nil, fmt.Errorf("session expired due to inactivity")
 	}
 
-	return &Session{
-		ID:            dbSession.ID,
-		UserID:        dbSession.UserID,
-		WalletAddress: dbSession.WalletAddress,
-		Role:          dbSession.Role,
-		CreatedAt:     dbSession.CreatedAt,
-		LastActiveAt:  dbSession.LastActiveAt,
-		IPAddress:     dbSession.IPAddress,
-		UserAgent:     dbSession.UserAgent,
-	}, nil
+	return toSession(dbSession), nil
 }
 
-// Touch 更新 Session 的最後活躍時間
-func (m *PostgresSessionManager) Touch(sessionID string) error {
-	ctx := context.Background()
-	return m.repo.Update(ctx, sessionID)
+// Touch 更新 Session 的最後活躍時間（滑動到期時一併延長到期時間，不超過絕對期限）
+func (m *PostgresSessionManager) Touch(ctx context.Context, sessionID string) error {
+	var expiresAt *time.Time
+	if m.policy.SlidingExpiry {
+		extended := time.Now().Add(m.policy.IdleTimeout)
+		expiresAt = &extended
+	}
+	return m.repo.Update(ctx, sessionID, expiresAt, m.policy.AbsoluteTimeout)
 }
 
 // Delete 刪除特定的 Session
-func (m *PostgresSessionManager) Delete(sessionID string) error {
-	ctx := context.Background()
+func (m *PostgresSessionManager) Delete(ctx context.Context, sessionID string) error {
 	return m.repo.Delete(ctx, sessionID)
 }
 
 // DeleteAllUserSessions 刪除特定錢包地址的所有 Session
-func (m *PostgresSessionManager) DeleteAllUserSessions(walletAddress string) error {
-	ctx := context.Background()
+func (m *PostgresSessionManager) DeleteAllUserSessions(ctx context.Context, walletAddress string) error {
 	return m.repo.DeleteByWalletAddress(ctx, walletAddress)
 }
 
 // GetUserSessions 取得特定錢包地址的所有 Session
-func (m *PostgresSessionManager) GetUserSessions(walletAddress string) ([]*Session, error) {
-	ctx := context.Background()
-
+func (m *PostgresSessionManager) GetUserSessions(ctx context.Context, walletAddress string) ([]*Session, error) {
 	dbSessions, err := m.repo.GetByWalletAddress(ctx, walletAddress)
 	if err != nil {
 		return nil, err
 	}
+	return m.activeSessions(dbSessions), nil
+}
 
-	sessions := make([]*Session, 0, len(dbSessions))
-	for _, dbSession := range dbSessions {
-		// 檢查閒置超時
-		if time.Since(dbSession.LastActiveAt) > m.idleTimeout {
-			continue
-		}
+// DeleteAllByUserID 刪除特定使用者所有錢包的 Session
+func (m *PostgresSessionManager) DeleteAllByUserID(ctx context.Context, userID int64) error {
+	return m.repo.DeleteByUserID(ctx, userID)
+}
 
-		sessions = append(sessions, &Session{
-			ID:            dbSession.ID,
-			UserID:        dbSession.UserID,
-			WalletAddress: dbSession.WalletAddress,
-			Role:          dbSession.Role,
-			CreatedAt:     dbSession.CreatedAt,
-			LastActiveAt:  dbSession.LastActiveAt,
-			IPAddress:     dbSession.IPAddress,
-			UserAgent:     dbSession.UserAgent,
-		})
+// GetUserSessionsByUserID 取得特定使用者所有錢包的 Session
+func (m *PostgresSessionManager) GetUserSessionsByUserID(ctx context.Context, userID int64) ([]*Session, error) {
+	dbSessions, err := m.repo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
 	}
+	return m.activeSessions(dbSessions), nil
+}
+
+// UpdateUserKYC 更新特定使用者所有 Session 的 KYC 狀態
+func (m *PostgresSessionManager) UpdateUserKYC(ctx context.Context, userID int64, kycStatus string, kycLevel int) error {
+	return m.repo.UpdateKYCByUserID(ctx, userID, kycStatus, kycLevel)
+}
+
+// UpdateUserRole 更新特定使用者所有 Session 的角色
+func (m *PostgresSessionManager) UpdateUserRole(ctx context.Context, userID int64, role string) error {
+	return m.repo.UpdateRoleByUserID(ctx, userID, role)
+}
 
-	return sessions, nil
+// UpdateUserRoles 更新特定使用者所有 Session 的額外角色
+func (m *PostgresSessionManager) UpdateUserRoles(ctx context.Context, userID int64, roles []string) error {
+	return m.repo.UpdateRolesByUserID(ctx, userID, roles)
+}
+
+// Policy 取得 Session 政策
+func (m *PostgresSessionManager) Policy() Policy {
+	return m.policy
 }
 
 // cleanup 定期清理過期的 Session
@@ -181,3 +183,34 @@ func (m *PostgresSessionManager) cleanup() {
 		}
 	}
 }
+
+// activeSessions 過濾掉閒置超時的 Session
+func (m *PostgresSessionManager) activeSessions(dbSessions []*models.DBSession) []*Session {
+	sessions := make([]*Session, 0, len(dbSessions))
+	for _, dbSession := range dbSessions {
+		// 檢查閒置超時
+		if time.Since(dbSession.LastActiveAt) > m.policy.IdleTimeout {
+			continue
+		}
+
+		sessions = append(sessions, toSession(dbSession))
+	}
+	return sessions
+}
+
+func toSession(dbSession *models.DBSession) *Session {
+	return &Session{
+		ID:            dbSession.ID,
+		UserID:        dbSession.UserID,
+		WalletAddress: dbSession.WalletAddress,
+		Role:          dbSession.Role,
+		Roles:         dbSession.Roles,
+		KYCStatus:     dbSession.KYCStatus,
+		KYCLevel:      dbSession.KYCLevel,
+		CreatedAt:     dbSession.CreatedAt,
+		LastActiveAt:  dbSession.LastActiveAt,
+		ExpiresAt:     dbSession.ExpiresAt,
+		IPAddress:     dbSession.IPAddress,
+		UserAgent:     dbSession.UserAgent,
+	}
+}
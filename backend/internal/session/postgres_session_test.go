@@ -0,0 +1,38 @@
+package session
+
+import (
+	"bluelink-backend/internal/repository"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestPostgresCreateEvictsByUser(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	manager := NewPostgresSessionManager(repository.NewSessionRepository(db),
+		Policy{AbsoluteTimeout: time.Hour, IdleTimeout: time.Hour, MaxDevices: 2, Eviction: EvictOldest})
+
+	// 裝置數以使用者計算（含綁定錢包），而非登入使用的錢包
+	mock.ExpectQuery(`SELECT COUNT\(\*\)\s+FROM sessions\s+WHERE user_id = \$1`).
+		WithArgs(int64(7)).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+	mock.ExpectExec(`DELETE FROM sessions\s+WHERE id = \(\s+SELECT id\s+FROM sessions\s+WHERE user_id = \$1`).
+		WithArgs(int64(7)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO sessions`).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	if _, err := manager.Create(context.Background(), 7, "0xdef", "buyer", nil, "approved", 1, "203.0.113.1", "test"); err != nil {
+		t.Fatal(err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,221 @@
+package session
+
+import (
+	"bluelink-backend/internal/repository"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+/*
+   Token 模式（SESSION_MODE=token）：
+   - Access token：HS256 簽章的 JWT，攜帶使用者 ID、錢包、角色、KYC 與 session ID，
+     驗證時不查資料庫；有效期短（ACCESS_TOKEN_TTL），撤銷 session 後最晚在到期時失效
+   - Refresh token：「session ID.隨機值」，僅在 sessions 表保存 SHA-256 雜湊，每次使用即輪替；
+     被替換的雜湊記錄於 rotated_refresh_tokens，出示已輪替過的舊 token 視為外洩重用，立即撤銷整個 session；
+     其他不相符的 token（偽造或猜測）僅回傳無效，不影響 session
+*/
+
+var (
+	ErrInvalidAccessToken  = errors.New("invalid access token")
+	ErrAccessTokenExpired  = errors.New("access token expired")
+	ErrInvalidRefreshToken = errors.New("invalid refresh token")
+	ErrRefreshTokenReused  = errors.New("refresh token reuse detected")
+)
+
+// accessTokenHeader 固定的 JWT header（僅接受 HS256）
+var accessTokenHeader = base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+
+// AccessClaims access token 攜帶的使用者資訊
+type AccessClaims struct {
+	UserID        int64    `json:"uid"`
+	WalletAddress string   `json:"wallet"`
+	Role          string   `json:"role"`
+	Roles         []string `json:"roles,omitempty"`
+	KYCStatus     string   `json:"kyc_status"`
+	KYCLevel      int      `json:"kyc_level"`
+	SessionID     string   `json:"sid"`
+	IssuedAt      int64    `json:"iat"`
+	ExpiresAt     int64    `json:"exp"`
+}
+
+// TokenPair 登入或刷新後發給客戶端的 token
+type TokenPair struct {
+	AccessToken  string    `json:"access_token"`
+	RefreshToken string    `json:"refresh_token"`
+	TokenType    string    `json:"token_type"`
+	ExpiresAt    time.Time `json:"expires_at"`         // access token 到期時間
+	RefreshUntil time.Time `json:"refresh_expires_at"` // refresh token（session）到期時間
+}
+
+// TokenManager 簽發與驗證 access token，並管理 refresh token 輪替
+// refresh token 的有效期與滑動到期沿用 SessionManager 的政策
+type TokenManager struct {
+	sessions  SessionManager
+	repo      *repository.SessionRepository
+	secret    []byte
+	accessTTL time.Duration
+}
+
+// NewTokenManager 建立新的 TokenManager
+func NewTokenManager(sessions SessionManager, repo *repository.SessionRepository, secret string, accessTTL time.Duration) *TokenManager {
+	return &TokenManager{
+		sessions:  sessions,
+		repo:      repo,
+		secret:    []byte(secret),
+		accessTTL: accessTTL,
+	}
+}
+
+// Issue 為剛建立的 session 簽發 access token 與第一個 refresh token
+func (m *TokenManager) Issue(ctx context.Context, sess *Session) (*TokenPair, error) {
+	refreshToken, refreshHash, err := newRefreshToken(sess.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshUntil := sess.ExpiresAt
+	if err := m.repo.SetRefreshToken(ctx, sess.ID, refreshHash, refreshUntil); err != nil {
+		return nil, err
+	}
+
+	return m.pair(sess, refreshToken, refreshUntil)
+}
+
+// Refresh 以 refresh token 換發新的 token，並輪替 refresh token
+// 出示已輪替過的 token（外洩重用或並發重複使用）時撤銷整個 session
+func (m *TokenManager) Refresh(ctx context.Context, refreshToken string) (*TokenPair, *Session, error) {
+	sessionID, _, ok := strings.Cut(refreshToken, ".")
+	if !ok || sessionID == "" {
+		return nil, nil, ErrInvalidRefreshToken
+	}
+
+	// 透過 SessionManager 取得，一併檢查到期與閒置逾時
+	sess, err := m.sessions.Get(ctx, sessionID)
+	if err != nil || sess == nil {
+		return nil, nil, ErrInvalidRefreshToken
+	}
+
+	newToken, newHash, err := newRefreshToken(sessionID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var extendTo *time.Time
+	if policy := m.sessions.Policy(); policy.SlidingExpiry {
+		extended := policy.ExpiresAt(sess.CreatedAt, time.Now())
+		extendTo = &extended
+	}
+
+	currentHash := hashRefreshToken(refreshToken)
+	rotated, refreshUntil, err := m.repo.RotateRefreshToken(ctx, sessionID, currentHash, newHash, extendTo)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !rotated {
+		// 僅在出示的 token 確實曾發給此 session 時才視為重用；否則只是無效的 token
+		reused, err := m.repo.IsRotatedRefreshToken(ctx, sessionID, currentHash)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !reused {
+			return nil, nil, ErrInvalidRefreshToken
+		}
+		if err := m.sessions.Delete(ctx, sessionID); err != nil {
+			return nil, nil, fmt.Errorf("failed to revoke session after refresh token reuse: %w", err)
+		}
+		return nil, sess, ErrRefreshTokenReused
+	}
+
+	pair, err := m.pair(sess, newToken, refreshUntil)
+	if err != nil {
+		return nil, nil, err
+	}
+	return pair, sess, nil
+}
+
+// ParseAccessToken 驗證 access token 的簽章與到期時間（不查資料庫）
+func (m *TokenManager) ParseAccessToken(token string) (*AccessClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 || parts[0] != accessTokenHeader {
+		return nil, ErrInvalidAccessToken
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil || !hmac.Equal(signature, m.sign(parts[0]+"."+parts[1])) {
+		return nil, ErrInvalidAccessToken
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, ErrInvalidAccessToken
+	}
+	var claims AccessClaims
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.SessionID == "" {
+		return nil, ErrInvalidAccessToken
+	}
+
+	if time.Now().Unix() >= claims.ExpiresAt {
+		return nil, ErrAccessTokenExpired
+	}
+	return &claims, nil
+}
+
+// pair 以 session 目前的角色與 KYC 狀態簽發 access token
+func (m *TokenManager) pair(sess *Session, refreshToken string, refreshUntil time.Time) (*TokenPair, error) {
+	now := time.Now()
+	expiresAt := now.Add(m.accessTTL)
+
+	payload, err := json.Marshal(AccessClaims{
+		UserID:        sess.UserID,
+		WalletAddress: sess.WalletAddress,
+		Role:          sess.Role,
+		Roles:         sess.Roles,
+		KYCStatus:     sess.KYCStatus,
+		KYCLevel:      sess.KYCLevel,
+		SessionID:     sess.ID,
+		IssuedAt:      now.Unix(),
+		ExpiresAt:     expiresAt.Unix(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode access token: %w", err)
+	}
+
+	unsigned := accessTokenHeader + "." + base64.RawURLEncoding.EncodeToString(payload)
+	return &TokenPair{
+		AccessToken:  unsigned + "." + base64.RawURLEncoding.EncodeToString(m.sign(unsigned)),
+		RefreshToken: refreshToken,
+		TokenType:    "Bearer",
+		ExpiresAt:    expiresAt,
+		RefreshUntil: refreshUntil,
+	}, nil
+}
+
+func (m *TokenManager) sign(data string) []byte {
+	mac := hmac.New(sha256.New, m.secret)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// newRefreshToken 產生 refresh token 與其雜湊
+func newRefreshToken(sessionID string) (string, string, error) {
+	random := make([]byte, 32)
+	if _, err := rand.Read(random); err != nil {
+		return "", "", fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+	token := sessionID + "." + base64.RawURLEncoding.EncodeToString(random)
+	return token, hashRefreshToken(token), nil
+}
+
+func hashRefreshToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
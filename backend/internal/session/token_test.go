@@ -0,0 +1,98 @@
+package session
+
+import (
+	"bluelink-backend/internal/repository"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func newTokenTestManager(t *testing.T) (*TokenManager, *MemorySessionManager, *Session, sqlmock.Sqlmock) {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	sessions := NewMemorySessionManager(Policy{AbsoluteTimeout: time.Hour, IdleTimeout: time.Hour, MaxDevices: 5})
+	sess, err := sessions.Create(context.Background(), 7, "0xabc", "buyer", nil, "approved", 1, "203.0.113.1", "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return NewTokenManager(sessions, repository.NewSessionRepository(db), "secret", time.Minute), sessions, sess, mock
+}
+
+// expectRotationMiss 目前的雜湊不相符，輪替失敗
+func expectRotationMiss(mock sqlmock.Sqlmock) {
+	mock.ExpectBegin()
+	mock.ExpectQuery(`UPDATE sessions`).WillReturnRows(sqlmock.NewRows([]string{"expires_at"}))
+	mock.ExpectRollback()
+}
+
+func TestRefreshUnknownTokenKeepsSession(t *testing.T) {
+	manager, sessions, sess, mock := newTokenTestManager(t)
+
+	expectRotationMiss(mock)
+	mock.ExpectQuery(`FROM rotated_refresh_tokens`).
+		WithArgs(hashRefreshToken(sess.ID+".forged"), sess.ID).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+
+	_, _, err := manager.Refresh(context.Background(), sess.ID+".forged")
+	if !errors.Is(err, ErrInvalidRefreshToken) {
+		t.Fatalf("Refresh() error = %v, want ErrInvalidRefreshToken", err)
+	}
+	if _, err := sessions.Get(context.Background(), sess.ID); err != nil {
+		t.Fatalf("session should not be revoked by an unknown token: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRefreshRotatedTokenRevokesSession(t *testing.T) {
+	manager, sessions, sess, mock := newTokenTestManager(t)
+
+	expectRotationMiss(mock)
+	mock.ExpectQuery(`FROM rotated_refresh_tokens`).
+		WithArgs(hashRefreshToken(sess.ID+".old"), sess.ID).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+
+	_, _, err := manager.Refresh(context.Background(), sess.ID+".old")
+	if !errors.Is(err, ErrRefreshTokenReused) {
+		t.Fatalf("Refresh() error = %v, want ErrRefreshTokenReused", err)
+	}
+	if _, err := sessions.Get(context.Background(), sess.ID); err == nil {
+		t.Fatal("session should be revoked after refresh token reuse")
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRefreshRecordsRotatedToken(t *testing.T) {
+	manager, _, sess, mock := newTokenTestManager(t)
+	current := sess.ID + ".current"
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`UPDATE sessions`).WillReturnRows(sqlmock.NewRows([]string{"expires_at"}).AddRow(sess.ExpiresAt))
+	mock.ExpectExec(`INSERT INTO rotated_refresh_tokens`).
+		WithArgs(hashRefreshToken(current), sess.ID, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	pair, _, err := manager.Refresh(context.Background(), current)
+	if err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+	if pair.RefreshToken == current {
+		t.Fatal("refresh token should be rotated")
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,180 @@
+package siws
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+/*
+   Sign-In with Sui 登入訊息（參考 EIP-4361），版本 1 格式：
+
+   {domain} wants you to sign in with your Sui account:
+   {address}
+
+   {statement}
+
+   URI: {uri}
+   Version: 1
+   Chain ID: sui:{network}
+   Nonce: {nonce}
+   Issued At: {RFC3339}
+   Expiration Time: {RFC3339}
+   Request ID: {request_id}
+
+   訊息綁定網域、URI、網路與有效期限，仿冒網站取得的簽名無法在本服務使用
+   格式變更時遞增 Version，舊版本在客戶端遷移完成前仍可保留於 SupportedVersions
+*/
+
+// Version1 目前的訊息格式版本
+const Version1 = "1"
+
+// SupportedVersions 伺服器接受的訊息格式版本
+var SupportedVersions = []string{Version1}
+
+const (
+	headerSuffix = " wants you to sign in with your Sui account:"
+	chainPrefix  = "sui:"
+)
+
+var (
+	ErrMalformedMessage   = errors.New("malformed sign-in message")
+	ErrUnsupportedVersion = errors.New("unsupported sign-in message version")
+	ErrFieldMismatch      = errors.New("sign-in message field mismatch")
+	ErrMessageExpired     = errors.New("sign-in message has expired")
+	ErrMessageNotYetValid = errors.New("sign-in message issued in the future")
+)
+
+// Message 結構化的登入訊息
+type Message struct {
+	Domain         string
+	Address        string
+	Statement      string
+	URI            string
+	Version        string
+	ChainID        string
+	Nonce          string
+	IssuedAt       time.Time
+	ExpirationTime time.Time
+	RequestID      string // 可為空
+}
+
+// String 產生要簽署的訊息文字
+func (m *Message) String() string {
+	var b strings.Builder
+	b.WriteString(m.Domain + headerSuffix + "\n")
+	b.WriteString(m.Address + "\n\n")
+	b.WriteString(m.Statement + "\n\n")
+	b.WriteString("URI: " + m.URI + "\n")
+	b.WriteString("Version: " + m.Version + "\n")
+	b.WriteString("Chain ID: " + m.ChainID + "\n")
+	b.WriteString("Nonce: " + m.Nonce + "\n")
+	b.WriteString("Issued At: " + m.IssuedAt.UTC().Format(time.RFC3339) + "\n")
+	b.WriteString("Expiration Time: " + m.ExpirationTime.UTC().Format(time.RFC3339))
+	if m.RequestID != "" {
+		b.WriteString("\nRequest ID: " + m.RequestID)
+	}
+	return b.String()
+}
+
+// Parse 解析登入訊息；先取得 Version 再依該版本的格式嚴格解析
+func Parse(text string) (*Message, error) {
+	lines := strings.Split(text, "\n")
+
+	version := ""
+	for _, line := range lines {
+		if value, ok := strings.CutPrefix(line, "Version: "); ok {
+			version = value
+			break
+		}
+	}
+
+	switch version {
+	case "":
+		return nil, fmt.Errorf("%w: missing Version", ErrMalformedMessage)
+	case Version1:
+		return parseV1(lines)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedVersion, version)
+	}
+}
+
+// parseV1 依版本 1 的固定行序解析，不接受多餘或缺少的欄位
+func parseV1(lines []string) (*Message, error) {
+	if len(lines) != 11 && len(lines) != 12 {
+		return nil, fmt.Errorf("%w: unexpected number of lines", ErrMalformedMessage)
+	}
+
+	m := &Message{}
+
+	domain, ok := strings.CutSuffix(lines[0], headerSuffix)
+	if !ok || domain == "" {
+		return nil, fmt.Errorf("%w: invalid header", ErrMalformedMessage)
+	}
+	m.Domain = domain
+
+	m.Address = lines[1]
+	if m.Address == "" || lines[2] != "" || lines[3] == "" || lines[4] != "" {
+		return nil, fmt.Errorf("%w: invalid address or statement", ErrMalformedMessage)
+	}
+	m.Statement = lines[3]
+
+	fields := []struct {
+		key   string
+		value *string
+	}{
+		{"URI", &m.URI},
+		{"Version", &m.Version},
+		{"Chain ID", &m.ChainID},
+		{"Nonce", &m.Nonce},
+	}
+	for i, field := range fields {
+		value, err := fieldValue(lines[5+i], field.key)
+		if err != nil {
+			return nil, err
+		}
+		*field.value = value
+	}
+
+	var err error
+	if m.IssuedAt, err = timeField(lines[9], "Issued At"); err != nil {
+		return nil, err
+	}
+	if m.ExpirationTime, err = timeField(lines[10], "Expiration Time"); err != nil {
+		return nil, err
+	}
+
+	if len(lines) == 12 {
+		if m.RequestID, err = fieldValue(lines[11], "Request ID"); err != nil {
+			return nil, err
+		}
+	}
+
+	return m, nil
+}
+
+func fieldValue(line, key string) (string, error) {
+	value, ok := strings.CutPrefix(line, key+": ")
+	if !ok || value == "" {
+		return "", fmt.Errorf("%w: expected %s", ErrMalformedMessage, key)
+	}
+	return value, nil
+}
+
+func timeField(line, key string) (time.Time, error) {
+	value, err := fieldValue(line, key)
+	if err != nil {
+		return time.Time{}, err
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: invalid %s", ErrMalformedMessage, key)
+	}
+	return t, nil
+}
+
+// ChainID 將 Sui 網路名稱（testnet / mainnet ...）轉為訊息中的 Chain ID
+func ChainID(network string) string {
+	return chainPrefix + network
+}
@@ -0,0 +1,83 @@
+package siws
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+// DefaultStatement 登入訊息的說明文字
+const DefaultStatement = "Sign in to BlueLink"
+
+// clockSkew 容許客戶端與伺服器之間的時鐘誤差
+const clockSkew = time.Minute
+
+// Config 伺服器端預期的訊息欄位，產生與驗證訊息皆以此為準
+type Config struct {
+	Domain    string        // 例如 app.bluelink.io
+	URI       string        // 例如 https://app.bluelink.io
+	ChainID   string        // 例如 sui:testnet
+	Statement string        // 空字串時使用 DefaultStatement
+	TTL       time.Duration // 訊息有效期限
+}
+
+// NewMessage 以目前版本產生登入訊息
+func (c Config) NewMessage(address, nonce, requestID string, now time.Time) *Message {
+	issuedAt := now.UTC().Truncate(time.Second)
+	return &Message{
+		Domain:         c.Domain,
+		Address:        address,
+		Statement:      c.statement(),
+		URI:            c.URI,
+		Version:        Version1,
+		ChainID:        c.ChainID,
+		Nonce:          nonce,
+		IssuedAt:       issuedAt,
+		ExpirationTime: issuedAt.Add(c.TTL),
+		RequestID:      requestID,
+	}
+}
+
+// Validate 逐一檢查訊息欄位是否符合伺服器設定、指定的地址與 nonce，且仍在有效期限內
+func (c Config) Validate(m *Message, address, nonce string, now time.Time) error {
+	if !slices.Contains(SupportedVersions, m.Version) {
+		return fmt.Errorf("%w: %s", ErrUnsupportedVersion, m.Version)
+	}
+
+	checks := []struct {
+		field    string
+		got      string
+		expected string
+	}{
+		{"domain", m.Domain, c.Domain},
+		{"uri", m.URI, c.URI},
+		{"chain id", m.ChainID, c.ChainID},
+		{"statement", m.Statement, c.statement()},
+		{"address", m.Address, address},
+		{"nonce", m.Nonce, nonce},
+	}
+	for _, check := range checks {
+		if check.got != check.expected {
+			return fmt.Errorf("%w: %s %q", ErrFieldMismatch, check.field, check.got)
+		}
+	}
+
+	if !m.ExpirationTime.After(m.IssuedAt) || m.ExpirationTime.Sub(m.IssuedAt) > c.TTL {
+		return fmt.Errorf("%w: invalid validity period", ErrFieldMismatch)
+	}
+	if m.IssuedAt.After(now.Add(clockSkew)) {
+		return ErrMessageNotYetValid
+	}
+	if !now.Before(m.ExpirationTime) {
+		return ErrMessageExpired
+	}
+
+	return nil
+}
+
+func (c Config) statement() string {
+	if c.Statement == "" {
+		return DefaultStatement
+	}
+	return c.Statement
+}
@@ -0,0 +1,37 @@
+package storage
+
+import (
+	"bluelink-backend/internal/config"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ErrBlobNotFound 指定的物件不存在
+var ErrBlobNotFound = errors.New("blob not found")
+
+// BlobStore 定義檔案儲存介面（KYC 文件等不適合存入資料庫的內容）
+type BlobStore interface {
+	// Put 寫入物件，key 相同時覆寫
+	Put(ctx context.Context, key string, r io.Reader) error
+
+	// Get 讀取物件，呼叫端負責關閉
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+
+	// Delete 刪除物件，不存在時不回傳錯誤
+	Delete(ctx context.Context, key string) error
+}
+
+// NewBlobStore 依設定建立檔案儲存
+func NewBlobStore(cfg *config.Config) (BlobStore, error) {
+	switch cfg.BlobStore {
+	case "", "local":
+		if cfg.BlobStoreDir == "" {
+			return nil, fmt.Errorf("BLOB_STORE_DIR is required for the local blob store")
+		}
+		return NewLocalBlobStore(cfg.BlobStoreDir)
+	default:
+		return nil, fmt.Errorf("unknown blob store: %s", cfg.BlobStore)
+	}
+}
@@ -0,0 +1,258 @@
+package suisig
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/block-vision/sui-go-sdk/mystenbcs"
+)
+
+/*
+   MultiSig 序列化格式（BCS）：
+
+   MultiSig {
+       sigs:        Vec<CompressedSignature>  // enum { Ed25519([u8;64]), Secp256k1([u8;64]), Secp256r1([u8;64]) }
+       bitmap:      u16                       // 第 i 位為 1 表示 pk_map[i] 有簽名，sigs 依索引遞增排列
+       multisig_pk: MultiSigPublicKey {
+           pk_map:    Vec<(PublicKey, u8)>    // enum { Ed25519([u8;32]), Secp256k1([u8;33]), Secp256r1([u8;33]) }, 權重
+           threshold: u16
+       }
+   }
+
+   多簽地址：blake2b256(0x03 || threshold(u16 LE) || 每個公鑰的 flag || public key || weight)
+   ZkLogin / Passkey 成員目前不支援
+*/
+
+// maxMultiSigKeys 多簽公鑰數量上限（與 Sui 協議一致）
+const maxMultiSigKeys = 10
+
+// WeightedPublicKey 多簽成員公鑰與權重
+type WeightedPublicKey struct {
+	PublicKey
+	Weight uint8
+}
+
+// MultiSigPublicKey 多簽公鑰：成員公鑰、權重與門檻
+type MultiSigPublicKey struct {
+	Keys      []WeightedPublicKey
+	Threshold uint16
+}
+
+// Address 推導多簽地址
+func (m *MultiSigPublicKey) Address() string {
+	data := []byte{FlagMultiSig}
+	data = binary.LittleEndian.AppendUint16(data, m.Threshold)
+	for _, key := range m.Keys {
+		data = append(data, key.Flag)
+		data = append(data, key.Bytes...)
+		data = append(data, key.Weight)
+	}
+	return addressOf(data)
+}
+
+// validate 檢查成員數量、權重與門檻是否有效，且公鑰不重複
+func (m *MultiSigPublicKey) validate() error {
+	if len(m.Keys) == 0 || len(m.Keys) > maxMultiSigKeys {
+		return fmt.Errorf("%w: multisig must have 1 to %d keys", ErrMalformedSignature, maxMultiSigKeys)
+	}
+	if m.Threshold == 0 {
+		return fmt.Errorf("%w: multisig threshold must be positive", ErrMalformedSignature)
+	}
+
+	totalWeight := 0
+	seen := make(map[string]bool, len(m.Keys))
+	for _, key := range m.Keys {
+		if err := key.PublicKey.validate(); err != nil {
+			return err
+		}
+		if key.Weight == 0 {
+			return fmt.Errorf("%w: multisig weight must be positive", ErrMalformedSignature)
+		}
+		id := string(append([]byte{key.Flag}, key.Bytes...))
+		if seen[id] {
+			return fmt.Errorf("%w: duplicate multisig public key", ErrMalformedSignature)
+		}
+		seen[id] = true
+		totalWeight += int(key.Weight)
+	}
+
+	if int(m.Threshold) > totalWeight {
+		return fmt.Errorf("%w: multisig threshold exceeds total weight", ErrMalformedSignature)
+	}
+	return nil
+}
+
+// CompressedSignature 多簽成員的簽名（不含公鑰）
+type CompressedSignature struct {
+	Flag      byte
+	Signature []byte
+}
+
+// MultiSig 多簽簽名
+type MultiSig struct {
+	Signatures []CompressedSignature
+	Bitmap     uint16
+	PublicKey  MultiSigPublicKey
+}
+
+// Verify 驗證每個成員簽名，並確認簽名成員的權重總和達到門檻
+func (m *MultiSig) Verify(digest []byte) error {
+	if err := m.PublicKey.validate(); err != nil {
+		return err
+	}
+
+	// 依 bitmap 找出每個簽名對應的成員公鑰
+	var signers []int
+	for i := 0; i < 16; i++ {
+		if m.Bitmap&(1<<i) == 0 {
+			continue
+		}
+		if i >= len(m.PublicKey.Keys) {
+			return fmt.Errorf("%w: multisig bitmap out of range", ErrMalformedSignature)
+		}
+		signers = append(signers, i)
+	}
+	if len(signers) == 0 || len(signers) != len(m.Signatures) {
+		return fmt.Errorf("%w: multisig bitmap does not match signatures", ErrMalformedSignature)
+	}
+
+	weight := 0
+	for i, index := range signers {
+		key := m.PublicKey.Keys[index]
+		signature := m.Signatures[i]
+		if signature.Flag != key.Flag {
+			return fmt.Errorf("%w: multisig signature %d scheme mismatch", ErrInvalidSignature, i)
+		}
+		if err := key.PublicKey.verify(digest, signature.Signature); err != nil {
+			return fmt.Errorf("multisig signature %d: %w", i, err)
+		}
+		weight += int(key.Weight)
+	}
+
+	if weight < int(m.PublicKey.Threshold) {
+		return fmt.Errorf("%w: weight %d < threshold %d", ErrThresholdNotMet, weight, m.PublicKey.Threshold)
+	}
+	return nil
+}
+
+// Serialize 編碼為序列化簽名（含 0x03 旗標，未經 Base64）
+func (m *MultiSig) Serialize() []byte {
+	data := []byte{FlagMultiSig}
+
+	data = append(data, mystenbcs.ULEB128Encode(len(m.Signatures))...)
+	for _, signature := range m.Signatures {
+		data = append(data, signature.Flag)
+		data = append(data, signature.Signature...)
+	}
+	data = binary.LittleEndian.AppendUint16(data, m.Bitmap)
+
+	data = append(data, mystenbcs.ULEB128Encode(len(m.PublicKey.Keys))...)
+	for _, key := range m.PublicKey.Keys {
+		data = append(data, key.Flag)
+		data = append(data, key.Bytes...)
+		data = append(data, key.Weight)
+	}
+	return binary.LittleEndian.AppendUint16(data, m.PublicKey.Threshold)
+}
+
+// ParseMultiSig 解析 BCS 編碼的多簽簽名（不含 0x03 旗標）
+func ParseMultiSig(data []byte) (*MultiSig, error) {
+	r := bytes.NewReader(data)
+	m := &MultiSig{}
+
+	count, err := readLength(r, maxMultiSigKeys)
+	if err != nil {
+		return nil, err
+	}
+	for i := 0; i < count; i++ {
+		flag, err := readFlag(r)
+		if err != nil {
+			return nil, err
+		}
+		signature, err := readBytes(r, signatureSize)
+		if err != nil {
+			return nil, err
+		}
+		m.Signatures = append(m.Signatures, CompressedSignature{Flag: flag, Signature: signature})
+	}
+
+	if m.Bitmap, err = readUint16(r); err != nil {
+		return nil, err
+	}
+
+	if count, err = readLength(r, maxMultiSigKeys); err != nil {
+		return nil, err
+	}
+	for i := 0; i < count; i++ {
+		flag, err := readFlag(r)
+		if err != nil {
+			return nil, err
+		}
+		size := ecdsaPublicKeySize
+		if flag == FlagEd25519 {
+			size = ed25519PublicKeySize
+		}
+		publicKey, err := readBytes(r, size)
+		if err != nil {
+			return nil, err
+		}
+		weight, err := r.ReadByte()
+		if err != nil {
+			return nil, fmt.Errorf("%w: truncated multisig", ErrMalformedSignature)
+		}
+		m.PublicKey.Keys = append(m.PublicKey.Keys, WeightedPublicKey{
+			PublicKey: PublicKey{Flag: flag, Bytes: publicKey},
+			Weight:    weight,
+		})
+	}
+
+	if m.PublicKey.Threshold, err = readUint16(r); err != nil {
+		return nil, err
+	}
+	if r.Len() != 0 {
+		return nil, fmt.Errorf("%w: trailing bytes after multisig", ErrMalformedSignature)
+	}
+
+	return m, nil
+}
+
+func readLength(r *bytes.Reader, max int) (int, error) {
+	length, _, err := mystenbcs.ULEB128Decode[int](r)
+	if err != nil {
+		return 0, fmt.Errorf("%w: truncated multisig", ErrMalformedSignature)
+	}
+	if length < 0 || length > max {
+		return 0, fmt.Errorf("%w: multisig vector too long", ErrMalformedSignature)
+	}
+	return length, nil
+}
+
+// readFlag 讀取 enum 變體（即簽名方案旗標），僅接受單一金鑰方案
+func readFlag(r *bytes.Reader) (byte, error) {
+	flag, err := r.ReadByte()
+	if err != nil {
+		return 0, fmt.Errorf("%w: truncated multisig", ErrMalformedSignature)
+	}
+	if flag != FlagEd25519 && flag != FlagSecp256k1 && flag != FlagSecp256r1 {
+		return 0, fmt.Errorf("%w: multisig member flag 0x%02x", ErrUnsupportedScheme, flag)
+	}
+	return flag, nil
+}
+
+func readBytes(r *bytes.Reader, size int) ([]byte, error) {
+	buf := make([]byte, size)
+	if _, err := io.ReadFull(r, buf); err != nil {
+		return nil, fmt.Errorf("%w: truncated multisig", ErrMalformedSignature)
+	}
+	return buf, nil
+}
+
+func readUint16(r *bytes.Reader) (uint16, error) {
+	buf, err := readBytes(r, 2)
+	if err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint16(buf), nil
+}
@@ -0,0 +1,207 @@
+package suisig
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"testing"
+)
+
+// newTestMultiSig 以指定成員（bitmap 索引）簽署 testMessage
+func newTestMultiSig(signers []*testSigner, weights []uint8, threshold uint16, signing ...int) *MultiSig {
+	m := &MultiSig{PublicKey: MultiSigPublicKey{Threshold: threshold}}
+	for i, signer := range signers {
+		m.PublicKey.Keys = append(m.PublicKey.Keys, WeightedPublicKey{PublicKey: signer.publicKey, Weight: weights[i]})
+	}
+
+	digest := PersonalMessageDigest(testMessage)
+	for _, index := range signing {
+		m.Bitmap |= 1 << index
+		m.Signatures = append(m.Signatures, CompressedSignature{
+			Flag:      signers[index].publicKey.Flag,
+			Signature: signers[index].sign(digest),
+		})
+	}
+	return m
+}
+
+func newTestSigners(t *testing.T) []*testSigner {
+	return []*testSigner{newEd25519Signer(t), newSecp256k1Signer(t), newSecp256r1Signer(t)}
+}
+
+// knownAnswerMultiSig 2-of-3 多簽（權重皆為 1，成員依序為 knownAnswerVectors 的 Ed25519、Secp256k1、Secp256r1 金鑰），
+// 由 Ed25519 與 Secp256r1 成員簽署 knownAnswerMessage；BCS 序列化與地址以 Python 獨立計算
+const (
+	knownAnswerMultiSig        = "AwIA4buapJYLzKNfHnK6ILRbDg5PEIDXO9mDTSlG50ez+smd5utx1fMO1xaVkuBpO/em4pZHzKi38cFowhhBU4DKAAIATcG3ksV/wM3ndgPSyKriz5D+kS0AkeunBG/SlEKGhkOkamGFtID8JfjgBTevFcKRuhBNOYxbqtgrgp0qwNcXBQADALnG7hYw7z5xEUSmSNsGu7IoT3J0z77lP/zuUDzBpJIAAQEDLIwx/J+ZDGtV44ZaGEpM5Q4JSB8urrPmDsHOoTpq5kUBAgNg/tS6JVqdMclh63TGNW1owEm4kjth+mzmaWIuYPKftgECAA=="
+	knownAnswerMultiSigAddress = "0xb76d6e786cf1120458cef6e7ef844f2e264ce3164a22300ffe456313648bb75b"
+)
+
+func TestMultiSigKnownAnswer(t *testing.T) {
+	address, err := VerifyPersonalMessage([]byte(knownAnswerMessage), knownAnswerMultiSig)
+	if err != nil {
+		t.Fatalf("verify: %v", err)
+	}
+	if address != knownAnswerMultiSigAddress {
+		t.Fatalf("address %s, expected %s", address, knownAnswerMultiSigAddress)
+	}
+
+	raw, err := base64.StdEncoding.DecodeString(knownAnswerMultiSig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed, err := ParseMultiSig(raw[1:])
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if parsed.PublicKey.Threshold != 2 || len(parsed.PublicKey.Keys) != 3 || parsed.Bitmap != 1<<0|1<<2 {
+		t.Fatalf("unexpected parsed multisig: %+v", parsed)
+	}
+	// 成員地址與單一金鑰向量一致
+	for i, key := range parsed.PublicKey.Keys {
+		if got, want := key.PublicKey.Address(), knownAnswerVectors[i+1].address; got != want {
+			t.Fatalf("member %d address %s, expected %s", i, got, want)
+		}
+	}
+	// 序列化結果與原始位元組一致
+	if !bytes.Equal(parsed.Serialize(), raw) {
+		t.Fatal("re-serialized multisig should match the vector")
+	}
+
+	if _, err := VerifyPersonalMessage([]byte("another message"), knownAnswerMultiSig); !errors.Is(err, ErrInvalidSignature) {
+		t.Fatalf("multisig over another message should be invalid, got %v", err)
+	}
+}
+
+func TestVerifyPersonalMessageMultiSig(t *testing.T) {
+	signers := newTestSigners(t)
+
+	cases := []struct {
+		name    string
+		signing []int
+		wantErr error
+	}{
+		{"ed25519 and secp256k1", []int{0, 1}, nil},
+		{"secp256k1 and secp256r1", []int{1, 2}, nil},
+		{"all members", []int{0, 1, 2}, nil},
+		{"single member below threshold", []int{1}, ErrThresholdNotMet},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			m := newTestMultiSig(signers, []uint8{1, 1, 1}, 2, tc.signing...)
+			signature := base64.StdEncoding.EncodeToString(m.Serialize())
+
+			address, err := VerifyPersonalMessage(testMessage, signature)
+			if tc.wantErr != nil {
+				if !errors.Is(err, tc.wantErr) {
+					t.Fatalf("expected %v, got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("verify: %v", err)
+			}
+			if address != m.PublicKey.Address() {
+				t.Fatalf("address %s, expected multisig address %s", address, m.PublicKey.Address())
+			}
+		})
+	}
+}
+
+func TestVerifyMultiSigWeights(t *testing.T) {
+	signers := newTestSigners(t)
+	digest := PersonalMessageDigest(testMessage)
+
+	// 權重 3 的 Secp256k1 成員可單獨達到門檻
+	if err := newTestMultiSig(signers, []uint8{1, 3, 1}, 3, 1).Verify(digest); err != nil {
+		t.Fatalf("heavy member alone should meet threshold: %v", err)
+	}
+
+	// 兩個權重 1 的成員未達門檻 3
+	err := newTestMultiSig(signers, []uint8{1, 3, 1}, 3, 0, 2).Verify(digest)
+	if !errors.Is(err, ErrThresholdNotMet) {
+		t.Fatalf("expected threshold error, got %v", err)
+	}
+}
+
+func TestVerifyMultiSigRejectsInvalid(t *testing.T) {
+	signers := newTestSigners(t)
+	digest := PersonalMessageDigest(testMessage)
+
+	t.Run("tampered signature", func(t *testing.T) {
+		m := newTestMultiSig(signers, []uint8{1, 1, 1}, 2, 0, 1)
+		m.Signatures[1].Signature[5] ^= 0xff
+		if err := m.Verify(digest); !errors.Is(err, ErrInvalidSignature) {
+			t.Fatalf("expected invalid signature, got %v", err)
+		}
+	})
+
+	t.Run("bitmap does not match signatures", func(t *testing.T) {
+		m := newTestMultiSig(signers, []uint8{1, 1, 1}, 2, 0, 1)
+		m.Bitmap = 1<<0 | 1<<2
+		if err := m.Verify(digest); err == nil {
+			t.Fatal("signature mapped to the wrong member should fail")
+		}
+	})
+
+	t.Run("bitmap out of range", func(t *testing.T) {
+		m := newTestMultiSig(signers, []uint8{1, 1, 1}, 2, 0, 1)
+		m.Bitmap = 1<<0 | 1<<5
+		if err := m.Verify(digest); !errors.Is(err, ErrMalformedSignature) {
+			t.Fatalf("expected malformed signature, got %v", err)
+		}
+	})
+
+	t.Run("duplicate signature", func(t *testing.T) {
+		m := newTestMultiSig(signers, []uint8{1, 1, 1}, 2, 0)
+		m.Signatures = append(m.Signatures, m.Signatures[0])
+		if err := m.Verify(digest); !errors.Is(err, ErrMalformedSignature) {
+			t.Fatalf("expected malformed signature, got %v", err)
+		}
+	})
+
+	t.Run("threshold exceeds total weight", func(t *testing.T) {
+		m := newTestMultiSig(signers, []uint8{1, 1, 1}, 4, 0, 1, 2)
+		if err := m.Verify(digest); !errors.Is(err, ErrMalformedSignature) {
+			t.Fatalf("expected malformed signature, got %v", err)
+		}
+	})
+
+	t.Run("duplicate public key", func(t *testing.T) {
+		m := newTestMultiSig([]*testSigner{signers[0], signers[0]}, []uint8{1, 1}, 2, 0, 1)
+		if err := m.Verify(digest); !errors.Is(err, ErrMalformedSignature) {
+			t.Fatalf("expected malformed signature, got %v", err)
+		}
+	})
+}
+
+func TestParseMultiSig(t *testing.T) {
+	signers := newTestSigners(t)
+	m := newTestMultiSig(signers, []uint8{1, 2, 3}, 3, 0, 2)
+	serialized := m.Serialize()
+
+	parsed, err := ParseMultiSig(serialized[1:])
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if parsed.Bitmap != m.Bitmap || parsed.PublicKey.Threshold != 3 || len(parsed.Signatures) != 2 {
+		t.Fatalf("unexpected parsed multisig: %+v", parsed)
+	}
+	if parsed.PublicKey.Address() != m.PublicKey.Address() {
+		t.Fatal("parsed multisig should derive the same address")
+	}
+
+	if _, err := ParseMultiSig(serialized[1 : len(serialized)-1]); !errors.Is(err, ErrMalformedSignature) {
+		t.Fatalf("truncated multisig should be malformed, got %v", err)
+	}
+	if _, err := ParseMultiSig(append(serialized[1:], 0)); !errors.Is(err, ErrMalformedSignature) {
+		t.Fatalf("trailing bytes should be malformed, got %v", err)
+	}
+
+	// zkLogin 成員不支援
+	unsupported := append([]byte{}, serialized[1:]...)
+	unsupported[1] = 0x05
+	if _, err := ParseMultiSig(unsupported); !errors.Is(err, ErrUnsupportedScheme) {
+		t.Fatalf("unsupported member scheme should fail, got %v", err)
+	}
+}
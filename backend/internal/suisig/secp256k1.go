@@ -0,0 +1,23 @@
+package suisig
+
+import (
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+)
+
+// verifySecp256k1 驗證 ECDSA 簽名（r||s，僅接受 low-s），hash 為 32 bytes 訊息雜湊
+// 曲線運算使用 dcrd 的 secp256k1 實作（標準函式庫未提供此曲線）
+func verifySecp256k1(publicKey, hash, signature []byte) bool {
+	pubKey, err := secp256k1.ParsePubKey(publicKey)
+	if err != nil {
+		return false
+	}
+
+	// r、s 需小於曲線階數 n，s 需為 low-s（r、s 為 0 時由 Verify 拒絕）
+	var r, s secp256k1.ModNScalar
+	if r.SetByteSlice(signature[:32]) || s.SetByteSlice(signature[32:]) || s.IsOverHalfOrder() {
+		return false
+	}
+
+	return ecdsa.NewSignature(&r, &s).Verify(hash, pubKey)
+}
@@ -0,0 +1,174 @@
+package suisig
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/block-vision/sui-go-sdk/constant"
+	suiModels "github.com/block-vision/sui-go-sdk/models"
+	"github.com/block-vision/sui-go-sdk/mystenbcs"
+	"golang.org/x/crypto/blake2b"
+)
+
+/*
+   Sui 序列化簽名驗證（personal message）：
+
+   單一金鑰：flag || signature || public key
+     0x00 Ed25519    64 bytes 簽名 + 32 bytes 公鑰
+     0x01 Secp256k1  64 bytes 簽名 (r||s) + 33 bytes 壓縮公鑰
+     0x02 Secp256r1  64 bytes 簽名 (r||s) + 33 bytes 壓縮公鑰
+   多簽：0x03 || BCS(MultiSig)，見 multisig.go
+
+   所有簽名皆針對 blake2b256(intent || BCS(message))；
+   Secp256k1 / Secp256r1 再以 SHA-256 雜湊後做 ECDSA，且 s 必須為 low-s
+*/
+
+// 簽名方案旗標
+const (
+	FlagEd25519   byte = 0x00
+	FlagSecp256k1 byte = 0x01
+	FlagSecp256r1 byte = 0x02
+	FlagMultiSig  byte = 0x03
+)
+
+const (
+	ed25519PublicKeySize = 32
+	ecdsaPublicKeySize   = 33
+	signatureSize        = 64
+)
+
+var (
+	ErrMalformedSignature = errors.New("malformed signature")
+	ErrUnsupportedScheme  = errors.New("unsupported signature scheme")
+	ErrInvalidSignature   = errors.New("invalid signature")
+	ErrThresholdNotMet    = errors.New("multisig threshold not met")
+)
+
+// PublicKey 帶有簽名方案旗標的公鑰
+type PublicKey struct {
+	Flag  byte
+	Bytes []byte
+}
+
+// Address 由公鑰推導 Sui 地址：blake2b256(flag || public key)
+func (pk PublicKey) Address() string {
+	return addressOf(append([]byte{pk.Flag}, pk.Bytes...))
+}
+
+// validate 檢查公鑰長度是否符合簽名方案
+func (pk PublicKey) validate() error {
+	switch pk.Flag {
+	case FlagEd25519:
+		if len(pk.Bytes) != ed25519PublicKeySize {
+			return fmt.Errorf("%w: ed25519 public key must be %d bytes", ErrMalformedSignature, ed25519PublicKeySize)
+		}
+	case FlagSecp256k1, FlagSecp256r1:
+		if len(pk.Bytes) != ecdsaPublicKeySize {
+			return fmt.Errorf("%w: ecdsa public key must be %d bytes", ErrMalformedSignature, ecdsaPublicKeySize)
+		}
+	default:
+		return fmt.Errorf("%w: flag 0x%02x", ErrUnsupportedScheme, pk.Flag)
+	}
+	return nil
+}
+
+// verify 以公鑰驗證 digest 的簽名
+func (pk PublicKey) verify(digest, signature []byte) error {
+	if err := pk.validate(); err != nil {
+		return err
+	}
+	if len(signature) != signatureSize {
+		return fmt.Errorf("%w: signature must be %d bytes", ErrMalformedSignature, signatureSize)
+	}
+
+	valid := false
+	switch pk.Flag {
+	case FlagEd25519:
+		valid = ed25519.Verify(ed25519.PublicKey(pk.Bytes), digest, signature)
+	case FlagSecp256k1:
+		hash := sha256.Sum256(digest)
+		valid = verifySecp256k1(pk.Bytes, hash[:], signature)
+	case FlagSecp256r1:
+		hash := sha256.Sum256(digest)
+		valid = verifySecp256r1(pk.Bytes, hash[:], signature)
+	}
+	if !valid {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// VerifyPersonalMessage 驗證 Base64 編碼的序列化簽名，回傳簽名者地址（多簽時為多簽地址）
+func VerifyPersonalMessage(message []byte, serializedSignature string) (string, error) {
+	raw, err := base64.StdEncoding.DecodeString(serializedSignature)
+	if err != nil || len(raw) == 0 {
+		return "", fmt.Errorf("%w: invalid base64", ErrMalformedSignature)
+	}
+
+	digest := PersonalMessageDigest(message)
+
+	switch flag := raw[0]; flag {
+	case FlagMultiSig:
+		multiSig, err := ParseMultiSig(raw[1:])
+		if err != nil {
+			return "", err
+		}
+		if err := multiSig.Verify(digest); err != nil {
+			return "", err
+		}
+		return multiSig.PublicKey.Address(), nil
+
+	case FlagEd25519, FlagSecp256k1, FlagSecp256r1:
+		if len(raw) < 1+signatureSize {
+			return "", fmt.Errorf("%w: too short", ErrMalformedSignature)
+		}
+		pk := PublicKey{Flag: flag, Bytes: raw[1+signatureSize:]}
+		if err := pk.verify(digest, raw[1:1+signatureSize]); err != nil {
+			return "", err
+		}
+		return pk.Address(), nil
+
+	default:
+		return "", fmt.Errorf("%w: flag 0x%02x", ErrUnsupportedScheme, flag)
+	}
+}
+
+// PersonalMessageDigest 計算 personal message 要簽署的 digest：blake2b256(intent || BCS(message))
+func PersonalMessageDigest(message []byte) []byte {
+	var encoded bytes.Buffer
+	// BCS 編碼 []byte 不會失敗
+	_ = mystenbcs.NewEncoder(&encoded).Encode(message)
+
+	digest := blake2b.Sum256(suiModels.NewMessageWithIntent(encoded.Bytes(), constant.PersonalMessageIntentScope))
+	return digest[:]
+}
+
+func addressOf(data []byte) string {
+	sum := blake2b.Sum256(data)
+	return "0x" + hex.EncodeToString(sum[:])
+}
+
+// verifySecp256r1 以標準函式庫驗證 P-256 ECDSA 簽名（僅接受 low-s）
+func verifySecp256r1(publicKey, hash, signature []byte) bool {
+	x, y := elliptic.UnmarshalCompressed(elliptic.P256(), publicKey)
+	if x == nil {
+		return false
+	}
+
+	r := new(big.Int).SetBytes(signature[:32])
+	s := new(big.Int).SetBytes(signature[32:])
+	halfOrder := new(big.Int).Rsh(elliptic.P256().Params().N, 1)
+	if s.Cmp(halfOrder) > 0 {
+		return false
+	}
+
+	return ecdsa.Verify(&ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y}, hash, r, s)
+}
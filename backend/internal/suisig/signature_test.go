@@ -0,0 +1,238 @@
+package suisig
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"testing"
+
+	suiModels "github.com/block-vision/sui-go-sdk/models"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	secpecdsa "github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+)
+
+// secp256k1N secp256k1 曲線的階數
+var secp256k1N, _ = new(big.Int).SetString("FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEBAAEDCE6AF48A03BBFD25E8CD0364141", 16)
+
+var testMessage = []byte("example.com wants you to sign in with your Sui account")
+
+// testSigner 測試用的本機金鑰
+type testSigner struct {
+	publicKey PublicKey
+	sign      func(digest []byte) []byte
+}
+
+func newEd25519Signer(t *testing.T) *testSigner {
+	t.Helper()
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("generate ed25519 key: %v", err)
+	}
+	return &testSigner{
+		publicKey: PublicKey{Flag: FlagEd25519, Bytes: pub},
+		sign: func(digest []byte) []byte {
+			return ed25519.Sign(priv, digest)
+		},
+	}
+}
+
+func newSecp256k1Signer(t *testing.T) *testSigner {
+	t.Helper()
+	priv, err := secp256k1.GeneratePrivateKey()
+	if err != nil {
+		t.Fatalf("generate secp256k1 key: %v", err)
+	}
+	return &testSigner{
+		publicKey: PublicKey{Flag: FlagSecp256k1, Bytes: priv.PubKey().SerializeCompressed()},
+		sign: func(digest []byte) []byte {
+			// RFC 6979 決定性簽名，s 已正規化為 low-s
+			hash := sha256.Sum256(digest)
+			signature := secpecdsa.Sign(priv, hash[:])
+			r, s := signature.R(), signature.S()
+			rBytes, sBytes := r.Bytes(), s.Bytes()
+			return append(rBytes[:], sBytes[:]...)
+		},
+	}
+}
+
+func newSecp256r1Signer(t *testing.T) *testSigner {
+	t.Helper()
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generate secp256r1 key: %v", err)
+	}
+	return &testSigner{
+		publicKey: PublicKey{Flag: FlagSecp256r1, Bytes: elliptic.MarshalCompressed(elliptic.P256(), priv.X, priv.Y)},
+		sign: func(digest []byte) []byte {
+			hash := sha256.Sum256(digest)
+			r, s, err := ecdsa.Sign(rand.Reader, priv, hash[:])
+			if err != nil {
+				t.Fatalf("sign secp256r1: %v", err)
+			}
+			n := elliptic.P256().Params().N
+			if s.Cmp(new(big.Int).Rsh(n, 1)) > 0 {
+				s.Sub(n, s)
+			}
+			return append(r.FillBytes(make([]byte, 32)), s.FillBytes(make([]byte, 32))...)
+		},
+	}
+}
+
+// serialized 單一金鑰的序列化簽名：flag || signature || public key
+func (s *testSigner) serialized(message []byte) string {
+	data := []byte{s.publicKey.Flag}
+	data = append(data, s.sign(PersonalMessageDigest(message))...)
+	data = append(data, s.publicKey.Bytes...)
+	return base64.StdEncoding.EncodeToString(data)
+}
+
+// 已知答案向量（personal message 簽名與簽名者地址），不經由本套件產生：
+//   - sui-go-sdk 測試中的 Ed25519 錢包簽名
+//   - 其餘以 OpenSSL（Node.js crypto）簽署、Python hashlib 計算 intent digest 與地址，
+//     金鑰為固定值，ECDSA 簽名的 s 已正規化為 low-s
+var knownAnswerVectors = []struct {
+	name      string
+	message   string
+	signature string
+	address   string
+}{
+	{
+		name:      "ed25519 sdk",
+		message:   "123456 is the thing that you need to sign",
+		signature: "AIjj13rXd9GFZRNPd4XNUvthHMHg5bovf8/mW4a7EYAWC6mQtAAaa0tSPhk6YpNED34/qeaCYwnN1QAsKm253gfQ6i6fULpM+uscFuJIXoTT/JQvMo3CUlLODcGxPkUbHg==",
+		address:   "0x00dccd645260cfe9145bdabb7b45b42e188af8661086aa7bb2e7f3adc1cd2785",
+	},
+	{
+		name:      "ed25519",
+		message:   knownAnswerMessage,
+		signature: "AOG7mqSWC8yjXx5yuiC0Ww4OTxCA1zvZg00pRudHs/rJnebrcdXzDtcWlZLgaTv3puKWR8yot/HBaMIYQVOAygC5xu4WMO8+cRFEpkjbBruyKE9ydM++5T/87lA8waSSAA==",
+		address:   "0xcc2196ee1fa156836daf9bb021d88d648a0023fa387e695d3701667a634a331f",
+	},
+	{
+		name:      "secp256k1",
+		message:   knownAnswerMessage,
+		signature: "AQlUdN7d+funtDy0948wKZS2Bp/n481yrwSfMpm30NjhWADG1uxgbBnYghl+5w3D/I4m1H8WB42/fwXffKk7ZFEDLIwx/J+ZDGtV44ZaGEpM5Q4JSB8urrPmDsHOoTpq5kU=",
+		address:   "0x13c6e328c68503a9181a2bdd0821e373335f3e8395e021bf4345223f7e7bbc49",
+	},
+	{
+		name:      "secp256r1",
+		message:   knownAnswerMessage,
+		signature: "AgBNwbeSxX/Azed2A9LIquLPkP6RLQCR66cEb9KUQoaGQ6RqYYW0gPwl+OAFN68VwpG6EE05jFuq2CuCnSrA1xcDYP7UuiVanTHJYet0xjVtaMBJuJI7Yfps5mliLmDyn7Y=",
+		address:   "0x29904aff00e84ab76121239016269d05c4ef37920afee9bd0e3441856902bd5e",
+	},
+}
+
+const knownAnswerMessage = "Sign in to BlueLink"
+
+func TestVerifyPersonalMessageKnownAnswers(t *testing.T) {
+	for _, vector := range knownAnswerVectors {
+		t.Run(vector.name, func(t *testing.T) {
+			address, err := VerifyPersonalMessage([]byte(vector.message), vector.signature)
+			if err != nil {
+				t.Fatalf("verify: %v", err)
+			}
+			if address != vector.address {
+				t.Fatalf("address %s, expected %s", address, vector.address)
+			}
+
+			_, err = VerifyPersonalMessage([]byte("another message"), vector.signature)
+			if !errors.Is(err, ErrInvalidSignature) {
+				t.Fatalf("signature over another message should be invalid, got %v", err)
+			}
+		})
+	}
+}
+
+func TestKnownAnswerDigest(t *testing.T) {
+	// blake2b256([3, 0, 0] || ULEB128(len) || message)
+	want := "64802ee74f84f1e6273104a8fd62b697932fbbe0c488662b391f97e87e2e0807"
+	if got := hex.EncodeToString(PersonalMessageDigest([]byte(knownAnswerMessage))); got != want {
+		t.Fatalf("digest %s, expected %s", got, want)
+	}
+}
+
+func TestVerifyPersonalMessageMatchesSDK(t *testing.T) {
+	// SDK 僅支援 Ed25519，確認兩者推導出相同的地址
+	for _, vector := range knownAnswerVectors[:2] {
+		sdkAddress, pass, err := suiModels.VerifyPersonalMessage(vector.message, vector.signature)
+		if err != nil || !pass {
+			t.Fatalf("%s: sdk verify: pass=%v err=%v", vector.name, pass, err)
+		}
+		if sdkAddress != vector.address {
+			t.Fatalf("%s: sdk derived %s, expected %s", vector.name, sdkAddress, vector.address)
+		}
+	}
+}
+
+func TestVerifyPersonalMessageGeneratedKeys(t *testing.T) {
+	signers := map[string]*testSigner{
+		"ed25519":   newEd25519Signer(t),
+		"secp256k1": newSecp256k1Signer(t),
+		"secp256r1": newSecp256r1Signer(t),
+	}
+
+	for name, signer := range signers {
+		t.Run(name, func(t *testing.T) {
+			address, err := VerifyPersonalMessage(testMessage, signer.serialized(testMessage))
+			if err != nil {
+				t.Fatalf("verify: %v", err)
+			}
+			if address != signer.publicKey.Address() {
+				t.Fatalf("address %s, expected %s", address, signer.publicKey.Address())
+			}
+		})
+	}
+}
+
+func TestVerifyRejectsHighS(t *testing.T) {
+	digest := PersonalMessageDigest([]byte(knownAnswerMessage))
+
+	for _, vector := range knownAnswerVectors[2:] {
+		t.Run(vector.name, func(t *testing.T) {
+			raw, err := base64.StdEncoding.DecodeString(vector.signature)
+			if err != nil {
+				t.Fatal(err)
+			}
+			pk := PublicKey{Flag: raw[0], Bytes: raw[1+signatureSize:]}
+			signature := raw[1 : 1+signatureSize]
+
+			// 同一簽名的 high-s 形式 (r, n-s) 在數學上有效，但 Sui 僅接受 low-s
+			n := secp256k1N
+			if pk.Flag == FlagSecp256r1 {
+				n = elliptic.P256().Params().N
+			}
+			highS := new(big.Int).Sub(n, new(big.Int).SetBytes(signature[32:]))
+			malleated := append(append([]byte{}, signature[:32]...), highS.FillBytes(make([]byte, 32))...)
+
+			if err := pk.verify(digest, signature); err != nil {
+				t.Fatalf("low-s signature should verify: %v", err)
+			}
+			if err := pk.verify(digest, malleated); !errors.Is(err, ErrInvalidSignature) {
+				t.Fatalf("high-s signature should be rejected, got %v", err)
+			}
+		})
+	}
+}
+
+func TestVerifyPersonalMessageMalformed(t *testing.T) {
+	cases := map[string]string{
+		"not base64":     "!!!",
+		"empty":          "",
+		"too short":      base64.StdEncoding.EncodeToString([]byte{FlagEd25519, 1, 2, 3}),
+		"unknown scheme": base64.StdEncoding.EncodeToString(append([]byte{0x05}, make([]byte, 96)...)),
+	}
+
+	for name, signature := range cases {
+		t.Run(name, func(t *testing.T) {
+			if _, err := VerifyPersonalMessage(testMessage, signature); err == nil {
+				t.Fatal("expected an error")
+			}
+		})
+	}
+}
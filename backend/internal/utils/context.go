@@ -33,6 +33,18 @@ func GetUserRole(c *gin.Context) (string, error) {
 	return role.(string), nil
 }
 
+// GetUserRoles 從 context 取得使用者的所有角色（主要角色與額外授予的角色）
+func GetUserRoles(c *gin.Context) []string {
+	var roles []string
+	if role, err := GetUserRole(c); err == nil {
+		roles = append(roles, role)
+	}
+	if granted, exists := c.Get("Roles"); exists {
+		roles = append(roles, granted.([]string)...)
+	}
+	return roles
+}
+
 // GetRequestID 從 context 取得請求 ID
 func GetRequestID(c *gin.Context) string {
 	requestID, exists := c.Get("RequestID")
@@ -0,0 +1,77 @@
+package utils
+
+import "strings"
+
+// uaRule User-Agent 關鍵字與對應名稱（依序比對，先符合者優先）
+type uaRule struct {
+	token string
+	name  string
+}
+
+// 瀏覽器：Edge / Opera / Samsung 的 UA 同時含有 Chrome，需排在前面；Chrome 的 UA 同時含有 Safari
+var browserRules = []uaRule{
+	{"edg/", "Edge"},
+	{"opr/", "Opera"},
+	{"samsungbrowser/", "Samsung Internet"},
+	{"firefox/", "Firefox"},
+	{"fxios/", "Firefox"},
+	{"crios/", "Chrome"},
+	{"chrome/", "Chrome"},
+	{"safari/", "Safari"},
+}
+
+// 作業系統：iOS / Android 的 UA 同時含有 Mac OS X / Linux，需排在前面
+var osRules = []uaRule{
+	{"iphone", "iOS"},
+	{"ipad", "iPadOS"},
+	{"android", "Android"},
+	{"windows", "Windows"},
+	{"mac os x", "macOS"},
+	{"cros", "ChromeOS"},
+	{"linux", "Linux"},
+}
+
+// 非瀏覽器客戶端（API 整合、指令列工具）
+var clientRules = []uaRule{
+	{"curl/", "curl"},
+	{"postman", "Postman"},
+	{"python-requests/", "Python"},
+	{"go-http-client/", "Go"},
+	{"okhttp/", "OkHttp"},
+	{"axios/", "axios"},
+	{"node-fetch/", "Node.js"},
+}
+
+// DeviceLabel 由 User-Agent 解析裝置標籤，例如 "Chrome on macOS"、"Safari on iOS"
+func DeviceLabel(userAgent string) string {
+	ua := strings.ToLower(userAgent)
+	if ua == "" {
+		return "Unknown device"
+	}
+
+	if client := matchUARule(ua, clientRules); client != "" {
+		return client
+	}
+
+	browser := matchUARule(ua, browserRules)
+	os := matchUARule(ua, osRules)
+	switch {
+	case browser != "" && os != "":
+		return browser + " on " + os
+	case browser != "":
+		return browser
+	case os != "":
+		return "Unknown browser on " + os
+	default:
+		return "Unknown device"
+	}
+}
+
+func matchUARule(ua string, rules []uaRule) string {
+	for _, rule := range rules {
+		if strings.Contains(ua, rule.token) {
+			return rule.name
+		}
+	}
+	return ""
+}